@@ -0,0 +1,115 @@
+import { useMutation, useQuery } from "@tanstack/react-query";
+
+import { api } from "@/lib/api";
+
+export type DiscoveredFederatedAddon = {
+  manifest: FederatedAddonManifest;
+  url: string;
+};
+
+export type FederatedAddon = {
+  checked_at: null | string;
+  created_at: string;
+  error: string;
+  is_peer: boolean;
+  manifest: FederatedAddonManifest;
+  seen_at: null | string;
+  status: "healthy" | "unhealthy" | "unknown";
+  updated_at: string;
+  url: string;
+};
+
+type FederatedAddonManifest = {
+  description: string;
+  id: string;
+  logo?: string;
+  name: string;
+  version: string;
+};
+
+type RegisterFederatedAddonParams = {
+  is_peer: boolean;
+  url: string;
+};
+
+export function useDiscoveredFederatedAddons(enabled: boolean) {
+  return useQuery({
+    enabled,
+    queryFn: getDiscoveredFederatedAddons,
+    queryKey: ["/stremio/federated-addons/discover"],
+  });
+}
+
+export function useFederatedAddonMutation() {
+  const register = useMutation({
+    mutationFn: registerFederatedAddon,
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/stremio/federated-addons"],
+      });
+    },
+  });
+
+  const check = useMutation({
+    mutationFn: checkFederatedAddon,
+    onSuccess: async (data, _, __, ctx) => {
+      ctx.client.setQueryData<FederatedAddon[]>(
+        ["/stremio/federated-addons"],
+        (list) => list?.map((item) => (item.url === data.url ? data : item)),
+      );
+    },
+  });
+
+  const remove = useMutation({
+    mutationFn: deleteFederatedAddon,
+    onSuccess: async (_, url, __, ctx) => {
+      ctx.client.setQueryData<FederatedAddon[]>(
+        ["/stremio/federated-addons"],
+        (list) => list?.filter((item) => item.url !== url),
+      );
+      await ctx.client.invalidateQueries({
+        queryKey: ["/stremio/federated-addons/discover"],
+      });
+    },
+  });
+
+  return { check, register, remove };
+}
+
+export function useFederatedAddons() {
+  return useQuery({
+    queryFn: getFederatedAddons,
+    queryKey: ["/stremio/federated-addons"],
+  });
+}
+
+async function checkFederatedAddon(url: string) {
+  const { data } = await api<FederatedAddon>(
+    `POST /stremio/federated-addons/check?url=${encodeURIComponent(url)}`,
+  );
+  return data;
+}
+
+async function deleteFederatedAddon(url: string) {
+  await api(`DELETE /stremio/federated-addons?url=${encodeURIComponent(url)}`);
+}
+
+async function getDiscoveredFederatedAddons() {
+  const { data } = await api<DiscoveredFederatedAddon[]>(
+    "/stremio/federated-addons/discover",
+  );
+  return data;
+}
+
+async function getFederatedAddons() {
+  const { data } = await api<FederatedAddon[]>("/stremio/federated-addons");
+  return data;
+}
+
+async function registerFederatedAddon(params: RegisterFederatedAddonParams) {
+  const { data } = await api<FederatedAddon>(
+    "POST /stremio/federated-addons",
+    { body: params },
+  );
+  return data;
+}
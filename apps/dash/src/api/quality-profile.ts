@@ -0,0 +1,66 @@
+import { useMutation, useQuery } from "@tanstack/react-query";
+
+import { api } from "@/lib/api";
+
+export type QualityProfile = {
+  allow_groups?: string[];
+  created_at: string;
+  deny_groups?: string[];
+  id: string;
+  min_size?: Record<string, string>;
+  resolutions?: string[];
+  seeders_half_life?: number;
+  updated_at: string;
+};
+
+type SaveQualityProfileParams = Omit<
+  QualityProfile,
+  "created_at" | "updated_at"
+>;
+
+export function useQualityProfileMutation() {
+  const save = useMutation({
+    mutationFn: saveQualityProfile,
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/stremio/quality-profiles"],
+      });
+    },
+  });
+
+  const remove = useMutation({
+    mutationFn: deleteQualityProfile,
+    onSuccess: async (_, id, __, ctx) => {
+      ctx.client.setQueryData<QualityProfile[]>(
+        ["/stremio/quality-profiles"],
+        (list) => list?.filter((item) => item.id !== id),
+      );
+    },
+  });
+
+  return { remove, save };
+}
+
+export function useQualityProfiles() {
+  return useQuery({
+    queryFn: getQualityProfiles,
+    queryKey: ["/stremio/quality-profiles"],
+  });
+}
+
+async function deleteQualityProfile(id: string) {
+  await api(`DELETE /stremio/quality-profiles/${encodeURIComponent(id)}`);
+}
+
+async function getQualityProfiles() {
+  const { data } = await api<QualityProfile[]>("/stremio/quality-profiles");
+  return data;
+}
+
+async function saveQualityProfile({ id, ...params }: SaveQualityProfileParams) {
+  const { data } = await api<QualityProfile>(
+    `PUT /stremio/quality-profiles/${encodeURIComponent(id)}`,
+    { body: params },
+  );
+  return data;
+}
@@ -19,6 +19,9 @@ type ServerStats = {
     vault: boolean;
   };
   integration: {
+    anilist: boolean;
+    mal: boolean;
+    simkl: boolean;
     trakt: boolean;
   };
   started_at: string;
@@ -0,0 +1,187 @@
+import { useMutation, useQuery } from "@tanstack/react-query";
+
+import { api } from "@/lib/api";
+
+export type CreateStremioTrackerLinkParams = {
+  stremio_account_id: string;
+  sync_config: SyncConfig;
+  tracker_account_id: string;
+};
+
+export type StremioTrackerLink = {
+  created_at: string;
+  stremio_account_id: string;
+  sync_config: SyncConfig;
+  sync_state: SyncState;
+  tracker_account_id: string;
+  updated_at: string;
+};
+
+export type SyncConfig = {
+  watched: SyncConfigWatched;
+};
+
+export type SyncConfigWatched = {
+  dir: SyncDirection;
+};
+
+export type SyncDirection =
+  | "both"
+  | "none"
+  | "stremio_to_tracker"
+  | "tracker_to_stremio";
+
+export type SyncState = {
+  watched: SyncStateWatched;
+};
+
+export type SyncStateWatched = {
+  last_synced_at?: string;
+};
+
+export type UpdateStremioTrackerLinkParams = {
+  sync_config: SyncConfig;
+};
+
+export function useStremioTrackerLinkMutation() {
+  const create = useMutation({
+    mutationFn: createStremioTrackerLink,
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/sync/stremio-tracker/links"],
+      });
+    },
+  });
+
+  const update = useMutation({
+    mutationFn: async ({
+      stremio_account_id,
+      tracker_account_id,
+      ...params
+    }: UpdateStremioTrackerLinkParams & {
+      stremio_account_id: string;
+      tracker_account_id: string;
+    }) => {
+      return updateStremioTrackerLink(
+        stremio_account_id,
+        tracker_account_id,
+        params,
+      );
+    },
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/sync/stremio-tracker/links"],
+      });
+    },
+  });
+
+  const remove = useMutation({
+    mutationFn: ({
+      stremio_account_id,
+      tracker_account_id,
+    }: {
+      stremio_account_id: string;
+      tracker_account_id: string;
+    }) => deleteStremioTrackerLink(stremio_account_id, tracker_account_id),
+    onSuccess: async (_, { stremio_account_id, tracker_account_id }, __, ctx) => {
+      ctx.client.setQueryData<StremioTrackerLink[]>(
+        ["/sync/stremio-tracker/links"],
+        (list) =>
+          list?.filter(
+            (item) =>
+              item.stremio_account_id !== stremio_account_id ||
+              item.tracker_account_id !== tracker_account_id,
+          ),
+      );
+    },
+  });
+
+  const sync = useMutation({
+    mutationFn: ({
+      stremio_account_id,
+      tracker_account_id,
+    }: {
+      stremio_account_id: string;
+      tracker_account_id: string;
+    }) => syncStremioTrackerLink(stremio_account_id, tracker_account_id),
+  });
+
+  const resetSyncState = useMutation({
+    mutationFn: ({
+      stremio_account_id,
+      tracker_account_id,
+    }: {
+      stremio_account_id: string;
+      tracker_account_id: string;
+    }) => resetStremioTrackerLinkSyncState(stremio_account_id, tracker_account_id),
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/sync/stremio-tracker/links"],
+      });
+    },
+  });
+
+  return { create, remove, resetSyncState, sync, update };
+}
+
+export function useStremioTrackerLinks() {
+  return useQuery({
+    queryFn: getStremioTrackerLinks,
+    queryKey: ["/sync/stremio-tracker/links"],
+  });
+}
+
+async function createStremioTrackerLink(params: CreateStremioTrackerLinkParams) {
+  const { data } = await api<StremioTrackerLink>(
+    "POST /sync/stremio-tracker/links",
+    {
+      body: params,
+    },
+  );
+  return data;
+}
+
+async function deleteStremioTrackerLink(
+  stremioAccountId: string,
+  trackerAccountId: string,
+) {
+  await api(
+    `DELETE /sync/stremio-tracker/links/${stremioAccountId}:${encodeURIComponent(trackerAccountId)}`,
+  );
+}
+
+async function getStremioTrackerLinks() {
+  const { data } = await api<StremioTrackerLink[]>("/sync/stremio-tracker/links");
+  return data;
+}
+
+async function resetStremioTrackerLinkSyncState(
+  stremioAccountId: string,
+  trackerAccountId: string,
+) {
+  const { data } = await api<StremioTrackerLink>(
+    `POST /sync/stremio-tracker/links/${stremioAccountId}:${encodeURIComponent(trackerAccountId)}/reset-sync-state`,
+  );
+  return data;
+}
+
+async function syncStremioTrackerLink(
+  stremioAccountId: string,
+  trackerAccountId: string,
+) {
+  await api(
+    `POST /sync/stremio-tracker/links/${stremioAccountId}:${encodeURIComponent(trackerAccountId)}/sync`,
+  );
+}
+
+async function updateStremioTrackerLink(
+  stremioAccountId: string,
+  trackerAccountId: string,
+  params: UpdateStremioTrackerLinkParams,
+) {
+  const { data } = await api<StremioTrackerLink>(
+    `PATCH /sync/stremio-tracker/links/${stremioAccountId}:${encodeURIComponent(trackerAccountId)}`,
+    { body: params },
+  );
+  return data;
+}
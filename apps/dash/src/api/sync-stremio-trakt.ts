@@ -18,13 +18,28 @@ export type StremioTraktLink = {
 };
 
 export type SyncConfig = {
+  collection: SyncConfigCollection;
+  ratings: SyncConfigRatings;
   watched: SyncConfigWatched;
+  watchlist: SyncConfigWatchlist;
+};
+
+export type SyncConfigCollection = {
+  dir: SyncDirection;
+};
+
+export type SyncConfigRatings = {
+  dir: SyncDirection;
 };
 
 export type SyncConfigWatched = {
   dir: SyncDirection;
 };
 
+export type SyncConfigWatchlist = {
+  dir: SyncDirection;
+};
+
 export type SyncDirection =
   | "both"
   | "none"
@@ -32,13 +47,28 @@ export type SyncDirection =
   | "trakt_to_stremio";
 
 export type SyncState = {
+  collection: SyncStateCollection;
+  ratings: SyncStateRatings;
   watched: SyncStateWatched;
+  watchlist: SyncStateWatchlist;
+};
+
+export type SyncStateCollection = {
+  last_synced_at?: string;
+};
+
+export type SyncStateRatings = {
+  last_synced_at?: string;
 };
 
 export type SyncStateWatched = {
   last_synced_at?: string;
 };
 
+export type SyncStateWatchlist = {
+  last_synced_at?: string;
+};
+
 export type UpdateStremioTraktLinkParams = {
   sync_config: SyncConfig;
 };
@@ -12,7 +12,7 @@ export type TorznabIndexer = {
   url: string;
 };
 
-export type TorznabIndexerType = "jackett";
+export type TorznabIndexerType = "jackett" | "prowlarr" | "torznab";
 
 type CreateTorznabIndexerParams = {
   api_key: string;
@@ -0,0 +1,113 @@
+import { useMutation, useQuery } from "@tanstack/react-query";
+
+import { api } from "@/lib/api";
+
+export type CreateTrackerAccountParams = {
+  oauth_token_id: string;
+};
+
+export type TrackerAccount = {
+  created_at: string;
+  id: string; // `${provider}:${user_id}`
+  is_valid: boolean;
+  provider: TrackerProvider;
+  updated_at: string;
+  user_name: string;
+};
+
+export type TrackerAuthURL = {
+  url: string;
+};
+
+export type TrackerProvider = "anilist.co" | "myanimelist.net" | "simkl.com";
+
+export const trackerProviderName: Record<TrackerProvider, string> = {
+  "anilist.co": "AniList",
+  "myanimelist.net": "MyAnimeList",
+  "simkl.com": "Simkl",
+};
+
+export async function getTrackerAuthURL(
+  provider: TrackerProvider,
+  state: string,
+) {
+  const { data } = await api<TrackerAuthURL>(
+    `/vault/tracker/auth/url?provider=${provider}&state=${state}`,
+  );
+  return data.url;
+}
+
+export function useTrackerAccountMutation() {
+  const create = useMutation({
+    mutationFn: createTrackerAccount,
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/vault/tracker/accounts"],
+      });
+    },
+  });
+
+  const get = useMutation({
+    mutationFn: getTrackerAccount,
+    onSuccess: async (data, { id }, __, ctx) => {
+      ctx.client.setQueryData<TrackerAccount[]>(
+        ["/vault/tracker/accounts"],
+        (list) =>
+          list?.map((item) => (item.id === id ? { ...item, ...data } : item)),
+      );
+    },
+  });
+
+  const remove = useMutation({
+    mutationFn: deleteTrackerAccount,
+    onSuccess: async (_, id, __, ctx) => {
+      const list = ctx.client.getQueryData<TrackerAccount[]>([
+        "/vault/tracker/accounts",
+      ]);
+      if (list) {
+        ctx.client.setQueryData(
+          ["/vault/tracker/accounts"],
+          list.filter((item) => item.id !== id),
+        );
+      }
+    },
+  });
+
+  return { create, get, remove };
+}
+
+export function useTrackerAccounts() {
+  return useQuery({
+    queryFn: getTrackerAccounts,
+    queryKey: ["/vault/tracker/accounts"],
+  });
+}
+
+async function createTrackerAccount(params: CreateTrackerAccountParams) {
+  const { data } = await api<TrackerAccount>("POST /vault/tracker/accounts", {
+    body: params,
+  });
+  return data;
+}
+
+async function deleteTrackerAccount(id: string) {
+  await api(`DELETE /vault/tracker/accounts/${encodeURIComponent(id)}`);
+}
+
+async function getTrackerAccount({
+  id,
+  refresh = false,
+}: {
+  id: string;
+  refresh?: boolean;
+}) {
+  const { data } = await api<TrackerAccount>(
+    `GET /vault/tracker/accounts/${encodeURIComponent(id)}?refresh=${refresh}`,
+  );
+  return data;
+}
+
+async function getTrackerAccounts() {
+  const { data } = await api<TrackerAccount[]>("/vault/tracker/accounts");
+  return data;
+}
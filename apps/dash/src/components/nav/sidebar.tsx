@@ -302,6 +302,20 @@ function useNavItems(): NavItem[] {
           title: "Trakt Accounts",
         });
       }
+      const hasTracker =
+        server.integration.anilist ||
+        server.integration.mal ||
+        server.integration.simkl;
+      if (hasTracker) {
+        vault.items!.push({
+          path: "/dash/vault/tracker-accounts",
+          title: "Tracker Accounts",
+        });
+      }
+      vault.items!.push({
+        path: "/dash/vault/newznab-indexers",
+        title: "Newznab Indexers",
+      });
       vault.items!.push({
         path: "/dash/vault/torznab-indexers",
         title: "Torznab Indexers",
@@ -329,6 +343,12 @@ function useNavItems(): NavItem[] {
           title: "Stremio ↔ Trakt",
         });
       }
+      if (hasTracker) {
+        sync.items!.push({
+          path: "/dash/sync/stremio-tracker",
+          title: "Stremio ↔ Tracker",
+        });
+      }
       items.push(sync);
     }
 
@@ -339,6 +359,14 @@ function useNavItems(): NavItem[] {
           path: "/dash/settings/ratelimit-configs",
           title: "Rate Limit Configs",
         },
+        {
+          path: "/dash/settings/quality-profiles",
+          title: "Quality Profiles",
+        },
+        {
+          path: "/dash/settings/federated-addons",
+          title: "Federated Addons",
+        },
       ],
       path: "/dash/settings",
       title: "Settings",
@@ -346,5 +374,11 @@ function useNavItems(): NavItem[] {
     items.push(settings);
 
     return items;
-  }, [server?.feature.vault, server?.integration.trakt]);
+  }, [
+    server?.feature.vault,
+    server?.integration.anilist,
+    server?.integration.mal,
+    server?.integration.simkl,
+    server?.integration.trakt,
+  ]);
 }
@@ -24,11 +24,16 @@ import { Route as DashSyncIndexRouteImport } from './routes/dash/sync/index'
 import { Route as DashSettingsIndexRouteImport } from './routes/dash/settings/index'
 import { Route as DashListsIndexRouteImport } from './routes/dash/lists/index'
 import { Route as DashVaultTraktAccountsRouteImport } from './routes/dash/vault/trakt-accounts'
+import { Route as DashVaultTrackerAccountsRouteImport } from './routes/dash/vault/tracker-accounts'
 import { Route as DashVaultTorznabIndexersRouteImport } from './routes/dash/vault/torznab-indexers'
 import { Route as DashVaultStremioAccountsRouteImport } from './routes/dash/vault/stremio-accounts'
+import { Route as DashVaultNewznabIndexersRouteImport } from './routes/dash/vault/newznab-indexers'
 import { Route as DashTorrentsIndexersSyncRouteImport } from './routes/dash/torrents/indexers-sync'
 import { Route as DashSyncStremioTraktRouteImport } from './routes/dash/sync/stremio-trakt'
+import { Route as DashSyncStremioTrackerRouteImport } from './routes/dash/sync/stremio-tracker'
 import { Route as DashSyncStremioStremioRouteImport } from './routes/dash/sync/stremio-stremio'
+import { Route as DashSettingsQualityProfilesRouteImport } from './routes/dash/settings/quality-profiles'
+import { Route as DashSettingsFederatedAddonsRouteImport } from './routes/dash/settings/federated-addons'
 import { Route as DashSettingsRatelimitConfigsRouteImport } from './routes/dash/settings/ratelimit-configs'
 
 const DashRoute = DashRouteImport.update({
@@ -106,6 +111,12 @@ const DashVaultTraktAccountsRoute = DashVaultTraktAccountsRouteImport.update({
   path: '/trakt-accounts',
   getParentRoute: () => DashVaultRoute,
 } as any)
+const DashVaultTrackerAccountsRoute =
+  DashVaultTrackerAccountsRouteImport.update({
+    id: '/tracker-accounts',
+    path: '/tracker-accounts',
+    getParentRoute: () => DashVaultRoute,
+  } as any)
 const DashVaultTorznabIndexersRoute =
   DashVaultTorznabIndexersRouteImport.update({
     id: '/torznab-indexers',
@@ -118,6 +129,12 @@ const DashVaultStremioAccountsRoute =
     path: '/stremio-accounts',
     getParentRoute: () => DashVaultRoute,
   } as any)
+const DashVaultNewznabIndexersRoute =
+  DashVaultNewznabIndexersRouteImport.update({
+    id: '/newznab-indexers',
+    path: '/newznab-indexers',
+    getParentRoute: () => DashVaultRoute,
+  } as any)
 const DashTorrentsIndexersSyncRoute =
   DashTorrentsIndexersSyncRouteImport.update({
     id: '/indexers-sync',
@@ -129,11 +146,28 @@ const DashSyncStremioTraktRoute = DashSyncStremioTraktRouteImport.update({
   path: '/stremio-trakt',
   getParentRoute: () => DashSyncRoute,
 } as any)
+const DashSyncStremioTrackerRoute = DashSyncStremioTrackerRouteImport.update({
+  id: '/stremio-tracker',
+  path: '/stremio-tracker',
+  getParentRoute: () => DashSyncRoute,
+} as any)
 const DashSyncStremioStremioRoute = DashSyncStremioStremioRouteImport.update({
   id: '/stremio-stremio',
   path: '/stremio-stremio',
   getParentRoute: () => DashSyncRoute,
 } as any)
+const DashSettingsFederatedAddonsRoute =
+  DashSettingsFederatedAddonsRouteImport.update({
+    id: '/federated-addons',
+    path: '/federated-addons',
+    getParentRoute: () => DashSettingsRoute,
+  } as any)
+const DashSettingsQualityProfilesRoute =
+  DashSettingsQualityProfilesRouteImport.update({
+    id: '/quality-profiles',
+    path: '/quality-profiles',
+    getParentRoute: () => DashSettingsRoute,
+  } as any)
 const DashSettingsRatelimitConfigsRoute =
   DashSettingsRatelimitConfigsRouteImport.update({
     id: '/ratelimit-configs',
@@ -151,12 +185,17 @@ export interface FileRoutesByFullPath {
   '/dash/vault': typeof DashVaultRouteWithChildren
   '/dash/workers': typeof DashWorkersRoute
   '/dash/': typeof DashIndexRoute
+  '/dash/settings/federated-addons': typeof DashSettingsFederatedAddonsRoute
+  '/dash/settings/quality-profiles': typeof DashSettingsQualityProfilesRoute
   '/dash/settings/ratelimit-configs': typeof DashSettingsRatelimitConfigsRoute
   '/dash/sync/stremio-stremio': typeof DashSyncStremioStremioRoute
+  '/dash/sync/stremio-tracker': typeof DashSyncStremioTrackerRoute
   '/dash/sync/stremio-trakt': typeof DashSyncStremioTraktRoute
   '/dash/torrents/indexers-sync': typeof DashTorrentsIndexersSyncRoute
+  '/dash/vault/newznab-indexers': typeof DashVaultNewznabIndexersRoute
   '/dash/vault/stremio-accounts': typeof DashVaultStremioAccountsRoute
   '/dash/vault/torznab-indexers': typeof DashVaultTorznabIndexersRoute
+  '/dash/vault/tracker-accounts': typeof DashVaultTrackerAccountsRoute
   '/dash/vault/trakt-accounts': typeof DashVaultTraktAccountsRoute
   '/dash/lists/': typeof DashListsIndexRoute
   '/dash/settings/': typeof DashSettingsIndexRoute
@@ -168,12 +207,17 @@ export interface FileRoutesByTo {
   '/dash/login': typeof DashLoginRoute
   '/dash/workers': typeof DashWorkersRoute
   '/dash': typeof DashIndexRoute
+  '/dash/settings/federated-addons': typeof DashSettingsFederatedAddonsRoute
+  '/dash/settings/quality-profiles': typeof DashSettingsQualityProfilesRoute
   '/dash/settings/ratelimit-configs': typeof DashSettingsRatelimitConfigsRoute
   '/dash/sync/stremio-stremio': typeof DashSyncStremioStremioRoute
+  '/dash/sync/stremio-tracker': typeof DashSyncStremioTrackerRoute
   '/dash/sync/stremio-trakt': typeof DashSyncStremioTraktRoute
   '/dash/torrents/indexers-sync': typeof DashTorrentsIndexersSyncRoute
+  '/dash/vault/newznab-indexers': typeof DashVaultNewznabIndexersRoute
   '/dash/vault/stremio-accounts': typeof DashVaultStremioAccountsRoute
   '/dash/vault/torznab-indexers': typeof DashVaultTorznabIndexersRoute
+  '/dash/vault/tracker-accounts': typeof DashVaultTrackerAccountsRoute
   '/dash/vault/trakt-accounts': typeof DashVaultTraktAccountsRoute
   '/dash/lists': typeof DashListsIndexRoute
   '/dash/settings': typeof DashSettingsIndexRoute
@@ -192,12 +236,17 @@ export interface FileRoutesById {
   '/dash/vault': typeof DashVaultRouteWithChildren
   '/dash/workers': typeof DashWorkersRoute
   '/dash/': typeof DashIndexRoute
+  '/dash/settings/federated-addons': typeof DashSettingsFederatedAddonsRoute
+  '/dash/settings/quality-profiles': typeof DashSettingsQualityProfilesRoute
   '/dash/settings/ratelimit-configs': typeof DashSettingsRatelimitConfigsRoute
   '/dash/sync/stremio-stremio': typeof DashSyncStremioStremioRoute
+  '/dash/sync/stremio-tracker': typeof DashSyncStremioTrackerRoute
   '/dash/sync/stremio-trakt': typeof DashSyncStremioTraktRoute
   '/dash/torrents/indexers-sync': typeof DashTorrentsIndexersSyncRoute
+  '/dash/vault/newznab-indexers': typeof DashVaultNewznabIndexersRoute
   '/dash/vault/stremio-accounts': typeof DashVaultStremioAccountsRoute
   '/dash/vault/torznab-indexers': typeof DashVaultTorznabIndexersRoute
+  '/dash/vault/tracker-accounts': typeof DashVaultTrackerAccountsRoute
   '/dash/vault/trakt-accounts': typeof DashVaultTraktAccountsRoute
   '/dash/lists/': typeof DashListsIndexRoute
   '/dash/settings/': typeof DashSettingsIndexRoute
@@ -217,12 +266,17 @@ export interface FileRouteTypes {
     | '/dash/vault'
     | '/dash/workers'
     | '/dash/'
+    | '/dash/settings/federated-addons'
+    | '/dash/settings/quality-profiles'
     | '/dash/settings/ratelimit-configs'
     | '/dash/sync/stremio-stremio'
+    | '/dash/sync/stremio-tracker'
     | '/dash/sync/stremio-trakt'
     | '/dash/torrents/indexers-sync'
+    | '/dash/vault/newznab-indexers'
     | '/dash/vault/stremio-accounts'
     | '/dash/vault/torznab-indexers'
+    | '/dash/vault/tracker-accounts'
     | '/dash/vault/trakt-accounts'
     | '/dash/lists/'
     | '/dash/settings/'
@@ -234,12 +288,17 @@ export interface FileRouteTypes {
     | '/dash/login'
     | '/dash/workers'
     | '/dash'
+    | '/dash/settings/federated-addons'
+    | '/dash/settings/quality-profiles'
     | '/dash/settings/ratelimit-configs'
     | '/dash/sync/stremio-stremio'
+    | '/dash/sync/stremio-tracker'
     | '/dash/sync/stremio-trakt'
     | '/dash/torrents/indexers-sync'
+    | '/dash/vault/newznab-indexers'
     | '/dash/vault/stremio-accounts'
     | '/dash/vault/torznab-indexers'
+    | '/dash/vault/tracker-accounts'
     | '/dash/vault/trakt-accounts'
     | '/dash/lists'
     | '/dash/settings'
@@ -257,12 +316,17 @@ export interface FileRouteTypes {
     | '/dash/vault'
     | '/dash/workers'
     | '/dash/'
+    | '/dash/settings/federated-addons'
+    | '/dash/settings/quality-profiles'
     | '/dash/settings/ratelimit-configs'
     | '/dash/sync/stremio-stremio'
+    | '/dash/sync/stremio-tracker'
     | '/dash/sync/stremio-trakt'
     | '/dash/torrents/indexers-sync'
+    | '/dash/vault/newznab-indexers'
     | '/dash/vault/stremio-accounts'
     | '/dash/vault/torznab-indexers'
+    | '/dash/vault/tracker-accounts'
     | '/dash/vault/trakt-accounts'
     | '/dash/lists/'
     | '/dash/settings/'
@@ -382,6 +446,13 @@ declare module '@tanstack/react-router' {
       preLoaderRoute: typeof DashVaultTraktAccountsRouteImport
       parentRoute: typeof DashVaultRoute
     }
+    '/dash/vault/tracker-accounts': {
+      id: '/dash/vault/tracker-accounts'
+      path: '/tracker-accounts'
+      fullPath: '/dash/vault/tracker-accounts'
+      preLoaderRoute: typeof DashVaultTrackerAccountsRouteImport
+      parentRoute: typeof DashVaultRoute
+    }
     '/dash/vault/torznab-indexers': {
       id: '/dash/vault/torznab-indexers'
       path: '/torznab-indexers'
@@ -396,6 +467,13 @@ declare module '@tanstack/react-router' {
       preLoaderRoute: typeof DashVaultStremioAccountsRouteImport
       parentRoute: typeof DashVaultRoute
     }
+    '/dash/vault/newznab-indexers': {
+      id: '/dash/vault/newznab-indexers'
+      path: '/newznab-indexers'
+      fullPath: '/dash/vault/newznab-indexers'
+      preLoaderRoute: typeof DashVaultNewznabIndexersRouteImport
+      parentRoute: typeof DashVaultRoute
+    }
     '/dash/torrents/indexers-sync': {
       id: '/dash/torrents/indexers-sync'
       path: '/indexers-sync'
@@ -410,6 +488,13 @@ declare module '@tanstack/react-router' {
       preLoaderRoute: typeof DashSyncStremioTraktRouteImport
       parentRoute: typeof DashSyncRoute
     }
+    '/dash/sync/stremio-tracker': {
+      id: '/dash/sync/stremio-tracker'
+      path: '/stremio-tracker'
+      fullPath: '/dash/sync/stremio-tracker'
+      preLoaderRoute: typeof DashSyncStremioTrackerRouteImport
+      parentRoute: typeof DashSyncRoute
+    }
     '/dash/sync/stremio-stremio': {
       id: '/dash/sync/stremio-stremio'
       path: '/stremio-stremio'
@@ -424,6 +509,20 @@ declare module '@tanstack/react-router' {
       preLoaderRoute: typeof DashSettingsRatelimitConfigsRouteImport
       parentRoute: typeof DashSettingsRoute
     }
+    '/dash/settings/federated-addons': {
+      id: '/dash/settings/federated-addons'
+      path: '/federated-addons'
+      fullPath: '/dash/settings/federated-addons'
+      preLoaderRoute: typeof DashSettingsFederatedAddonsRouteImport
+      parentRoute: typeof DashSettingsRoute
+    }
+    '/dash/settings/quality-profiles': {
+      id: '/dash/settings/quality-profiles'
+      path: '/quality-profiles'
+      fullPath: '/dash/settings/quality-profiles'
+      preLoaderRoute: typeof DashSettingsQualityProfilesRouteImport
+      parentRoute: typeof DashSettingsRoute
+    }
   }
 }
 
@@ -440,11 +539,15 @@ const DashListsRouteWithChildren = DashListsRoute._addFileChildren(
 )
 
 interface DashSettingsRouteChildren {
+  DashSettingsFederatedAddonsRoute: typeof DashSettingsFederatedAddonsRoute
+  DashSettingsQualityProfilesRoute: typeof DashSettingsQualityProfilesRoute
   DashSettingsRatelimitConfigsRoute: typeof DashSettingsRatelimitConfigsRoute
   DashSettingsIndexRoute: typeof DashSettingsIndexRoute
 }
 
 const DashSettingsRouteChildren: DashSettingsRouteChildren = {
+  DashSettingsFederatedAddonsRoute: DashSettingsFederatedAddonsRoute,
+  DashSettingsQualityProfilesRoute: DashSettingsQualityProfilesRoute,
   DashSettingsRatelimitConfigsRoute: DashSettingsRatelimitConfigsRoute,
   DashSettingsIndexRoute: DashSettingsIndexRoute,
 }
@@ -455,12 +558,14 @@ const DashSettingsRouteWithChildren = DashSettingsRoute._addFileChildren(
 
 interface DashSyncRouteChildren {
   DashSyncStremioStremioRoute: typeof DashSyncStremioStremioRoute
+  DashSyncStremioTrackerRoute: typeof DashSyncStremioTrackerRoute
   DashSyncStremioTraktRoute: typeof DashSyncStremioTraktRoute
   DashSyncIndexRoute: typeof DashSyncIndexRoute
 }
 
 const DashSyncRouteChildren: DashSyncRouteChildren = {
   DashSyncStremioStremioRoute: DashSyncStremioStremioRoute,
+  DashSyncStremioTrackerRoute: DashSyncStremioTrackerRoute,
   DashSyncStremioTraktRoute: DashSyncStremioTraktRoute,
   DashSyncIndexRoute: DashSyncIndexRoute,
 }
@@ -484,15 +589,19 @@ const DashTorrentsRouteWithChildren = DashTorrentsRoute._addFileChildren(
 )
 
 interface DashVaultRouteChildren {
+  DashVaultNewznabIndexersRoute: typeof DashVaultNewznabIndexersRoute
   DashVaultStremioAccountsRoute: typeof DashVaultStremioAccountsRoute
   DashVaultTorznabIndexersRoute: typeof DashVaultTorznabIndexersRoute
+  DashVaultTrackerAccountsRoute: typeof DashVaultTrackerAccountsRoute
   DashVaultTraktAccountsRoute: typeof DashVaultTraktAccountsRoute
   DashVaultIndexRoute: typeof DashVaultIndexRoute
 }
 
 const DashVaultRouteChildren: DashVaultRouteChildren = {
+  DashVaultNewznabIndexersRoute: DashVaultNewznabIndexersRoute,
   DashVaultStremioAccountsRoute: DashVaultStremioAccountsRoute,
   DashVaultTorznabIndexersRoute: DashVaultTorznabIndexersRoute,
+  DashVaultTrackerAccountsRoute: DashVaultTrackerAccountsRoute,
   DashVaultTraktAccountsRoute: DashVaultTraktAccountsRoute,
   DashVaultIndexRoute: DashVaultIndexRoute,
 }
@@ -0,0 +1,392 @@
+import { createFileRoute } from "@tanstack/react-router";
+import { ColumnDef, createColumnHelper } from "@tanstack/react-table";
+import { Plus, RefreshCw, Trash2 } from "lucide-react";
+import { DateTime } from "luxon";
+import { useState } from "react";
+import { toast } from "sonner";
+import z from "zod";
+
+import {
+  FederatedAddon,
+  useDiscoveredFederatedAddons,
+  useFederatedAddonMutation,
+  useFederatedAddons,
+} from "@/api/federated-addon";
+import { DataTable } from "@/components/data-table";
+import { useDataTable } from "@/components/data-table/use-data-table";
+import { Form } from "@/components/form/Form";
+import { useAppForm } from "@/components/form/hook";
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+  AlertDialogTrigger,
+} from "@/components/ui/alert-dialog";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import {
+  Sheet,
+  SheetContent,
+  SheetDescription,
+  SheetFooter,
+  SheetHeader,
+  SheetTitle,
+  SheetTrigger,
+} from "@/components/ui/sheet";
+import {
+  Tooltip,
+  TooltipContent,
+  TooltipTrigger,
+} from "@/components/ui/tooltip";
+import { APIError } from "@/lib/api";
+
+declare module "@/components/data-table" {
+  export interface DataTableMetaCtx {
+    FederatedAddon: ReturnType<typeof useFederatedAddonMutation>;
+  }
+
+  export interface DataTableMetaCtxKey {
+    FederatedAddon: FederatedAddon;
+  }
+}
+
+function formatDate(value: null | string) {
+  if (!value) {
+    return "";
+  }
+  return DateTime.fromISO(value).toLocaleString(DateTime.DATETIME_MED);
+}
+
+function toastError(err: APIError) {
+  console.error(err);
+  return {
+    closeButton: true,
+    message: err.error?.errors?.[0]?.message ?? err.message,
+  };
+}
+
+const col = createColumnHelper<FederatedAddon>();
+
+const columns: ColumnDef<FederatedAddon>[] = [
+  col.accessor("manifest.name", {
+    cell: ({ getValue, row }) => (
+      <div className="flex items-center gap-2">
+        {getValue()}
+        {row.original.is_peer && <Badge variant="secondary">Peer</Badge>}
+      </div>
+    ),
+    header: "Name",
+  }),
+  col.accessor("url", {
+    cell: ({ getValue }) => (
+      <div className="max-w-80 truncate" title={getValue()}>
+        {getValue()}
+      </div>
+    ),
+    header: "Manifest URL",
+  }),
+  col.accessor("status", {
+    cell: ({ getValue, row }) => {
+      const status = getValue();
+      const badge = (
+        <Badge
+          variant={
+            status === "healthy"
+              ? "default"
+              : status === "unhealthy"
+                ? "destructive"
+                : "outline"
+          }
+        >
+          {status}
+        </Badge>
+      );
+      if (!row.original.error) {
+        return badge;
+      }
+      return (
+        <Tooltip>
+          <TooltipTrigger>{badge}</TooltipTrigger>
+          <TooltipContent>{row.original.error}</TooltipContent>
+        </Tooltip>
+      );
+    },
+    header: "Status",
+  }),
+  col.accessor("seen_at", {
+    cell: ({ getValue }) => formatDate(getValue()),
+    header: "Last Seen",
+  }),
+  col.accessor("checked_at", {
+    cell: ({ getValue }) => formatDate(getValue()),
+    header: "Last Checked",
+  }),
+  col.display({
+    cell: (c) => {
+      const { check, remove } = c.table.options.meta!.ctx;
+      const item = c.row.original;
+      return (
+        <div className="flex gap-1">
+          <Tooltip>
+            <TooltipTrigger asChild>
+              <Button
+                disabled={check.isPending}
+                onClick={() => {
+                  toast.promise(check.mutateAsync(item.url), {
+                    error: toastError,
+                    loading: "Checking...",
+                    success: (data) => ({
+                      closeButton: true,
+                      message:
+                        data.status === "healthy"
+                          ? "Addon is healthy!"
+                          : "Addon is unhealthy!",
+                    }),
+                  });
+                }}
+                size="icon-sm"
+                variant="ghost"
+              >
+                <RefreshCw />
+              </Button>
+            </TooltipTrigger>
+            <TooltipContent>Check Now</TooltipContent>
+          </Tooltip>
+          <AlertDialog>
+            <AlertDialogTrigger asChild>
+              <Button size="icon-sm" variant="ghost">
+                <Trash2 className="text-destructive" />
+              </Button>
+            </AlertDialogTrigger>
+            <AlertDialogContent>
+              <AlertDialogHeader>
+                <AlertDialogTitle>Remove Federated Addon?</AlertDialogTitle>
+                <AlertDialogDescription>
+                  <strong>{item.manifest.name}</strong> will no longer be
+                  listed in the StremThru addon catalog.
+                </AlertDialogDescription>
+              </AlertDialogHeader>
+              <AlertDialogFooter>
+                <AlertDialogCancel>Cancel</AlertDialogCancel>
+                <AlertDialogAction asChild>
+                  <Button
+                    disabled={remove.isPending}
+                    onClick={() => {
+                      toast.promise(remove.mutateAsync(item.url), {
+                        error: toastError,
+                        loading: "Removing...",
+                        success: {
+                          closeButton: true,
+                          message: "Removed successfully!",
+                        },
+                      });
+                    }}
+                    variant="destructive"
+                  >
+                    Remove
+                  </Button>
+                </AlertDialogAction>
+              </AlertDialogFooter>
+            </AlertDialogContent>
+          </AlertDialog>
+        </div>
+      );
+    },
+    header: "",
+    id: "actions",
+  }),
+];
+
+const federatedAddonSchema = z.object({
+  url: z.url("Invalid URL"),
+});
+
+function FederatedAddonFormSheet() {
+  const [isOpen, setIsOpen] = useState(false);
+  const { register } = useFederatedAddonMutation();
+
+  const form = useAppForm({
+    canSubmitWhenInvalid: true,
+    defaultValues: { url: "" },
+    onSubmit: async ({ value }) => {
+      value = federatedAddonSchema.parse(value);
+      await register.mutateAsync({ is_peer: false, url: value.url });
+      toast.success("Added successfully!");
+      form.reset();
+      setIsOpen(false);
+    },
+    validators: {
+      onChange: federatedAddonSchema,
+    },
+  });
+
+  return (
+    <Sheet onOpenChange={setIsOpen} open={isOpen}>
+      <SheetTrigger asChild>
+        <Button size="sm">
+          <Plus className="mr-2 size-4" />
+          Add Addon
+        </Button>
+      </SheetTrigger>
+      <SheetContent asChild>
+        <Form form={form}>
+          <SheetHeader>
+            <SheetTitle>Add Federated Addon</SheetTitle>
+            <SheetDescription>
+              Only add addons you trust. They are listed in the StremThru addon
+              catalog for every user.
+            </SheetDescription>
+          </SheetHeader>
+
+          <div className="flex flex-col gap-4 px-4">
+            <form.AppField name="url">
+              {(field) => (
+                <field.Input
+                  label="Manifest URL"
+                  placeholder="https://example.com/manifest.json"
+                  type="text"
+                />
+              )}
+            </form.AppField>
+          </div>
+
+          <SheetFooter>
+            <form.AppForm>
+              <form.SubmitButton className="w-full">Add Addon</form.SubmitButton>
+            </form.AppForm>
+          </SheetFooter>
+        </Form>
+      </SheetContent>
+    </Sheet>
+  );
+}
+
+function PeerAddons() {
+  const [isEnabled, setIsEnabled] = useState(false);
+  const discovered = useDiscoveredFederatedAddons(isEnabled);
+  const { register } = useFederatedAddonMutation();
+
+  if (!isEnabled) {
+    return (
+      <Button
+        className="self-start"
+        onClick={() => setIsEnabled(true)}
+        size="sm"
+        variant="outline"
+      >
+        Discover from Peer
+      </Button>
+    );
+  }
+
+  if (discovered.isLoading) {
+    return <div className="text-muted-foreground text-sm">Discovering...</div>;
+  }
+
+  if (discovered.isError) {
+    return (
+      <div className="text-sm text-red-600">
+        Error discovering peer addons: {discovered.error.message}
+      </div>
+    );
+  }
+
+  if (!discovered.data?.length) {
+    return (
+      <div className="text-muted-foreground text-sm">
+        No new addons found on peer.
+      </div>
+    );
+  }
+
+  return (
+    <div className="flex flex-col gap-2">
+      {discovered.data.map((addon) => (
+        <div
+          className="flex items-center justify-between gap-4 rounded-md border p-3"
+          key={addon.url}
+        >
+          <div className="flex flex-col">
+            <span className="font-medium">{addon.manifest.name}</span>
+            <span className="text-muted-foreground text-xs">
+              {addon.manifest.description}
+            </span>
+          </div>
+          <Button
+            disabled={register.isPending}
+            onClick={() => {
+              toast.promise(
+                register.mutateAsync({ is_peer: true, url: addon.url }),
+                {
+                  error: toastError,
+                  loading: "Adding...",
+                  success: {
+                    closeButton: true,
+                    message: "Added successfully!",
+                  },
+                },
+              );
+            }}
+            size="sm"
+            variant="outline"
+          >
+            <Plus className="mr-2 size-4" />
+            Add
+          </Button>
+        </div>
+      ))}
+    </div>
+  );
+}
+
+export const Route = createFileRoute("/dash/settings/federated-addons")({
+  component: RouteComponent,
+  staticData: {
+    crumb: "Federated Addons",
+  },
+});
+
+function RouteComponent() {
+  const federatedAddons = useFederatedAddons();
+  const mutation = useFederatedAddonMutation();
+
+  const table = useDataTable({
+    columns,
+    data: federatedAddons.data ?? [],
+    initialState: {
+      columnPinning: { right: ["actions"] },
+    },
+    meta: {
+      ctx: mutation,
+    },
+  });
+
+  return (
+    <div className="flex flex-col gap-6">
+      <div className="flex items-center justify-between">
+        <h2 className="text-lg font-semibold">Federated Addons</h2>
+        <FederatedAddonFormSheet />
+      </div>
+
+      {federatedAddons.isLoading ? (
+        <div className="text-muted-foreground text-sm">Loading...</div>
+      ) : federatedAddons.isError ? (
+        <div className="text-sm text-red-600">
+          Error loading federated addons
+        </div>
+      ) : (
+        <DataTable table={table} />
+      )}
+
+      <div className="flex flex-col gap-3">
+        <h3 className="text-md font-semibold">Peer Addons</h3>
+        <PeerAddons />
+      </div>
+    </div>
+  );
+}
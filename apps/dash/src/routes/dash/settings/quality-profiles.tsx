@@ -0,0 +1,385 @@
+import { createFileRoute } from "@tanstack/react-router";
+import { ColumnDef, createColumnHelper } from "@tanstack/react-table";
+import { Pencil, Plus, Trash2 } from "lucide-react";
+import { DateTime } from "luxon";
+import { useEffect, useMemo, useState } from "react";
+import { toast } from "sonner";
+import z from "zod";
+
+import {
+  QualityProfile,
+  useQualityProfileMutation,
+  useQualityProfiles,
+} from "@/api/quality-profile";
+import { DataTable } from "@/components/data-table";
+import { useDataTable } from "@/components/data-table/use-data-table";
+import { Form } from "@/components/form/Form";
+import { useAppForm } from "@/components/form/hook";
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+  AlertDialogTrigger,
+} from "@/components/ui/alert-dialog";
+import { Button } from "@/components/ui/button";
+import { ScrollArea } from "@/components/ui/scroll-area";
+import {
+  Sheet,
+  SheetContent,
+  SheetDescription,
+  SheetFooter,
+  SheetHeader,
+  SheetTitle,
+  SheetTrigger,
+} from "@/components/ui/sheet";
+import {
+  Tooltip,
+  TooltipContent,
+  TooltipTrigger,
+} from "@/components/ui/tooltip";
+import { APIError } from "@/lib/api";
+
+declare module "@/components/data-table" {
+  export interface DataTableMetaCtx {
+    QualityProfile: {
+      onEdit: (item: QualityProfile) => void;
+      removeProfile: ReturnType<typeof useQualityProfileMutation>["remove"];
+    };
+  }
+
+  export interface DataTableMetaCtxKey {
+    QualityProfile: QualityProfile;
+  }
+}
+
+function formatMinSize(minSize: QualityProfile["min_size"]) {
+  return Object.entries(minSize ?? {})
+    .map(([resolution, size]) => `${resolution}=${size}`)
+    .join(", ");
+}
+
+function parseList(value: string) {
+  return value
+    .split(",")
+    .map((item) => item.trim())
+    .filter(Boolean);
+}
+
+function parseMinSize(value: string) {
+  const minSize: Record<string, string> = {};
+  for (const item of parseList(value)) {
+    const [resolution, size] = item.split("=").map((part) => part.trim());
+    if (resolution && size) {
+      minSize[resolution] = size;
+    }
+  }
+  return minSize;
+}
+
+const col = createColumnHelper<QualityProfile>();
+
+const columns: ColumnDef<QualityProfile>[] = [
+  col.accessor("id", {
+    header: "Name",
+  }),
+  col.accessor("resolutions", {
+    cell: ({ getValue }) => getValue()?.join(", ") || "Any",
+    header: "Resolutions",
+  }),
+  col.accessor("min_size", {
+    cell: ({ getValue }) => formatMinSize(getValue()),
+    header: "Min Size",
+  }),
+  col.accessor("seeders_half_life", {
+    cell: ({ getValue }) => (getValue() ? `${getValue()}d` : ""),
+    header: "Seeders Half Life",
+  }),
+  col.accessor("updated_at", {
+    cell: ({ getValue }) => {
+      const date = DateTime.fromISO(getValue());
+      return date.toLocaleString(DateTime.DATETIME_MED);
+    },
+    header: "Updated At",
+  }),
+  col.display({
+    cell: (c) => {
+      const { onEdit, removeProfile } = c.table.options.meta!.ctx;
+      const item = c.row.original;
+      return (
+        <div className="flex gap-1">
+          <Tooltip>
+            <TooltipTrigger asChild>
+              <Button
+                onClick={() => onEdit(item)}
+                size="icon-sm"
+                variant="ghost"
+              >
+                <Pencil />
+              </Button>
+            </TooltipTrigger>
+            <TooltipContent>Edit</TooltipContent>
+          </Tooltip>
+          <AlertDialog>
+            <AlertDialogTrigger asChild>
+              <Button size="icon-sm" variant="ghost">
+                <Trash2 className="text-destructive" />
+              </Button>
+            </AlertDialogTrigger>
+            <AlertDialogContent>
+              <AlertDialogHeader>
+                <AlertDialogTitle>Delete Quality Profile?</AlertDialogTitle>
+                <AlertDialogDescription>
+                  This will permanently delete the quality profile{" "}
+                  <strong>{item.id}</strong>. This action cannot be undone.
+                </AlertDialogDescription>
+              </AlertDialogHeader>
+              <AlertDialogFooter>
+                <AlertDialogCancel>Cancel</AlertDialogCancel>
+                <AlertDialogAction asChild>
+                  <Button
+                    disabled={removeProfile.isPending}
+                    onClick={() => {
+                      toast.promise(removeProfile.mutateAsync(item.id), {
+                        error(err: APIError) {
+                          console.error(err);
+                          return {
+                            closeButton: true,
+                            message: err.message,
+                          };
+                        },
+                        loading: "Deleting...",
+                        success: {
+                          closeButton: true,
+                          message: "Deleted successfully!",
+                        },
+                      });
+                    }}
+                    variant="destructive"
+                  >
+                    Delete
+                  </Button>
+                </AlertDialogAction>
+              </AlertDialogFooter>
+            </AlertDialogContent>
+          </AlertDialog>
+        </div>
+      );
+    },
+    header: "",
+    id: "actions",
+  }),
+];
+
+const qualityProfileSchema = z.object({
+  allow_groups: z.string(),
+  deny_groups: z.string(),
+  id: z.string().min(1, "Name is required"),
+  min_size: z.string(),
+  resolutions: z.string(),
+  seeders_half_life: z.coerce.number<number>().min(0),
+});
+
+function QualityProfileFormSheet({
+  editItem,
+  setEditItem,
+}: {
+  editItem: null | QualityProfile;
+  setEditItem: (item: null | QualityProfile) => void;
+}) {
+  const [isOpen, setIsOpen] = useState(false);
+  const { save } = useQualityProfileMutation();
+
+  useEffect(() => {
+    if (editItem) {
+      setIsOpen(true);
+    }
+  }, [editItem]);
+
+  const defaultValues = useMemo(
+    () => ({
+      allow_groups: editItem?.allow_groups?.join(", ") ?? "",
+      deny_groups: editItem?.deny_groups?.join(", ") ?? "",
+      id: editItem?.id ?? "",
+      min_size: formatMinSize(editItem?.min_size),
+      resolutions: editItem?.resolutions?.join(", ") ?? "2160p, 1080p, 720p",
+      seeders_half_life: editItem?.seeders_half_life ?? 30,
+    }),
+    [editItem],
+  );
+
+  const form = useAppForm({
+    canSubmitWhenInvalid: true,
+    defaultValues,
+    onSubmit: async ({ value }) => {
+      value = qualityProfileSchema.parse(value);
+      await save.mutateAsync({
+        allow_groups: parseList(value.allow_groups),
+        deny_groups: parseList(value.deny_groups),
+        id: value.id,
+        min_size: parseMinSize(value.min_size),
+        resolutions: parseList(value.resolutions),
+        seeders_half_life: value.seeders_half_life,
+      });
+      toast.success(
+        editItem ? "Updated successfully!" : "Created successfully!",
+      );
+      setIsOpen(false);
+    },
+    validators: {
+      onChange: qualityProfileSchema,
+    },
+  });
+
+  useEffect(() => {
+    form.reset(defaultValues);
+  }, [defaultValues, form]);
+
+  return (
+    <Sheet onOpenChange={setIsOpen} open={isOpen}>
+      <SheetTrigger asChild>
+        <Button
+          onClick={() => {
+            setEditItem(null);
+          }}
+          size="sm"
+        >
+          <Plus className="mr-2 size-4" />
+          Add Profile
+        </Button>
+      </SheetTrigger>
+      <SheetContent asChild>
+        <Form form={form}>
+          <SheetHeader>
+            <SheetTitle>{editItem ? "Edit" : "Add"} Quality Profile</SheetTitle>
+            <SheetDescription>
+              Streams not matching the profile are dropped. The rest are ranked
+              by preferred resolution, then by seeders weighted by how recently
+              they were seen.
+            </SheetDescription>
+          </SheetHeader>
+
+          <ScrollArea className="overflow-hidden">
+            <div className="flex flex-col gap-4 px-4">
+              <form.AppField name="id">
+                {(field) => (
+                  <field.Input
+                    disabled={Boolean(editItem)}
+                    label="Name"
+                    type="text"
+                  />
+                )}
+              </form.AppField>
+              <form.AppField name="resolutions">
+                {(field) => (
+                  <field.Input
+                    label="Resolutions (in order of preference)"
+                    placeholder="e.g., 2160p, 1080p, 720p"
+                    type="text"
+                  />
+                )}
+              </form.AppField>
+              <form.AppField name="min_size">
+                {(field) => (
+                  <field.Input
+                    label="Min Size per Resolution"
+                    placeholder="e.g., 2160p=8GB, 1080p=2GB"
+                    type="text"
+                  />
+                )}
+              </form.AppField>
+              <form.AppField name="allow_groups">
+                {(field) => (
+                  <field.Input
+                    label="Allowed Release Groups"
+                    placeholder="leave empty to allow all"
+                    type="text"
+                  />
+                )}
+              </form.AppField>
+              <form.AppField name="deny_groups">
+                {(field) => (
+                  <field.Input label="Denied Release Groups" type="text" />
+                )}
+              </form.AppField>
+              <form.AppField name="seeders_half_life">
+                {(field) => (
+                  <field.Input
+                    label="Seeders Half Life (days)"
+                    min={0}
+                    type="number"
+                  />
+                )}
+              </form.AppField>
+            </div>
+          </ScrollArea>
+
+          <SheetFooter>
+            <form.AppForm>
+              <form.SubmitButton className="w-full">
+                {editItem ? "Update" : "Add"} Quality Profile
+              </form.SubmitButton>
+            </form.AppForm>
+          </SheetFooter>
+        </Form>
+      </SheetContent>
+    </Sheet>
+  );
+}
+
+export const Route = createFileRoute("/dash/settings/quality-profiles")({
+  component: RouteComponent,
+  staticData: {
+    crumb: "Quality Profiles",
+  },
+});
+
+function RouteComponent() {
+  const qualityProfiles = useQualityProfiles();
+  const { remove: removeProfile } = useQualityProfileMutation();
+
+  const [editItem, setEditItem] = useState<null | QualityProfile>(null);
+  const onEditItem = (item: QualityProfile) => {
+    setEditItem(item);
+  };
+
+  const table = useDataTable({
+    columns,
+    data: qualityProfiles.data ?? [],
+    initialState: {
+      columnPinning: { right: ["actions"] },
+    },
+    meta: {
+      ctx: {
+        onEdit: onEditItem,
+        removeProfile,
+      },
+    },
+  });
+
+  return (
+    <div className="flex flex-col gap-6">
+      <div className="flex items-center justify-between">
+        <h2 className="text-lg font-semibold">Quality Profiles</h2>
+        <QualityProfileFormSheet
+          editItem={editItem}
+          setEditItem={setEditItem}
+        />
+      </div>
+
+      {qualityProfiles.isLoading ? (
+        <div className="text-muted-foreground text-sm">Loading...</div>
+      ) : qualityProfiles.isError ? (
+        <div className="text-sm text-red-600">
+          Error loading quality profiles
+        </div>
+      ) : (
+        <DataTable table={table} />
+      )}
+    </div>
+  );
+}
@@ -15,6 +15,7 @@ import { toast } from "sonner";
 
 import {
   StremioTraktLink,
+  SyncConfig,
   SyncDirection,
   useStremioTraktLinkMutation,
   useStremioTraktLinks,
@@ -97,6 +98,63 @@ const syncDirectionOptions: Array<{
   },
 ];
 
+const syncConfigKeys: Array<{
+  key: keyof SyncConfig;
+  label: string;
+}> = [
+  { key: "watched", label: "Watched Sync Direction" },
+  { key: "watchlist", label: "Watchlist Sync Direction" },
+  { key: "collection", label: "Collection Sync Direction" },
+  { key: "ratings", label: "Ratings Sync Direction" },
+];
+
+function SyncDirectionSelect({
+  label,
+  onChange,
+  value,
+}: {
+  label: string;
+  onChange: (value: SyncDirection) => void;
+  value: SyncDirection;
+}) {
+  const selectedOption = syncDirectionOptions.find(
+    (opt) => opt.value === value,
+  );
+  const SelectedIcon = selectedOption?.icon || XCircle;
+
+  return (
+    <div className="flex flex-col gap-2">
+      <label className="text-sm font-medium">{label}</label>
+      <Select
+        onValueChange={(value) => onChange(value as SyncDirection)}
+        value={value}
+      >
+        <SelectTrigger className="w-full">
+          <SelectValue>
+            <div className="flex items-center gap-2">
+              <SelectedIcon className="size-4" />
+              {selectedOption?.label}
+            </div>
+          </SelectValue>
+        </SelectTrigger>
+        <SelectContent>
+          {syncDirectionOptions.map((option) => {
+            const OptionIcon = option.icon;
+            return (
+              <SelectItem key={option.value} value={option.value}>
+                <div className="flex items-center gap-2">
+                  <OptionIcon className="size-4" />
+                  {option.label}
+                </div>
+              </SelectItem>
+            );
+          })}
+        </SelectContent>
+      </Select>
+    </div>
+  );
+}
+
 function LinkAccountSheet({
   onClose,
   stremioAccounts,
@@ -119,7 +177,12 @@ function LinkAccountSheet({
     onSubmit: async ({ value }) => {
       await create.mutateAsync({
         stremio_account_id: value.stremio_account_id,
-        sync_config: { watched: { dir: "none" } },
+        sync_config: {
+          collection: { dir: "none" },
+          ratings: { dir: "none" },
+          watched: { dir: "none" },
+          watchlist: { dir: "none" },
+        },
         trakt_account_id: value.trakt_account_id,
       });
       toast.success("Accounts linked successfully!");
@@ -232,16 +295,33 @@ function LinkCard({
   const { remove, resetSyncState, sync, update } =
     useStremioTraktLinkMutation();
 
-  const selectedWatchedSyncDirection = syncDirectionOptions.find(
-    (opt) => opt.value === link.sync_config.watched.dir,
+  const syncConfig: SyncConfig = {
+    collection: { dir: link.sync_config.collection?.dir || "none" },
+    ratings: { dir: link.sync_config.ratings?.dir || "none" },
+    watched: { dir: link.sync_config.watched.dir },
+    watchlist: { dir: link.sync_config.watchlist?.dir || "none" },
+  };
+  const isSyncDisabled = syncConfigKeys.every(
+    ({ key }) => syncConfig[key].dir === "none",
   );
-  const SyncDirectionIcon = selectedWatchedSyncDirection?.icon || XCircle;
+  const lastSyncedAt = [
+    link.sync_state.watched.last_synced_at,
+    link.sync_state.watchlist?.last_synced_at,
+    link.sync_state.collection?.last_synced_at,
+    link.sync_state.ratings?.last_synced_at,
+  ]
+    .filter((value): value is string => Boolean(value))
+    .sort()
+    .at(-1);
 
-  const handleWatchedSyncDirectionChange = (value: string) => {
+  const handleSyncDirectionChange = (
+    key: keyof SyncConfig,
+    value: SyncDirection,
+  ) => {
     toast.promise(
       update.mutateAsync({
         stremio_account_id: link.stremio_account_id,
-        sync_config: { watched: { dir: value as SyncDirection } },
+        sync_config: { ...syncConfig, [key]: { dir: value } },
         trakt_account_id: link.trakt_account_id,
       }),
       {
@@ -351,46 +431,25 @@ function LinkCard({
         </CardDescription>
       </CardHeader>
       <CardContent className="flex flex-col gap-4">
-        <div className="flex flex-col gap-2">
-          <label className="text-sm font-medium">Watched Sync Direction</label>
-          <Select
-            onValueChange={handleWatchedSyncDirectionChange}
-            value={link.sync_config.watched.dir}
-          >
-            <SelectTrigger className="w-full">
-              <SelectValue>
-                <div className="flex items-center gap-2">
-                  <SyncDirectionIcon className="size-4" />
-                  {selectedWatchedSyncDirection?.label}
-                </div>
-              </SelectValue>
-            </SelectTrigger>
-            <SelectContent>
-              {syncDirectionOptions.map((option) => {
-                const OptionIcon = option.icon;
-                return (
-                  <SelectItem key={option.value} value={option.value}>
-                    <div className="flex items-center gap-2">
-                      <OptionIcon className="size-4" />
-                      {option.label}
-                    </div>
-                  </SelectItem>
-                );
-              })}
-            </SelectContent>
-          </Select>
-        </div>
+        {syncConfigKeys.map(({ key, label }) => (
+          <SyncDirectionSelect
+            key={key}
+            label={label}
+            onChange={(value) => handleSyncDirectionChange(key, value)}
+            value={syncConfig[key].dir}
+          />
+        ))}
 
-        {link.sync_state.watched.last_synced_at && (
+        {lastSyncedAt && (
           <div className="text-muted-foreground flex flex-col gap-1 text-sm">
             <div className="flex items-center justify-between gap-2">
               <div className="flex items-center gap-1">
                 <CheckCircle className="size-3.5 text-green-500" />
                 <span>
                   Last synced:{" "}
-                  {DateTime.fromISO(
-                    link.sync_state.watched.last_synced_at,
-                  ).toLocaleString(DateTime.DATETIME_MED)}
+                  {DateTime.fromISO(lastSyncedAt).toLocaleString(
+                    DateTime.DATETIME_MED,
+                  )}
                 </span>
               </div>
               <AlertDialog>
@@ -428,7 +487,7 @@ function LinkCard({
       <CardFooter className="mt-auto gap-4">
         <Button
           className="hidden flex-1"
-          disabled={link.sync_config.watched.dir === "none" || sync.isPending}
+          disabled={isSyncDisabled || sync.isPending}
           onClick={handleSync}
           size="sm"
           variant="outline"
@@ -504,7 +563,8 @@ function RouteComponent() {
         <div>
           <h2 className="text-lg font-semibold">Stremio ↔ Trakt Sync</h2>
           <p className="text-muted-foreground text-sm">
-            Link Stremio and Trakt accounts to sync watch history
+            Link Stremio and Trakt accounts to sync watch history, watchlist,
+            collection and ratings
           </p>
         </div>
         <Sheet onOpenChange={setSheetOpen} open={sheetOpen}>
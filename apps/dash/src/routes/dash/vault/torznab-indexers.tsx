@@ -11,6 +11,7 @@ import {
 } from "@/api/ratelimit-config";
 import {
   TorznabIndexer,
+  TorznabIndexerType,
   useTorznabIndexerMutation,
   useTorznabIndexers,
 } from "@/api/vault-torznab-indexer";
@@ -63,6 +64,13 @@ declare module "@/components/data-table" {
 
 const col = createColumnHelper<TorznabIndexer>();
 
+const indexerTypeOptions: Array<{ label: string; value: TorznabIndexerType }> =
+  [
+    { label: "Jackett", value: "jackett" },
+    { label: "Prowlarr", value: "prowlarr" },
+    { label: "Torznab", value: "torznab" },
+  ];
+
 function RateLimitConfigName({ id }: { id: null | string }) {
   const conf = useRateLimitConfig(id);
   return conf ? conf.name : "-";
@@ -224,9 +232,15 @@ function TorznabIndexerFormSheet({
       api_key: "",
       name: editItem?.name ?? "",
       rate_limit_config_id: editItem?.rate_limit_config_id ?? "",
+      type: editItem?.type ?? "",
       url: editItem?.url ?? "",
     }),
-    [editItem?.name, editItem?.rate_limit_config_id, editItem?.url],
+    [
+      editItem?.name,
+      editItem?.rate_limit_config_id,
+      editItem?.type,
+      editItem?.url,
+    ],
   );
 
   const form = useAppForm({
@@ -245,6 +259,7 @@ function TorznabIndexerFormSheet({
           api_key: value.api_key,
           name: value.name,
           rate_limit_config_id: value.rate_limit_config_id || null,
+          type: (value.type as TorznabIndexerType) || undefined,
           url: value.url,
         });
         toast.success("Created successfully!");
@@ -277,7 +292,7 @@ function TorznabIndexerFormSheet({
             <SheetDescription>
               {editItem
                 ? "Update the API key for this Torznab indexer."
-                : "Add a Jackett indexer. The API key will be encrypted before storage."}
+                : "Add a Jackett, Prowlarr or generic Torznab indexer. The API key will be encrypted before storage."}
             </SheetDescription>
           </SheetHeader>
 
@@ -286,6 +301,16 @@ function TorznabIndexerFormSheet({
               <form.AppField name="name">
                 {(field) => <field.Input label="Name" type="text" />}
               </form.AppField>
+              <form.AppField name="type">
+                {(field) => (
+                  <field.Select
+                    disabled={Boolean(editItem)}
+                    label="Type"
+                    options={indexerTypeOptions}
+                    placeholder="Detect from URL"
+                  />
+                )}
+              </form.AppField>
               <form.AppField name="url">
                 {(field) => (
                   <field.Input
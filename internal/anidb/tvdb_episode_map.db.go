@@ -311,6 +311,75 @@ func (ms AniDBTVDBEpisodeMaps) GetByAnidbEpisode(ep int) *AniDBTVDBEpisodeMap {
 	return nil
 }
 
+// GetAniDBEpisode translates the TVDB season episode to the episode of the
+// AniDB regular season. For split seasons, the map with largest offset covering
+// the episode wins. Returns empty anidbId if not found.
+func (ms AniDBTVDBEpisodeMaps) GetAniDBEpisode(tvdbSeason, tvdbEpisode int) (anidbId string, anidbEpisode int) {
+	if tvdbSeason < 1 || tvdbEpisode < 1 {
+		return "", -1
+	}
+	for i := range ms {
+		m := &ms[i]
+		if !m.IsAniDBRegularSeason() || m.TVDBSeason != tvdbSeason {
+			continue
+		}
+		for anidbEp, tvdbEps := range m.Map {
+			if slices.Contains(tvdbEps, tvdbEpisode) {
+				return m.AniDBId, anidbEp
+			}
+		}
+	}
+	var match *AniDBTVDBEpisodeMap
+	for i := range ms {
+		m := &ms[i]
+		if !m.IsAniDBRegularSeason() || m.TVDBSeason != tvdbSeason {
+			continue
+		}
+		ep := tvdbEpisode - m.Offset
+		if ep < 1 || (m.Start != 0 && ep < m.Start) || (m.End != 0 && ep > m.End) {
+			continue
+		}
+		if match == nil || match.Offset < m.Offset {
+			match = m
+		}
+	}
+	if match == nil {
+		return "", -1
+	}
+	return match.AniDBId, tvdbEpisode - match.Offset
+}
+
+// GetTVDBEpisodes translates the episode of the AniDB regular season to the
+// TVDB season episodes, the reverse of GetAniDBEpisode. Returns -1 season if
+// not found.
+func (ms AniDBTVDBEpisodeMaps) GetTVDBEpisodes(anidbId string, anidbEpisode int) (tvdbSeason int, tvdbEpisodes []int) {
+	if anidbEpisode < 1 {
+		return -1, nil
+	}
+	for i := range ms {
+		m := &ms[i]
+		if m.AniDBId != anidbId || !m.IsAniDBRegularSeason() || m.TVDBSeason < 1 {
+			continue
+		}
+		if eps, ok := m.Map[anidbEpisode]; ok && len(eps) > 0 {
+			return m.TVDBSeason, eps
+		}
+	}
+	for i := range ms {
+		m := &ms[i]
+		if m.AniDBId != anidbId || !m.IsAniDBRegularSeason() || m.TVDBSeason < 1 {
+			continue
+		}
+		if (m.Start != 0 && anidbEpisode < m.Start) || (m.End != 0 && anidbEpisode > m.End) {
+			continue
+		}
+		if ep := anidbEpisode + m.Offset; ep > 0 {
+			return m.TVDBSeason, []int{ep}
+		}
+	}
+	return -1, nil
+}
+
 func (ms AniDBTVDBEpisodeMaps) GetTVDBId() string {
 	return ms[0].TVDBId
 }
@@ -548,3 +617,45 @@ func GetTVDBEpisodeMaps(anidbId string, includeRelated bool) (*AniDBTVDBEpisodeM
 	maps.Sort()
 	return &AniDBTVDBEpisodeMapsResult{AniDBTVDBEpisodeMaps: maps}, nil
 }
+
+var query_get_tvdb_episode_maps_by_tvdbid = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ?`,
+	db.JoinColumnNames(TVDBEpisodeMapColumns...),
+	TVDBEpisodeMapTableName,
+	TVDBEpisodeMapColumn.TVDBId,
+)
+
+func GetTVDBEpisodeMapsByTVDBId(tvdbId string) (AniDBTVDBEpisodeMaps, error) {
+	rows, err := db.Query(query_get_tvdb_episode_maps_by_tvdbid, tvdbId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	maps := AniDBTVDBEpisodeMaps{}
+	for rows.Next() {
+		m := AniDBTVDBEpisodeMap{
+			Before: AniDBTVDBEpisodeMapBefore{},
+			Map:    AniDBTVDBEpisodeMapMap{},
+		}
+		if err := rows.Scan(
+			&m.AniDBId,
+			&m.TVDBId,
+			&m.AniDBSeason,
+			&m.TVDBSeason,
+			&m.Start,
+			&m.End,
+			&m.Offset,
+			&m.Before,
+			&m.Map,
+		); err != nil {
+			return nil, err
+		}
+		maps = append(maps, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	maps.Sort()
+	return maps, nil
+}
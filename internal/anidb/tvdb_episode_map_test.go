@@ -0,0 +1,85 @@
+package anidb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testTVDBEpisodeMaps = AniDBTVDBEpisodeMaps{
+	// single anidb entry split across tvdb seasons
+	{AniDBId: "19", TVDBId: "83692", AniDBSeason: 1, TVDBSeason: -1},
+	{AniDBId: "19", TVDBId: "83692", AniDBSeason: 1, TVDBSeason: 1, Start: 1, End: 12},
+	{AniDBId: "19", TVDBId: "83692", AniDBSeason: 1, TVDBSeason: 2, Start: 13, End: 24, Offset: -12},
+	// split cours of the same tvdb season
+	{AniDBId: "100", TVDBId: "1000", AniDBSeason: 1, TVDBSeason: 1},
+	{AniDBId: "101", TVDBId: "1000", AniDBSeason: 1, TVDBSeason: 1, Offset: 12},
+	{AniDBId: "101", TVDBId: "1000", AniDBSeason: 0, TVDBSeason: 0, Map: AniDBTVDBEpisodeMapMap{1: {1}}},
+	// explicit episode mapping
+	{AniDBId: "200", TVDBId: "2000", AniDBSeason: 1, TVDBSeason: 3, Map: AniDBTVDBEpisodeMapMap{1: {1, 2}, 2: {3}}},
+}
+
+func TestAniDBTVDBEpisodeMapsGetAniDBEpisode(t *testing.T) {
+	ms := testTVDBEpisodeMaps
+
+	getByTVDBId := func(tvdbId string) AniDBTVDBEpisodeMaps {
+		result := AniDBTVDBEpisodeMaps{}
+		for _, m := range ms {
+			if m.TVDBId == tvdbId {
+				result = append(result, m)
+			}
+		}
+		return result
+	}
+
+	for _, tc := range []struct {
+		name         string
+		tvdbId       string
+		season       int
+		episode      int
+		anidbId      string
+		anidbEpisode int
+	}{
+		{"first season", "83692", 1, 5, "19", 5},
+		{"second season", "83692", 2, 1, "19", 13},
+		{"second season out of range", "83692", 2, 13, "", -1},
+		{"first cour", "1000", 1, 12, "100", 12},
+		{"second cour", "1000", 1, 13, "101", 1},
+		{"explicit map", "2000", 3, 2, "200", 1},
+		{"explicit map next", "2000", 3, 3, "200", 2},
+		{"special", "1000", 0, 1, "", -1},
+		{"unknown season", "83692", 4, 1, "", -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			anidbId, anidbEpisode := getByTVDBId(tc.tvdbId).GetAniDBEpisode(tc.season, tc.episode)
+			assert.Equal(t, tc.anidbId, anidbId)
+			assert.Equal(t, tc.anidbEpisode, anidbEpisode)
+		})
+	}
+}
+
+func TestAniDBTVDBEpisodeMapsGetTVDBEpisodes(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		anidbId      string
+		anidbEpisode int
+		season       int
+		episodes     []int
+	}{
+		{"first season", "19", 5, 1, []int{5}},
+		{"second season", "19", 13, 2, []int{1}},
+		{"out of range", "19", 25, -1, nil},
+		{"first cour", "100", 12, 1, []int{12}},
+		{"second cour", "101", 1, 1, []int{13}},
+		{"explicit map", "200", 1, 3, []int{1, 2}},
+		{"explicit map next", "200", 2, 3, []int{3}},
+		{"unknown anidb id", "300", 1, -1, nil},
+		{"invalid episode", "19", 0, -1, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			season, episodes := testTVDBEpisodeMaps.GetTVDBEpisodes(tc.anidbId, tc.anidbEpisode)
+			assert.Equal(t, tc.season, season)
+			assert.Equal(t, tc.episodes, episodes)
+		})
+	}
+}
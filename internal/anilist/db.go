@@ -346,6 +346,15 @@ func getMedias(mediaIds []int, scoreByMediaId map[int]int) ([]AniListMedia, erro
 	return items, nil
 }
 
+// GetMediaById returns the media from database. Returns nil if not found.
+func GetMediaById(id int) (*AniListMedia, error) {
+	medias, err := getMedias([]int{id}, nil)
+	if err != nil || len(medias) == 0 {
+		return nil, err
+	}
+	return &medias[0], nil
+}
+
 func getListMedias(listId string) ([]AniListMedia, error) {
 	mediaIds, scoreByMediaId, err := getListMediaIds(listId)
 	if err != nil {
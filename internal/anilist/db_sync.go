@@ -123,6 +123,64 @@ func ScheduleIdMapSync(medias []AniListMedia) {
 	}
 }
 
+func toAniListMedia(media *Media) AniListMedia {
+	return AniListMedia{
+		Id:          media.Id,
+		Title:       media.Title,
+		Type:        media.Format,
+		Description: media.Description,
+		Banner:      media.BannerImage,
+		Cover:       media.CoverImage,
+		Duration:    media.Duration,
+		IsAdult:     media.IsAdult,
+		StartYear:   media.StartYear,
+		UpdatedAt:   db.Timestamp{Time: time.Now()},
+		Genres:      media.Genres,
+	}
+}
+
+func fetchMedia(id int, dbMedia *AniListMedia) (*AniListMedia, error) {
+	log.Debug("fetching media", "id", id)
+	fetchedMedias, err := FetchMedias([]int{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(fetchedMedias) == 0 {
+		return dbMedia, nil
+	}
+
+	media := toAniListMedia(&fetchedMedias[0])
+	if dbMedia != nil {
+		media.IdMap = dbMedia.IdMap
+	}
+	if err := upsertMedias(db.GetDB(), []AniListMedia{media}); err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// GetMedia returns the media from database, fetching it from AniList when
+// missing. Stale media is refreshed in background. Returns nil if not found.
+func GetMedia(id int) (*AniListMedia, error) {
+	dbMedia, err := GetMediaById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbMedia == nil {
+		return fetchMedia(id, nil)
+	}
+
+	if dbMedia.IsStale() {
+		go func() {
+			if _, err := fetchMedia(id, dbMedia); err != nil {
+				log.Error("failed to refresh stale media", "error", err, "id", id)
+			}
+		}()
+	}
+	return dbMedia, nil
+}
+
 func getListCacheKey(l *AniListList) string {
 	return l.Id
 }
@@ -181,19 +239,7 @@ func syncList(l *AniListList) error {
 
 		for i := range medias {
 			media := &medias[i]
-			dbMedia := AniListMedia{
-				Id:          media.Id,
-				Title:       media.Title,
-				Type:        media.Format,
-				Description: media.Description,
-				Banner:      media.BannerImage,
-				Cover:       media.CoverImage,
-				Duration:    media.Duration,
-				IsAdult:     media.IsAdult,
-				StartYear:   media.StartYear,
-				UpdatedAt:   db.Timestamp{Time: time.Now()},
-				Genres:      media.Genres,
-			}
+			dbMedia := toAniListMedia(media)
 			if dbM, ok := dbMediaById[media.Id]; ok {
 				dbMedia.IdMap = dbM.IdMap
 			}
@@ -0,0 +1,191 @@
+package anilist
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/oauth"
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/hasura/go-graphql-client"
+	"golang.org/x/oauth2"
+)
+
+type MediaListStatus string
+
+const (
+	MediaListStatusCurrent   MediaListStatus = "CURRENT"
+	MediaListStatusPlanning  MediaListStatus = "PLANNING"
+	MediaListStatusCompleted MediaListStatus = "COMPLETED"
+	MediaListStatusDropped   MediaListStatus = "DROPPED"
+	MediaListStatusPaused    MediaListStatus = "PAUSED"
+	MediaListStatusRepeating MediaListStatus = "REPEATING"
+)
+
+type ViewerClient struct {
+	UserId string
+	client *graphql.Client
+}
+
+var viewerClientCache = cache.NewLRUCache[ViewerClient](&cache.CacheConfig{
+	Lifetime: 1 * time.Hour,
+	Name:     "anilist:viewer-client",
+})
+
+// GetViewerClient returns a client authenticated as the owner of the oauth
+// token, nil if the token does not exist.
+func GetViewerClient(tokenId string) *ViewerClient {
+	var cachedClient ViewerClient
+	if viewerClientCache.Get(tokenId, &cachedClient) {
+		return &cachedClient
+	}
+
+	otok, _ := oauth.GetOAuthTokenById(tokenId)
+	if otok == nil {
+		return nil
+	}
+
+	httpClient := oauth2.NewClient(
+		context.WithValue(context.Background(), oauth2.HTTPClient, config.GetHTTPClient(config.TUNNEL_TYPE_AUTO)),
+		oauth.DatabaseTokenSource(&oauth.DatabaseTokenSourceConfig{
+			OAuth:             &oauth.AniListOAuthConfig.Config,
+			TokenSourceConfig: oauth.AniListTokenSourceConfig,
+		}, otok.ToToken()),
+	)
+
+	client := ViewerClient{
+		UserId: otok.UserId,
+		client: graphql.NewClient(
+			"https://graphql.anilist.co/graphql",
+			httpClient,
+			graphql.WithRetry(3),
+			graphql.WithRetryBaseDelay(2*time.Second),
+			graphql.WithRetryExponentialRate(2),
+			graphql.WithRetryHTTPStatus([]int{http.StatusTooManyRequests}),
+		).WithDebug(config.Environment == config.EnvDev),
+	}
+
+	viewerClientCache.Add(tokenId, client)
+
+	return &client
+}
+
+const fetchViewerQuery = `query {
+  Viewer {
+    id
+    name
+  }
+}`
+
+type Viewer struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c ViewerClient) FetchViewer() (*Viewer, error) {
+	var data struct {
+		Viewer Viewer `json:"Viewer"`
+	}
+	if err := c.client.Exec(context.Background(), fetchViewerQuery, &data, nil); err != nil {
+		return nil, err
+	}
+	return &data.Viewer, nil
+}
+
+type ViewerMediaListEntry struct {
+	MediaId   int             `json:"mediaId"`
+	Progress  int             `json:"progress"`
+	Status    MediaListStatus `json:"status"`
+	UpdatedAt int64           `json:"updatedAt"` // unix timestamp
+	Media     struct {
+		Episodes int `json:"episodes"`
+	} `json:"media"`
+}
+
+const fetchViewerAnimeListQuery = `query ($userId: Int!) {
+  MediaListCollection(userId: $userId, type: ANIME) {
+    lists {
+      entries {
+        mediaId
+        progress
+        status
+        updatedAt
+        media {
+          episodes
+        }
+      }
+    }
+  }
+}`
+
+type fetchViewerAnimeListData struct {
+	MediaListCollection struct {
+		Lists []struct {
+			Entries []ViewerMediaListEntry `json:"entries"`
+		} `json:"lists"`
+	} `json:"MediaListCollection"`
+}
+
+// FetchAnimeList returns the anime list entries of the viewer, by media id.
+func (c ViewerClient) FetchAnimeList() (map[int]ViewerMediaListEntry, error) {
+	var data fetchViewerAnimeListData
+	err := c.client.Exec(context.Background(), fetchViewerAnimeListQuery, &data, map[string]any{
+		"userId": util.SafeParseInt(c.UserId, 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	entryByMediaId := map[int]ViewerMediaListEntry{}
+	for i := range data.MediaListCollection.Lists {
+		for _, entry := range data.MediaListCollection.Lists[i].Entries {
+			entryByMediaId[entry.MediaId] = entry
+		}
+	}
+	return entryByMediaId, nil
+}
+
+const saveMediaListEntryMutation = `mutation ($mediaId: Int!, $progress: Int!, $status: MediaListStatus) {
+  SaveMediaListEntry(mediaId: $mediaId, progress: $progress, status: $status) {
+    id
+  }
+}`
+
+func (c ViewerClient) SaveMediaListEntry(mediaId, progress int, status MediaListStatus) error {
+	var data struct {
+		SaveMediaListEntry struct {
+			Id int `json:"id"`
+		} `json:"SaveMediaListEntry"`
+	}
+	input := map[string]any{
+		"mediaId":  mediaId,
+		"progress": progress,
+	}
+	if status != "" {
+		input["status"] = status
+	}
+	return c.client.Exec(context.Background(), saveMediaListEntryMutation, &data, input)
+}
+
+const fetchMediaEpisodesQuery = `query ($id: Int!) {
+  Media(id: $id, type: ANIME) {
+    episodes
+  }
+}`
+
+// FetchMediaEpisodes returns the total episodes of the anime, 0 if unknown.
+func FetchMediaEpisodes(mediaId int) (int, error) {
+	var data struct {
+		Media struct {
+			Episodes int `json:"episodes"`
+		} `json:"Media"`
+	}
+	err := client.Exec(context.Background(), fetchMediaEpisodesQuery, &data, map[string]any{
+		"id": mediaId,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return data.Media.Episodes, nil
+}
@@ -193,6 +193,54 @@ func GetIdMapsForAniList(ids []int) ([]AnimeIdMap, error) {
 	for i := range ids {
 		args[i] = strconv.Itoa(ids[i])
 	}
+	return queryIdMaps(query, args...)
+}
+
+var query_get_id_maps_by_imdb_id = fmt.Sprintf(
+	"SELECT %s FROM %s WHERE %s = ? OR %s IN (SELECT %s FROM %s WHERE %s = ? AND %s IS NOT NULL)",
+	strings.Join(IdMapColumns, ","),
+	IdMapTableName,
+	IdMapColumn.IMDB,
+	IdMapColumn.TVDB,
+	IdMapColumn.TVDB,
+	IdMapTableName,
+	IdMapColumn.IMDB,
+	IdMapColumn.TVDB,
+)
+
+// GetIdMapsByIds looks up id maps by any of the id columns, e.g.
+// `IdMapColumn.Kitsu`.
+func GetIdMapsByIds(column string, ids []string) ([]AnimeIdMap, error) {
+	count := len(ids)
+	if count == 0 {
+		return nil, nil
+	}
+	switch column {
+	case IdMapColumn.AniDB, IdMapColumn.AniList, IdMapColumn.AniSearch, IdMapColumn.AnimePlanet, IdMapColumn.Kitsu, IdMapColumn.LiveChart, IdMapColumn.MAL, IdMapColumn.NotifyMoe:
+	default:
+		return nil, fmt.Errorf("unsupported id column: %s", column)
+	}
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s IN (%s)",
+		strings.Join(IdMapColumns, ","),
+		IdMapTableName,
+		column,
+		util.RepeatJoin("?", count, ","),
+	)
+	args := make([]any, count)
+	for i := range ids {
+		args[i] = ids[i]
+	}
+	return queryIdMaps(query, args...)
+}
+
+// GetIdMapsByIMDBId also includes the id maps sharing the same TVDB id, i.e.
+// all the seasons of the series.
+func GetIdMapsByIMDBId(imdbId string) ([]AnimeIdMap, error) {
+	return queryIdMaps(query_get_id_maps_by_imdb_id, imdbId, imdbId)
+}
+
+func queryIdMaps(query string, args ...any) ([]AnimeIdMap, error) {
 	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, err
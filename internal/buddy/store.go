@@ -30,7 +30,22 @@ var Peer = peer.NewAPIClient(&peer.APIClientConfig{
 
 var peerLog = logger.Scoped("buddy:upstream")
 
+// isUntrackedStore checks if the store's magnets should be kept out of the
+// shared cache, i.e. multi store (tracked by member stores) and local store.
+func isUntrackedStore(s store.Store) bool {
+	switch s.GetName() {
+	case store.StoreNameLocal, store.StoreNameMulti:
+		return true
+	default:
+		return false
+	}
+}
+
 func TrackMagnet(s store.Store, hash string, name string, size int64, private bool, files []store.MagnetFile, tInfoCategory torrent_info.TorrentInfoCategory, cacheMiss bool, storeToken string) {
+	if isUntrackedStore(s) {
+		return
+	}
+
 	storeCode := s.GetName().Code()
 	tInfoSource := torrent_info.TorrentInfoSource(storeCode)
 	tsFiles := torrent_stream.Files{}
@@ -107,6 +122,10 @@ func BulkTrackMagnet(s store.Store, tInfos []TorrentInfoInput, cached map[string
 		return
 	}
 
+	if isUntrackedStore(s) {
+		return
+	}
+
 	storeCode := s.GetName().Code()
 	tInfoSource := torrent_info.TorrentInfoSource(storeCode)
 	filesByHash := map[string]torrent_stream.Files{}
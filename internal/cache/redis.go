@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/redis"
 	"github.com/elastic/go-freelru"
 	rc "github.com/go-redis/cache/v9"
@@ -64,10 +65,8 @@ func (cache *RedisCache[V]) AddWithLifetime(key string, value V, lifetime time.D
 
 func (cache *RedisCache[V]) Get(key string, value *V) bool {
 	err := cache.c.Get(context.Background(), cache.name+":"+key, value)
-	if err != nil {
-		return false
-	}
-	return true
+	metrics.ObserveCacheLookup(cache.name, err == nil)
+	return err == nil
 }
 
 func (cache *RedisCache[V]) Remove(key string) {
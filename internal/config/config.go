@@ -16,6 +16,7 @@ import (
 	llog "github.com/MunifTanjim/stremthru/internal/logger/log"
 	"github.com/MunifTanjim/stremthru/internal/util"
 	"github.com/MunifTanjim/stremthru/store"
+	"github.com/MunifTanjim/stremthru/store/multi"
 	"github.com/google/uuid"
 )
 
@@ -57,6 +58,7 @@ var defaultValueByEnv = map[string]map[string]string{
 	"": {
 		"STREMTHRU_BASE_URL":                               "http://localhost:8080",
 		"STREMTHRU_CONTENT_PROXY_CONNECTION_LIMIT":         "*:0",
+		"STREMTHRU_CONTENT_PROXY_QUOTA":                    "",
 		"STREMTHRU_DATABASE_URI":                           "sqlite://./data/stremthru.db",
 		"STREMTHRU_DATA_DIR":                               "./data",
 		"STREMTHRU_LANDING_PAGE":                           "{}",
@@ -74,6 +76,10 @@ var defaultValueByEnv = map[string]map[string]string{
 		"STREMTHRU_INTEGRATION_TRAKT_LIST_STALE_TIME":      "12h",
 		"STREMTHRU_INTEGRATION_TVDB_LIST_STALE_TIME":       "12h",
 		"STREMTHRU_STREMIO_LIST_PUBLIC_MAX_LIST_COUNT":     "10",
+		"STREMTHRU_STREMIO_P2P_CACHE_SIZE":                 "10GB",
+		"STREMTHRU_STREMIO_P2P_IDLE_TIMEOUT":               "15m",
+		"STREMTHRU_STREMIO_SIDEKICK_BACKUP_INTERVAL":       "24h",
+		"STREMTHRU_STREMIO_SIDEKICK_BACKUP_KEEP_COUNT":     "7",
 		"STREMTHRU_STREMIO_STORE_CATALOG_ITEM_LIMIT":       "2000",
 		"STREMTHRU_STREMIO_STORE_CATALOG_CACHE_TIME":       "10m",
 		"STREMTHRU_STREMIO_TORZ_INDEXER_MAX_TIMEOUT":       "10s",
@@ -212,7 +218,9 @@ const (
 	FeatureAnime           string = "anime"
 	FeatureDMMHashlist     string = "dmm_hashlist"
 	FeatureIMDBTitle       string = "imdb_title"
+	FeatureStoreLocal      string = "store_local"
 	FeatureStremioList     string = "stremio_list"
+	FeatureStremioMeta     string = "stremio_meta"
 	FeatureStremioP2P      string = "stremio_p2p"
 	FeatureStremioSidekick string = "stremio_sidekick"
 	FeatureStremioStore    string = "stremio_store"
@@ -225,7 +233,9 @@ var features = []string{
 	FeatureAnime,
 	FeatureDMMHashlist,
 	FeatureIMDBTitle,
+	FeatureStoreLocal,
 	FeatureStremioList,
+	FeatureStremioMeta,
 	FeatureStremioP2P,
 	FeatureStremioSidekick,
 	FeatureStremioStore,
@@ -271,6 +281,10 @@ func (f FeatureConfig) HasIMDBTitle() bool {
 	return !f.IsDisabled(FeatureIMDBTitle) && f.HasTorrentInfo()
 }
 
+func (f FeatureConfig) HasStoreLocal() bool {
+	return f.IsEnabled(FeatureStoreLocal)
+}
+
 func (f FeatureConfig) HasVault() bool {
 	return !f.IsDisabled(FeatureVault) && VaultSecret != ""
 }
@@ -289,6 +303,56 @@ func (scp StoreContentProxyMap) IsEnabled(name string) bool {
 	return scp[name]
 }
 
+type ContentProxyQuotaLimit struct {
+	Daily   int64
+	Monthly int64
+}
+
+func (q ContentProxyQuotaLimit) HasLimit() bool {
+	return q.Daily > 0 || q.Monthly > 0
+}
+
+type ContentProxyQuotaMap map[string]ContentProxyQuotaLimit
+
+func (cpq ContentProxyQuotaMap) Get(user string) ContentProxyQuotaLimit {
+	if quota, ok := cpq[user]; ok {
+		return quota
+	}
+	if user != "*" {
+		return cpq.Get("*")
+	}
+	return ContentProxyQuotaLimit{}
+}
+
+func parseContentProxyQuota(value string) (ContentProxyQuotaMap, error) {
+	quotaMap := ContentProxyQuotaMap{}
+	for _, userQuota := range strings.FieldsFunc(value, func(c rune) bool {
+		return c == ','
+	}) {
+		parts := strings.Split(userQuota, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid content proxy quota: %s", userQuota)
+		}
+		quota := ContentProxyQuotaLimit{}
+		for i, size := range parts[1:] {
+			bytes := int64(0)
+			if size != "" && size != "0" {
+				bytes = util.ToBytes(size)
+				if bytes < 0 {
+					return nil, fmt.Errorf("invalid content proxy quota size: %s", size)
+				}
+			}
+			if i == 0 {
+				quota.Daily = bytes
+			} else {
+				quota.Monthly = bytes
+			}
+		}
+		quotaMap[parts[0]] = quota
+	}
+	return quotaMap, nil
+}
+
 type ContentProxyConnectionLimitMap map[string]int
 
 func (cpcl ContentProxyConnectionLimitMap) Get(user string) int {
@@ -398,6 +462,7 @@ type Config struct {
 	StoreContentCachedStaleTime storeContentCachedStaleTimeMap
 	StoreClientUserAgent        string
 	ContentProxyConnectionLimit ContentProxyConnectionLimitMap
+	ContentProxyQuota           ContentProxyQuotaMap
 	IP                          *IPResolver
 
 	DataDir     string
@@ -463,6 +528,7 @@ var config = func() Config {
 		return c == ','
 	})
 	storeAuthTokenMap := make(StoreAuthTokenMap)
+	multiStoreNamesByUser := map[string][]string{}
 	for _, userStoreToken := range storeAlldebridTokenList {
 		if user, storeToken, ok := strings.Cut(userStoreToken, ":"); ok {
 			if storeName, token, ok := strings.Cut(storeToken, ":"); ok {
@@ -470,10 +536,28 @@ var config = func() Config {
 					log.Fatalf("invalid store name: %s", storeName)
 				}
 				storeAuthTokenMap.addStore(user, storeName)
+				if store.StoreName(storeName) == store.StoreNameMulti {
+					multiStoreNamesByUser[user] = strings.Split(token, "+")
+					continue
+				}
 				storeAuthTokenMap.setToken(user, storeName, token)
 			}
 		}
 	}
+	for user, storeNames := range multiStoreNamesByUser {
+		members := make([]multi.Member, len(storeNames))
+		for i, storeName := range storeNames {
+			if sn := store.StoreName(storeName); !sn.IsValid() || sn == store.StoreNameMulti {
+				log.Fatalf("invalid store name for %s: %s", store.StoreNameMulti, storeName)
+			}
+			token := storeAuthTokenMap.GetToken(user, storeName)
+			if token == "" {
+				log.Fatalf("missing store auth for %s, required by %s: %s", storeName, store.StoreNameMulti, user)
+			}
+			members[i] = multi.Member{StoreName: store.StoreName(storeName), Token: token}
+		}
+		storeAuthTokenMap.setToken(user, string(store.StoreNameMulti), multi.FormatToken(members))
+	}
 
 	buddyUrl, _ := parseUri(getEnv("STREMTHRU_BUDDY_URI"))
 	pullPeerUrl := ""
@@ -497,7 +581,7 @@ var config = func() Config {
 	databaseUri := getEnvWithFallback("STREMTHRU_DATABASE_URI", "DATABASE_URL")
 
 	feature := FeatureConfig{
-		disabled: []string{FeatureAnime, FeatureStremioP2P},
+		disabled: []string{FeatureAnime, FeatureStoreLocal, FeatureStremioP2P},
 	}
 	for _, name := range strings.FieldsFunc(strings.TrimSpace(getEnv("STREMTHRU_FEATURE")), func(c rune) bool {
 		return c == ','
@@ -563,6 +647,11 @@ var config = func() Config {
 		}
 	}
 
+	contentProxyQuotaMap, err := parseContentProxyQuota(getEnv("STREMTHRU_CONTENT_PROXY_QUOTA"))
+	if err != nil {
+		log.Fatalf("failed to parse content proxy quota: %v", err)
+	}
+
 	dataDir, err := filepath.Abs(getEnv("STREMTHRU_DATA_DIR"))
 	if err != nil {
 		log.Fatalf("failed to resolve data directory: %v", err)
@@ -625,6 +714,7 @@ var config = func() Config {
 		StoreContentCachedStaleTime: storeContentCachedStaleTimeMap,
 		StoreClientUserAgent:        getEnv("STREMTHRU_STORE_CLIENT_USER_AGENT"),
 		ContentProxyConnectionLimit: contentProxyConnectionMap,
+		ContentProxyQuota:           contentProxyQuotaMap,
 		IP: &IPResolver{
 			checker: getEnv("STREMTHRU_IP_CHECKER"),
 		},
@@ -659,6 +749,7 @@ var StoreContentProxy = config.StoreContentProxy
 var StoreContentCachedStaleTime = config.StoreContentCachedStaleTime
 var StoreClientUserAgent = config.StoreClientUserAgent
 var ContentProxyConnectionLimit = config.ContentProxyConnectionLimit
+var ContentProxyQuota = config.ContentProxyQuota
 var InstanceId = strings.ReplaceAll(uuid.NewString(), "-", "")
 var IP = config.IP
 
@@ -794,6 +885,16 @@ func PrintConfig(state *AppState) {
 			if cpcl := ContentProxyConnectionLimit.Get(user); cpcl > 0 {
 				l.Println("       content_proxy_connection_limit: " + strconv.FormatUint(uint64(cpcl), 10))
 			}
+			if cpq := ContentProxyQuota.Get(user); cpq.HasLimit() {
+				daily, monthly := "-", "-"
+				if cpq.Daily > 0 {
+					daily = util.ToSize(cpq.Daily)
+				}
+				if cpq.Monthly > 0 {
+					monthly = util.ToSize(cpq.Monthly)
+				}
+				l.Println("       content_proxy_quota: " + daily + " (daily) / " + monthly + " (monthly)")
+			}
 		}
 		l.Println()
 	}
@@ -917,6 +1018,10 @@ func PrintConfig(state *AppState) {
 		switch feature {
 		case FeatureStremioList:
 			l.Println("       public max list count: " + strconv.Itoa(Stremio.List.PublicMaxListCount))
+		case FeatureStremioP2P:
+			l.Println("         cache dir: " + Stremio.P2P.CacheDir)
+			l.Println("        cache size: " + util.ToSize(Stremio.P2P.CacheSize))
+			l.Println("      idle timeout: " + Stremio.P2P.IdleTimeout.String())
 		case FeatureStremioStore:
 			l.Println("       catalog item limit: " + strconv.Itoa(Stremio.Store.CatalogItemLimit))
 			l.Println("       catalog cache time: " + Stremio.Store.CatalogCacheTime.String())
@@ -940,7 +1045,7 @@ func PrintConfig(state *AppState) {
 	l.Println()
 
 	l.Println(" Integrations:")
-	for _, integration := range []string{"anilist.co", "bitmagnet.io", "github.com", "kitsu.app", "letterboxd.com", "mdblist.com", "themoviedb.org", "trakt.tv", "thetvdb.com"} {
+	for _, integration := range []string{"anilist.co", "bitmagnet.io", "github.com", "kitsu.app", "letterboxd.com", "mdblist.com", "myanimelist.net", "simkl.com", "themoviedb.org", "trakt.tv", "thetvdb.com"} {
 		switch integration {
 		case "anilist.co":
 			disabled := ""
@@ -949,6 +1054,10 @@ func PrintConfig(state *AppState) {
 			}
 			l.Println("   - " + integration + disabled)
 			if disabled == "" {
+				if Integration.AniList.IsEnabled() {
+					l.Println("             client_id: " + Integration.AniList.ClientId[0:3] + "..." + Integration.AniList.ClientId[len(Integration.AniList.ClientId)-3:])
+					l.Println("         client_secret: " + Integration.AniList.ClientSecret[0:3] + "..." + Integration.AniList.ClientSecret[len(Integration.AniList.ClientSecret)-3:])
+				}
 				l.Println("       list stale time: " + Integration.AniList.ListStaleTime.String())
 			}
 		case "bitmagnet.io":
@@ -1004,6 +1113,20 @@ func PrintConfig(state *AppState) {
 		case "mdblist.com":
 			l.Println("   - " + integration)
 			l.Println("       list stale time: " + Integration.MDBList.ListStaleTime.String())
+		case "myanimelist.net":
+			if Integration.MAL.IsEnabled() {
+				l.Println("   - " + integration)
+				l.Println("             client_id: " + Integration.MAL.ClientId[0:3] + "..." + Integration.MAL.ClientId[len(Integration.MAL.ClientId)-3:])
+				if Integration.MAL.ClientSecret != "" {
+					l.Println("         client_secret: " + Integration.MAL.ClientSecret[0:3] + "..." + Integration.MAL.ClientSecret[len(Integration.MAL.ClientSecret)-3:])
+				}
+			}
+		case "simkl.com":
+			if Integration.Simkl.IsEnabled() {
+				l.Println("   - " + integration)
+				l.Println("             client_id: " + Integration.Simkl.ClientId[0:3] + "..." + Integration.Simkl.ClientId[len(Integration.Simkl.ClientId)-3:])
+				l.Println("         client_secret: " + Integration.Simkl.ClientSecret[0:3] + "..." + Integration.Simkl.ClientSecret[len(Integration.Simkl.ClientSecret)-3:])
+			}
 		case "themoviedb.org":
 			disabled := ""
 			if !Integration.TMDB.IsEnabled() {
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/util"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -43,6 +44,29 @@ func (s *StoreContentCachedStaleTimeTestSuite) TestStoreContentCachedStaleTime()
 	s.Equal(staleTime.GetStaleTime(false, "torbox"), 8*time.Hour)
 }
 
+type ContentProxyQuotaTestSuite struct {
+	suite.Suite
+}
+
+func (s *ContentProxyQuotaTestSuite) TestContentProxyQuota() {
+	_, err := parseContentProxyQuota("*:50GB")
+	s.ErrorContains(err, "invalid content proxy quota")
+
+	_, err = parseContentProxyQuota("*:lots:1TB")
+	s.ErrorContains(err, "invalid content proxy quota size")
+
+	quota, err := parseContentProxyQuota("")
+	s.Nil(err)
+	s.False(quota.Get("alice").HasLimit())
+
+	quota, err = parseContentProxyQuota("*:50GB:1TB,bob::0,carol:0:2TB")
+	s.Nil(err)
+	s.Equal(ContentProxyQuotaLimit{Daily: util.ToBytes("50GB"), Monthly: util.ToBytes("1TB")}, quota.Get("alice"))
+	s.False(quota.Get("bob").HasLimit())
+	s.Equal(ContentProxyQuotaLimit{Daily: 0, Monthly: util.ToBytes("2TB")}, quota.Get("carol"))
+}
+
 func TestConfig(t *testing.T) {
 	suite.Run(t, new(StoreContentCachedStaleTimeTestSuite))
+	suite.Run(t, new(ContentProxyQuotaTestSuite))
 }
@@ -18,9 +18,15 @@ var BaseURL = func() *url.URL {
 }()
 
 type integrationConfigAniList struct {
+	ClientId      string
+	ClientSecret  string
 	ListStaleTime time.Duration
 }
 
+func (c integrationConfigAniList) IsEnabled() bool {
+	return c.ClientId != "" && c.ClientSecret != ""
+}
+
 type integrationConfigBitmagnet struct {
 	BaseURL     *url.URL
 	DatabaseURI string
@@ -45,10 +51,28 @@ func (c integrationConfigLettterboxd) IsPiggybacked() bool {
 	return !c.IsEnabled() && HasPeer
 }
 
+type integrationConfigMAL struct {
+	ClientId     string
+	ClientSecret string
+}
+
+func (c integrationConfigMAL) IsEnabled() bool {
+	return c.ClientId != ""
+}
+
 type integrationConfigMDBList struct {
 	ListStaleTime time.Duration
 }
 
+type integrationConfigSimkl struct {
+	ClientId     string
+	ClientSecret string
+}
+
+func (c integrationConfigSimkl) IsEnabled() bool {
+	return c.ClientId != "" && c.ClientSecret != ""
+}
+
 type integrationConfigTrakt struct {
 	ClientId      string
 	ClientSecret  string
@@ -103,13 +127,20 @@ type IntegrationConfig struct {
 	Bitmagnet  integrationConfigBitmagnet
 	GitHub     integrationConfigGitHub
 	Letterboxd integrationConfigLettterboxd
+	MAL        integrationConfigMAL
 	MDBList    integrationConfigMDBList
+	Simkl      integrationConfigSimkl
 	Trakt      integrationConfigTrakt
 	Kitsu      integrationConfigKitsu
 	TMDB       integrationConfigTMDB
 	TVDB       integrationConfigTVDB
 }
 
+// HasTracker tells if any of the watched history trackers is enabled.
+func (c IntegrationConfig) HasTracker() bool {
+	return c.AniList.IsEnabled() || c.MAL.IsEnabled() || c.Simkl.IsEnabled()
+}
+
 func parseIntegration() IntegrationConfig {
 	bitmagnet := integrationConfigBitmagnet{
 		DatabaseURI: getEnv("STREMTHRU_INTEGRATION_BITMAGNET_DATABASE_URI"),
@@ -133,6 +164,8 @@ func parseIntegration() IntegrationConfig {
 
 	integration := IntegrationConfig{
 		AniList: integrationConfigAniList{
+			ClientId:      getEnv("STREMTHRU_INTEGRATION_ANILIST_CLIENT_ID"),
+			ClientSecret:  getEnv("STREMTHRU_INTEGRATION_ANILIST_CLIENT_SECRET"),
 			ListStaleTime: mustParseDuration("anilist list stale time", getEnv("STREMTHRU_INTEGRATION_ANILIST_LIST_STALE_TIME"), 15*time.Minute),
 		},
 		Bitmagnet: bitmagnet,
@@ -141,9 +174,17 @@ func parseIntegration() IntegrationConfig {
 			Token: getEnv("STREMTHRU_INTEGRATION_GITHUB_TOKEN"),
 		},
 		Letterboxd: letterboxd,
+		MAL: integrationConfigMAL{
+			ClientId:     getEnv("STREMTHRU_INTEGRATION_MAL_CLIENT_ID"),
+			ClientSecret: getEnv("STREMTHRU_INTEGRATION_MAL_CLIENT_SECRET"),
+		},
 		MDBList: integrationConfigMDBList{
 			ListStaleTime: mustParseDuration("mdblist list stale time", getEnv("STREMTHRU_INTEGRATION_MDBLIST_LIST_STALE_TIME"), 15*time.Minute),
 		},
+		Simkl: integrationConfigSimkl{
+			ClientId:     getEnv("STREMTHRU_INTEGRATION_SIMKL_CLIENT_ID"),
+			ClientSecret: getEnv("STREMTHRU_INTEGRATION_SIMKL_CLIENT_SECRET"),
+		},
 		Trakt: integrationConfigTrakt{
 			ClientId:      getEnv("STREMTHRU_INTEGRATION_TRAKT_CLIENT_ID"),
 			ClientSecret:  getEnv("STREMTHRU_INTEGRATION_TRAKT_CLIENT_SECRET"),
@@ -0,0 +1,13 @@
+package config
+
+type metricsConfig struct {
+	Token string
+}
+
+var Metrics = func() metricsConfig {
+	conf := metricsConfig{}
+
+	conf.Token = getEnv("STREMTHRU_METRICS_TOKEN")
+
+	return conf
+}()
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"log"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -11,6 +13,17 @@ type stremioConfigList struct {
 	PublicMaxListCount int
 }
 
+type stremioConfigP2P struct {
+	CacheDir    string
+	CacheSize   int64
+	IdleTimeout time.Duration
+}
+
+type stremioConfigSidekick struct {
+	BackupInterval  time.Duration
+	BackupKeepCount int
+}
+
 type stremioConfigStore struct {
 	CatalogItemLimit int
 	CatalogCacheTime time.Duration
@@ -29,10 +42,12 @@ type stremioConfigWrap struct {
 }
 
 type StremioConfig struct {
-	List  stremioConfigList
-	Store stremioConfigStore
-	Torz  stremioConfigTorz
-	Wrap  stremioConfigWrap
+	List     stremioConfigList
+	P2P      stremioConfigP2P
+	Sidekick stremioConfigSidekick
+	Store    stremioConfigStore
+	Torz     stremioConfigTorz
+	Wrap     stremioConfigWrap
 }
 
 func parseStremio() StremioConfig {
@@ -40,6 +55,15 @@ func parseStremio() StremioConfig {
 		List: stremioConfigList{
 			PublicMaxListCount: util.MustParseInt(getEnv("STREMTHRU_STREMIO_LIST_PUBLIC_MAX_LIST_COUNT")),
 		},
+		P2P: stremioConfigP2P{
+			CacheDir:    filepath.Join(DataDir, "p2p"),
+			CacheSize:   util.ToBytes(getEnv("STREMTHRU_STREMIO_P2P_CACHE_SIZE")),
+			IdleTimeout: mustParseDuration("stremio p2p idle timeout", getEnv("STREMTHRU_STREMIO_P2P_IDLE_TIMEOUT"), 1*time.Minute),
+		},
+		Sidekick: stremioConfigSidekick{
+			BackupInterval:  mustParseDuration("stremio sidekick backup interval", getEnv("STREMTHRU_STREMIO_SIDEKICK_BACKUP_INTERVAL"), 1*time.Hour),
+			BackupKeepCount: util.MustParseInt(getEnv("STREMTHRU_STREMIO_SIDEKICK_BACKUP_KEEP_COUNT")),
+		},
 		Store: stremioConfigStore{
 			CatalogItemLimit: util.MustParseInt(getEnv("STREMTHRU_STREMIO_STORE_CATALOG_ITEM_LIMIT")),
 			CatalogCacheTime: mustParseDuration("store catalog cache time", getEnv("STREMTHRU_STREMIO_STORE_CATALOG_CACHE_TIME"), 1*time.Minute),
@@ -55,6 +79,9 @@ func parseStremio() StremioConfig {
 			PublicMaxStoreCount:    util.MustParseInt(getEnv("STREMTHRU_STREMIO_WRAP_PUBLIC_MAX_STORE_COUNT")),
 		},
 	}
+	if stremio.P2P.CacheSize <= 0 {
+		log.Fatalf("invalid stremio p2p cache size: %s", getEnv("STREMTHRU_STREMIO_P2P_CACHE_SIZE"))
+	}
 	return stremio
 }
 
@@ -0,0 +1,138 @@
+package content_proxy_usage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/db"
+)
+
+const TableName = "content_proxy_usage"
+
+const dateLayout = "2006-01-02"
+
+var Column = struct {
+	User      string
+	Date      string
+	Bytes     string
+	UpdatedAt string
+}{
+	User:      "username",
+	Date:      "date",
+	Bytes:     "bytes",
+	UpdatedAt: "uat",
+}
+
+type Usage struct {
+	User    string
+	Daily   int64
+	Monthly int64
+}
+
+func getDayAndMonthStart(t time.Time) (string, string) {
+	t = t.UTC()
+	return t.Format(dateLayout), t.Format("2006-01") + "-01"
+}
+
+var query_record = fmt.Sprintf(
+	`INSERT INTO %s AS cpu (%s) VALUES (?, ?, ?) ON CONFLICT (%s) DO UPDATE SET %s = cpu.%s + EXCLUDED.%s, %s = %s`,
+	TableName,
+	db.JoinColumnNames(Column.User, Column.Date, Column.Bytes),
+	db.JoinColumnNames(Column.User, Column.Date),
+	Column.Bytes,
+	Column.Bytes,
+	Column.Bytes,
+	Column.UpdatedAt,
+	db.CurrentTimestamp,
+)
+
+func Record(user string, bytes int64) error {
+	if user == "" || bytes <= 0 {
+		return nil
+	}
+	day, _ := getDayAndMonthStart(time.Now())
+	_, err := db.Exec(query_record, user, day, bytes)
+	return err
+}
+
+var query_get_usage_select = fmt.Sprintf(
+	`SELECT %s, COALESCE(SUM(CASE WHEN %s = ? THEN %s ELSE 0 END), 0), COALESCE(SUM(%s), 0) FROM %s WHERE %s >= ?`,
+	db.JoinColumnNames(Column.User),
+	db.JoinColumnNames(Column.Date),
+	db.JoinColumnNames(Column.Bytes),
+	db.JoinColumnNames(Column.Bytes),
+	TableName,
+	db.JoinColumnNames(Column.Date),
+)
+
+var query_get_usage = fmt.Sprintf(
+	`%s AND %s = ? GROUP BY %s`,
+	query_get_usage_select,
+	db.JoinColumnNames(Column.User),
+	db.JoinColumnNames(Column.User),
+)
+
+func GetUsage(user string) (*Usage, error) {
+	day, monthStart := getDayAndMonthStart(time.Now())
+	usage := &Usage{User: user}
+	rows, err := db.Query(query_get_usage, day, monthStart, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := rows.Scan(&usage.User, &usage.Daily, &usage.Monthly); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+var query_list_usage = fmt.Sprintf(
+	`%s GROUP BY %s ORDER BY %s`,
+	query_get_usage_select,
+	db.JoinColumnNames(Column.User),
+	db.JoinColumnNames(Column.User),
+)
+
+func ListUsage() ([]Usage, error) {
+	day, monthStart := getDayAndMonthStart(time.Now())
+	rows, err := db.Query(query_list_usage, day, monthStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Usage{}
+	for rows.Next() {
+		usage := Usage{}
+		if err := rows.Scan(&usage.User, &usage.Daily, &usage.Monthly); err != nil {
+			return nil, err
+		}
+		items = append(items, usage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (u *Usage) IsOverQuota() bool {
+	quota := config.ContentProxyQuota.Get(u.User)
+	return (quota.Daily > 0 && u.Daily >= quota.Daily) || (quota.Monthly > 0 && u.Monthly >= quota.Monthly)
+}
+
+func IsOverQuota(user string) (bool, error) {
+	if user == "" || !config.ContentProxyQuota.Get(user).HasLimit() {
+		return false, nil
+	}
+	usage, err := GetUsage(user)
+	if err != nil {
+		return false, err
+	}
+	return usage.IsOverQuota(), nil
+}
@@ -0,0 +1,105 @@
+package content_proxy_usage
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+)
+
+var ErrQuotaExceeded = errors.New("content proxy quota exceeded")
+
+const (
+	syncBytes    = 16 * 1024 * 1024
+	syncInterval = 30 * time.Second
+)
+
+var (
+	recordUsage = Record
+	isOverQuota = IsOverQuota
+)
+
+// Writer counts the bytes streamed to the client and records them
+// periodically, so that a long stream (or many parallel ones) can not run far
+// past the quota. Once the quota is exceeded, further writes fail with
+// ErrQuotaExceeded, which aborts the stream.
+type Writer struct {
+	http.ResponseWriter
+	user     string
+	hasLimit bool
+
+	BytesWritten int64
+	pending      int64
+	syncedAt     time.Time
+	exceeded     bool
+	err          error
+}
+
+func NewWriter(w http.ResponseWriter, user string) *Writer {
+	return &Writer{
+		ResponseWriter: w,
+		user:           user,
+		hasLimit:       user != "" && config.ContentProxyQuota.Get(user).HasLimit(),
+		syncedAt:       time.Now(),
+	}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.exceeded {
+		return 0, ErrQuotaExceeded
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.BytesWritten += int64(n)
+	w.pending += int64(n)
+	if err == nil && (w.pending >= syncBytes || time.Since(w.syncedAt) >= syncInterval) {
+		w.sync()
+		if w.exceeded {
+			err = ErrQuotaExceeded
+		}
+	}
+	return n, err
+}
+
+func (w *Writer) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *Writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *Writer) sync() {
+	w.syncedAt = time.Now()
+	if w.pending > 0 {
+		if err := recordUsage(w.user, w.pending); err != nil {
+			w.err = err
+			return
+		}
+		w.pending = 0
+	}
+	if !w.hasLimit {
+		return
+	}
+	exceeded, err := isOverQuota(w.user)
+	if err != nil {
+		w.err = err
+		return
+	}
+	w.exceeded = exceeded
+}
+
+// Close records the bytes not yet accounted for.
+func (w *Writer) Close() error {
+	if w.pending > 0 {
+		if err := recordUsage(w.user, w.pending); err != nil {
+			return err
+		}
+		w.pending = 0
+	}
+	err := w.err
+	w.err = nil
+	return err
+}
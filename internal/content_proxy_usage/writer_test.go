@@ -0,0 +1,83 @@
+package content_proxy_usage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var recorded int64
+	var limit int64
+	recordUsage = func(user string, bytes int64) error {
+		recorded += bytes
+		return nil
+	}
+	isOverQuota = func(user string) (bool, error) {
+		return limit > 0 && recorded >= limit, nil
+	}
+	t.Cleanup(func() {
+		recordUsage = Record
+		isOverQuota = IsOverQuota
+	})
+
+	chunk := bytes.Repeat([]byte{0}, syncBytes/4)
+
+	t.Run("records while streaming", func(t *testing.T) {
+		recorded, limit = 0, 0
+		w := NewWriter(httptest.NewRecorder(), "user")
+		for range 5 {
+			_, err := w.Write(chunk)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int64(syncBytes), recorded)
+		require.NoError(t, w.Close())
+		assert.Equal(t, int64(5*len(chunk)), recorded)
+		assert.Equal(t, recorded, w.BytesWritten)
+	})
+
+	t.Run("aborts once over quota", func(t *testing.T) {
+		recorded, limit = 0, 2*syncBytes
+		w := NewWriter(httptest.NewRecorder(), "user")
+		w.hasLimit = true
+		n, err := io.Copy(w, io.LimitReader(zeroReader{}, 10*syncBytes))
+		assert.True(t, errors.Is(err, ErrQuotaExceeded))
+		assert.Equal(t, int64(2*syncBytes), n)
+		require.NoError(t, w.Close())
+		assert.Equal(t, int64(2*syncBytes), recorded)
+
+		_, err = w.Write(chunk)
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+	})
+
+	t.Run("keeps pending bytes on record failure", func(t *testing.T) {
+		recorded, limit = 0, 0
+		fail := true
+		recordUsage = func(user string, bytes int64) error {
+			if fail {
+				return errors.New("db down")
+			}
+			recorded += bytes
+			return nil
+		}
+		w := NewWriter(httptest.NewRecorder(), "user")
+		_, err := w.Write(bytes.Repeat([]byte{0}, syncBytes))
+		require.NoError(t, err)
+		assert.Zero(t, recorded)
+		fail = false
+		assert.Error(t, w.Close())
+		assert.Equal(t, int64(syncBytes), recorded)
+	})
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
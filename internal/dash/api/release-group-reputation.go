@@ -0,0 +1,138 @@
+package dash_api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/release_group_reputation"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type ReleaseGroupReputationResponse struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Success   int    `json:"success"`
+	Failed    int    `json:"failed"`
+	Invalid   int    `json:"invalid"`
+	Override  *int   `json:"override"`
+	Score     int    `json:"score"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type ListReleaseGroupReputationResponse struct {
+	Items      []ReleaseGroupReputationResponse `json:"items"`
+	TotalCount int                              `json:"total_count"`
+}
+
+func toReleaseGroupReputationResponse(item *release_group_reputation.Reputation) ReleaseGroupReputationResponse {
+	res := ReleaseGroupReputationResponse{
+		Kind:      string(item.Kind),
+		Name:      item.Name,
+		Success:   item.Success,
+		Failed:    item.Failed,
+		Invalid:   item.Invalid,
+		Score:     item.Score(),
+		CreatedAt: item.CAt.Format(time.RFC3339),
+		UpdatedAt: item.UAt.Format(time.RFC3339),
+	}
+	if item.Override.Valid {
+		override := int(item.Override.Int64)
+		res.Override = &override
+	}
+	return res
+}
+
+func handleGetReleaseGroupReputations(w http.ResponseWriter, r *http.Request, kind release_group_reputation.Kind) {
+	query := r.URL.Query()
+
+	limit := util.SafeParseInt(query.Get("limit"), 100)
+	offset := util.SafeParseInt(query.Get("offset"), 0)
+
+	items, err := release_group_reputation.List(kind, limit, offset)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	totalCount, err := release_group_reputation.Count(kind)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	responseItems := make([]ReleaseGroupReputationResponse, len(items))
+	for i := range items {
+		responseItems[i] = toReleaseGroupReputationResponse(&items[i])
+	}
+
+	SendData(w, r, 200, ListReleaseGroupReputationResponse{
+		Items:      responseItems,
+		TotalCount: totalCount,
+	})
+}
+
+type SetReleaseGroupReputationOverrideRequest struct {
+	Override *int `json:"override"`
+}
+
+func handleSetReleaseGroupReputationOverride(w http.ResponseWriter, r *http.Request, kind release_group_reputation.Kind) {
+	name := r.PathValue("name")
+
+	request := &SetReleaseGroupReputationOverrideRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if request.Override != nil && (*request.Override < 0 || *request.Override > 100) {
+		ErrorBadRequest(r, "").Append(Error{
+			Location: "override",
+			Message:  "must be between 0 and 100",
+		}).Send(w, r)
+		return
+	}
+
+	if err := release_group_reputation.SetOverride(kind, name, request.Override); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	item, err := release_group_reputation.Get(kind, name)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if item == nil {
+		ErrorNotFound(r, string(kind)+" not found").Send(w, r)
+		return
+	}
+
+	SendData(w, r, 200, toReleaseGroupReputationResponse(item))
+}
+
+func AddReleaseGroupReputationEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	for kind, path := range map[release_group_reputation.Kind]string{
+		release_group_reputation.KindGroup:    "/torrents/release-groups",
+		release_group_reputation.KindUploader: "/torrents/uploaders",
+	} {
+		router.HandleFunc(path, authed(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				handleGetReleaseGroupReputations(w, r, kind)
+			default:
+				ErrorMethodNotAllowed(r).Send(w, r)
+			}
+		}))
+		router.HandleFunc(path+"/{name}", authed(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPut:
+				handleSetReleaseGroupReputationOverride(w, r, kind)
+			default:
+				ErrorMethodNotAllowed(r).Send(w, r)
+			}
+		}))
+	}
+}
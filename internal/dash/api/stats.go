@@ -3,11 +3,14 @@ package dash_api
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/MunifTanjim/stremthru/internal/anilist"
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/content_proxy_usage"
 	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/imdb_title"
 	"github.com/MunifTanjim/stremthru/internal/letterboxd"
@@ -87,7 +90,10 @@ type ServerStatsFeature struct {
 }
 
 type ServerStatsIntegration struct {
-	Trakt bool `json:"trakt"`
+	AniList bool `json:"anilist"`
+	MAL     bool `json:"mal"`
+	Simkl   bool `json:"simkl"`
+	Trakt   bool `json:"trakt"`
 }
 
 type ServerStats struct {
@@ -110,7 +116,10 @@ func HandleGetServerStats(w http.ResponseWriter, r *http.Request) {
 			Vault: config.Feature.HasVault(),
 		},
 		Integration: ServerStatsIntegration{
-			Trakt: config.Integration.Trakt.IsEnabled(),
+			AniList: config.Integration.AniList.IsEnabled(),
+			MAL:     config.Integration.MAL.IsEnabled(),
+			Simkl:   config.Integration.Simkl.IsEnabled(),
+			Trakt:   config.Integration.Trakt.IsEnabled(),
 		},
 	}
 	SendData(w, r, 200, data)
@@ -214,3 +223,58 @@ func HandleGetListsStats(w http.ResponseWriter, r *http.Request) {
 
 	SendData(w, r, 200, stats)
 }
+
+type ContentProxyUsageStats struct {
+	User  string `json:"user"`
+	Usage struct {
+		Daily   int64 `json:"daily"`
+		Monthly int64 `json:"monthly"`
+	} `json:"usage"`
+	Quota struct {
+		Daily   int64 `json:"daily"`
+		Monthly int64 `json:"monthly"`
+	} `json:"quota"`
+	IsOverQuota bool `json:"is_over_quota"`
+}
+
+func HandleGetContentProxyStats(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodGet) {
+		ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	usages, err := content_proxy_usage.ListUsage()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	seen := map[string]struct{}{}
+	data := []ContentProxyUsageStats{}
+	for i := range usages {
+		usage := &usages[i]
+		seen[usage.User] = struct{}{}
+		data = append(data, newContentProxyUsageStats(usage))
+	}
+	for user := range config.ProxyAuthPassword {
+		if _, ok := seen[user]; !ok {
+			data = append(data, newContentProxyUsageStats(&content_proxy_usage.Usage{User: user}))
+		}
+	}
+	slices.SortFunc(data, func(a, b ContentProxyUsageStats) int {
+		return strings.Compare(a.User, b.User)
+	})
+
+	SendData(w, r, 200, data)
+}
+
+func newContentProxyUsageStats(usage *content_proxy_usage.Usage) ContentProxyUsageStats {
+	quota := config.ContentProxyQuota.Get(usage.User)
+	stats := ContentProxyUsageStats{User: usage.User}
+	stats.Usage.Daily = usage.Daily
+	stats.Usage.Monthly = usage.Monthly
+	stats.Quota.Daily = quota.Daily
+	stats.Quota.Monthly = quota.Monthly
+	stats.IsOverQuota = usage.IsOverQuota()
+	return stats
+}
@@ -0,0 +1,198 @@
+package dash_api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	stremio_federated_addon "github.com/MunifTanjim/stremthru/internal/stremio/federated_addon"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+type StremioFederatedAddonResponse struct {
+	URL       string            `json:"url"`
+	IsPeer    bool              `json:"is_peer"`
+	Manifest  *stremio.Manifest `json:"manifest"`
+	Status    string            `json:"status"`
+	Error     string            `json:"error"`
+	CheckedAt *string           `json:"checked_at"`
+	SeenAt    *string           `json:"seen_at"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+func toStremioFederatedAddonResponse(item *stremio_federated_addon.FederatedAddon) StremioFederatedAddonResponse {
+	res := StremioFederatedAddonResponse{
+		URL:       item.URL,
+		IsPeer:    item.IsPeer,
+		Manifest:  &item.Manifest.Manifest,
+		Status:    string(item.Status),
+		Error:     item.Error,
+		CreatedAt: item.CAt.Format(time.RFC3339),
+		UpdatedAt: item.UAt.Format(time.RFC3339),
+	}
+	if !item.CheckedAt.IsZero() {
+		checkedAt := item.CheckedAt.Format(time.RFC3339)
+		res.CheckedAt = &checkedAt
+	}
+	if !item.SeenAt.IsZero() {
+		seenAt := item.SeenAt.Format(time.RFC3339)
+		res.SeenAt = &seenAt
+	}
+	return res
+}
+
+func handleGetStremioFederatedAddons(w http.ResponseWriter, r *http.Request) {
+	items, err := stremio_federated_addon.GetAll()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]StremioFederatedAddonResponse, len(items))
+	for i := range items {
+		data[i] = toStremioFederatedAddonResponse(&items[i])
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type RegisterStremioFederatedAddonRequest struct {
+	URL    string `json:"url"`
+	IsPeer bool   `json:"is_peer"`
+}
+
+func handleRegisterStremioFederatedAddon(w http.ResponseWriter, r *http.Request) {
+	request := &RegisterStremioFederatedAddonRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	request.URL = strings.TrimSpace(request.URL)
+	if request.URL == "" {
+		ErrorBadRequest(r, "").Append(Error{
+			Location: "url",
+			Message:  "missing url",
+		}).Send(w, r)
+		return
+	}
+
+	item, err := stremio_federated_addon.Register(request.URL, request.IsPeer)
+	if err != nil {
+		ErrorBadRequest(r, "").Append(Error{
+			Location: "url",
+			Message:  "failed to fetch manifest: " + err.Error(),
+		}).Send(w, r)
+		return
+	}
+
+	SendData(w, r, 201, toStremioFederatedAddonResponse(item))
+}
+
+func getStremioFederatedAddonByQueryURL(w http.ResponseWriter, r *http.Request) *stremio_federated_addon.FederatedAddon {
+	url := r.URL.Query().Get("url")
+	if url == "" {
+		ErrorBadRequest(r, "missing url").Send(w, r)
+		return nil
+	}
+
+	item, err := stremio_federated_addon.GetByURL(url)
+	if err != nil {
+		SendError(w, r, err)
+		return nil
+	}
+	if item == nil {
+		ErrorNotFound(r, "federated addon not found").Send(w, r)
+		return nil
+	}
+	return item
+}
+
+func handleUnregisterStremioFederatedAddon(w http.ResponseWriter, r *http.Request) {
+	item := getStremioFederatedAddonByQueryURL(w, r)
+	if item == nil {
+		return
+	}
+
+	if err := stremio_federated_addon.Unregister(item.URL); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+func handleCheckStremioFederatedAddon(w http.ResponseWriter, r *http.Request) {
+	item := getStremioFederatedAddonByQueryURL(w, r)
+	if item == nil {
+		return
+	}
+
+	// the failure is recorded in the status
+	_ = stremio_federated_addon.Check(item.URL)
+
+	item, err := stremio_federated_addon.GetByURL(item.URL)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 200, toStremioFederatedAddonResponse(item))
+}
+
+type DiscoveredStremioFederatedAddonResponse struct {
+	URL      string           `json:"url"`
+	Manifest stremio.Manifest `json:"manifest"`
+}
+
+func handleDiscoverStremioFederatedAddons(w http.ResponseWriter, r *http.Request) {
+	addons, err := stremio_federated_addon.DiscoverPeerAddons()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]DiscoveredStremioFederatedAddonResponse, len(addons))
+	for i := range addons {
+		data[i] = DiscoveredStremioFederatedAddonResponse{
+			URL:      addons[i].TransportUrl,
+			Manifest: addons[i].Manifest,
+		}
+	}
+
+	SendData(w, r, 200, data)
+}
+
+func AddStremioFederatedAddonEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/stremio/federated-addons", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioFederatedAddons(w, r)
+		case http.MethodPost:
+			handleRegisterStremioFederatedAddon(w, r)
+		case http.MethodDelete:
+			handleUnregisterStremioFederatedAddon(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/stremio/federated-addons/check", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handleCheckStremioFederatedAddon(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/stremio/federated-addons/discover", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleDiscoverStremioFederatedAddons(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+}
@@ -0,0 +1,99 @@
+package dash_api
+
+import (
+	"net/http"
+	"time"
+
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+)
+
+type QualityProfileResponse struct {
+	Id string `json:"id"`
+	stremio_transformer.StreamQualityProfile
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func handleGetQualityProfiles(w http.ResponseWriter, r *http.Request) {
+	items, err := stremio_transformer.StreamQualityProfiles.List()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]QualityProfileResponse, len(items))
+	for i := range items {
+		item := &items[i]
+		data[i] = QualityProfileResponse{
+			Id:                   item.Id,
+			StreamQualityProfile: item.Value,
+			CreatedAt:            item.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:            item.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+
+	SendData(w, r, 200, data)
+}
+
+func handleSaveQualityProfile(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	request := &stremio_transformer.StreamQualityProfile{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if err := stremio_transformer.StreamQualityProfiles.Set(id, request); err != nil {
+		ErrorBadRequest(r, err.Error()).Send(w, r)
+		return
+	}
+
+	SendData(w, r, 200, QualityProfileResponse{
+		Id:                   id,
+		StreamQualityProfile: *request,
+		UpdatedAt:            time.Now().Format(time.RFC3339),
+	})
+}
+
+func handleDeleteQualityProfile(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if profile, err := stremio_transformer.StreamQualityProfiles.Get(id); err != nil {
+		SendError(w, r, err)
+		return
+	} else if profile == nil {
+		ErrorNotFound(r, "quality profile not found").Send(w, r)
+		return
+	}
+
+	if err := stremio_transformer.StreamQualityProfiles.Del(id); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+func AddStremioQualityProfileEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/stremio/quality-profiles", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetQualityProfiles(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/stremio/quality-profiles/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			handleSaveQualityProfile(w, r)
+		case http.MethodDelete:
+			handleDeleteQualityProfile(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+}
@@ -0,0 +1,128 @@
+package dash_api
+
+import (
+	"net/http"
+	"time"
+
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+)
+
+type StreamPresetResponse struct {
+	Id        string `json:"id"`
+	Value     string `json:"value"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func toStreamPresetResponse(item *stremio_transformer.StreamPreset) StreamPresetResponse {
+	return StreamPresetResponse{
+		Id:        item.Id,
+		Value:     item.Value,
+		CreatedAt: item.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: item.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func handleGetStreamPresets(store *stremio_transformer.StreamPresetStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := store.List()
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		data := make([]StreamPresetResponse, len(items))
+		for i := range items {
+			data[i] = toStreamPresetResponse(&items[i])
+		}
+
+		SendData(w, r, 200, data)
+	}
+}
+
+type SaveStreamPresetRequest struct {
+	Value string `json:"value"`
+}
+
+func handleSaveStreamPreset(store *stremio_transformer.StreamPresetStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+
+		request := &SaveStreamPresetRequest{}
+		if err := ReadRequestBodyJSON(r, request); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		if request.Value == "" {
+			ErrorBadRequest(r, "").Append(Error{
+				Location: "value",
+				Message:  "missing value",
+			}).Send(w, r)
+			return
+		}
+
+		if err := store.Set(id, request.Value); err != nil {
+			ErrorBadRequest(r, "").Append(Error{
+				Location: "value",
+				Message:  err.Error(),
+			}).Send(w, r)
+			return
+		}
+
+		SendData(w, r, 200, StreamPresetResponse{
+			Id:        id,
+			Value:     request.Value,
+			UpdatedAt: time.Now().Format(time.RFC3339),
+		})
+	}
+}
+
+func handleDeleteStreamPreset(store *stremio_transformer.StreamPresetStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+
+		if value, err := store.Get(id); err != nil {
+			SendError(w, r, err)
+			return
+		} else if value == "" {
+			ErrorNotFound(r, "stream preset not found").Send(w, r)
+			return
+		}
+
+		if err := store.Del(id); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		SendData(w, r, 204, nil)
+	}
+}
+
+func AddStremioStreamPresetEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	for path, store := range map[string]*stremio_transformer.StreamPresetStore{
+		"/stremio/stream-presets/sort":   stremio_transformer.StreamSortPresets,
+		"/stremio/stream-presets/filter": stremio_transformer.StreamFilterPresets,
+	} {
+		router.HandleFunc(path, authed(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				handleGetStreamPresets(store)(w, r)
+			default:
+				ErrorMethodNotAllowed(r).Send(w, r)
+			}
+		}))
+		router.HandleFunc(path+"/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPut:
+				handleSaveStreamPreset(store)(w, r)
+			case http.MethodDelete:
+				handleDeleteStreamPreset(store)(w, r)
+			default:
+				ErrorMethodNotAllowed(r).Send(w, r)
+			}
+		}))
+	}
+}
@@ -0,0 +1,265 @@
+package dash_api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/sync/stremio_tracker"
+	"github.com/MunifTanjim/stremthru/internal/worker"
+)
+
+type StremioTrackerLinkResponse struct {
+	StremioAccountId string                          `json:"stremio_account_id"`
+	TrackerAccountId string                          `json:"tracker_account_id"`
+	SyncConfig       sync_stremio_tracker.SyncConfig `json:"sync_config"`
+	SyncState        sync_stremio_tracker.SyncState  `json:"sync_state"`
+	CreatedAt        string                          `json:"created_at"`
+	UpdatedAt        string                          `json:"updated_at"`
+}
+
+func toStremioTrackerLinkResponse(item *sync_stremio_tracker.SyncStremioTrackerLink) StremioTrackerLinkResponse {
+	resp := StremioTrackerLinkResponse{
+		StremioAccountId: item.StremioAccountId,
+		TrackerAccountId: item.TrackerAccountId,
+		SyncConfig:       item.SyncConfig,
+		SyncState:        item.SyncState,
+		CreatedAt:        item.CAt.Format(time.RFC3339),
+		UpdatedAt:        item.UAt.Format(time.RFC3339),
+	}
+	return resp
+}
+
+func handleGetStremioTrackerLinks(w http.ResponseWriter, r *http.Request) {
+	items, err := sync_stremio_tracker.GetAll()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]StremioTrackerLinkResponse, len(items))
+	for i, item := range items {
+		data[i] = toStremioTrackerLinkResponse(&item)
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type CreateStremioTrackerLinkRequest struct {
+	StremioAccountId string                          `json:"stremio_account_id"`
+	TrackerAccountId string                          `json:"tracker_account_id"`
+	SyncConfig       sync_stremio_tracker.SyncConfig `json:"sync_config"`
+}
+
+func handleCreateStremioTrackerLink(w http.ResponseWriter, r *http.Request) {
+	request := &CreateStremioTrackerLinkRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	errs := []Error{}
+	if request.StremioAccountId == "" {
+		errs = append(errs, Error{
+			Location: "stremio_account_id",
+			Message:  "missing stremio_account_id",
+		})
+	}
+	if request.TrackerAccountId == "" {
+		errs = append(errs, Error{
+			Location: "tracker_account_id",
+			Message:  "missing tracker_account_id",
+		})
+	}
+	if len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	existing, err := sync_stremio_tracker.GetById(request.StremioAccountId, request.TrackerAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing != nil {
+		ErrorBadRequest(r, "link already exists").Send(w, r)
+		return
+	}
+
+	if !request.SyncConfig.IsValid() {
+		ErrorBadRequest(r, "invalid sync direction").Send(w, r)
+		return
+	}
+
+	link, err := sync_stremio_tracker.Link(request.StremioAccountId, request.TrackerAccountId, request.SyncConfig)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 201, toStremioTrackerLinkResponse(link))
+}
+
+func handleGetStremioTrackerLink(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, trackerAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_tracker.GetById(stremioAccountId, trackerAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	SendData(w, r, 200, toStremioTrackerLinkResponse(link))
+}
+
+type UpdateStremioTrackerAccountRequest struct {
+	SyncConfig sync_stremio_tracker.SyncConfig `json:"sync_config"`
+}
+
+func handleUpdateStremioTrackerLink(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, trackerAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	request := &UpdateStremioTrackerAccountRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	link, err := sync_stremio_tracker.GetById(stremioAccountId, trackerAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	if !request.SyncConfig.IsValid() {
+		ErrorBadRequest(r, "invalid sync direction").Send(w, r)
+		return
+	}
+
+	if err := sync_stremio_tracker.SetSyncConfig(stremioAccountId, trackerAccountId, request.SyncConfig); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	link.SyncConfig = request.SyncConfig
+	SendData(w, r, 200, toStremioTrackerLinkResponse(link))
+}
+
+func handleDeleteStremioTrackerLink(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, trackerAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_tracker.GetById(stremioAccountId, trackerAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	if err := sync_stremio_tracker.Unlink(stremioAccountId, trackerAccountId); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+func handleSyncStremioTrackerLink(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, trackerAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_tracker.GetById(stremioAccountId, trackerAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	if !worker.SyncStremioTrackerLinkNow(link) {
+		ErrorBadRequest(r, "sync worker is disabled").Send(w, r)
+		return
+	}
+
+	SendData(w, r, 202, map[string]string{})
+}
+
+func handleResetStremioTrackerLinkSyncState(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, trackerAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_tracker.GetById(stremioAccountId, trackerAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	link.SyncState.Watched.LastSyncedAt = nil
+
+	if err := sync_stremio_tracker.SetSyncState(
+		link.StremioAccountId,
+		link.TrackerAccountId,
+		link.SyncState,
+	); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 200, toStremioTrackerLinkResponse(link))
+}
+
+func AddSyncStremioTrackerEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/sync/stremio-tracker/links", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioTrackerLinks(w, r)
+		case http.MethodPost:
+			handleCreateStremioTrackerLink(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/sync/stremio-tracker/links/{account_id_pair}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioTrackerLink(w, r)
+		case http.MethodPatch:
+			handleUpdateStremioTrackerLink(w, r)
+		case http.MethodDelete:
+			handleDeleteStremioTrackerLink(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/sync/stremio-tracker/links/{account_id_pair}/sync", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handleSyncStremioTrackerLink(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/sync/stremio-tracker/links/{account_id_pair}/reset-sync-state", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handleResetStremioTrackerLinkSyncState(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+}
@@ -85,7 +85,7 @@ func handleCreateStremioTraktLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !request.SyncConfig.Watched.Direction.IsValid() {
+	if !request.SyncConfig.IsValid() {
 		ErrorBadRequest(r, "invalid sync direction").Send(w, r)
 		return
 	}
@@ -143,7 +143,7 @@ func handleUpdateStremioTraktLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !request.SyncConfig.Watched.Direction.IsValid() {
+	if !request.SyncConfig.IsValid() {
 		ErrorBadRequest(r, "invalid sync direction").Send(w, r)
 		return
 	}
@@ -209,6 +209,9 @@ func handleResetStremioTraktLinkSyncState(w http.ResponseWriter, r *http.Request
 	}
 
 	link.SyncState.Watched.LastSyncedAt = nil
+	link.SyncState.Watchlist.LastSyncedAt = nil
+	link.SyncState.Collection.LastSyncedAt = nil
+	link.SyncState.Ratings.LastSyncedAt = nil
 
 	if err := sync_stremio_trakt.SetSyncState(
 		link.StremioAccountId,
@@ -92,7 +92,13 @@ func handleCreateTorznabIndexer(w http.ResponseWriter, r *http.Request) {
 
 	indexerType := request.Type
 	if indexerType == "" {
-		indexerType = torznab_indexer.IndexerTypeJackett
+		indexerType = torznab_indexer.DetectIndexerType(request.URL)
+	} else if !indexerType.IsValid() {
+		ErrorBadRequest(r, "").Append(Error{
+			Location: "type",
+			Message:  "invalid type",
+		}).Send(w, r)
+		return
 	}
 
 	indexer, err := torznab_indexer.NewTorznabIndexer(indexerType, request.URL, request.APIKey)
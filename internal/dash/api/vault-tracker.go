@@ -0,0 +1,216 @@
+package dash_api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/anilist"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/mal"
+	"github.com/MunifTanjim/stremthru/internal/oauth"
+	"github.com/MunifTanjim/stremthru/internal/simkl"
+	tracker_account "github.com/MunifTanjim/stremthru/internal/tracker/account"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type TrackerAccountResponse struct {
+	Id        string         `json:"id"`
+	Provider  oauth.Provider `json:"provider"`
+	UserName  string         `json:"user_name"`
+	IsValid   bool           `json:"is_valid"`
+	CreatedAt string         `json:"created_at"`
+	UpdatedAt string         `json:"updated_at"`
+}
+
+func toTrackerAccountResponse(item *tracker_account.TrackerAccount) TrackerAccountResponse {
+	username := ""
+	if otok := item.OAuthToken(); otok != nil {
+		username = otok.UserName
+	}
+	return TrackerAccountResponse{
+		Id:        item.Id,
+		Provider:  item.Provider,
+		UserName:  username,
+		IsValid:   item.IsValid(),
+		CreatedAt: item.CAt.Format(time.RFC3339),
+		UpdatedAt: item.UAt.Format(time.RFC3339),
+	}
+}
+
+func getTrackerOAuthConfig(provider oauth.Provider) *oauth.OAuthConfig {
+	switch provider {
+	case oauth.ProviderAniList:
+		if config.Integration.AniList.IsEnabled() {
+			return &oauth.AniListOAuthConfig
+		}
+	case oauth.ProviderMAL:
+		if config.Integration.MAL.IsEnabled() {
+			return &oauth.MALOAuthConfig
+		}
+	case oauth.ProviderSimkl:
+		if config.Integration.Simkl.IsEnabled() {
+			return &oauth.SimklOAuthConfig
+		}
+	}
+	return nil
+}
+
+func handleGetTrackerAccounts(w http.ResponseWriter, r *http.Request) {
+	items, err := tracker_account.GetAll()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]TrackerAccountResponse, len(items))
+	for i, item := range items {
+		data[i] = toTrackerAccountResponse(&item)
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type CreateTrackerAccountRequest struct {
+	OAuthTokenId string `json:"oauth_token_id"`
+}
+
+func handleCreateTrackerAccount(w http.ResponseWriter, r *http.Request) {
+	request := &CreateTrackerAccountRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if request.OAuthTokenId == "" {
+		ErrorBadRequest(r, "").Append(Error{
+			Location: "oauth_token_id",
+			Message:  "missing oauth_token_id",
+		}).Send(w, r)
+		return
+	}
+
+	account, err := tracker_account.Insert(request.OAuthTokenId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 201, toTrackerAccountResponse(account))
+}
+
+func refreshTrackerAccount(account *tracker_account.TrackerAccount) error {
+	switch account.Provider {
+	case oauth.ProviderAniList:
+		client := anilist.GetViewerClient(account.OAuthTokenId)
+		if client == nil {
+			return errors.New("oauth token not found")
+		}
+		_, err := client.FetchViewer()
+		return err
+	case oauth.ProviderMAL:
+		_, err := mal.GetAPIClient(account.OAuthTokenId).GetMyUser(&mal.GetMyUserParams{})
+		return err
+	case oauth.ProviderSimkl:
+		_, err := simkl.GetAPIClient(account.OAuthTokenId).RetrieveSettings(&simkl.RetrieveSettingsParams{})
+		return err
+	}
+	return nil
+}
+
+func handleGetTrackerAccount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	account, err := tracker_account.GetById(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if account == nil {
+		ErrorNotFound(r, "tracker account not found").Send(w, r)
+		return
+	}
+
+	forceRefresh := util.StringToBool(r.URL.Query().Get("refresh"), false)
+	if forceRefresh {
+		if err := refreshTrackerAccount(account); err != nil {
+			SendError(w, r, err)
+			return
+		}
+	}
+
+	SendData(w, r, 200, toTrackerAccountResponse(account))
+}
+
+func handleDeleteTrackerAccount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	existing, err := tracker_account.GetById(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing == nil {
+		ErrorNotFound(r, "tracker account not found").Send(w, r)
+		return
+	}
+
+	if err := tracker_account.Delete(id); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+type TrackerAuthURLResponse struct {
+	URL string `json:"url"`
+}
+
+func handleGetTrackerAuthURL(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	oauthConfig := getTrackerOAuthConfig(oauth.Provider(query.Get("provider")))
+	if oauthConfig == nil {
+		ErrorBadRequest(r, "").Append(Error{
+			Location: "provider",
+			Message:  "unsupported provider",
+		}).Send(w, r)
+		return
+	}
+	authURL := oauthConfig.AuthCodeURL(query.Get("state"))
+	SendData(w, r, 200, TrackerAuthURLResponse{
+		URL: authURL,
+	})
+}
+
+func AddVaultTrackerEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/vault/tracker/accounts", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetTrackerAccounts(w, r)
+		case http.MethodPost:
+			handleCreateTrackerAccount(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/vault/tracker/accounts/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetTrackerAccount(w, r)
+		case http.MethodDelete:
+			handleDeleteTrackerAccount(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/vault/tracker/auth/url", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetTrackerAuthURL(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+}
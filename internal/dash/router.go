@@ -31,20 +31,30 @@ func AddEndpoints(mux *http.ServeMux) {
 	router.HandleFunc("/stats/imdb-titles", authed(dash_api.HandleGetIMDBTitleStats))
 	router.HandleFunc("/stats/torrents", authed(dash_api.HandleGetTorrentsStats))
 	router.HandleFunc("/stats/server", authed(dash_api.HandleGetServerStats))
+	router.HandleFunc("/stats/content-proxy", authed(dash_api.HandleGetContentProxyStats))
 
 	dash_api.AddIMDBEndpoints(router)
 	dash_api.AddWorkerEndpoints(router)
 	dash_api.AddTorznabIndexerSyncInfoEndpoints(router)
 	dash_api.AddRateLimitEndpoints(router)
+	dash_api.AddStremioStreamPresetEndpoints(router)
+	dash_api.AddStremioQualityProfileEndpoints(router)
+	dash_api.AddReleaseGroupReputationEndpoints(router)
+	dash_api.AddStremioFederatedAddonEndpoints(router)
 
 	if config.Feature.HasVault() {
 		dash_api.AddVaultStremioEndpoints(router)
 		dash_api.AddVaultTraktEndpoints(router)
 		dash_api.AddVaultTorznabEndpoints(router)
+		dash_api.AddVaultNewznabEndpoints(router)
 		dash_api.AddSyncStremioStremioEndpoints(router)
 		if config.Integration.Trakt.IsEnabled() {
 			dash_api.AddSyncStremioTraktEndpoints(router)
 		}
+		if config.Integration.HasTracker() {
+			dash_api.AddVaultTrackerEndpoints(router)
+			dash_api.AddSyncStremioTrackerEndpoints(router)
+		}
 	}
 
 	mux.Handle("/dash/api/", http.StripPrefix("/dash/api", dash_api.WithMiddleware(commonMiddleware)(router.ServeHTTP)))
@@ -42,35 +42,39 @@ var ExecuteAuthCallbackTemplate = func() func(data *AuthCallbackTemplateData) (b
 	}
 }()
 
-func handleTraktAuthCallback(w http.ResponseWriter, r *http.Request) {
-	if !shared.IsMethod(r, http.MethodGet) {
-		shared.ErrorMethodNotAllowed(r).Send(w, r)
-		return
-	}
+func getAuthCallbackHandler(provider string, oauthConfig *oauth.OAuthConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !shared.IsMethod(r, http.MethodGet) {
+			shared.ErrorMethodNotAllowed(r).Send(w, r)
+			return
+		}
 
-	code := r.URL.Query().Get("code")
-	state := r.URL.Query().Get("state")
+		code := r.URL.Query().Get("code")
+		state := r.URL.Query().Get("state")
 
-	td := &AuthCallbackTemplateData{
-		Title:    "StremThru",
-		Version:  config.Version,
-		Provider: "Trakt.tv",
-		State:    state,
-	}
+		td := &AuthCallbackTemplateData{
+			Title:    "StremThru",
+			Version:  config.Version,
+			Provider: provider,
+			State:    state,
+		}
 
-	tok, err := oauth.TraktOAuthConfig.Exchange(code, state)
-	if err != nil {
-		td.Error = err.Error()
-	} else {
-		td.Code = tok.Extra("id").(string)
-	}
+		tok, err := oauthConfig.Exchange(code, state)
+		if err != nil {
+			td.Error = err.Error()
+		} else if id, ok := tok.Extra("id").(string); ok {
+			td.Code = id
+		} else {
+			td.Error = "missing token id"
+		}
 
-	buf, err := ExecuteAuthCallbackTemplate(td)
-	if err != nil {
-		SendError(w, r, err)
-		return
+		buf, err := ExecuteAuthCallbackTemplate(td)
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+		SendHTML(w, 200, buf)
 	}
-	SendHTML(w, 200, buf)
 }
 
 func handleTMDBAuthInit(w http.ResponseWriter, r *http.Request) {
@@ -90,37 +94,6 @@ func handleTMDBAuthInit(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, authCodeUrl, http.StatusFound)
 }
 
-func handleTMDBAuthCallback(w http.ResponseWriter, r *http.Request) {
-	if !shared.IsMethod(r, http.MethodGet) {
-		shared.ErrorMethodNotAllowed(r).Send(w, r)
-		return
-	}
-
-	code := r.URL.Query().Get("code")
-	state := r.URL.Query().Get("state")
-
-	td := &AuthCallbackTemplateData{
-		Title:    "StremThru",
-		Version:  config.Version,
-		Provider: "TMDB",
-		State:    state,
-	}
-
-	tok, err := oauth.TMDBOAuthConfig.Exchange(code, state)
-	if err != nil {
-		td.Error = err.Error()
-	} else {
-		td.Code = tok.Extra("id").(string)
-	}
-
-	buf, err := ExecuteAuthCallbackTemplate(td)
-	if err != nil {
-		SendError(w, r, err)
-		return
-	}
-	SendHTML(w, 200, buf)
-}
-
 func handleTVDBAuthToken(w http.ResponseWriter, r *http.Request) {
 	if !shared.IsMethod(r, http.MethodPost) {
 		shared.ErrorMethodNotAllowed(r).Send(w, r)
@@ -187,11 +160,20 @@ func handleTVDBAuthToken(w http.ResponseWriter, r *http.Request) {
 
 func AddAuthEndpoints(mux *http.ServeMux) {
 	if config.Integration.Trakt.IsEnabled() {
-		mux.HandleFunc("/auth/trakt.tv/callback", handleTraktAuthCallback)
+		mux.HandleFunc("/auth/trakt.tv/callback", getAuthCallbackHandler("Trakt.tv", &oauth.TraktOAuthConfig))
+	}
+	if config.Integration.AniList.IsEnabled() {
+		mux.HandleFunc("/auth/anilist.co/callback", getAuthCallbackHandler("AniList", &oauth.AniListOAuthConfig))
+	}
+	if config.Integration.MAL.IsEnabled() {
+		mux.HandleFunc("/auth/myanimelist.net/callback", getAuthCallbackHandler("MyAnimeList", &oauth.MALOAuthConfig))
+	}
+	if config.Integration.Simkl.IsEnabled() {
+		mux.HandleFunc("/auth/simkl.com/callback", getAuthCallbackHandler("Simkl", &oauth.SimklOAuthConfig))
 	}
 	if config.Integration.TMDB.IsEnabled() {
 		mux.HandleFunc("/auth/themoviedb.org/init", handleTMDBAuthInit)
-		mux.HandleFunc("/auth/themoviedb.org/callback", handleTMDBAuthCallback)
+		mux.HandleFunc("/auth/themoviedb.org/callback", getAuthCallbackHandler("TMDB", &oauth.TMDBOAuthConfig))
 	}
 	if config.Integration.TVDB.IsEnabled() {
 		mux.HandleFunc("/auth/thetvdb.com/token", handleTVDBAuthToken)
@@ -0,0 +1,57 @@
+package endpoint
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+)
+
+var metricsHandler = metrics.Handler()
+
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+	metricsHandler.ServeHTTP(w, r)
+}
+
+// MetricsAuthed allows the request with `STREMTHRU_METRICS_TOKEN` as bearer
+// token, otherwise falls back to admin auth.
+func MetricsAuthed(next http.HandlerFunc) http.HandlerFunc {
+	withAdminAuth := AdminAuthed(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if config.Metrics.Token != "" {
+			if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(config.Metrics.Token)) == 1 {
+					next.ServeHTTP(w, r)
+				} else {
+					shared.ErrorUnauthorized(r).Send(w, r)
+				}
+				return
+			}
+		}
+		withAdminAuth.ServeHTTP(w, r)
+	})
+}
+
+func AddMetricsEndpoints(mux *http.ServeMux) {
+	for user := range config.ProxyAuthPassword {
+		cpStore := contentProxyConnectionStore.WithScope(user)
+		metrics.RegisterGaugeFunc("proxy", "active_connections", "Number of active content proxy connections.", map[string]string{"user": user}, func() float64 {
+			count, err := cpStore.Count()
+			if err != nil {
+				return 0
+			}
+			return float64(count)
+		})
+	}
+
+	withMetricsAuth := shared.Middleware(MetricsAuthed)
+
+	mux.HandleFunc("/metrics", withMetricsAuth(handleMetrics))
+}
@@ -0,0 +1,97 @@
+package endpoint
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/newznab"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/internal/torznab"
+)
+
+func isNewznabAuthorized(apiKey string) bool {
+	auth, err := core.ParseBasicAuth(apiKey)
+	return err == nil && auth.Username != "" && config.ProxyAuthPassword.GetPassword(auth.Username) == auth.Password
+}
+
+func handleNewznab(w http.ResponseWriter, r *http.Request) {
+	t := r.URL.Query().Get("t")
+
+	if t == "" {
+		http.Redirect(w, r, r.URL.Path+"?t=caps", http.StatusTemporaryRedirect)
+		return
+	}
+
+	o := strings.ToLower(r.URL.Query().Get("o"))
+	if o != "" && o != "json" && o != "xml" {
+		shared.SendXML(w, r, 200, torznab.ErrorIncorrectParameter("invalid output format"))
+		return
+	}
+
+	switch t {
+	case "caps":
+		w.Header().Set("Cache-Control", "public, max-age=7200")
+		sendResponse(w, r, 200, newznab.StremThruIndexer.Capabilities(), o)
+	case "search", "tvsearch", "movie":
+		query, err := torznab.ParseQuery(r.URL.Query())
+		if err != nil {
+			sendResponse(w, r, 200, torznab.ErrorIncorrectParameter(err.Error()), o)
+			return
+		}
+		if !isNewznabAuthorized(query.APIKey) {
+			sendResponse(w, r, 200, torznab.ErrorIncorrectUserCreds, o)
+			return
+		}
+		items, err := newznab.StremThruIndexer.Search(query)
+		if err != nil {
+			sendResponse(w, r, 200, torznab.ErrorUnknownError(err.Error()), o)
+			return
+		}
+		w.Header().Set("Cache-Control", "private, max-age=1800")
+		sendResponse(w, r, 200, torznab.ResultFeed{
+			Info:    newznab.StremThruIndexer.Info(),
+			Items:   items,
+			Newznab: true,
+		}, o)
+	case "get":
+		query := r.URL.Query()
+		if !isNewznabAuthorized(query.Get("apikey")) {
+			sendResponse(w, r, 200, torznab.ErrorIncorrectUserCreds, o)
+			return
+		}
+		id := query.Get("id")
+		if id == "" {
+			sendResponse(w, r, 200, torznab.ErrorMissingParameter("id"), o)
+			return
+		}
+		body, header, err := newznab.StremThruIndexer.Download(id)
+		if err != nil {
+			core.LogError(r, "failed to download nzb", err)
+			sendResponse(w, r, 200, torznab.ErrorNoSuchItem, o)
+			return
+		}
+		defer body.Close()
+		for _, key := range []string{"Content-Type", "Content-Disposition", "Content-Length"} {
+			if value := header.Get(key); value != "" {
+				w.Header().Set(key, value)
+			}
+		}
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.WriteHeader(200)
+		io.Copy(w, body)
+	default:
+		w.Header().Set("Cache-Control", "public, max-age=7200")
+		sendResponse(w, r, 200, torznab.ErrorIncorrectParameter(t), o)
+	}
+}
+
+func AddNewznabEndpoints(mux *http.ServeMux) {
+	if !config.Feature.HasVault() {
+		return
+	}
+
+	mux.HandleFunc("/v0/newznab/api", handleNewznab)
+}
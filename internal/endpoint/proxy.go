@@ -8,6 +8,9 @@ import (
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/content_proxy_usage"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
+	"github.com/MunifTanjim/stremthru/internal/p2p"
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	store_video "github.com/MunifTanjim/stremthru/internal/store/video"
@@ -55,14 +58,35 @@ func handleProxyLinkAccess(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		if isOverQuota, err := content_proxy_usage.IsOverQuota(user); err != nil {
+			ctx.Log.Error("[proxy] failed to check quota", "error", err)
+		} else if isOverQuota {
+			store_video.Redirect(store_video.StoreVideoNameContentProxyQuotaExceeded, w, r)
+			return
+		}
+
 		if err := cpStore.Set(ctx.RequestId, contentProxyConnection{IP: core.GetRequestIP(r), Link: link}); err != nil {
 			ctx.Log.Error("[proxy] failed to record connection", "error", err)
 		} else {
 			defer cpStore.Del(ctx.RequestId)
 		}
 	}
-	bytesWritten, err := shared.ProxyResponse(w, r, link, tunnelType)
+	uw := content_proxy_usage.NewWriter(w, user)
+	var bytesWritten int64
+	if p2p.IsLink(link) {
+		bytesWritten, err = p2p.ServeFile(uw, r, link)
+		metrics.AddProxyBytes(bytesWritten)
+		if err != nil && bytesWritten == 0 {
+			SendError(w, r, err)
+		}
+	} else {
+		bytesWritten, err = shared.ProxyResponse(uw, r, link, tunnelType)
+	}
 	ctx.Log.Info("[proxy] connection closed", "user", user, "size", util.ToSize(bytesWritten), "error", err)
+
+	if err := uw.Close(); err != nil {
+		ctx.Log.Error("[proxy] failed to record usage", "error", err)
+	}
 }
 
 type proxifyLinksData struct {
@@ -217,6 +217,10 @@ func addMagnet(ctx *context.StoreContext, magnet string, torrent *multipart.File
 	return data, err
 }
 
+func isTorrentLink(link string) bool {
+	return strings.HasPrefix(link, "https://") || strings.HasPrefix(link, "http://")
+}
+
 func handleStoreMagnetAdd(w http.ResponseWriter, r *http.Request) {
 	if !shared.IsMethod(r, http.MethodPost) {
 		shared.ErrorMethodNotAllowed(r).Send(w, r)
@@ -241,15 +245,24 @@ func handleStoreMagnetAdd(w http.ResponseWriter, r *http.Request) {
 
 		ctx := context.GetStoreContext(r)
 
-		if payload.Magnet != "" {
-			data, err = addMagnet(ctx, payload.Magnet, nil)
-		} else if payload.Torrent != "" {
-			fileHeader, err := shared.FetchTorrentFile(payload.Torrent, 1024*1024)
-			if err != nil {
-				shared.ErrorBadRequest(r, "unable to fetch torrent file").WithCause(err).Send(w, r)
+		torrentLink := payload.Torrent
+		if torrentLink == "" && isTorrentLink(payload.Magnet) {
+			torrentLink = payload.Magnet
+		}
+
+		if torrentLink != "" {
+			if !isTorrentLink(torrentLink) {
+				shared.ErrorBadRequest(r, "invalid torrent link").Send(w, r)
+				return
+			}
+			fileHeader, fetchErr := shared.FetchTorrentFile(torrentLink, 1024*1024)
+			if fetchErr != nil {
+				shared.ErrorBadRequest(r, "unable to fetch torrent file").WithCause(fetchErr).Send(w, r)
 				return
 			}
 			data, err = addMagnet(ctx, "", fileHeader)
+		} else {
+			data, err = addMagnet(ctx, payload.Magnet, nil)
 		}
 
 	case strings.Contains(contentType, "multipart/form-data"):
@@ -260,7 +273,18 @@ func handleStoreMagnetAdd(w http.ResponseWriter, r *http.Request) {
 		}
 
 		var fileHeader *multipart.FileHeader
-		if r.MultipartForm.File != nil {
+		if link := r.FormValue("torrent"); link != "" && len(r.MultipartForm.File["torrent"]) == 0 {
+			if !isTorrentLink(link) {
+				shared.ErrorBadRequest(r, "invalid torrent link").Send(w, r)
+				return
+			}
+			fh, fetchErr := shared.FetchTorrentFile(link, 1024*1024)
+			if fetchErr != nil {
+				shared.ErrorBadRequest(r, "unable to fetch torrent file").WithCause(fetchErr).Send(w, r)
+				return
+			}
+			fileHeader = fh
+		} else if r.MultipartForm.File != nil {
 			fileHeaders := r.MultipartForm.File["torrent"]
 			if len(fileHeaders) == 0 {
 				shared.ErrorBadRequest(r, "missing torrent file").Send(w, r)
@@ -420,6 +444,23 @@ func handleStatic(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func handleLocalStoreContent(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodGet) && !shared.IsMethod(r, http.MethodHead) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	s := shared.GetLocalStore()
+	if s == nil {
+		shared.ErrorNotFound(r).Send(w, r)
+		return
+	}
+
+	if err := s.ServeContent(w, r, r.PathValue("token")); err != nil {
+		SendError(w, r, err)
+	}
+}
+
 func AddStoreEndpoints(mux *http.ServeMux) {
 	withCors := shared.Middleware(shared.EnableCORS)
 	withStore := StoreMiddleware(ProxyAuthContext, StoreContext, StoreRequired)
@@ -430,5 +471,11 @@ func AddStoreEndpoints(mux *http.ServeMux) {
 	mux.HandleFunc("/v0/store/magnets/{magnetId}", withStore(handleStoreMagnet))
 	mux.HandleFunc("/v0/store/link/generate", withStore(handleStoreLinkGenerate))
 
+	mux.HandleFunc("/v0/store/newz", withStore(handleStoreNewzs))
+	mux.HandleFunc("/v0/store/newz/check", withStore(handleStoreNewzCheck))
+	mux.HandleFunc("/v0/store/newz/link/generate", withStore(handleStoreNewzLinkGenerate))
+	mux.HandleFunc("/v0/store/newz/{newzId}", withStore(handleStoreNewz))
+
 	mux.HandleFunc("/v0/store/_/static/{video}", withCors(handleStatic))
+	mux.HandleFunc("/v0/store/_/local/{token}/{filename}", withCors(handleLocalStoreContent))
 }
@@ -0,0 +1,274 @@
+package endpoint
+
+import (
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/server"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+func getUsenetStore(r *http.Request) (store.UsenetStore, error) {
+	ctx := context.GetStoreContext(r)
+	s, ok := ctx.Store.(store.UsenetStore)
+	if !ok {
+		return nil, shared.ErrorUsenetNotSupported(r, ctx.Store.GetName())
+	}
+	return s, nil
+}
+
+func handleStoreNewzCheck(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	s, err := getUsenetStore(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	queryParams := r.URL.Query()
+	hash, ok := queryParams["hash"]
+	if !ok {
+		shared.ErrorBadRequest(r, "missing hash").Send(w, r)
+		return
+	}
+
+	hashes := []string{}
+	for _, h := range hash {
+		hashes = append(hashes, strings.FieldsFunc(h, func(r rune) bool {
+			return r == ','
+		})...)
+	}
+
+	rCtx := server.GetReqCtx(r)
+	rCtx.ReqQuery.Set("hash", "..."+strconv.Itoa(len(hashes))+" items...")
+
+	if len(hashes) == 0 {
+		shared.ErrorBadRequest(r, "missing hash").Send(w, r)
+		return
+	}
+
+	if len(hashes) > 500 {
+		shared.ErrorBadRequest(r, "too many hashes, max allowed 500").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	params := &store.CheckNZBParams{}
+	params.APIKey = ctx.StoreAuthToken
+	params.Hashes = hashes
+	params.ClientIP = ctx.ClientIP
+	data, err := s.CheckNZB(params)
+	if err == nil && data.Items == nil {
+		data.Items = []store.CheckNZBDataItem{}
+	}
+	SendResponse(w, r, 200, data, err)
+}
+
+func handleStoreNewzList(w http.ResponseWriter, r *http.Request) {
+	s, err := getUsenetStore(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	queryParams := r.URL.Query()
+	limit, err := GetQueryInt(queryParams, "limit", 100)
+	if err != nil {
+		shared.ErrorBadRequest(r, err.Error()).Send(w, r)
+		return
+	}
+	limit = max(1, min(limit, 500))
+	offset, err := GetQueryInt(queryParams, "offset", 0)
+	if err != nil {
+		shared.ErrorBadRequest(r, err.Error()).Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	params := &store.ListNZBsParams{
+		Limit:    limit,
+		Offset:   offset,
+		ClientIP: ctx.ClientIP,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := s.ListNZBs(params)
+	if err == nil && data.Items == nil {
+		data.Items = []store.ListNZBsDataItem{}
+	}
+	SendResponse(w, r, 200, data, err)
+}
+
+type AddNewzPayload struct {
+	Link     string `json:"link"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+func handleStoreNewzAdd(w http.ResponseWriter, r *http.Request) {
+	s, err := getUsenetStore(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	params := &store.AddNZBParams{}
+	params.APIKey = ctx.StoreAuthToken
+	params.ClientIP = ctx.ClientIP
+
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "application/json"):
+		payload := &AddNewzPayload{}
+		if err := shared.ReadRequestBodyJSON(r, payload); err != nil {
+			SendError(w, r, err)
+			return
+		}
+		if payload.Link == "" {
+			shared.ErrorBadRequest(r, "missing link").Send(w, r)
+			return
+		}
+		params.Link = payload.Link
+		params.Name = payload.Name
+		params.Password = payload.Password
+
+	case strings.Contains(contentType, "multipart/form-data"):
+		r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+		if err := r.ParseMultipartForm(2 << 20); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		var fileHeader *multipart.FileHeader
+		if r.MultipartForm.File != nil {
+			fileHeaders := r.MultipartForm.File["file"]
+			if len(fileHeaders) > 1 {
+				shared.ErrorBadRequest(r, "multiple nzb files provided").Send(w, r)
+				return
+			}
+			if len(fileHeaders) == 1 {
+				fileHeader = fileHeaders[0]
+			}
+		}
+		params.File = fileHeader
+		params.Link = r.FormValue("link")
+		params.Name = r.FormValue("name")
+		params.Password = r.FormValue("password")
+		if params.File == nil && params.Link == "" {
+			shared.ErrorBadRequest(r, "missing nzb file").Send(w, r)
+			return
+		}
+
+	default:
+		shared.ErrorUnsupportedMediaType(r).Send(w, r)
+		return
+	}
+
+	data, err := s.AddNZB(params)
+	if err == nil && data.Files == nil {
+		data.Files = []store.NZBFile{}
+	}
+	SendResponse(w, r, 201, data, err)
+}
+
+func handleStoreNewzs(w http.ResponseWriter, r *http.Request) {
+	if shared.IsMethod(r, http.MethodGet) {
+		handleStoreNewzList(w, r)
+		return
+	}
+
+	if shared.IsMethod(r, http.MethodPost) {
+		handleStoreNewzAdd(w, r)
+		return
+	}
+
+	shared.ErrorMethodNotAllowed(r).Send(w, r)
+}
+
+func handleStoreNewzGet(w http.ResponseWriter, r *http.Request) {
+	s, err := getUsenetStore(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	newzId := r.PathValue("newzId")
+	if newzId == "" {
+		shared.ErrorBadRequest(r, "missing newzId").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	params := &store.GetNZBParams{}
+	params.APIKey = ctx.StoreAuthToken
+	params.Id = newzId
+	params.ClientIP = ctx.ClientIP
+	params.BypassCache = util.StringToBool(r.URL.Query().Get("bypass_cache"), false)
+	data, err := s.GetNZB(params)
+	if err == nil && data.Files == nil {
+		data.Files = []store.NZBFile{}
+	}
+	SendResponse(w, r, 200, data, err)
+}
+
+func handleStoreNewzRemove(w http.ResponseWriter, r *http.Request) {
+	s, err := getUsenetStore(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	newzId := r.PathValue("newzId")
+	if newzId == "" {
+		shared.ErrorBadRequest(r, "missing newzId").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	params := &store.RemoveNZBParams{}
+	params.APIKey = ctx.StoreAuthToken
+	params.Id = newzId
+	data, err := s.RemoveNZB(params)
+	SendResponse(w, r, 200, data, err)
+}
+
+func handleStoreNewz(w http.ResponseWriter, r *http.Request) {
+	if shared.IsMethod(r, http.MethodGet) {
+		handleStoreNewzGet(w, r)
+		return
+	}
+
+	if shared.IsMethod(r, http.MethodDelete) {
+		handleStoreNewzRemove(w, r)
+		return
+	}
+
+	shared.ErrorMethodNotAllowed(r).Send(w, r)
+}
+
+func handleStoreNewzLinkGenerate(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	payload := &GenerateLinkPayload{}
+	err := shared.ReadRequestBodyJSON(r, payload)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	link, err := shared.GenerateStremThruNZBLink(r, ctx, payload.Link)
+	SendResponse(w, r, 200, link, err)
+}
@@ -0,0 +1,178 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/server"
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUsenetStore struct {
+	store.Store
+
+	lastAPIKey string
+	getNZB     *store.GetNZBParams
+	addNZB     *store.AddNZBParams
+	listNZBs   *store.ListNZBsParams
+}
+
+func (s *fakeUsenetStore) GetName() store.StoreName {
+	return store.StoreNameTorBox
+}
+
+func (s *fakeUsenetStore) CheckNZB(params *store.CheckNZBParams) (*store.CheckNZBData, error) {
+	s.lastAPIKey = params.APIKey
+	data := &store.CheckNZBData{}
+	for _, hash := range params.Hashes {
+		data.Items = append(data.Items, store.CheckNZBDataItem{Hash: hash, Status: store.MagnetStatusCached})
+	}
+	return data, nil
+}
+
+func (s *fakeUsenetStore) AddNZB(params *store.AddNZBParams) (*store.AddNZBData, error) {
+	s.addNZB = params
+	return &store.AddNZBData{Id: "1", Status: store.MagnetStatusQueued}, nil
+}
+
+func (s *fakeUsenetStore) GetNZB(params *store.GetNZBParams) (*store.GetNZBData, error) {
+	s.getNZB = params
+	return &store.GetNZBData{Id: params.Id}, nil
+}
+
+func (s *fakeUsenetStore) ListNZBs(params *store.ListNZBsParams) (*store.ListNZBsData, error) {
+	s.listNZBs = params
+	return &store.ListNZBsData{}, nil
+}
+
+func (s *fakeUsenetStore) RemoveNZB(params *store.RemoveNZBParams) (*store.RemoveNZBData, error) {
+	return &store.RemoveNZBData{Id: params.Id}, nil
+}
+
+func (s *fakeUsenetStore) GenerateNZBLink(params *store.GenerateNZBLinkParams) (*store.GenerateLinkData, error) {
+	return &store.GenerateLinkData{Link: params.Link}, nil
+}
+
+type fakeTorrentStore struct {
+	store.Store
+}
+
+func (s *fakeTorrentStore) GetName() store.StoreName {
+	return store.StoreNameRealDebrid
+}
+
+func newNewzRequest(s store.Store, method, target string, body string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	r = server.SetReqCtx(r, &server.ReqCtx{
+		StartTime: time.Now(),
+		ReqMethod: r.Method,
+		ReqPath:   r.URL.Path,
+		ReqQuery:  r.URL.Query(),
+	})
+	r = context.SetStoreContext(r)
+	ctx := context.GetStoreContext(r)
+	ctx.Store = s
+	ctx.StoreAuthToken = "token"
+	return r
+}
+
+func decodeNewzResponse(t *testing.T, w *httptest.ResponseRecorder, data any) {
+	t.Helper()
+	res := struct {
+		Data any `json:"data"`
+	}{Data: data}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+}
+
+func TestHandleStoreNewz(t *testing.T) {
+	t.Run("usenet not supported", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handleStoreNewzCheck(w, newNewzRequest(&fakeTorrentStore{}, http.MethodGet, "/v0/store/newz/check?hash=abc", ""))
+		assert.NotEqual(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		s := &fakeUsenetStore{}
+
+		w := httptest.NewRecorder()
+		handleStoreNewzCheck(w, newNewzRequest(s, http.MethodGet, "/v0/store/newz/check?hash=abc,def&hash=ghi", ""))
+		require.Equal(t, http.StatusOK, w.Code)
+		data := &store.CheckNZBData{}
+		decodeNewzResponse(t, w, data)
+		assert.Len(t, data.Items, 3)
+		assert.Equal(t, "token", s.lastAPIKey)
+
+		w = httptest.NewRecorder()
+		handleStoreNewzCheck(w, newNewzRequest(s, http.MethodGet, "/v0/store/newz/check", ""))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		s := &fakeUsenetStore{}
+
+		w := httptest.NewRecorder()
+		handleStoreNewzs(w, newNewzRequest(s, http.MethodGet, "/v0/store/newz?limit=1000&offset=5", ""))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 500, s.listNZBs.Limit)
+		assert.Equal(t, 5, s.listNZBs.Offset)
+		data := &store.ListNZBsData{}
+		decodeNewzResponse(t, w, data)
+		assert.NotNil(t, data.Items)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		s := &fakeUsenetStore{}
+
+		w := httptest.NewRecorder()
+		handleStoreNewzs(w, newNewzRequest(s, http.MethodPost, "/v0/store/newz", `{"link":"https://indexer/nzb/1","name":"Movie"}`))
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "https://indexer/nzb/1", s.addNZB.Link)
+		assert.Equal(t, "Movie", s.addNZB.Name)
+
+		w = httptest.NewRecorder()
+		handleStoreNewzs(w, newNewzRequest(s, http.MethodPost, "/v0/store/newz", `{}`))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		s := &fakeUsenetStore{}
+
+		for _, tc := range []struct {
+			query       url.Values
+			bypassCache bool
+		}{
+			{url.Values{}, false},
+			{url.Values{"bypass_cache": {"true"}}, true},
+		} {
+			r := newNewzRequest(s, http.MethodGet, "/v0/store/newz/42?"+tc.query.Encode(), "")
+			r.SetPathValue("newzId", "42")
+			w := httptest.NewRecorder()
+			handleStoreNewz(w, r)
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "42", s.getNZB.Id)
+			assert.Equal(t, tc.bypassCache, s.getNZB.BypassCache)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		r := newNewzRequest(&fakeUsenetStore{}, http.MethodDelete, "/v0/store/newz/42", "")
+		r.SetPathValue("newzId", "42")
+		w := httptest.NewRecorder()
+		handleStoreNewz(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		data := &store.RemoveNZBData{}
+		decodeNewzResponse(t, w, data)
+		assert.Equal(t, "42", data.Id)
+	})
+}
@@ -1,7 +1,9 @@
 package endpoint
 
 import (
+	"io"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	"github.com/MunifTanjim/stremthru/internal/torrent_info"
+	torrent_info_importer "github.com/MunifTanjim/stremthru/internal/torrent_info/importer"
 )
 
 type RecordTorrentsPayload struct {
@@ -100,11 +103,80 @@ func handleTorrentStats(w http.ResponseWriter, r *http.Request) {
 	SendResponse(w, r, 200, stats, nil)
 }
 
+type ImportTorrentsPayload struct {
+	Path string `json:"path"`
+}
+
+func handleImportTorrents(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	imp := torrent_info_importer.New()
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			shared.ErrorBadRequest(r, "invalid multipart body").Send(w, r)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				shared.ErrorBadRequest(r, "invalid multipart body").Send(w, r)
+				return
+			}
+			if part.FileName() == "" {
+				part.Close()
+				continue
+			}
+			err = imp.ImportFile(part.FileName(), part)
+			part.Close()
+			if err != nil {
+				SendError(w, r, err)
+				return
+			}
+		}
+	} else {
+		payload := &ImportTorrentsPayload{}
+		if err := shared.ReadRequestBodyJSON(r, payload); err != nil {
+			SendError(w, r, err)
+			return
+		}
+		if payload.Path == "" {
+			shared.ErrorBadRequest(r, "missing path").Send(w, r)
+			return
+		}
+		if _, err := os.Stat(payload.Path); err != nil {
+			shared.ErrorBadRequest(r, "invalid path").Send(w, r)
+			return
+		}
+		if err := imp.ImportPath(payload.Path); err != nil {
+			SendError(w, r, err)
+			return
+		}
+	}
+
+	if err := imp.Flush(); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendResponse(w, r, 200, imp.Result, nil)
+}
+
 func AddTorrentEndpoints(mux *http.ServeMux) {
 	if !config.Feature.HasTorrentInfo() {
 		return
 	}
 
+	withAdminAuth := shared.Middleware(AdminAuthed)
+
 	mux.HandleFunc("/v0/torrents", handleTorrents)
 	mux.HandleFunc("/v0/torrents/stats", handleTorrentStats)
+	mux.HandleFunc("/v0/torrents/import", withAdminAuth(handleImportTorrents))
 }
@@ -824,3 +824,74 @@ func GetIdMapsByTraktIds(titleType IMDBTitleSimpleType, traktIds []string) (map[
 	}
 	return idMapById, nil
 }
+
+func getIdMapsByTypedId(titleType IMDBTitleSimpleType, column string, ids []string) (map[string]IMDBTitleMap, error) {
+	count := len(ids)
+	if count == 0 {
+		return nil, nil
+	}
+
+	var titleTypes []IMDBTitleType
+	switch titleType {
+	case IMDBTitleSimpleTypeMovie:
+		titleTypes = movieTypes
+	case IMDBTitleSimpleTypeShow:
+		titleTypes = showTypes
+	default:
+		return nil, ErrUnexpectedTitleType
+	}
+
+	// same projection and join as the trakt id lookup
+	query := fmt.Sprintf(
+		`%sit.%s IN (%s) AND itm.%s IN (%s)`,
+		query_get_id_maps_by_trakt_id_before_cond,
+		Column.Type,
+		db.ToValues(titleTypes, "'%s'"),
+		column,
+		util.RepeatJoin("?", count, ","),
+	)
+	args := make([]any, count)
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	idMapById := make(map[string]IMDBTitleMap, count)
+	for rows.Next() {
+		idMap := IMDBTitleMap{}
+		if err := rows.Scan(
+			&idMap.IMDBId,
+			&idMap.TMDBId,
+			&idMap.TVDBId,
+			&idMap.TraktId,
+			&idMap.LetterboxdId,
+			&idMap.MALId,
+			&idMap.Type,
+		); err != nil {
+			return nil, err
+		}
+
+		switch column {
+		case MapColumn.TMDBId:
+			idMapById[idMap.TMDBId] = idMap
+		case MapColumn.TVDBId:
+			idMapById[idMap.TVDBId] = idMap
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return idMapById, nil
+}
+
+func GetIdMapsByTMDBIds(titleType IMDBTitleSimpleType, tmdbIds []string) (map[string]IMDBTitleMap, error) {
+	return getIdMapsByTypedId(titleType, MapColumn.TMDBId, tmdbIds)
+}
+
+func GetIdMapsByTVDBIds(titleType IMDBTitleSimpleType, tvdbIds []string) (map[string]IMDBTitleMap, error) {
+	return getIdMapsByTypedId(titleType, MapColumn.TVDBId, tvdbIds)
+}
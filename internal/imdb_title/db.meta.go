@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -230,3 +231,140 @@ func GetMetasByIds(imdbIds []string) ([]IMDBTitleMeta, error) {
 	}
 	return metas, nil
 }
+
+type IMDBTitleWithMeta struct {
+	IMDBTitle
+	Meta IMDBTitleMeta
+}
+
+var query_list_titles_with_meta_before_cond = fmt.Sprintf(
+	`SELECT it.%s, it.%s, it.%s, it.%s, %s, %s(itg.%s) AS genres FROM %s itm JOIN %s it ON it.%s = itm.%s JOIN %s itg ON itg.%s = itm.%s WHERE it.%s = %s`,
+	Column.TId,
+	Column.Title,
+	Column.Year,
+	Column.Type,
+	db.JoinPrefixedColumnNames("itm.", MetaColumns...),
+	db.FnJSONGroupArray,
+	GenreColumn.Genre,
+	MetaTableName,
+	TableName,
+	Column.TId,
+	MetaColumn.TId,
+	GenreTableName,
+	GenreColumn.TId,
+	MetaColumn.TId,
+	Column.IsAdult,
+	db.BooleanFalse,
+)
+var query_list_titles_with_meta_cond_genre = fmt.Sprintf(
+	` AND itm.%s IN (SELECT %s FROM %s WHERE %s IN `,
+	MetaColumn.TId,
+	GenreColumn.TId,
+	GenreTableName,
+	GenreColumn.Genre,
+)
+var query_list_titles_with_meta_after_cond = fmt.Sprintf(
+	` GROUP BY it.%s, it.%s, it.%s, it.%s, %s ORDER BY itm.%s DESC, it.%s DESC, it.%s ASC LIMIT ? OFFSET ?`,
+	Column.TId,
+	Column.Title,
+	Column.Year,
+	Column.Type,
+	db.JoinPrefixedColumnNames("itm.", MetaColumns...),
+	MetaColumn.Rating,
+	Column.Year,
+	Column.TId,
+)
+
+type ListTitlesWithMetaParams struct {
+	Type      IMDBTitleSimpleType // movie and show, if empty
+	Genres    [][]string          // all of them must match, by any of the names
+	YearGte   int
+	YearLte   int
+	RatingGte int // 0-100
+	RatingLte int // 0-100
+	Limit     int
+	Offset    int
+}
+
+// ListTitlesWithMeta returns the non-adult movies and shows that have meta
+// recorded, ordered by rating and year.
+func ListTitlesWithMeta(params *ListTitlesWithMetaParams) ([]IMDBTitleWithMeta, error) {
+	var titleTypes []IMDBTitleType
+	switch params.Type {
+	case IMDBTitleSimpleTypeMovie:
+		titleTypes = movieTypes
+	case IMDBTitleSimpleTypeShow:
+		titleTypes = showTypes
+	default:
+		titleTypes = append(slices.Clone(movieTypes), showTypes...)
+	}
+
+	var query strings.Builder
+	args := []any{}
+
+	query.WriteString(query_list_titles_with_meta_before_cond)
+	query.WriteString(" AND it." + Column.Type + " IN (" + util.RepeatJoin("?", len(titleTypes), ",") + ")")
+	for _, titleType := range titleTypes {
+		args = append(args, titleType)
+	}
+	for _, names := range params.Genres {
+		query.WriteString(query_list_titles_with_meta_cond_genre)
+		query.WriteString("(" + util.RepeatJoin("?", len(names), ",") + "))")
+		for _, name := range names {
+			args = append(args, name)
+		}
+	}
+	if params.YearGte > 0 {
+		query.WriteString(" AND it." + Column.Year + " >= ?")
+		args = append(args, params.YearGte)
+	}
+	if params.YearLte > 0 {
+		query.WriteString(" AND it." + Column.Year + " <= ?")
+		args = append(args, params.YearLte)
+	}
+	if params.RatingGte > 0 {
+		query.WriteString(" AND itm." + MetaColumn.Rating + " >= ?")
+		args = append(args, params.RatingGte)
+	}
+	if params.RatingLte > 0 {
+		query.WriteString(" AND itm." + MetaColumn.Rating + " <= ?")
+		args = append(args, params.RatingLte)
+	}
+	query.WriteString(query_list_titles_with_meta_after_cond)
+	args = append(args, params.Limit, params.Offset)
+
+	rows, err := db.Query(query.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []IMDBTitleWithMeta
+	for rows.Next() {
+		var item IMDBTitleWithMeta
+		if err := rows.Scan(
+			&item.TId,
+			&item.Title,
+			&item.Year,
+			&item.Type,
+			&item.Meta.TId,
+			&item.Meta.Description,
+			&item.Meta.Runtime,
+			&item.Meta.Poster,
+			&item.Meta.Backdrop,
+			&item.Meta.Trailer,
+			&item.Meta.Rating,
+			&item.Meta.MPARating,
+			&item.Meta.UpdatedAt,
+			&item.Meta.Genres,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,108 @@
+package mal
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type AnimeListStatus string
+
+const (
+	AnimeListStatusWatching    AnimeListStatus = "watching"
+	AnimeListStatusCompleted   AnimeListStatus = "completed"
+	AnimeListStatusOnHold      AnimeListStatus = "on_hold"
+	AnimeListStatusDropped     AnimeListStatus = "dropped"
+	AnimeListStatusPlanToWatch AnimeListStatus = "plan_to_watch"
+)
+
+type ListStatus struct {
+	ResponseError
+	Status             AnimeListStatus `json:"status"`
+	NumEpisodesWatched int             `json:"num_episodes_watched"`
+	IsRewatching       bool            `json:"is_rewatching"`
+	UpdatedAt          string          `json:"updated_at"`
+}
+
+type AnimeListItem struct {
+	Node struct {
+		Id          int    `json:"id"`
+		Title       string `json:"title"`
+		NumEpisodes int    `json:"num_episodes"`
+	} `json:"node"`
+	ListStatus ListStatus `json:"list_status"`
+}
+
+type GetMyAnimeListData struct {
+	ResponseError
+	Data   []AnimeListItem `json:"data"`
+	Paging struct {
+		Next string `json:"next"`
+	} `json:"paging"`
+}
+
+type GetMyAnimeListParams struct {
+	Ctx
+	Limit  int // max 1000
+	Offset int
+}
+
+func (c APIClient) GetMyAnimeList(params *GetMyAnimeListParams) (request.APIResponse[GetMyAnimeListData], error) {
+	if params.Limit == 0 {
+		params.Limit = 1000
+	}
+	query := url.Values{}
+	query.Set("fields", "list_status,num_episodes")
+	query.Set("limit", strconv.Itoa(params.Limit))
+	if params.Offset > 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+	params.Query = &query
+
+	response := GetMyAnimeListData{}
+	res, err := c.Request("GET", "/users/@me/animelist", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type GetAnimeData struct {
+	ResponseError
+	Id          int    `json:"id"`
+	Title       string `json:"title"`
+	NumEpisodes int    `json:"num_episodes"`
+}
+
+type GetAnimeParams struct {
+	Ctx
+	Id int
+}
+
+func (c APIClient) GetAnime(params *GetAnimeParams) (request.APIResponse[GetAnimeData], error) {
+	query := url.Values{}
+	query.Set("fields", "num_episodes")
+	params.Query = &query
+
+	response := GetAnimeData{}
+	res, err := c.Request("GET", "/anime/"+strconv.Itoa(params.Id), params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type UpdateMyListStatusParams struct {
+	Ctx
+	Id                 int
+	Status             AnimeListStatus
+	NumWatchedEpisodes int
+}
+
+func (c APIClient) UpdateMyListStatus(params *UpdateMyListStatusParams) (request.APIResponse[ListStatus], error) {
+	form := url.Values{}
+	if params.Status != "" {
+		form.Set("status", string(params.Status))
+	}
+	form.Set("num_watched_episodes", strconv.Itoa(params.NumWatchedEpisodes))
+	params.Form = &form
+
+	response := ListStatus{}
+	res, err := c.Request("PATCH", "/anime/"+strconv.Itoa(params.Id)+"/my_list_status", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
@@ -0,0 +1,29 @@
+package mal
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/oauth"
+	tracker_api "github.com/MunifTanjim/stremthru/internal/tracker/api"
+)
+
+type Ctx = tracker_api.Ctx
+type ResponseError = tracker_api.ResponseError
+
+type APIClient struct {
+	*tracker_api.APIClient
+}
+
+var provider = tracker_api.NewProvider(&tracker_api.ProviderConfig{
+	Name:              "mal",
+	BaseURL:           "https://api.myanimelist.net/v2",
+	OAuth:             &oauth.MALOAuthConfig,
+	TokenSourceConfig: oauth.MALTokenSourceConfig,
+	SetHeader: func(header *http.Header, clientId string) {
+		header.Set("X-MAL-CLIENT-ID", clientId)
+	},
+})
+
+func GetAPIClient(tokenId string) *APIClient {
+	return &APIClient{provider.GetAPIClient(tokenId)}
+}
@@ -0,0 +1,19 @@
+package mal
+
+import "github.com/MunifTanjim/stremthru/internal/request"
+
+type GetMyUserData struct {
+	ResponseError
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type GetMyUserParams struct {
+	Ctx
+}
+
+func (c APIClient) GetMyUser(params *GetMyUserParams) (request.APIResponse[GetMyUserData], error) {
+	response := GetMyUserData{}
+	res, err := c.Request("GET", "/users/@me", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/anime"
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/imdb_title"
@@ -41,15 +42,16 @@ const (
 type IdMapAnime = meta_type.IdMapAnime
 type IdMap = meta_type.IdMap
 
+// ParseId parses `{provider}:{id}`, or bare IMDB id, e.g. `tt0110912`,
+// `tmdb:680`, `kitsu:1`.
 func ParseId(idStr string) (provider IdProvider, id string) {
 	if strings.HasPrefix(idStr, "tt") {
 		return IdProviderIMDB, idStr
 	}
-	if traktId, ok := strings.CutPrefix(idStr, "trakt:"); ok {
-		return IdProviderTrakt, traktId
-	}
-	if tvdbId, ok := strings.CutPrefix(idStr, "tvdb:"); ok {
-		return IdProviderTVDB, tvdbId
+	if p, id, ok := strings.Cut(idStr, ":"); ok && id != "" {
+		if provider := IdProvider(p); provider.IsValid() {
+			return provider, id
+		}
 	}
 	return "", ""
 }
@@ -57,63 +59,82 @@ func ParseId(idStr string) (provider IdProvider, id string) {
 var ErrorUnsupportedId = errors.New("unsupported id")
 var ErrorUnsupportedIdAnchor = errors.New("unsupported id anchor")
 
-var idMapCache = cache.NewCache[IdMap](&cache.CacheConfig{
+// cachedIdMap without Found is a negative lookup, the id map itself can be
+// without type, e.g. for anime specials.
+type cachedIdMap struct {
+	IdMap IdMap
+	Found bool
+}
+
+var idMapCache = cache.NewCache[cachedIdMap](&cache.CacheConfig{
 	Lifetime:      3 * time.Hour,
 	Name:          "meta:id-map",
 	LocalCapacity: 2048,
 })
 
-func GetIdMap(idType IdType, idStr string) (*IdMap, error) {
-	idProvider, id := ParseId(idStr)
+// missing ids can be recorded later, e.g. by the dataset syncs
+const idMapNotFoundLifetime = 15 * time.Minute
 
-	idMap := IdMap{IMDB: id}
+func fromIMDBTitleMap(idm *imdb_title.IMDBTitleMap) IdMap {
+	idMap := IdMap{
+		Type:       IdType(idm.Type.ToSimple()),
+		IMDB:       idm.IMDBId,
+		TMDB:       idm.TMDBId,
+		TVDB:       idm.TVDBId,
+		Trakt:      idm.TraktId,
+		Letterboxd: idm.LetterboxdId,
+	}
+	if idm.MALId != "" {
+		idMap.Anime = &IdMapAnime{MAL: idm.MALId}
+	}
+	return idMap
+}
 
-	cacheKey := meta_type.GetIdProviderCacheKey(idProvider, idType, id)
-	if !idMapCache.Get(cacheKey, &idMap) {
-		switch idProvider {
-		case IdProviderIMDB:
-			idm, err := imdb_title.GetIdMapByIMDBId(id)
-			if err != nil || idm == nil {
-				return &idMap, err
-			}
-			idMap.Type = IdType(idm.Type.ToSimple())
-			idMap.IMDB = id
-			idMap.TMDB = idm.TMDBId
-			idMap.TVDB = idm.TVDBId
-			idMap.Trakt = idm.TraktId
-			idMap.Letterboxd = idm.LetterboxdId
-		case IdProviderTVDB:
-			idm, err := imdb_title.GetIdMapByTVDBId(imdb_title.IMDBTitleSimpleType(idType), id)
-			if err != nil || idm == nil {
-				return &idMap, err
-			}
-			idMap.Type = IdType(idm.Type.ToSimple())
-			idMap.IMDB = idm.IMDBId
-			idMap.TMDB = idm.TMDBId
-			idMap.TVDB = id
-			idMap.Trakt = idm.TraktId
-			idMap.Letterboxd = idm.LetterboxdId
-		case IdProviderTrakt:
-			idMaps, err := imdb_title.GetIdMapsByTraktIds(imdb_title.IMDBTitleSimpleType(idType), []string{id})
-			if err != nil {
-				return &idMap, err
-			}
-			idm := idMaps[id]
-			idMap.Type = IdType(idm.Type.ToSimple())
-			idMap.IMDB = idm.IMDBId
-			idMap.TMDB = idm.TMDBId
-			idMap.TVDB = idm.TVDBId
-			idMap.Trakt = id
-			idMap.Letterboxd = idm.LetterboxdId
-		default:
-			return nil, ErrorUnsupportedId
-		}
+func fromAnimeIdMap(aim *anime.AnimeIdMap) IdMap {
+	idMap := IdMap{
+		IMDB:       aim.IMDB,
+		TMDB:       aim.TMDB,
+		TVDB:       aim.TVDB,
+		Trakt:      aim.Trakt,
+		Letterboxd: aim.Letterboxd,
+		Anime: &IdMapAnime{
+			AniDB:       aim.AniDB,
+			AniList:     aim.AniList,
+			AniSearch:   aim.AniSearch,
+			AnimePlanet: aim.AnimePlanet,
+			Kitsu:       aim.Kitsu,
+			LiveChart:   aim.LiveChart,
+			MAL:         aim.MAL,
+			NotifyMoe:   aim.NotifyMoe,
+		},
+	}
+	switch aim.Type {
+	case anime.AnimeIdMapTypeMovie:
+		idMap.Type = IdTypeMovie
+	case anime.AnimeIdMapTypeTV, anime.AnimeIdMapTypeTVShort, anime.AnimeIdMapTypeOVA, anime.AnimeIdMapTypeONA:
+		idMap.Type = IdTypeShow
+	}
+	return idMap
+}
 
-		if err := idMapCache.Add(cacheKey, idMap); err != nil {
-			return nil, err
-		}
+func GetIdMap(idType IdType, idStr string) (*IdMap, error) {
+	idProvider, id := ParseId(idStr)
+	if idProvider == "" {
+		return nil, ErrorUnsupportedId
+	}
+
+	idMaps, err := GetIdMaps(idType, []string{idStr})
+	if err != nil {
+		return nil, err
+	}
+	if idMap, ok := idMaps[idStr]; ok {
+		return &idMap, nil
 	}
 
+	idMap := IdMap{Type: idType}
+	if idProvider == IdProviderIMDB {
+		idMap.IMDB = id
+	}
 	return &idMap, nil
 }
 
@@ -152,3 +173,146 @@ func SetIdMapsInTrx(tx db.Executor, idMaps []IdMap, anchor IdProvider) error {
 func SetIdMaps(idMaps []IdMap, anchor IdProvider) error {
 	return SetIdMapsInTrx(db.GetDB(), idMaps, anchor)
 }
+
+var animeIdMapColumnByIdProvider = map[IdProvider]string{
+	IdProviderAniDB:       anime.IdMapColumn.AniDB,
+	IdProviderAniList:     anime.IdMapColumn.AniList,
+	IdProviderAniSearch:   anime.IdMapColumn.AniSearch,
+	IdProviderAnimePlanet: anime.IdMapColumn.AnimePlanet,
+	IdProviderKitsu:       anime.IdMapColumn.Kitsu,
+	IdProviderLiveChart:   anime.IdMapColumn.LiveChart,
+	IdProviderMAL:         anime.IdMapColumn.MAL,
+	IdProviderNotifyMoe:   anime.IdMapColumn.NotifyMoe,
+}
+
+func getTypedIdMaps(idType IdType, ids []string, getIdMaps func(titleType imdb_title.IMDBTitleSimpleType, ids []string) (map[string]imdb_title.IMDBTitleMap, error)) (map[string]imdb_title.IMDBTitleMap, error) {
+	if idType != IdTypeUnknown {
+		return getIdMaps(imdb_title.IMDBTitleSimpleType(idType), ids)
+	}
+	movieIdMaps, err := getIdMaps(imdb_title.IMDBTitleSimpleTypeMovie, ids)
+	if err != nil {
+		return nil, err
+	}
+	showIdMaps, err := getIdMaps(imdb_title.IMDBTitleSimpleTypeShow, ids)
+	if err != nil {
+		return nil, err
+	}
+	// without type, only the unambiguous ones are usable
+	for id, idm := range showIdMaps {
+		if _, found := movieIdMaps[id]; found {
+			delete(movieIdMaps, id)
+		} else {
+			movieIdMaps[id] = idm
+		}
+	}
+	return movieIdMaps, nil
+}
+
+// GetIdMaps resolves ids of any provider (same format as `ParseId`) in bulk.
+// The result is keyed by the given id string; unsupported and unknown ids
+// are missing from it. The type is needed to tell apart movie and show for
+// TMDB, TVDB and Trakt ids, without it ambiguous ids are skipped.
+func GetIdMaps(idType IdType, idStrs []string) (map[string]IdMap, error) {
+	result := make(map[string]IdMap, len(idStrs))
+
+	idStrsByProviderId := map[IdProvider]map[string][]string{}
+	for _, idStr := range idStrs {
+		idProvider, id := ParseId(idStr)
+		if idProvider == "" {
+			continue
+		}
+		var cached cachedIdMap
+		if idMapCache.Get(meta_type.GetIdProviderCacheKey(idProvider, idType, id), &cached) {
+			if cached.Found {
+				result[idStr] = cached.IdMap
+			}
+			continue
+		}
+		if idStrsByProviderId[idProvider] == nil {
+			idStrsByProviderId[idProvider] = map[string][]string{}
+		}
+		idStrsByProviderId[idProvider][id] = append(idStrsByProviderId[idProvider][id], idStr)
+	}
+
+	for idProvider, idStrsById := range idStrsByProviderId {
+		ids := make([]string, 0, len(idStrsById))
+		for id := range idStrsById {
+			ids = append(ids, id)
+		}
+
+		idMapById := map[string]IdMap{}
+		switch idProvider {
+		case IdProviderIMDB, IdProviderLetterboxd, IdProviderTMDB, IdProviderTVDB, IdProviderTrakt:
+			var idms map[string]imdb_title.IMDBTitleMap
+			var err error
+			switch idProvider {
+			case IdProviderIMDB:
+				idms, err = imdb_title.GetIdMapsByIMDBId(ids)
+			case IdProviderLetterboxd:
+				idms, err = imdb_title.GetIdMapsByLetterboxdId(ids)
+			case IdProviderTMDB:
+				idms, err = getTypedIdMaps(idType, ids, imdb_title.GetIdMapsByTMDBIds)
+			case IdProviderTVDB:
+				idms, err = getTypedIdMaps(idType, ids, imdb_title.GetIdMapsByTVDBIds)
+			case IdProviderTrakt:
+				idms, err = getTypedIdMaps(idType, ids, imdb_title.GetIdMapsByTraktIds)
+			}
+			if err != nil {
+				return nil, err
+			}
+			for id, idm := range idms {
+				idMapById[id] = fromIMDBTitleMap(&idm)
+			}
+		default:
+			column, ok := animeIdMapColumnByIdProvider[idProvider]
+			if !ok {
+				continue
+			}
+			aims, err := anime.GetIdMapsByIds(column, ids)
+			if err != nil {
+				return nil, err
+			}
+			for i := range aims {
+				aim := &aims[i]
+				idMap := fromAnimeIdMap(aim)
+				switch idProvider {
+				case IdProviderAniDB:
+					idMapById[aim.AniDB] = idMap
+				case IdProviderAniList:
+					idMapById[aim.AniList] = idMap
+				case IdProviderAniSearch:
+					idMapById[aim.AniSearch] = idMap
+				case IdProviderAnimePlanet:
+					idMapById[aim.AnimePlanet] = idMap
+				case IdProviderKitsu:
+					idMapById[aim.Kitsu] = idMap
+				case IdProviderLiveChart:
+					idMapById[aim.LiveChart] = idMap
+				case IdProviderMAL:
+					idMapById[aim.MAL] = idMap
+				case IdProviderNotifyMoe:
+					idMapById[aim.NotifyMoe] = idMap
+				}
+			}
+		}
+
+		for _, id := range ids {
+			cacheKey := meta_type.GetIdProviderCacheKey(idProvider, idType, id)
+			idMap, found := idMapById[id]
+			if !found {
+				if err := idMapCache.AddWithLifetime(cacheKey, cachedIdMap{}, idMapNotFoundLifetime); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			for _, idStr := range idStrsById[id] {
+				result[idStr] = idMap
+			}
+			if err := idMapCache.Add(cacheKey, cachedIdMap{IdMap: idMap, Found: true}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
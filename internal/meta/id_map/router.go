@@ -42,6 +42,69 @@ func handleIdMap(w http.ResponseWriter, r *http.Request) {
 	SendResponse(w, r, 200, idMap, nil)
 }
 
+const maxIdMapsBatchSize = 500
+
+type IdMapsPayload struct {
+	Type meta.IdType `json:"type"`
+	Ids  []string    `json:"ids"`
+}
+
+type IdMapsData struct {
+	Items   map[string]meta.IdMap `json:"items"`
+	Missing []string              `json:"missing"`
+	Invalid []string              `json:"invalid"`
+}
+
+func handleIdMaps(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	payload := &IdMapsPayload{}
+	if err := shared.ReadRequestBodyJSON(r, payload); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if !payload.Type.IsValid() {
+		shared.ErrorBadRequest(r, "invalid type").Send(w, r)
+		return
+	}
+	if len(payload.Ids) == 0 {
+		shared.ErrorBadRequest(r, "missing ids").Send(w, r)
+		return
+	}
+	if len(payload.Ids) > maxIdMapsBatchSize {
+		shared.ErrorBadRequest(r, "too many ids, max "+strconv.Itoa(maxIdMapsBatchSize)).Send(w, r)
+		return
+	}
+
+	idMaps, err := meta.GetIdMaps(payload.Type, payload.Ids)
+	if err != nil {
+		shared.ErrorInternalServerError(r, "").WithCause(err).Send(w, r)
+		return
+	}
+
+	data := IdMapsData{
+		Items:   idMaps,
+		Missing: []string{},
+		Invalid: []string{},
+	}
+	for _, id := range payload.Ids {
+		if _, found := idMaps[id]; found {
+			continue
+		}
+		if idProvider, _ := meta.ParseId(id); idProvider == "" {
+			data.Invalid = append(data.Invalid, id)
+		} else {
+			data.Missing = append(data.Missing, id)
+		}
+	}
+
+	SendResponse(w, r, 200, data, nil)
+}
+
 func commonMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := server.GetReqCtx(r)
@@ -55,5 +118,6 @@ func AddEndpoints(mux *http.ServeMux) {
 
 	router.HandleFunc("/{idType}/{id}", handleIdMap)
 
+	mux.Handle("/v0/meta/id-map", commonMiddleware(http.HandlerFunc(handleIdMaps)))
 	mux.Handle("/v0/meta/id-map/", http.StripPrefix("/v0/meta/id-map", commonMiddleware(router)))
 }
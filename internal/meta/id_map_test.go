@@ -0,0 +1,139 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/internal/imdb_title"
+	meta_type "github.com/MunifTanjim/stremthru/internal/meta/type"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseId(t *testing.T) {
+	for _, tc := range []struct {
+		idStr    string
+		provider IdProvider
+		id       string
+	}{
+		{"tt0110912", IdProviderIMDB, "tt0110912"},
+		{"imdb:tt0110912", IdProviderIMDB, "tt0110912"},
+		{"tmdb:680", IdProviderTMDB, "680"},
+		{"tvdb:81189", IdProviderTVDB, "81189"},
+		{"trakt:554", IdProviderTrakt, "554"},
+		{"lboxd:2b3s", IdProviderLetterboxd, "2b3s"},
+		{"kitsu:1", IdProviderKitsu, "1"},
+		{"mal:5114", IdProviderMAL, "5114"},
+		{"anilist:5114", IdProviderAniList, "5114"},
+		{"tvmaze:82", "", ""},
+		{"unknown:1", "", ""},
+		{"tmdb:", "", ""},
+		{"680", "", ""},
+	} {
+		t.Run(tc.idStr, func(t *testing.T) {
+			provider, id := ParseId(tc.idStr)
+			assert.Equal(t, tc.provider, provider)
+			assert.Equal(t, tc.id, id)
+		})
+	}
+}
+
+func TestGetIdMaps(t *testing.T) {
+	pulpFiction := IdMap{
+		Type:  IdTypeMovie,
+		IMDB:  "tt0110912",
+		TMDB:  "680",
+		Trakt: "554",
+	}
+	fullmetal := IdMap{
+		Type: IdTypeShow,
+		IMDB: "tt1355642",
+		TVDB: "85249",
+		Anime: &IdMapAnime{
+			Kitsu: "3936",
+			MAL:   "5114",
+		},
+	}
+	special := IdMap{
+		Anime: &IdMapAnime{
+			Kitsu: "6247",
+		},
+	}
+	for key, idMap := range map[string]IdMap{
+		meta_type.GetIdProviderCacheKey(IdProviderIMDB, IdTypeUnknown, pulpFiction.IMDB):       pulpFiction,
+		meta_type.GetIdProviderCacheKey(IdProviderTMDB, IdTypeMovie, pulpFiction.TMDB):         pulpFiction,
+		meta_type.GetIdProviderCacheKey(IdProviderIMDB, IdTypeUnknown, fullmetal.IMDB):         fullmetal,
+		meta_type.GetIdProviderCacheKey(IdProviderKitsu, IdTypeUnknown, fullmetal.Anime.Kitsu): fullmetal,
+		meta_type.GetIdProviderCacheKey(IdProviderKitsu, IdTypeUnknown, special.Anime.Kitsu):   special,
+	} {
+		assert.NoError(t, idMapCache.Add(key, cachedIdMap{IdMap: idMap, Found: true}))
+	}
+	assert.NoError(t, idMapCache.Add(meta_type.GetIdProviderCacheKey(IdProviderTMDB, IdTypeMovie, "0"), cachedIdMap{}))
+
+	t.Run("cached", func(t *testing.T) {
+		idMaps, err := GetIdMaps(IdTypeMovie, []string{"tt0110912", "imdb:tt0110912", "tmdb:680", "kitsu:3936"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]IdMap{
+			"tt0110912":      pulpFiction,
+			"imdb:tt0110912": pulpFiction,
+			"tmdb:680":       pulpFiction,
+			"kitsu:3936":     fullmetal,
+		}, idMaps)
+	})
+
+	t.Run("cached without type", func(t *testing.T) {
+		idMaps, err := GetIdMaps(IdTypeUnknown, []string{"kitsu:6247"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]IdMap{"kitsu:6247": special}, idMaps)
+	})
+
+	t.Run("cached not found", func(t *testing.T) {
+		idMaps, err := GetIdMaps(IdTypeMovie, []string{"tmdb:0"})
+		assert.NoError(t, err)
+		assert.Empty(t, idMaps)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		idMaps, err := GetIdMaps(IdTypeUnknown, []string{"tvmaze:82", "unknown:1", "680"})
+		assert.NoError(t, err)
+		assert.Empty(t, idMaps)
+	})
+
+	t.Run("same as GetIdMap", func(t *testing.T) {
+		for _, idStr := range []string{"tt1355642", "kitsu:3936"} {
+			idMaps, err := GetIdMaps(IdTypeShow, []string{idStr})
+			assert.NoError(t, err)
+			idMap, err := GetIdMap(IdTypeShow, idStr)
+			assert.NoError(t, err)
+			assert.Equal(t, idMaps[idStr], *idMap)
+			assert.Equal(t, "5114", idMap.Anime.MAL)
+		}
+	})
+}
+
+func TestGetTypedIdMaps(t *testing.T) {
+	getIdMaps := func(titleType imdb_title.IMDBTitleSimpleType, ids []string) (map[string]imdb_title.IMDBTitleMap, error) {
+		idMaps := map[string]imdb_title.IMDBTitleMap{}
+		for _, id := range ids {
+			switch {
+			case titleType == imdb_title.IMDBTitleSimpleTypeMovie && id != "2":
+				idMaps[id] = imdb_title.IMDBTitleMap{IMDBId: "tt-movie-" + id}
+			case titleType == imdb_title.IMDBTitleSimpleTypeShow && id != "1":
+				idMaps[id] = imdb_title.IMDBTitleMap{IMDBId: "tt-show-" + id}
+			}
+		}
+		return idMaps, nil
+	}
+
+	idMaps, err := getTypedIdMaps(IdTypeShow, []string{"1", "2", "3"}, getIdMaps)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]imdb_title.IMDBTitleMap{
+		"2": {IMDBId: "tt-show-2"},
+		"3": {IMDBId: "tt-show-3"},
+	}, idMaps)
+
+	idMaps, err = getTypedIdMaps(IdTypeUnknown, []string{"1", "2", "3"}, getIdMaps)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]imdb_title.IMDBTitleMap{
+		"1": {IMDBId: "tt-movie-1"},
+		"2": {IMDBId: "tt-show-2"},
+	}, idMaps)
+}
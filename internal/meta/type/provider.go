@@ -27,6 +27,14 @@ const (
 	IdProviderNotifyMoe   IdProvider = "notifymoe"
 )
 
+func (ip IdProvider) IsValid() bool {
+	switch ip {
+	case IdProviderIMDB, IdProviderTMDB, IdProviderTVDB, IdProviderTrakt, IdProviderLetterboxd:
+		return true
+	}
+	return ip.IsAnime()
+}
+
 func (ip IdProvider) IsAnime() bool {
 	return ip == IdProviderAniDB ||
 		ip == IdProviderAniList ||
@@ -42,10 +50,10 @@ func GetIdProviderCacheKey(idProvider IdProvider, idType IdType, id string) stri
 	switch idProvider {
 	case IdProviderIMDB:
 		return id
-	case IdProviderTrakt, IdProviderTVDB:
+	case IdProviderTMDB, IdProviderTrakt, IdProviderTVDB:
 		return string(idProvider) + ":" + string(idType) + ":" + id
 	default:
-		panic("unsupported id provider: " + string(idProvider))
+		return string(idProvider) + ":" + id
 	}
 }
 
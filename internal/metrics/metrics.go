@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "stremthru"
+
+var Registry = func() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}()
+
+var storeRequestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "store",
+	Name:      "requests_total",
+	Help:      "Number of store requests, by store, method and result.",
+}, []string{"store", "method", "result"})
+
+var storeRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "store",
+	Name:      "request_duration_seconds",
+	Help:      "Latency of store requests, by store and method.",
+	Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+}, []string{"store", "method"})
+
+var cacheRequestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "cache",
+	Name:      "requests_total",
+	Help:      "Number of cache lookups, by cache and result (hit/miss).",
+}, []string{"cache", "result"})
+
+var proxyBytesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "proxy",
+	Name:      "bytes_total",
+	Help:      "Number of bytes written by the content proxy.",
+})
+
+var workerRunTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "worker",
+	Name:      "runs_total",
+	Help:      "Number of worker job runs, by worker and status (done/failed).",
+}, []string{"worker", "status"})
+
+var workerRunDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "worker",
+	Name:      "run_duration_seconds",
+	Help:      "Duration of worker job runs, by worker and status (done/failed).",
+	Buckets:   []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600, 7200},
+}, []string{"worker", "status"})
+
+var rateLimitRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "ratelimit",
+	Name:      "rejected_total",
+	Help:      "Number of requests rejected by rate limiter, by limiter.",
+}, []string{"limiter"})
+
+func init() {
+	Registry.MustRegister(
+		storeRequestTotal,
+		storeRequestDuration,
+		cacheRequestTotal,
+		proxyBytesTotal,
+		workerRunTotal,
+		workerRunDuration,
+		rateLimitRejectedTotal,
+	)
+}
+
+func ObserveStoreRequest(store, method string, startedAt time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	storeRequestTotal.WithLabelValues(store, method, result).Inc()
+	storeRequestDuration.WithLabelValues(store, method).Observe(time.Since(startedAt).Seconds())
+}
+
+func ObserveCacheLookup(cache string, hit bool) {
+	if cache == "" {
+		cache = "unknown"
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequestTotal.WithLabelValues(cache, result).Inc()
+}
+
+func AddProxyBytes(bytes int64) {
+	if bytes > 0 {
+		proxyBytesTotal.Add(float64(bytes))
+	}
+}
+
+func ObserveWorkerRun(worker, status string, startedAt time.Time) {
+	workerRunTotal.WithLabelValues(worker, status).Inc()
+	workerRunDuration.WithLabelValues(worker, status).Observe(time.Since(startedAt).Seconds())
+}
+
+func IncRateLimitRejected(limiter string) {
+	rateLimitRejectedTotal.WithLabelValues(limiter).Inc()
+}
+
+// RegisterGaugeFunc registers a gauge that is evaluated on every scrape.
+func RegisterGaugeFunc(subsystem, name, help string, labels map[string]string, fn func() float64) {
+	Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Subsystem:   subsystem,
+		Name:        name,
+		Help:        help,
+		ConstLabels: labels,
+	}, fn))
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveStoreRequest(t *testing.T) {
+	ObserveStoreRequest("torbox", "CheckMagnet", time.Now(), nil)
+	ObserveStoreRequest("torbox", "CheckMagnet", time.Now(), errors.New("failed"))
+	ObserveStoreRequest("torbox", "CheckMagnet", time.Now(), nil)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(storeRequestTotal.WithLabelValues("torbox", "CheckMagnet", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(storeRequestTotal.WithLabelValues("torbox", "CheckMagnet", "error")))
+}
+
+func TestObserveCacheLookup(t *testing.T) {
+	ObserveCacheLookup("test", true)
+	ObserveCacheLookup("test", false)
+	ObserveCacheLookup("test", true)
+	ObserveCacheLookup("", false)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(cacheRequestTotal.WithLabelValues("test", "hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheRequestTotal.WithLabelValues("test", "miss")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheRequestTotal.WithLabelValues("unknown", "miss")))
+}
+
+func TestAddProxyBytes(t *testing.T) {
+	before := testutil.ToFloat64(proxyBytesTotal)
+	AddProxyBytes(1024)
+	AddProxyBytes(0)
+	AddProxyBytes(-1)
+	assert.Equal(t, before+1024, testutil.ToFloat64(proxyBytesTotal))
+}
@@ -0,0 +1,195 @@
+package newznab_client
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type NewznabAttrName string
+
+const (
+	NewznabAttrNameCategory   NewznabAttrName = "category"
+	NewznabAttrNameSize       NewznabAttrName = "size"
+	NewznabAttrNameFiles      NewznabAttrName = "files"
+	NewznabAttrNameGrabs      NewznabAttrName = "grabs"
+	NewznabAttrNameIMDB       NewznabAttrName = "imdb"
+	NewznabAttrNamePassword   NewznabAttrName = "password"
+	NewznabAttrNameUsenetDate NewznabAttrName = "usenetdate"
+)
+
+type NewznabAttr struct {
+	Name  NewznabAttrName `xml:"name,attr"`
+	Value string          `xml:"value,attr"`
+}
+
+type NewznabAttrs []NewznabAttr
+
+func (attrs NewznabAttrs) Get(name NewznabAttrName) string {
+	for _, attr := range attrs {
+		if attr.Name == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func (attrs NewznabAttrs) GetAll(name NewznabAttrName) []string {
+	values := []string{}
+	for _, attr := range attrs {
+		if attr.Name == name {
+			values = append(values, attr.Value)
+		}
+	}
+	return values
+}
+
+type Newz struct {
+	Indexer string
+
+	GUID  string
+	Title string
+	Size  int64
+	Files int
+	Grabs int
+
+	Categories  []int
+	IMDBId      string
+	PublishDate time.Time
+
+	Link string
+}
+
+type ChannelItem struct {
+	Title        string                       `xml:"title"`
+	GUID         string                       `xml:"guid"`
+	Comments     string                       `xml:"comments"`
+	PubDate      string                       `xml:"pubDate"` // Mon, 02 Jan 2006 15:04:05 -0700
+	Size         int64                        `xml:"size"`
+	Description  string                       `xml:"description"`
+	Link         string                       `xml:"link"`
+	Enclosure    torznab_client.ItemEnclosure `xml:"enclosure"`
+	NewznabAttrs NewznabAttrs                 `xml:"http://www.newznab.com/DTD/2010/feeds/attributes/ attr"`
+}
+
+func (o ChannelItem) ToNewz() *Newz {
+	n := &Newz{}
+	n.GUID = o.GUID
+	n.Title = o.Title
+	n.Size = o.Size
+	if n.Size == 0 {
+		n.Size = o.Enclosure.Length
+	}
+	if n.Size == 0 {
+		n.Size = int64(util.SafeParseInt(o.NewznabAttrs.Get(NewznabAttrNameSize), 0))
+	}
+	n.Files = util.SafeParseInt(o.NewznabAttrs.Get(NewznabAttrNameFiles), 0)
+	n.Grabs = util.SafeParseInt(o.NewznabAttrs.Get(NewznabAttrNameGrabs), 0)
+	for _, cat := range o.NewznabAttrs.GetAll(NewznabAttrNameCategory) {
+		if id := util.SafeParseInt(cat, -1); id >= 0 {
+			n.Categories = append(n.Categories, id)
+		}
+	}
+	if imdb := o.NewznabAttrs.Get(NewznabAttrNameIMDB); imdb != "" {
+		n.IMDBId = "tt" + strings.TrimPrefix(imdb, "tt")
+	}
+	if pubDate, err := time.Parse(time.RFC1123Z, o.PubDate); err == nil {
+		n.PublishDate = pubDate
+	} else if pubDate, err := time.Parse(time.RFC1123, o.PubDate); err == nil {
+		n.PublishDate = pubDate
+	}
+	n.Link = o.Enclosure.URL
+	if n.Link == "" {
+		n.Link = o.Link
+	}
+	if n.GUID == "" {
+		n.GUID = n.Link
+	}
+	return n
+}
+
+type Channel struct {
+	XMLName     xml.Name      `xml:"channel"`
+	Title       string        `xml:"title,omitempty"`
+	Description string        `xml:"description,omitempty"`
+	Link        string        `xml:"link,omitempty"`
+	Items       []ChannelItem `xml:"item"`
+}
+
+type SearchResponse struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr,omitempty"`
+	Channel Channel  `xml:"channel"`
+}
+
+type ClientConfig struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	APIKey     string
+	UserAgent  string
+}
+
+type Client struct {
+	*torznab_client.Client
+	id string
+}
+
+func NewClient(conf *ClientConfig) *Client {
+	c := torznab_client.NewClient(&torznab_client.ClientConfig{
+		BaseURL:    conf.BaseURL,
+		HTTPClient: conf.HTTPClient,
+		APIKey:     conf.APIKey,
+		UserAgent:  conf.UserAgent,
+	})
+	return &Client{Client: c, id: torznab_client.TorznabURL(conf.BaseURL).Encode()}
+}
+
+func (c Client) GetId() string {
+	return "newznab/" + c.id
+}
+
+func (c Client) Search(query url.Values) ([]Newz, error) {
+	params := &torznab_client.Ctx{}
+	params.Query = &query
+	var resp torznab_client.Response[SearchResponse]
+	_, err := c.Client.Request("GET", "/api", params, &resp)
+	if err != nil {
+		return nil, err
+	}
+	items := resp.Data.Channel.Items
+	result := make([]Newz, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		newz := item.ToNewz()
+		if newz.Link == "" {
+			continue
+		}
+		result = append(result, *newz)
+	}
+	return result, nil
+}
+
+// Download fetches the nzb behind an enclosure link returned by Search.
+func (c Client) Download(link string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", link, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		res.Body.Close()
+		error := core.NewUpstreamError("failed to download nzb")
+		error.StatusCode = res.StatusCode
+		return nil, error
+	}
+	return res, nil
+}
@@ -0,0 +1,45 @@
+package newznab_client
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelItemToNewz(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:newznab="http://www.newznab.com/DTD/2010/feeds/attributes/">
+  <channel>
+    <item>
+      <title>Movie.2024.1080p.WEB-DL</title>
+      <guid>https://indexer.example/details/abc</guid>
+      <pubDate>Mon, 02 Jan 2006 15:04:05 -0700</pubDate>
+      <link>https://indexer.example/getnzb/abc</link>
+      <enclosure url="https://indexer.example/getnzb/abc.nzb" length="1234" type="application/x-nzb" />
+      <newznab:attr name="category" value="2000" />
+      <newznab:attr name="category" value="2040" />
+      <newznab:attr name="files" value="42" />
+      <newznab:attr name="grabs" value="7" />
+      <newznab:attr name="imdb" value="0111161" />
+    </item>
+  </channel>
+</rss>`
+
+	var resp SearchResponse
+	require.NoError(t, xml.Unmarshal([]byte(body), &resp))
+	require.Len(t, resp.Channel.Items, 1)
+
+	newz := resp.Channel.Items[0].ToNewz()
+	assert.Equal(t, "Movie.2024.1080p.WEB-DL", newz.Title)
+	assert.Equal(t, "https://indexer.example/details/abc", newz.GUID)
+	assert.Equal(t, "https://indexer.example/getnzb/abc.nzb", newz.Link)
+	assert.Equal(t, int64(1234), newz.Size)
+	assert.Equal(t, 42, newz.Files)
+	assert.Equal(t, 7, newz.Grabs)
+	assert.Equal(t, []int{2000, 2040}, newz.Categories)
+	assert.Equal(t, "tt0111161", newz.IMDBId)
+	assert.True(t, newz.PublishDate.Equal(time.Date(2006, 1, 2, 22, 4, 5, 0, time.UTC)))
+}
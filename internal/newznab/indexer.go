@@ -0,0 +1,248 @@
+package newznab
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	newznab_client "github.com/MunifTanjim/stremthru/internal/newznab/client"
+	newznab_indexer "github.com/MunifTanjim/stremthru/internal/newznab/indexer"
+	"github.com/MunifTanjim/stremthru/internal/torznab"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type stremThruIndexer struct {
+	info torznab.Info
+	caps torznab.Caps
+}
+
+func (sti stremThruIndexer) Info() torznab.Info {
+	return sti.info
+}
+
+func toCategory(ids []int) torznab.Category {
+	if len(ids) == 0 {
+		return torznab.CategoryOther
+	}
+	if cats := torznab.AllCategories.Subset(ids[0]); len(cats) > 0 {
+		return cats[0]
+	}
+	return torznab.ParentCategory(torznab.Category{ID: ids[0]})
+}
+
+const nzbLinkAPIKeyPlaceholder = "{apikey}"
+
+// encodeNZBId seals the upstream enclosure link, with the indexer api key
+// swapped for a placeholder, so it can be handed out without leaking the key.
+func encodeNZBId(indexer *newznab_indexer.NewznabIndexer, apiKey, link string) (string, error) {
+	if apiKey != "" {
+		link = strings.ReplaceAll(link, apiKey, nzbLinkAPIKeyPlaceholder)
+	}
+	return core.Encrypt(config.VaultSecret, indexer.GetCompositeId()+"\n"+link)
+}
+
+func decodeNZBId(id string) (compositeId string, link string, err error) {
+	value, err := core.Decrypt(config.VaultSecret, id)
+	if err != nil {
+		return "", "", err
+	}
+	compositeId, link, ok := strings.Cut(value, "\n")
+	if !ok {
+		return "", "", errors.New("invalid nzb id")
+	}
+	return compositeId, link, nil
+}
+
+func getNZBLink(id, apiKey string) string {
+	u := config.BaseURL.JoinPath("/v0/newznab/api")
+	query := url.Values{}
+	query.Set("t", "get")
+	query.Set("id", id)
+	if apiKey != "" {
+		query.Set("apikey", apiKey)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func searchIndexer(indexer *newznab_indexer.NewznabIndexer, query torznab.Query) ([]newznab_client.Newz, error) {
+	rl, err := indexer.GetRateLimiter()
+	if err != nil {
+		return nil, err
+	}
+	if rl != nil {
+		if result, err := rl.Try(); err != nil {
+			return nil, err
+		} else if !result.Allowed {
+			log.Warn("rate limited, skipping indexer", "indexer", indexer.Name, "retry_after", result.RetryAfter.String())
+			return nil, nil
+		}
+	}
+
+	client, err := indexer.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	query.APIKey = ""
+	if query.Limit > 0 {
+		query.Limit += query.Offset
+	}
+	query.Offset = 0
+	return client.Search(*query.ToValues())
+}
+
+func (sti stremThruIndexer) Search(q torznab.Query) ([]torznab.ResultItem, error) {
+	indexers, err := newznab_indexer.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]newznab_client.Newz, len(indexers))
+	var wg sync.WaitGroup
+	for i := range indexers {
+		indexer := &indexers[i]
+		wg.Go(func() {
+			apiKey, err := indexer.GetAPIKey()
+			if err != nil {
+				log.Error("failed to get indexer api key", "error", err, "indexer", indexer.Name)
+				return
+			}
+			items, err := searchIndexer(indexer, q)
+			if err != nil {
+				log.Error("indexer search failed", "error", err, "indexer", indexer.Name)
+				return
+			}
+			for j := range items {
+				item := &items[j]
+				item.Indexer = indexer.Name
+				id, err := encodeNZBId(indexer, apiKey, item.Link)
+				if err != nil {
+					log.Error("failed to encode nzb id", "error", err, "indexer", indexer.Name)
+					return
+				}
+				item.Link = getNZBLink(id, q.APIKey)
+			}
+			results[i] = items
+		})
+	}
+	wg.Wait()
+
+	seenGUID := util.NewSet[string]()
+	items := []torznab.ResultItem{}
+	for _, newzs := range results {
+		for i := range newzs {
+			newz := &newzs[i]
+			if seenGUID.Has(newz.GUID) {
+				continue
+			}
+			seenGUID.Add(newz.GUID)
+			items = append(items, torznab.ResultItem{
+				Category:    toCategory(newz.Categories),
+				Files:       newz.Files,
+				GUID:        newz.GUID,
+				Grabs:       newz.Grabs,
+				IMDB:        newz.IMDBId,
+				NZBLink:     newz.Link,
+				PublishDate: newz.PublishDate,
+				Site:        newz.Indexer,
+				Size:        newz.Size,
+				Title:       newz.Title,
+			})
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b torznab.ResultItem) int {
+		return b.PublishDate.Compare(a.PublishDate)
+	})
+
+	if q.Offset > 0 {
+		items = items[min(q.Offset, len(items)):]
+	}
+
+	if q.Limit > 0 {
+		items = items[:min(q.Limit, len(items))]
+	}
+
+	return items, nil
+}
+
+// Download fetches the nzb for an id from the links returned by Search, using
+// the api key stored for the indexer.
+func (sti stremThruIndexer) Download(id string) (io.ReadCloser, http.Header, error) {
+	compositeId, link, err := decodeNZBId(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	indexer, err := newznab_indexer.GetByCompositeId(compositeId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if indexer == nil {
+		return nil, nil, errors.New("indexer not found")
+	}
+	apiKey, err := indexer.GetAPIKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := indexer.GetClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := client.Download(strings.ReplaceAll(link, nzbLinkAPIKeyPlaceholder, apiKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Body, res.Header, nil
+}
+
+func (sti stremThruIndexer) Capabilities() torznab.Caps {
+	return sti.caps
+}
+
+var StremThruIndexer = stremThruIndexer{
+	info: torznab.Info{
+		Title:       "StremThru",
+		Description: "StremThru Newznab",
+	},
+	caps: torznab.Caps{
+		Server: &torznab.CapsServer{
+			Title:     "StremThru",
+			Strapline: "StremThru Newznab",
+			Image:     "https://emojiapi.dev/api/v1/sparkles/256.png",
+			URL:       config.BaseURL.String(),
+			Version:   "1.3",
+		},
+		Searching: []torznab.CapsSearchingItem{
+			{
+				Name:            "search",
+				Available:       true,
+				SupportedParams: []string{"q"},
+			},
+			{
+				Name:            "tv-search",
+				Available:       true,
+				SupportedParams: []string{"q,imdbid,season,ep"},
+			},
+			{
+				Name:            "movie-search",
+				Available:       true,
+				SupportedParams: []string{"q,imdbid"},
+			},
+		},
+		Categories: []torznab.CapsCategory{
+			{
+				Category: torznab.CategoryMovies,
+			},
+			{
+				Category: torznab.CategoryTV,
+			},
+		},
+	},
+}
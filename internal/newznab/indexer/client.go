@@ -0,0 +1,46 @@
+package newznab_indexer
+
+import (
+	"errors"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	newznab_client "github.com/MunifTanjim/stremthru/internal/newznab/client"
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
+)
+
+var newznabCache = cache.NewLRUCache[*newznab_client.Client](&cache.CacheConfig{
+	Lifetime: 3 * time.Hour,
+	Name:     "newznab:indexer:newznab",
+})
+
+func (nidxr NewznabIndexer) GetClient() (*newznab_client.Client, error) {
+	switch nidxr.Type {
+	case IndexerTypeNewznab:
+		apiKey, err := nidxr.GetAPIKey()
+		if err != nil {
+			return nil, err
+		}
+
+		u := torznab_client.TorznabURL(nidxr.URL)
+		if err := u.Parse(); err != nil {
+			return nil, err
+		}
+
+		cacheKey := nidxr.Id + ":" + nidxr.APIKey
+		var client *newznab_client.Client
+		if !newznabCache.Get(cacheKey, &client) {
+			client = newznab_client.NewClient(&newznab_client.ClientConfig{
+				BaseURL: u.BaseURL,
+				APIKey:  apiKey,
+			})
+			err := newznabCache.Add(cacheKey, client)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return client, nil
+	default:
+		return nil, errors.New("invalid indexer type: " + string(nidxr.Type))
+	}
+}
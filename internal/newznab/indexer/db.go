@@ -0,0 +1,150 @@
+package newznab_indexer
+
+import (
+	"fmt"
+	"strings"
+
+	newznab_client "github.com/MunifTanjim/stremthru/internal/newznab/client"
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
+	torznab_indexer "github.com/MunifTanjim/stremthru/internal/torznab/indexer"
+)
+
+const TableName = "newznab_indexer"
+
+var table = torznab_indexer.NewTable(TableName)
+
+type IndexerType = torznab_indexer.IndexerType
+
+const (
+	IndexerTypeNewznab IndexerType = "newznab"
+)
+
+func isValidIndexerType(it IndexerType) bool {
+	switch it {
+	case IndexerTypeNewznab:
+		return true
+	default:
+		return false
+	}
+}
+
+func ParseCompositeId(compositeId string) (IndexerType, string, error) {
+	typeStr, id, ok := strings.Cut(compositeId, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid composite id format: expected {type}:{id}")
+	}
+	indexerType := IndexerType(typeStr)
+	if !isValidIndexerType(indexerType) {
+		return "", "", fmt.Errorf("invalid indexer type: %s", typeStr)
+	}
+	return indexerType, id, nil
+}
+
+// NewznabIndexer shares the storage, api key encryption and rate limiting of
+// torznab indexers, only the client differs.
+type NewznabIndexer struct {
+	torznab_indexer.TorznabIndexer
+}
+
+func NewNewznabIndexer(indexerType IndexerType, url, apiKey string) (*NewznabIndexer, error) {
+	switch indexerType {
+	case IndexerTypeNewznab:
+		u := torznab_client.TorznabURL(url)
+		if err := u.Parse(); err != nil {
+			return nil, fmt.Errorf("invalid newznab url: %w", err)
+		}
+
+		indexer := &NewznabIndexer{}
+		indexer.Type = indexerType
+		indexer.Id = u.Encode()
+		indexer.URL = url
+		err := indexer.SetAPIKey(apiKey)
+		if err != nil {
+			return nil, err
+		}
+		return indexer, nil
+	default:
+		return nil, fmt.Errorf("unsupported indexer type: %s", indexerType)
+	}
+}
+
+func (i *NewznabIndexer) Validate() error {
+	switch i.Type {
+	case IndexerTypeNewznab:
+		u := torznab_client.TorznabURL(i.URL)
+		if err := u.Parse(); err != nil {
+			return fmt.Errorf("invalid newznab url: %w", err)
+		}
+
+		apiKey, err := i.GetAPIKey()
+		if err != nil {
+			return fmt.Errorf("failed to decrypt api key: %w", err)
+		}
+
+		client := newznab_client.NewClient(&newznab_client.ClientConfig{
+			BaseURL: u.BaseURL,
+			APIKey:  apiKey,
+		})
+
+		caps, err := client.GetCaps()
+		if err != nil {
+			return fmt.Errorf("failed to fetch capabilities: %w", err)
+		}
+
+		if i.Name == "" {
+			if caps.Server.Title != "" {
+				i.Name = caps.Server.Title
+			} else {
+				i.Name = u.Host()
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported indexer type: %s", i.Type)
+	}
+}
+
+func GetAll() ([]NewznabIndexer, error) {
+	rows, err := table.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]NewznabIndexer, len(rows))
+	for i := range rows {
+		items[i] = NewznabIndexer{rows[i]}
+	}
+	return items, nil
+}
+
+func GetById(indexerType IndexerType, id string) (*NewznabIndexer, error) {
+	item, err := table.GetById(indexerType, id)
+	if err != nil || item == nil {
+		return nil, err
+	}
+	return &NewznabIndexer{*item}, nil
+}
+
+func GetByCompositeId(compositeId string) (*NewznabIndexer, error) {
+	indexerType, id, err := ParseCompositeId(compositeId)
+	if err != nil {
+		return nil, err
+	}
+	return GetById(indexerType, id)
+}
+
+func (i *NewznabIndexer) Upsert() error {
+	return table.Upsert(&i.TorznabIndexer)
+}
+
+func Delete(indexerType IndexerType, id string) error {
+	return table.Delete(indexerType, id)
+}
+
+func DeleteByCompositeId(compositeId string) error {
+	indexerType, id, err := ParseCompositeId(compositeId)
+	if err != nil {
+		return err
+	}
+	return Delete(indexerType, id)
+}
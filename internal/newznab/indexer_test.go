@@ -0,0 +1,37 @@
+package newznab
+
+import (
+	"net/url"
+	"testing"
+
+	newznab_indexer "github.com/MunifTanjim/stremthru/internal/newznab/indexer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNZBId(t *testing.T) {
+	indexer := &newznab_indexer.NewznabIndexer{}
+	indexer.Type = newznab_indexer.IndexerTypeNewznab
+	indexer.Id = "https:api.nzbgeek.info"
+
+	link := "https://api.nzbgeek.info/api?t=get&id=abc&apikey=secret-key"
+	id, err := encodeNZBId(indexer, "secret-key", link)
+	require.NoError(t, err)
+	assert.NotContains(t, id, "secret-key")
+
+	nzbLink := getNZBLink(id, "user:pass")
+	assert.NotContains(t, nzbLink, "secret-key")
+	u, err := url.Parse(nzbLink)
+	require.NoError(t, err)
+	assert.Equal(t, "/v0/newznab/api", u.Path)
+	assert.Equal(t, "get", u.Query().Get("t"))
+	assert.Equal(t, id, u.Query().Get("id"))
+
+	compositeId, decodedLink, err := decodeNZBId(u.Query().Get("id"))
+	require.NoError(t, err)
+	assert.Equal(t, "newznab:https:api.nzbgeek.info", compositeId)
+	assert.Equal(t, "https://api.nzbgeek.info/api?t=get&id=abc&apikey={apikey}", decodedLink)
+
+	_, _, err = decodeNZBId("invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,5 @@
+package newznab
+
+import "github.com/MunifTanjim/stremthru/internal/logger"
+
+var log = logger.Scoped("newznab")
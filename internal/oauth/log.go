@@ -7,4 +7,7 @@ import (
 var log = logger.Scoped("oauth")
 var traktLog = logger.Scoped("oauth/trakt")
 var kitsuLog = logger.Scoped("oauth/kitsu")
+var anilistLog = logger.Scoped("oauth/anilist")
+var malLog = logger.Scoped("oauth/mal")
+var simklLog = logger.Scoped("oauth/simkl")
 var tokenSourceLog = logger.Scoped("oauth/token_source")
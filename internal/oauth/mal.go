@@ -0,0 +1,94 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/request"
+	"golang.org/x/oauth2"
+)
+
+var MALTokenSourceConfig = TokenSourceConfig{
+	Provider: ProviderMAL,
+	GetUser: func(client *http.Client, oauthConfig *oauth2.Config) (userId, userName string, err error) {
+		req, err := http.NewRequest("GET", "https://api.myanimelist.net/v2/users/@me", nil)
+		if err != nil {
+			return "", "", err
+		}
+		res, err := client.Do(req)
+		var response struct {
+			responseError
+			Id   int64  `json:"id"`
+			Name string `json:"name"`
+		}
+		err = request.ProcessResponseBody(res, err, &response)
+		if err != nil {
+			return "", "", err
+		}
+		return strconv.FormatInt(response.Id, 10), response.Name, nil
+	},
+	// MyAnimeList does not return `scope` and `created_at` with the token.
+	PrepareToken: func(tok *oauth2.Token, id, userId string, userName string) *oauth2.Token {
+		return tok.WithExtra(map[string]any{
+			"id":         id,
+			"provider":   ProviderMAL,
+			"user_id":    userId,
+			"user_name":  userName,
+			"scope":      "",
+			"created_at": time.Now(),
+		})
+	},
+}
+
+var malOAuthConfig = oauth2.Config{
+	ClientID:     config.Integration.MAL.ClientId,
+	ClientSecret: config.Integration.MAL.ClientSecret,
+	Endpoint: oauth2.Endpoint{
+		AuthURL:   "https://myanimelist.net/v1/oauth2/authorize",
+		TokenURL:  "https://myanimelist.net/v1/oauth2/token",
+		AuthStyle: oauth2.AuthStyleInParams,
+	},
+	RedirectURL: config.BaseURL.JoinPath("/auth/myanimelist.net/callback").String(),
+}
+
+var malCodeVerifierCache = cache.NewCache[string](&cache.CacheConfig{
+	Lifetime: 10 * time.Minute,
+	Name:     "oauth:mal:code_verifier",
+})
+
+var MALOAuthConfig = OAuthConfig{
+	Config: malOAuthConfig,
+	// MyAnimeList only supports the `plain` PKCE method.
+	AuthCodeURL: func(state string, opts ...oauth2.AuthCodeOption) string {
+		verifier := oauth2.GenerateVerifier()
+		if err := malCodeVerifierCache.Add(state, verifier); err != nil {
+			malLog.Error("failed to cache code verifier", "error", err)
+		}
+		opts = append(
+			opts,
+			oauth2.SetAuthURLParam("code_challenge", verifier),
+			oauth2.SetAuthURLParam("code_challenge_method", "plain"),
+		)
+		return malOAuthConfig.AuthCodeURL(state, opts...)
+	},
+	Exchange: func(code, state string) (*oauth2.Token, error) {
+		var verifier string
+		if !malCodeVerifierCache.Get(state, &verifier) {
+			return nil, &oauth2.RetrieveError{
+				ErrorCode: "invalid_grant",
+			}
+		}
+		malCodeVerifierCache.Remove(state)
+
+		tok, err := malOAuthConfig.Exchange(context.Background(), code, oauth2.VerifierOption(verifier))
+		if err != nil {
+			return nil, err
+		}
+
+		return saveExchangedToken(tok, &malOAuthConfig, MALTokenSourceConfig, malLog)
+	},
+}
@@ -1,6 +1,18 @@
 package oauth
 
-import "golang.org/x/oauth2"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
 
 type OAuthConfig struct {
 	oauth2.Config
@@ -10,3 +22,95 @@ type OAuthConfig struct {
 	ClientCredentialsToken   func(clientId, clientSecret string) (*oauth2.Token, error)
 	TryAuthCodeURL           func(state string, opts ...oauth2.AuthCodeOption) (string, error)
 }
+
+type responseError struct {
+	Err     string `json:"error"`
+	Message string `json:"message"`
+}
+
+func (e *responseError) Error() string {
+	ret, _ := json.Marshal(e)
+	return string(ret)
+}
+
+func (e *responseError) Unmarshal(res *http.Response, body []byte, v any) error {
+	contentType := res.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "application/json"):
+		return core.UnmarshalJSON(res.StatusCode, body, v)
+	case strings.Contains(contentType, "text/html"):
+		if res.StatusCode >= http.StatusBadRequest {
+			errMsg := strings.TrimSpace(string(body))
+			if errMsg == "" {
+				errMsg = res.Status
+			}
+			return errors.New(errMsg)
+		}
+		fallthrough
+	default:
+		return fmt.Errorf("unexpected content type: %s", contentType)
+	}
+}
+
+func (r *responseError) GetError(res *http.Response) error {
+	if r == nil || r.Err == "" {
+		return nil
+	}
+	return r
+}
+
+// saveExchangedToken saves a newly exchanged token for its user. The id of
+// the user's existing token is reused, unless that token no longer works.
+func saveExchangedToken(tok *oauth2.Token, oauthConfig *oauth2.Config, tsConfig TokenSourceConfig, log *logger.Logger) (*oauth2.Token, error) {
+	log.Debug("fetching user info for new token")
+	userId, userName, err := tsConfig.GetUser(
+		oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(tok)),
+		oauthConfig,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	existingOTok, err := GetOAuthTokenByUserId(tsConfig.Provider, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingOTok != nil {
+		client := oauth2.NewClient(
+			context.Background(),
+			DatabaseTokenSource(&DatabaseTokenSourceConfig{
+				OAuth:             oauthConfig,
+				TokenSourceConfig: tsConfig,
+			}, existingOTok.ToToken()),
+		)
+
+		log.Debug("fetching user info for existing token")
+		uId, _, err := tsConfig.GetUser(client, oauthConfig)
+		if err != nil || uId != userId {
+			existingOTok.AccessToken = ""
+			existingOTok.RefreshToken = ""
+			err = SaveOAuthToken(existingOTok)
+			if err != nil {
+				return nil, err
+			}
+			existingOTok = nil
+		}
+	}
+
+	tokenId := uuid.NewString()
+	if existingOTok != nil {
+		tokenId = existingOTok.Id
+	}
+
+	tok = tsConfig.PrepareToken(tok, tokenId, userId, userName)
+
+	otok := &OAuthToken{}
+	otok = otok.FromToken(tok)
+	err = SaveOAuthToken(otok)
+	if err != nil {
+		return nil, err
+	}
+
+	return tok, nil
+}
@@ -0,0 +1,113 @@
+package oauth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/request"
+	"golang.org/x/oauth2"
+)
+
+var SimklTokenSourceConfig = TokenSourceConfig{
+	Provider: ProviderSimkl,
+	GetUser: func(client *http.Client, oauthConfig *oauth2.Config) (userId, userName string, err error) {
+		req, err := http.NewRequest("POST", "https://api.simkl.com/users/settings", nil)
+		if err != nil {
+			return "", "", err
+		}
+		req.Header.Set("simkl-api-key", oauthConfig.ClientID)
+		res, err := client.Do(req)
+		var response struct {
+			responseError
+			User struct {
+				Name string `json:"name"`
+			} `json:"user"`
+			Account struct {
+				Id int64 `json:"id"`
+			} `json:"account"`
+		}
+		err = request.ProcessResponseBody(res, err, &response)
+		if err != nil {
+			return "", "", err
+		}
+		if response.Account.Id == 0 {
+			return "", "", errors.New("failed to fetch user info")
+		}
+		return strconv.FormatInt(response.Account.Id, 10), response.User.Name, nil
+	},
+	PrepareToken: func(tok *oauth2.Token, id, userId string, userName string) *oauth2.Token {
+		return tok.WithExtra(map[string]any{
+			"id":         id,
+			"provider":   ProviderSimkl,
+			"user_id":    userId,
+			"user_name":  userName,
+			"scope":      tok.Extra("scope").(string),
+			"created_at": time.Unix(int64(tok.Extra("created_at").(float64)), 0),
+		})
+	},
+}
+
+var simklOAuthConfig = oauth2.Config{
+	ClientID:     config.Integration.Simkl.ClientId,
+	ClientSecret: config.Integration.Simkl.ClientSecret,
+	Endpoint: oauth2.Endpoint{
+		AuthURL:  "https://simkl.com/oauth/authorize",
+		TokenURL: "https://api.simkl.com/oauth/token",
+	},
+	RedirectURL: config.BaseURL.JoinPath("/auth/simkl.com/callback").String(),
+}
+
+var SimklOAuthConfig = OAuthConfig{
+	Config:      simklOAuthConfig,
+	AuthCodeURL: simklOAuthConfig.AuthCodeURL,
+	// Simkl expects a json body for the token request, and the issued
+	// token never expires.
+	Exchange: func(code, state string) (*oauth2.Token, error) {
+		jsonBytes, err := json.Marshal(map[string]string{
+			"code":          code,
+			"client_id":     simklOAuthConfig.ClientID,
+			"client_secret": simklOAuthConfig.ClientSecret,
+			"redirect_uri":  simklOAuthConfig.RedirectURL,
+			"grant_type":    "authorization_code",
+		})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest("POST", simklOAuthConfig.Endpoint.TokenURL, bytes.NewBuffer(jsonBytes))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("Accept", "application/json")
+		req.Header.Add("Content-Type", "application/json")
+
+		res, err := config.DefaultHTTPClient.Do(req)
+		var response struct {
+			responseError
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+			Scope       string `json:"scope"`
+		}
+		err = request.ProcessResponseBody(res, err, &response)
+		if err != nil {
+			return nil, err
+		}
+		if response.AccessToken == "" {
+			return nil, &oauth2.RetrieveError{
+				ErrorCode: "invalid_grant",
+			}
+		}
+
+		tok := &oauth2.Token{AccessToken: response.AccessToken, TokenType: response.TokenType}
+		tok = tok.WithExtra(map[string]any{
+			"scope":      response.Scope,
+			"created_at": float64(time.Now().Unix()),
+		})
+
+		return saveExchangedToken(tok, &simklOAuthConfig, SimklTokenSourceConfig, simklLog)
+	},
+}
@@ -0,0 +1,246 @@
+package p2p_client
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/anacrolix/torrent/storage"
+)
+
+var log = logger.Scoped("p2p/client")
+
+const metadataTimeout = 60 * time.Second
+
+const (
+	minReadahead = 8 * 1024 * 1024
+	maxReadahead = 64 * 1024 * 1024
+)
+
+type activeTorrent struct {
+	t          *torrent.Torrent
+	readers    int
+	lastAccess time.Time
+	paused     bool
+}
+
+var (
+	mutex  sync.Mutex
+	client *torrent.Client
+	active = map[string]*activeTorrent{}
+)
+
+func getClient() (*torrent.Client, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if client != nil {
+		return client, nil
+	}
+
+	cacheDir := config.Stremio.P2P.CacheDir
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, err
+	}
+
+	conf := torrent.NewDefaultClientConfig()
+	conf.DataDir = cacheDir
+	conf.DefaultStorage = storage.NewFileOpts(storage.NewFileClientOpts{
+		ClientBaseDir: cacheDir,
+		TorrentDirMaker: func(baseDir string, info *metainfo.Info, infoHash metainfo.Hash) string {
+			return filepath.Join(baseDir, infoHash.HexString())
+		},
+	})
+	conf.Seed = false
+
+	c, err := torrent.NewClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	client = c
+
+	// the cache size is checked on each tick, so it can be overshot by what
+	// is downloaded in between
+	go func() {
+		for range time.Tick(10 * time.Second) {
+			evict()
+		}
+	}()
+
+	return client, nil
+}
+
+func acquire(cl *torrent.Client, magnet core.MagnetLink) (*activeTorrent, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	at, ok := active[magnet.Hash]
+	if !ok {
+		if evictLocked() >= config.Stremio.P2P.CacheSize {
+			err := core.NewAPIError("p2p cache is full")
+			err.StatusCode = http.StatusInsufficientStorage
+			return nil, err
+		}
+		t, err := cl.AddMagnet(magnet.RawLink)
+		if err != nil {
+			return nil, err
+		}
+		at = &activeTorrent{t: t}
+		active[magnet.Hash] = at
+	} else if len(magnet.Trackers) > 0 {
+		at.t.AddTrackers([][]string{magnet.Trackers})
+	}
+	at.readers++
+	at.lastAccess = time.Now()
+	return at, nil
+}
+
+func release(at *activeTorrent) {
+	mutex.Lock()
+	at.readers--
+	at.lastAccess = time.Now()
+	mutex.Unlock()
+
+	evict()
+}
+
+// evict drops idle torrents, least recently used first, when they are idle
+// for too long or the disk cache is over the configured size. If the torrents
+// in use are still over the size, their downloads are paused till some space
+// is freed.
+func evict() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	evictLocked()
+}
+
+// evictLocked is evict with the mutex held, it returns the size of the disk
+// cache left.
+func evictLocked() int64 {
+	cacheSize := config.Stremio.P2P.CacheSize
+	idleTimeout := config.Stremio.P2P.IdleTimeout
+
+	totalSize := int64(0)
+	idle := []string{}
+	for hash, at := range active {
+		totalSize += at.t.BytesCompleted()
+		if at.readers == 0 {
+			idle = append(idle, hash)
+		}
+	}
+	slices.SortFunc(idle, func(a, b string) int {
+		return active[a].lastAccess.Compare(active[b].lastAccess)
+	})
+
+	for _, hash := range idle {
+		at := active[hash]
+		if totalSize <= cacheSize && time.Since(at.lastAccess) < idleTimeout {
+			continue
+		}
+		totalSize -= at.t.BytesCompleted()
+		at.t.Drop()
+		delete(active, hash)
+		if err := os.RemoveAll(filepath.Join(config.Stremio.P2P.CacheDir, hash)); err != nil {
+			log.Warn("failed to remove cached data", "hash", hash, "error", err)
+		}
+		log.Debug("evicted torrent", "hash", hash)
+	}
+
+	isFull := totalSize >= cacheSize
+	for hash, at := range active {
+		if isFull && !at.paused {
+			at.t.DisallowDataDownload()
+			at.paused = true
+			log.Warn("paused torrent, disk cache is full", "hash", hash, "size", totalSize)
+		} else if !isFull && at.paused {
+			at.t.AllowDataDownload()
+			at.paused = false
+			log.Debug("resumed torrent", "hash", hash)
+		}
+	}
+
+	return totalSize
+}
+
+func getReadahead(fileSize int64) int64 {
+	return min(max(fileSize/100, minReadahead), maxReadahead)
+}
+
+type countingResponseWriter struct {
+	http.ResponseWriter
+	bytesWritten int64
+}
+
+func (w *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.bytesWritten += int64(n)
+	return n, err
+}
+
+// ServeFile streams the file at `fileIdx` (the largest file for -1) of the
+// torrent, fetching pieces on demand around the requested byte range.
+func ServeFile(w http.ResponseWriter, r *http.Request, magnet core.MagnetLink, fileIdx int) (int64, error) {
+	cl, err := getClient()
+	if err != nil {
+		return 0, err
+	}
+
+	at, err := acquire(cl, magnet)
+	if err != nil {
+		return 0, err
+	}
+	defer release(at)
+
+	t := at.t
+	select {
+	case <-t.GotInfo():
+	case <-r.Context().Done():
+		return 0, r.Context().Err()
+	case <-time.After(metadataTimeout):
+		err := core.NewAPIError("timed out fetching torrent metadata")
+		err.StatusCode = http.StatusGatewayTimeout
+		return 0, err
+	}
+
+	files := t.Files()
+	var file *torrent.File
+	if fileIdx == -1 {
+		for _, f := range files {
+			if file == nil || f.Length() > file.Length() {
+				file = f
+			}
+		}
+	} else if fileIdx < len(files) {
+		file = files[fileIdx]
+	}
+	if file == nil {
+		err := core.NewAPIError("file not found")
+		err.StatusCode = http.StatusNotFound
+		return 0, err
+	}
+
+	// container headers/indexes usually live at the edges of the file
+	if file.Length() > 0 {
+		t.Piece(file.BeginPieceIndex()).SetPriority(torrent.PiecePriorityHigh)
+		t.Piece(file.EndPieceIndex() - 1).SetPriority(torrent.PiecePriorityHigh)
+	}
+
+	reader := file.NewReader()
+	defer reader.Close()
+	reader.SetContext(r.Context())
+	reader.SetResponsive()
+	reader.SetReadahead(getReadahead(file.Length()))
+
+	cw := &countingResponseWriter{ResponseWriter: w}
+	http.ServeContent(cw, r, filepath.Base(file.DisplayPath()), time.Time{}, reader)
+	return cw.bytesWritten, nil
+}
@@ -0,0 +1,101 @@
+package p2p_client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/anacrolix/torrent/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSeeder(t *testing.T, name string, content []byte) (*torrent.Client, metainfo.Hash) {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0644))
+
+	info := metainfo.Info{PieceLength: 16 * 1024}
+	require.NoError(t, info.BuildFromFilePath(filepath.Join(dir, name)))
+	infoBytes, err := bencode.Marshal(info)
+	require.NoError(t, err)
+	mi := &metainfo.MetaInfo{InfoBytes: infoBytes}
+
+	conf := torrent.NewDefaultClientConfig()
+	conf.DataDir = dir
+	conf.DefaultStorage = storage.NewFileOpts(storage.NewFileClientOpts{
+		ClientBaseDir:   dir,
+		PieceCompletion: storage.NewMapPieceCompletion(),
+	})
+	conf.Seed = true
+	conf.ListenPort = 0
+	conf.NoDHT = true
+	conf.DisableTrackers = true
+	conf.DisableWebtorrent = true
+	conf.DisableIPv6 = true
+	cl, err := torrent.NewClient(conf)
+	require.NoError(t, err)
+	t.Cleanup(func() { cl.Close() })
+
+	tor, err := cl.AddTorrent(mi)
+	require.NoError(t, err)
+	require.NoError(t, tor.VerifyDataContext(t.Context()))
+	require.True(t, tor.Complete().Bool())
+
+	return cl, mi.HashInfoBytes()
+}
+
+func TestServeFile(t *testing.T) {
+	config.Stremio.P2P.CacheDir = t.TempDir()
+
+	content := []byte(strings.Repeat("stremthru p2p ", 10*1024))
+	seeder, hash := newSeeder(t, "video.mkv", content)
+
+	rawLink := "magnet:?xt=urn:btih:" + hash.HexString() + "&x.pe=" + seeder.ListenAddrs()[0].String()
+	magnet, err := core.ParseMagnetLink(rawLink)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/v0/proxy/p2p", nil)
+	r.Header.Set("Range", "bytes=100-199")
+	w := httptest.NewRecorder()
+	n, err := ServeFile(w, r, magnet, 0)
+	require.NoError(t, err)
+
+	res := w.Result()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, res.StatusCode)
+	assert.Equal(t, content[100:200], body)
+	assert.EqualValues(t, 100, n)
+
+	w = httptest.NewRecorder()
+	_, err = ServeFile(w, httptest.NewRequest(http.MethodGet, "/v0/proxy/p2p", nil), magnet, 5)
+	var apiErr *core.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	cacheSize := config.Stremio.P2P.CacheSize
+	config.Stremio.P2P.CacheSize = 0
+	defer func() { config.Stremio.P2P.CacheSize = cacheSize }()
+	evict()
+	assert.Empty(t, active)
+	_, err = ServeFile(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v0/proxy/p2p", nil), magnet, 0)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusInsufficientStorage, apiErr.StatusCode)
+}
+
+func TestGetReadahead(t *testing.T) {
+	assert.EqualValues(t, minReadahead, getReadahead(100))
+	assert.EqualValues(t, 20*1024*1024, getReadahead(2000*1024*1024))
+	assert.EqualValues(t, maxReadahead, getReadahead(100*1024*1024*1024))
+}
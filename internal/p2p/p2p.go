@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+)
+
+var log = logger.Scoped("p2p")
+
+var Enabled = config.Feature.IsEnabled(config.FeatureStremioP2P)
+
+// CreateLink returns the magnet link used as the proxy link for a file
+// inside a torrent. The `so` (select-only) param carries the file index.
+func CreateLink(hash string, fileIdx int, trackers []string) string {
+	params := url.Values{}
+	params.Set("so", strconv.Itoa(fileIdx))
+	for _, tracker := range trackers {
+		params.Add("tr", tracker)
+	}
+	return "magnet:?xt=urn:btih:" + hash + "&" + params.Encode()
+}
+
+func IsLink(link string) bool {
+	return strings.HasPrefix(link, "magnet:")
+}
+
+func parseLink(link string) (magnet core.MagnetLink, fileIdx int, err error) {
+	magnet, err = core.ParseMagnetLink(link)
+	if err != nil {
+		return magnet, -1, err
+	}
+	if magnet.Hash == "" {
+		return magnet, -1, errors.New("invalid magnet")
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return magnet, -1, err
+	}
+	fileIdx = -1
+	if so := u.Query().Get("so"); so != "" {
+		fileIdx, err = strconv.Atoi(so)
+		if err != nil {
+			return magnet, -1, errors.New("invalid file index")
+		}
+	}
+	return magnet, fileIdx, nil
+}
+
+// ServeFile streams the file referenced by the magnet `link`, fetching
+// pieces on demand around the requested byte range.
+func ServeFile(w http.ResponseWriter, r *http.Request, link string) (int64, error) {
+	if !Enabled {
+		err := core.NewAPIError("p2p streaming is not enabled")
+		err.StatusCode = http.StatusForbidden
+		return 0, err
+	}
+
+	magnet, fileIdx, err := parseLink(link)
+	if err != nil {
+		return 0, err
+	}
+
+	return serveFile(w, r, magnet, fileIdx)
+}
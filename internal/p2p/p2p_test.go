@@ -0,0 +1,30 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLink(t *testing.T) {
+	hash := "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	link := CreateLink(hash, 2, []string{"udp://tracker.example.com:1337/announce"})
+	assert.True(t, IsLink(link))
+
+	magnet, fileIdx, err := parseLink(link)
+	require.NoError(t, err)
+	assert.Equal(t, hash, magnet.Hash)
+	assert.Equal(t, 2, fileIdx)
+	assert.Equal(t, []string{"udp://tracker.example.com:1337/announce"}, magnet.Trackers)
+
+	_, fileIdx, err = parseLink("magnet:?xt=urn:btih:" + hash)
+	require.NoError(t, err)
+	assert.Equal(t, -1, fileIdx)
+
+	_, _, err = parseLink("magnet:?xt=urn:btih:" + hash + "&so=x")
+	assert.Error(t, err)
+
+	assert.False(t, IsLink("https://example.com/file.mkv"))
+}
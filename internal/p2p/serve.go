@@ -0,0 +1,14 @@
+//go:build !cgo || nosqlite
+
+package p2p
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/core"
+	p2p_client "github.com/MunifTanjim/stremthru/internal/p2p/client"
+)
+
+func serveFile(w http.ResponseWriter, r *http.Request, magnet core.MagnetLink, fileIdx int) (int64, error) {
+	return p2p_client.ServeFile(w, r, magnet, fileIdx)
+}
@@ -0,0 +1,26 @@
+//go:build cgo && !nosqlite
+
+package p2p
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/core"
+)
+
+// With cgo, anacrolix/torrent links its own sqlite (for piece completion)
+// next to mattn/go-sqlite3 and the binary fails to link on duplicate symbols.
+// The `nosqlite` tag, set by the Makefile, Dockerfile and CI, switches it to
+// bolt. The client itself lives in `p2p/client`, so it is always compiled
+// and tested.
+func serveFile(w http.ResponseWriter, r *http.Request, magnet core.MagnetLink, fileIdx int) (int64, error) {
+	err := core.NewAPIError("p2p streaming is not available in this build")
+	err.StatusCode = http.StatusNotImplemented
+	return 0, err
+}
+
+func init() {
+	if Enabled {
+		log.Warn("p2p streaming is enabled, but this build lacks the `nosqlite` build tag")
+	}
+}
@@ -2,6 +2,7 @@ package peer
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
@@ -14,6 +15,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/torrent_info"
 	"github.com/MunifTanjim/stremthru/store"
+	"github.com/MunifTanjim/stremthru/stremio"
 )
 
 var defaultHTTPClient = func() *http.Client {
@@ -291,3 +293,25 @@ func (c APIClient) FetchLetterboxdUserWatchlist(params *FetchLetterboxdUserWatch
 	res, err := c.Request("GET", "/v0/meta/letterboxd/users/"+params.UserId+"/lists/watchlist", params, response)
 	return request.NewAPIResponse(res, response.Data), err
 }
+
+type addonCatalogResponse struct {
+	stremio.AddonCatalogHandlerResponse
+}
+
+func (r addonCatalogResponse) GetError() error {
+	if r.Addons == nil {
+		return errors.New("invalid addon catalog")
+	}
+	return nil
+}
+
+type FetchAddonCatalogParams struct {
+	request.Ctx
+}
+
+// FetchAddonCatalog fetches the addons served by the root addon of the peer.
+func (c APIClient) FetchAddonCatalog(params *FetchAddonCatalogParams) (request.APIResponse[stremio.AddonCatalogHandlerResponse], error) {
+	response := &addonCatalogResponse{}
+	res, err := c.Request("GET", "/stremio/addon_catalog/all/stremthru.json", params, response)
+	return request.NewAPIResponse(res, response.AddonCatalogHandlerResponse), err
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/redis"
 	rrl "github.com/nccapo/rate-limiter"
 )
@@ -25,7 +26,11 @@ type Limiter struct {
 }
 
 func (l *Limiter) Try(key string) (*rrl.RateLimitResult, error) {
-	return l.rl.Allow(context.Background(), key)
+	result, err := l.rl.Allow(context.Background(), key)
+	if err == nil && result != nil && !result.Allowed {
+		metrics.IncRateLimitRejected(l.config.Name)
+	}
+	return result, err
 }
 
 func (l *Limiter) Wait(key string) error {
@@ -0,0 +1,331 @@
+package release_group_reputation
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/torrent_info"
+	"github.com/MunifTanjim/stremthru/internal/torznab/jackett"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+var log = logger.Scoped("release_group_reputation")
+
+const TableName = "release_group_reputation"
+
+// DefaultScore is the score of a group without any signal.
+const DefaultScore = 50
+
+// Kind is what the reputation is tracked for. The uploader is the indexer
+// the torrent was found on, torrent_info does not know the uploading user.
+type Kind string
+
+const (
+	KindGroup    Kind = "group"
+	KindUploader Kind = "uploader"
+)
+
+func (k Kind) IsValid() bool {
+	return k == KindGroup || k == KindUploader
+}
+
+type Signal string
+
+const (
+	SignalSuccess Signal = "success"
+	SignalFailed  Signal = "failed"
+	SignalInvalid Signal = "invalid"
+)
+
+type Reputation struct {
+	Kind     Kind
+	Name     string
+	Success  int
+	Failed   int
+	Invalid  int
+	Override sql.NullInt64
+	CAt      db.Timestamp
+	UAt      db.Timestamp
+}
+
+// Score is between 0 and 100. Admin override wins, otherwise it is the
+// smoothed success ratio, with invalid (fake) torrents counted twice.
+func (r *Reputation) Score() int {
+	if r.Override.Valid {
+		return min(max(int(r.Override.Int64), 0), 100)
+	}
+	return 100 * (r.Success + 1) / (r.Success + r.Failed + 2*r.Invalid + 2)
+}
+
+var Column = struct {
+	Kind     string
+	Name     string
+	Success  string
+	Failed   string
+	Invalid  string
+	Override string
+	CAt      string
+	UAt      string
+}{
+	Kind:     "kind",
+	Name:     "name",
+	Success:  "success",
+	Failed:   "failed",
+	Invalid:  "invalid",
+	Override: "override",
+	CAt:      "cat",
+	UAt:      "uat",
+}
+
+var columns = []string{
+	Column.Kind,
+	Column.Name,
+	Column.Success,
+	Column.Failed,
+	Column.Invalid,
+	Column.Override,
+	Column.CAt,
+	Column.UAt,
+}
+
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// GetUploader returns the uploader name for the indexer stored in
+// torrent_info, as shown in the stream.
+func GetUploader(indexer string) string {
+	return jackett.GetIndexerName(indexer)
+}
+
+var scoreCache = cache.NewCache[int](&cache.CacheConfig{
+	Name:          "release_group_reputation:score",
+	Lifetime:      15 * time.Minute,
+	LocalCapacity: 2048,
+})
+
+func getScoreCacheKey(kind Kind, name string) string {
+	return string(kind) + ":" + name
+}
+
+func getQueryRecord(signal Signal) string {
+	return fmt.Sprintf(
+		`INSERT INTO %s AS rgr (%s, %s, %s) VALUES (?, ?, 1) ON CONFLICT (%s, %s) DO UPDATE SET %s = rgr.%s + 1, %s = %s`,
+		TableName,
+		Column.Kind,
+		Column.Name,
+		string(signal),
+		Column.Kind,
+		Column.Name,
+		string(signal),
+		string(signal),
+		Column.UAt,
+		db.CurrentTimestamp,
+	)
+}
+
+var query_record_by_signal = map[Signal]string{
+	SignalSuccess: getQueryRecord(SignalSuccess),
+	SignalFailed:  getQueryRecord(SignalFailed),
+	SignalInvalid: getQueryRecord(SignalInvalid),
+}
+
+func Record(kind Kind, name string, signal Signal) error {
+	name = NormalizeName(name)
+	query, ok := query_record_by_signal[signal]
+	if name == "" || !ok || !kind.IsValid() {
+		return nil
+	}
+	if _, err := db.Exec(query, kind, name); err != nil {
+		return err
+	}
+	scoreCache.Remove(getScoreCacheKey(kind, name))
+	return nil
+}
+
+// same torrent is counted once per signal in this window, so that replays
+// do not inflate the reputation.
+var recordedCache = cache.NewCache[bool](&cache.CacheConfig{
+	Name:     "release_group_reputation:recorded",
+	Lifetime: 6 * time.Hour,
+})
+
+// RecordByHash records the signal for the release group and the uploader of
+// the torrent.
+func RecordByHash(hash string, signal Signal) {
+	hash = strings.ToLower(hash)
+	cacheKey := hash + ":" + string(signal)
+	var recorded bool
+	if recordedCache.Get(cacheKey, &recorded) {
+		return
+	}
+	recordedCache.Add(cacheKey, true)
+
+	tInfoByHash, err := torrent_info.GetByHashes([]string{hash})
+	if err != nil {
+		log.Error("failed to get torrent info", "error", err, "hash", hash)
+		return
+	}
+	tInfo, ok := tInfoByHash[hash]
+	if !ok {
+		return
+	}
+	if err := Record(KindGroup, tInfo.Group, signal); err != nil {
+		log.Error("failed to record signal", "error", err, "group", tInfo.Group, "signal", signal)
+	}
+	uploader := GetUploader(tInfo.Indexer)
+	if err := Record(KindUploader, uploader, signal); err != nil {
+		log.Error("failed to record signal", "error", err, "uploader", uploader, "signal", signal)
+	}
+}
+
+var query_set_override = fmt.Sprintf(
+	`INSERT INTO %s AS rgr (%s, %s, %s) VALUES (?, ?, ?) ON CONFLICT (%s, %s) DO UPDATE SET %s = EXCLUDED.%s, %s = %s`,
+	TableName,
+	Column.Kind,
+	Column.Name,
+	Column.Override,
+	Column.Kind,
+	Column.Name,
+	Column.Override,
+	Column.Override,
+	Column.UAt,
+	db.CurrentTimestamp,
+)
+
+// SetOverride sets the admin override, `nil` removes it.
+func SetOverride(kind Kind, name string, override *int) error {
+	name = NormalizeName(name)
+	value := sql.NullInt64{}
+	if override != nil {
+		value.Valid = true
+		value.Int64 = int64(*override)
+	}
+	if _, err := db.Exec(query_set_override, kind, name, value); err != nil {
+		return err
+	}
+	scoreCache.Remove(getScoreCacheKey(kind, name))
+	return nil
+}
+
+var query_get_by_names = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s IN `,
+	db.JoinColumnNames(columns...),
+	TableName,
+	Column.Kind,
+	Column.Name,
+)
+
+func scanReputations(rows *sql.Rows) ([]Reputation, error) {
+	items := []Reputation{}
+	for rows.Next() {
+		item := Reputation{}
+		if err := rows.Scan(&item.Kind, &item.Name, &item.Success, &item.Failed, &item.Invalid, &item.Override, &item.CAt, &item.UAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func Get(kind Kind, name string) (*Reputation, error) {
+	name = NormalizeName(name)
+	rows, err := db.Query(query_get_by_names+"(?)", kind, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items, err := scanReputations(rows)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// GetScores returns the score by normalized name, for the given names.
+func GetScores(kind Kind, names []string) (map[string]int, error) {
+	scoreByName := map[string]int{}
+	missingNames := []string{}
+	for _, name := range names {
+		name = NormalizeName(name)
+		if name == "" {
+			continue
+		}
+		if _, seen := scoreByName[name]; seen {
+			continue
+		}
+		score := 0
+		if scoreCache.Get(getScoreCacheKey(kind, name), &score) {
+			scoreByName[name] = score
+			continue
+		}
+		scoreByName[name] = DefaultScore
+		missingNames = append(missingNames, name)
+	}
+
+	if len(missingNames) == 0 {
+		return scoreByName, nil
+	}
+
+	args := make([]any, 1+len(missingNames))
+	args[0] = kind
+	for i := range missingNames {
+		args[1+i] = missingNames[i]
+	}
+	rows, err := db.Query(query_get_by_names+"("+util.RepeatJoin("?", len(missingNames), ",")+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items, err := scanReputations(rows)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		scoreByName[items[i].Name] = items[i].Score()
+	}
+	for _, name := range missingNames {
+		scoreCache.Add(getScoreCacheKey(kind, name), scoreByName[name])
+	}
+	return scoreByName, nil
+}
+
+var query_list = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? ORDER BY %s DESC LIMIT ? OFFSET ?`,
+	db.JoinColumnNames(columns...),
+	TableName,
+	Column.Kind,
+	Column.UAt,
+)
+
+func List(kind Kind, limit, offset int) ([]Reputation, error) {
+	rows, err := db.Query(query_list, kind, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanReputations(rows)
+}
+
+var query_count = fmt.Sprintf(
+	`SELECT COUNT(%s) FROM %s WHERE %s = ?`,
+	Column.Name,
+	TableName,
+	Column.Kind,
+)
+
+func Count(kind Kind) (int, error) {
+	var count int
+	if err := db.QueryRow(query_count, kind).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,27 @@
+package release_group_reputation
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReputationScore(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		r     Reputation
+		score int
+	}{
+		{"no signal", Reputation{}, DefaultScore},
+		{"success", Reputation{Success: 8}, 90},
+		{"failed", Reputation{Success: 1, Failed: 3}, 33},
+		{"invalid", Reputation{Success: 1, Invalid: 3}, 22},
+		{"override", Reputation{Success: 8, Override: sql.NullInt64{Valid: true, Int64: 5}}, 5},
+		{"override clamped", Reputation{Override: sql.NullInt64{Valid: true, Int64: 500}}, 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.score, tc.r.Score())
+		})
+	}
+}
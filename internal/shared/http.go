@@ -15,6 +15,7 @@ import (
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/server"
 )
 
@@ -196,7 +197,9 @@ func ProxyResponse(w http.ResponseWriter, r *http.Request, url string, tunnelTyp
 
 	w.WriteHeader(response.StatusCode)
 
-	return io.Copy(w, response.Body)
+	bytesWritten, err = io.Copy(w, response.Body)
+	metrics.AddProxyBytes(bytesWritten)
+	return bytesWritten, err
 }
 
 func extractRequestScheme(r *http.Request) string {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"time"
@@ -17,6 +18,8 @@ import (
 	"github.com/MunifTanjim/stremthru/store/debrider"
 	"github.com/MunifTanjim/stremthru/store/debridlink"
 	"github.com/MunifTanjim/stremthru/store/easydebrid"
+	"github.com/MunifTanjim/stremthru/store/local"
+	"github.com/MunifTanjim/stremthru/store/multi"
 	"github.com/MunifTanjim/stremthru/store/offcloud"
 	"github.com/MunifTanjim/stremthru/store/pikpak"
 	"github.com/MunifTanjim/stremthru/store/premiumize"
@@ -61,8 +64,39 @@ var tbStore = torbox.NewStoreClient(&torbox.StoreClientConfig{
 	HTTPClient: config.GetHTTPClient(config.StoreTunnel.GetTypeForAPI("torbox")),
 	UserAgent:  config.StoreClientUserAgent,
 })
+var loStore = func() *local.StoreClient {
+	if !config.Feature.HasStoreLocal() {
+		return nil
+	}
+	return local.NewStoreClient(&local.StoreClientConfig{
+		Dir:     filepath.Join(config.DataDir, "local_store"),
+		BaseURL: config.BaseURL,
+	})
+}()
+
+func GetLocalStore() *local.StoreClient {
+	return loStore
+}
+
+var muStore = multi.NewStoreClient(&multi.StoreClientConfig{
+	Stores: func() []store.Store {
+		stores := []store.Store{adStore, drStore, dlStore, edStore, ocStore, ppStore, pmStore, rdStore, tbStore}
+		if loStore != nil {
+			stores = append(stores, loStore)
+		}
+		return stores
+	}(),
+})
 
 func GetStore(name string) store.Store {
+	s := getStore(name)
+	if s == nil {
+		return nil
+	}
+	return instrumentStore(s)
+}
+
+func getStore(name string) store.Store {
 	switch store.StoreName(name) {
 	case store.StoreNameAlldebrid:
 		return adStore
@@ -72,6 +106,13 @@ func GetStore(name string) store.Store {
 		return dlStore
 	case store.StoreNameEasyDebrid:
 		return edStore
+	case store.StoreNameLocal:
+		if loStore == nil {
+			return nil
+		}
+		return loStore
+	case store.StoreNameMulti:
+		return muStore
 	case store.StoreNameOffcloud:
 		return ocStore
 	case store.StoreNamePikPak:
@@ -87,7 +128,23 @@ func GetStore(name string) store.Store {
 	}
 }
 
+// GetUsenetStore returns nil if the store does not support usenet.
+func GetUsenetStore(name string) store.UsenetStore {
+	if s, ok := GetStore(name).(store.UsenetStore); ok {
+		return s
+	}
+	return nil
+}
+
 func GetStoreByCode(code string) store.Store {
+	s := getStoreByCode(code)
+	if s == nil {
+		return nil
+	}
+	return instrumentStore(s)
+}
+
+func getStoreByCode(code string) store.Store {
 	switch store.StoreCode(code) {
 	case store.StoreCodeAllDebrid:
 		return adStore
@@ -97,6 +154,13 @@ func GetStoreByCode(code string) store.Store {
 		return dlStore
 	case store.StoreCodeEasyDebrid:
 		return edStore
+	case store.StoreCodeLocal:
+		if loStore == nil {
+			return nil
+		}
+		return loStore
+	case store.StoreCodeMulti:
+		return muStore
 	case store.StoreCodeOffcloud:
 		return ocStore
 	case store.StoreCodePikPak:
@@ -126,6 +190,10 @@ type proxyLinkData struct {
 }
 
 func CreateProxyLink(r *http.Request, link string, headers map[string]string, tunnelType config.TunnelType, expiresIn time.Duration, user, password string, shouldEncrypt bool, filename string) (string, error) {
+	return createProxyLink(ExtractRequestBaseURL(r), link, headers, tunnelType, expiresIn, user, password, shouldEncrypt, filename)
+}
+
+func createProxyLink(baseURL *url.URL, link string, headers map[string]string, tunnelType config.TunnelType, expiresIn time.Duration, user, password string, shouldEncrypt bool, filename string) (string, error) {
 	var encodedToken string
 
 	if !shouldEncrypt && expiresIn == 0 {
@@ -183,7 +251,7 @@ func CreateProxyLink(r *http.Request, link string, headers map[string]string, tu
 		encodedToken = token
 	}
 
-	pLink := ExtractRequestBaseURL(r).JoinPath("/v0/proxy", encodedToken)
+	pLink := baseURL.JoinPath("/v0/proxy", encodedToken)
 
 	if filename == "" {
 		filename, _, _ = strings.Cut(filepath.Base(link), "?")
@@ -196,6 +264,12 @@ func CreateProxyLink(r *http.Request, link string, headers map[string]string, tu
 }
 
 func GenerateStremThruLink(r *http.Request, ctx *context.StoreContext, link string) (*store.GenerateLinkData, error) {
+	return GenerateStremThruLinkForBaseURL(ExtractRequestBaseURL(r), ctx, link)
+}
+
+// GenerateStremThruLinkForBaseURL is GenerateStremThruLink for use outside of
+// the request handler, e.g. in a background goroutine.
+func GenerateStremThruLinkForBaseURL(baseURL *url.URL, ctx *context.StoreContext, link string) (*store.GenerateLinkData, error) {
 	params := &store.GenerateLinkParams{}
 	params.APIKey = ctx.StoreAuthToken
 	params.Link = link
@@ -208,11 +282,42 @@ func GenerateStremThruLink(r *http.Request, ctx *context.StoreContext, link stri
 		return nil, err
 	}
 
+	return wrapStoreContentProxyLink(baseURL, ctx, data)
+}
+
+func ErrorUsenetNotSupported(r *http.Request, storeName store.StoreName) *core.APIError {
+	err := ErrorBadRequest(r, "usenet not supported by store: "+string(storeName))
+	err.StoreName = string(storeName)
+	return err
+}
+
+func GenerateStremThruNZBLink(r *http.Request, ctx *context.StoreContext, link string) (*store.GenerateLinkData, error) {
+	s, ok := ctx.Store.(store.UsenetStore)
+	if !ok {
+		return nil, ErrorUsenetNotSupported(r, ctx.Store.GetName())
+	}
+
+	params := &store.GenerateNZBLinkParams{}
+	params.APIKey = ctx.StoreAuthToken
+	params.Link = link
+	if ctx.ClientIP != "" {
+		params.ClientIP = ctx.ClientIP
+	}
+
+	data, err := s.GenerateNZBLink(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapStoreContentProxyLink(ExtractRequestBaseURL(r), ctx, data)
+}
+
+func wrapStoreContentProxyLink(baseURL *url.URL, ctx *context.StoreContext, data *store.GenerateLinkData) (*store.GenerateLinkData, error) {
 	storeName := string(ctx.Store.GetName())
 	if config.StoreContentProxy.IsEnabled(storeName) && ctx.StoreAuthToken == config.StoreAuthToken.GetToken(ctx.ProxyAuthUser, storeName) {
 		if ctx.IsProxyAuthorized {
 			tunnelType := config.StoreTunnel.GetTypeForStream(string(ctx.Store.GetName()))
-			proxyLink, err := CreateProxyLink(r, data.Link, nil, tunnelType, 12*time.Hour, ctx.ProxyAuthUser, ctx.ProxyAuthPassword, true, "")
+			proxyLink, err := createProxyLink(baseURL, data.Link, nil, tunnelType, 12*time.Hour, ctx.ProxyAuthUser, ctx.ProxyAuthPassword, true, "")
 			if err != nil {
 				return nil, err
 			}
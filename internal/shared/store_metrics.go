@@ -0,0 +1,130 @@
+package shared
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/metrics"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+// instrumentedStore records request count and latency for each store method.
+type instrumentedStore struct {
+	store.Store
+}
+
+func (s *instrumentedStore) observe(method string, startedAt time.Time, err error) {
+	metrics.ObserveStoreRequest(string(s.Store.GetName()), method, startedAt, err)
+}
+
+func (s *instrumentedStore) GetUser(params *store.GetUserParams) (*store.User, error) {
+	startedAt := time.Now()
+	data, err := s.Store.GetUser(params)
+	s.observe("GetUser", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedStore) CheckMagnet(params *store.CheckMagnetParams) (*store.CheckMagnetData, error) {
+	startedAt := time.Now()
+	data, err := s.Store.CheckMagnet(params)
+	s.observe("CheckMagnet", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedStore) AddMagnet(params *store.AddMagnetParams) (*store.AddMagnetData, error) {
+	startedAt := time.Now()
+	data, err := s.Store.AddMagnet(params)
+	s.observe("AddMagnet", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedStore) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnetData, error) {
+	startedAt := time.Now()
+	data, err := s.Store.GetMagnet(params)
+	s.observe("GetMagnet", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedStore) ListMagnets(params *store.ListMagnetsParams) (*store.ListMagnetsData, error) {
+	startedAt := time.Now()
+	data, err := s.Store.ListMagnets(params)
+	s.observe("ListMagnets", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedStore) RemoveMagnet(params *store.RemoveMagnetParams) (*store.RemoveMagnetData, error) {
+	startedAt := time.Now()
+	data, err := s.Store.RemoveMagnet(params)
+	s.observe("RemoveMagnet", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedStore) GenerateLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	startedAt := time.Now()
+	data, err := s.Store.GenerateLink(params)
+	s.observe("GenerateLink", startedAt, err)
+	return data, err
+}
+
+type instrumentedUsenetStore struct {
+	instrumentedStore
+	us store.UsenetStore
+}
+
+func (s *instrumentedUsenetStore) CheckNZB(params *store.CheckNZBParams) (*store.CheckNZBData, error) {
+	startedAt := time.Now()
+	data, err := s.us.CheckNZB(params)
+	s.observe("CheckNZB", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedUsenetStore) AddNZB(params *store.AddNZBParams) (*store.AddNZBData, error) {
+	startedAt := time.Now()
+	data, err := s.us.AddNZB(params)
+	s.observe("AddNZB", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedUsenetStore) GetNZB(params *store.GetNZBParams) (*store.GetNZBData, error) {
+	startedAt := time.Now()
+	data, err := s.us.GetNZB(params)
+	s.observe("GetNZB", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedUsenetStore) ListNZBs(params *store.ListNZBsParams) (*store.ListNZBsData, error) {
+	startedAt := time.Now()
+	data, err := s.us.ListNZBs(params)
+	s.observe("ListNZBs", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedUsenetStore) RemoveNZB(params *store.RemoveNZBParams) (*store.RemoveNZBData, error) {
+	startedAt := time.Now()
+	data, err := s.us.RemoveNZB(params)
+	s.observe("RemoveNZB", startedAt, err)
+	return data, err
+}
+
+func (s *instrumentedUsenetStore) GenerateNZBLink(params *store.GenerateNZBLinkParams) (*store.GenerateLinkData, error) {
+	startedAt := time.Now()
+	data, err := s.us.GenerateNZBLink(params)
+	s.observe("GenerateNZBLink", startedAt, err)
+	return data, err
+}
+
+var instrumentedStoreByName sync.Map // map[store.StoreName]store.Store
+
+func instrumentStore(s store.Store) store.Store {
+	if cached, ok := instrumentedStoreByName.Load(s.GetName()); ok {
+		return cached.(store.Store)
+	}
+	var is store.Store
+	if us, ok := s.(store.UsenetStore); ok {
+		is = &instrumentedUsenetStore{instrumentedStore: instrumentedStore{s}, us: us}
+	} else {
+		is = &instrumentedStore{s}
+	}
+	instrumentedStoreByName.Store(s.GetName(), is)
+	return is
+}
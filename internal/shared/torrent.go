@@ -27,8 +27,8 @@ func FetchTorrentFile(link string, maxSize int64) (*multipart.FileHeader, error)
 	}
 	defer res.Body.Close()
 
-	if res.ContentLength <= 0 {
-		return nil, fmt.Errorf("unable to determine torrent file size")
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
 	}
 
 	if res.ContentLength > maxSize {
@@ -39,6 +39,12 @@ func FetchTorrentFile(link string, maxSize int64) (*multipart.FileHeader, error)
 	if err != nil {
 		return nil, err
 	}
+	if int64(len(blob)) > maxSize {
+		return nil, fmt.Errorf("torrent file too large: more than %d bytes", maxSize)
+	}
+	if len(blob) == 0 {
+		return nil, fmt.Errorf("empty torrent file")
+	}
 
 	filename := "unknown.torrent"
 	if cd := res.Header.Get("Content-Disposition"); cd != "" {
@@ -48,8 +54,8 @@ func FetchTorrentFile(link string, maxSize int64) (*multipart.FileHeader, error)
 		}
 	}
 	if filename == "unknown.torrent" {
-		if fn := path.Base(link); strings.HasSuffix(fn, ".torrent") {
-			filename = "unknown.torrent"
+		if fn := path.Base(res.Request.URL.Path); strings.HasSuffix(fn, ".torrent") {
+			filename = fn
 		}
 	}
 
@@ -67,7 +73,7 @@ func FetchTorrentFile(link string, maxSize int64) (*multipart.FileHeader, error)
 	}
 
 	reader := multipart.NewReader(&buf, writer.Boundary())
-	form, err := reader.ReadForm(min(maxSize, res.ContentLength) + 1024) // Extra space for multipart headers
+	form, err := reader.ReadForm(int64(len(blob)) + 1024) // Extra space for multipart headers
 	if err != nil {
 		return nil, fmt.Errorf("failed to read form: %w", err)
 	}
@@ -0,0 +1,29 @@
+package simkl
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/oauth"
+	tracker_api "github.com/MunifTanjim/stremthru/internal/tracker/api"
+)
+
+type Ctx = tracker_api.Ctx
+type ResponseError = tracker_api.ResponseError
+
+type APIClient struct {
+	*tracker_api.APIClient
+}
+
+var provider = tracker_api.NewProvider(&tracker_api.ProviderConfig{
+	Name:              "simkl",
+	BaseURL:           "https://api.simkl.com",
+	OAuth:             &oauth.SimklOAuthConfig,
+	TokenSourceConfig: oauth.SimklTokenSourceConfig,
+	SetHeader: func(header *http.Header, clientId string) {
+		header.Set("simkl-api-key", clientId)
+	},
+})
+
+func GetAPIClient(tokenId string) *APIClient {
+	return &APIClient{provider.GetAPIClient(tokenId)}
+}
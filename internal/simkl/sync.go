@@ -0,0 +1,119 @@
+package simkl
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type ItemIds struct {
+	Simkl int    `json:"simkl,omitempty"`
+	IMDB  string `json:"imdb,omitempty"`
+	TMDB  string `json:"tmdb,omitempty"`
+	TVDB  string `json:"tvdb,omitempty"`
+}
+
+type AddToHistoryParamsMovie struct {
+	Ids       ItemIds    `json:"ids"`
+	WatchedAt *time.Time `json:"watched_at,omitempty"`
+}
+
+type AddToHistoryParamsEpisode struct {
+	Number    int        `json:"number"`
+	WatchedAt *time.Time `json:"watched_at,omitempty"`
+}
+
+type AddToHistoryParamsSeason struct {
+	Number   int                         `json:"number"`
+	Episodes []AddToHistoryParamsEpisode `json:"episodes"`
+}
+
+type AddToHistoryParamsShow struct {
+	Ids     ItemIds                    `json:"ids"`
+	Seasons []AddToHistoryParamsSeason `json:"seasons,omitempty"`
+}
+
+type AddToHistoryData struct {
+	ResponseError
+	Added struct {
+		Movies   int `json:"movies"`
+		Shows    int `json:"shows"`
+		Episodes int `json:"episodes"`
+	} `json:"added"`
+	NotFound struct {
+		Movies []AddToHistoryParamsMovie `json:"movies"`
+		Shows  []AddToHistoryParamsShow  `json:"shows"`
+	} `json:"not_found"`
+}
+
+type AddToHistoryParams struct {
+	Ctx
+	Movies []AddToHistoryParamsMovie `json:"movies,omitempty"`
+	Shows  []AddToHistoryParamsShow  `json:"shows,omitempty"`
+}
+
+// AddToHistory marks the movies and the episodes of the shows as watched.
+// Already watched ones are left as is.
+func (c APIClient) AddToHistory(params *AddToHistoryParams) (request.APIResponse[AddToHistoryData], error) {
+	params.JSON = params
+	response := AddToHistoryData{}
+	res, err := c.Request("POST", "/sync/history", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type AllItemsEpisode struct {
+	Number    int        `json:"number"`
+	WatchedAt *time.Time `json:"watched_at"`
+}
+
+type AllItemsSeason struct {
+	Number   int               `json:"number"`
+	Episodes []AllItemsEpisode `json:"episodes"`
+}
+
+type AllItemsMedia struct {
+	Title string  `json:"title"`
+	Ids   ItemIds `json:"ids"`
+}
+
+type AllItemsMovie struct {
+	LastWatchedAt *time.Time    `json:"last_watched_at"`
+	Status        string        `json:"status"`
+	Movie         AllItemsMedia `json:"movie"`
+}
+
+type AllItemsShow struct {
+	LastWatchedAt *time.Time       `json:"last_watched_at"`
+	Status        string           `json:"status"`
+	Show          AllItemsMedia    `json:"show"`
+	Seasons       []AllItemsSeason `json:"seasons"`
+}
+
+type GetAllItemsData struct {
+	ResponseError
+	Movies []AllItemsMovie `json:"movies"`
+	Shows  []AllItemsShow  `json:"shows"`
+}
+
+type GetAllItemsParams struct {
+	Ctx
+	Type     string // movies / shows
+	DateFrom time.Time
+}
+
+// GetAllItems returns the items of the watchlist, with the watched episodes
+// for shows. With DateFrom, only the items changed since then are returned.
+func (c APIClient) GetAllItems(params *GetAllItemsParams) (request.APIResponse[GetAllItemsData], error) {
+	query := url.Values{}
+	query.Set("extended", "full")
+	query.Set("episode_watched_at", "yes")
+	if !params.DateFrom.IsZero() {
+		query.Set("date_from", params.DateFrom.UTC().Format(time.RFC3339))
+	}
+	params.Query = &query
+
+	response := GetAllItemsData{}
+	res, err := c.Request("GET", "/sync/all-items/"+params.Type, params, &response)
+	return request.NewAPIResponse(res, response), err
+}
@@ -0,0 +1,24 @@
+package simkl
+
+import "github.com/MunifTanjim/stremthru/internal/request"
+
+type RetrieveSettingsData struct {
+	ResponseError
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+	Account struct {
+		Id       int64  `json:"id"`
+		Timezone string `json:"timezone"`
+	} `json:"account"`
+}
+
+type RetrieveSettingsParams struct {
+	Ctx
+}
+
+func (c APIClient) RetrieveSettings(params *RetrieveSettingsParams) (request.APIResponse[RetrieveSettingsData], error) {
+	response := RetrieveSettingsData{}
+	res, err := c.Request("POST", "/users/settings", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
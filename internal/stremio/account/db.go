@@ -10,8 +10,10 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/db"
 	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
+	stremio_backup "github.com/MunifTanjim/stremthru/internal/stremio/backup"
 	stremio_userdata_account "github.com/MunifTanjim/stremthru/internal/stremio/userdata/account"
 	"github.com/MunifTanjim/stremthru/internal/sync/stremio_stremio"
+	"github.com/MunifTanjim/stremthru/internal/sync/stremio_tracker"
 	"github.com/MunifTanjim/stremthru/internal/sync/stremio_trakt"
 )
 
@@ -306,5 +308,11 @@ func Delete(id string) error {
 	if err := sync_stremio_stremio.UnlinkByStremioAccount(id); err != nil {
 		return err
 	}
+	if err := sync_stremio_tracker.UnlinkByStremioAccount(id); err != nil {
+		return err
+	}
+	if err := stremio_backup.DeleteByAccountId(id); err != nil {
+		return err
+	}
 	return nil
 }
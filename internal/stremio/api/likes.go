@@ -0,0 +1,80 @@
+package stremio_api
+
+import (
+	"net/url"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+var likesBaseURL, _ = url.Parse("https://likes.stremio.com")
+
+// LikeStatus is the rating of an item in Stremio, there is no dislike.
+type LikeStatus string
+
+const (
+	LikeStatusNone  LikeStatus = ""
+	LikeStatusLiked LikeStatus = "liked"
+	LikeStatusLoved LikeStatus = "loved"
+)
+
+type likeStatusResponse struct {
+	Status *LikeStatus `json:"status"`
+}
+
+func (r likeStatusResponse) GetError() error {
+	return nil
+}
+
+func (c Client) requestLikes(method, path string, params request.Context, v ResponseEnvelop) error {
+	lc := c
+	lc.BaseURL = likesBaseURL
+	_, err := lc.Request(method, path, params, v)
+	return err
+}
+
+type GetLikeStatusParams struct {
+	Ctx
+	MediaId   string
+	MediaType string // movie / series
+}
+
+func (c Client) GetLikeStatus(params *GetLikeStatusParams) (LikeStatus, error) {
+	params.Query = &url.Values{
+		"authToken": []string{params.APIKey},
+		"mediaId":   []string{params.MediaId},
+		"mediaType": []string{params.MediaType},
+	}
+	response := likeStatusResponse{}
+	if err := c.requestLikes("GET", "/api/get_status", params, &response); err != nil {
+		return LikeStatusNone, err
+	}
+	if response.Status == nil {
+		return LikeStatusNone, nil
+	}
+	return *response.Status, nil
+}
+
+type sendLikeStatusPayload struct {
+	AuthToken string     `json:"authToken"`
+	MediaId   string     `json:"mediaId"`
+	MediaType string     `json:"mediaType"`
+	Status    LikeStatus `json:"status"`
+}
+
+type SendLikeStatusParams struct {
+	Ctx
+	MediaId   string
+	MediaType string // movie / series
+	Status    LikeStatus
+}
+
+func (c Client) SendLikeStatus(params *SendLikeStatusParams) error {
+	params.JSON = sendLikeStatusPayload{
+		AuthToken: params.APIKey,
+		MediaId:   params.MediaId,
+		MediaType: params.MediaType,
+		Status:    params.Status,
+	}
+	response := likeStatusResponse{}
+	return c.requestLikes("POST", "/api/send", params, &response)
+}
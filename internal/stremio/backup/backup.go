@@ -0,0 +1,125 @@
+package stremio_backup
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+var client = stremio_api.NewClient(&stremio_api.ClientConfig{})
+
+func (b *StremioBackup) GetAddons() ([]stremio.Addon, error) {
+	if b.Kind != KindAddons {
+		return nil, errors.New("not an addons backup")
+	}
+	addons := []stremio.Addon{}
+	err := json.Unmarshal(b.Data, &addons)
+	return addons, err
+}
+
+func (b *StremioBackup) GetLibraryItems() ([]stremio_api.LibraryItem, error) {
+	if b.Kind != KindLibrary {
+		return nil, errors.New("not a library backup")
+	}
+	items := []stremio_api.LibraryItem{}
+	err := json.Unmarshal(b.Data, &items)
+	return items, err
+}
+
+// Snapshot backs up the addons and library of the account, keeping the
+// latest `keep` backups of each.
+func Snapshot(accountId, token string, keep int) error {
+	gaParams := &stremio_api.GetAddonsParams{}
+	gaParams.APIKey = token
+	gaRes, err := client.GetAddons(gaParams)
+	if err != nil {
+		return err
+	}
+	if _, err := Create(accountId, KindAddons, len(gaRes.Data.Addons), gaRes.Data.Addons); err != nil {
+		return err
+	}
+	if err := Prune(accountId, KindAddons, keep); err != nil {
+		return err
+	}
+
+	glParams := &stremio_api.GetAllLibraryItemsParams{}
+	glParams.APIKey = token
+	glRes, err := client.GetAllLibraryItems(glParams)
+	if err != nil {
+		return err
+	}
+	if _, err := Create(accountId, KindLibrary, countLibraryItems(glRes.Data), glRes.Data); err != nil {
+		return err
+	}
+	return Prune(accountId, KindLibrary, keep)
+}
+
+// getLibraryRestoreChanges returns the library items of the backup, and the
+// current items missing from it as removed. All of them are marked modified
+// at `now`, so that the restored state wins when the clients sync.
+func getLibraryRestoreChanges(backupItems, currentItems []stremio_api.LibraryItem, now time.Time) []stremio_api.LibraryItem {
+	changes := make([]stremio_api.LibraryItem, 0, len(backupItems))
+	backupIds := make(map[string]struct{}, len(backupItems))
+	for _, item := range backupItems {
+		backupIds[item.Id] = struct{}{}
+		item.MTime = stremio_api.JSONTime{Time: now}
+		changes = append(changes, item)
+	}
+	for _, item := range currentItems {
+		if _, ok := backupIds[item.Id]; ok || !isActiveLibraryItem(&item) {
+			continue
+		}
+		item.Removed = true
+		item.MTime = stremio_api.JSONTime{Time: now}
+		changes = append(changes, item)
+	}
+	return changes
+}
+
+// Restore overwrites the addons, or library items, of the account with the
+// backup. Library items added after the backup are removed.
+func Restore(b *StremioBackup, token string) error {
+	switch b.Kind {
+	case KindAddons:
+		addons, err := b.GetAddons()
+		if err != nil {
+			return err
+		}
+		params := &stremio_api.SetAddonsParams{Addons: addons}
+		params.APIKey = token
+		res, err := client.SetAddons(params)
+		if err != nil {
+			return err
+		}
+		if !res.Data.Success {
+			return errors.New("failed to set addons")
+		}
+		return nil
+	case KindLibrary:
+		items, err := b.GetLibraryItems()
+		if err != nil {
+			return err
+		}
+		glParams := &stremio_api.GetAllLibraryItemsParams{}
+		glParams.APIKey = token
+		glRes, err := client.GetAllLibraryItems(glParams)
+		if err != nil {
+			return err
+		}
+		params := &stremio_api.UpdateLibraryItemsParams{Changes: getLibraryRestoreChanges(items, glRes.Data, time.Now())}
+		params.APIKey = token
+		res, err := client.UpdateLibraryItems(params)
+		if err != nil {
+			return err
+		}
+		if !res.Data.Success {
+			return errors.New("failed to update library items")
+		}
+		return nil
+	default:
+		return errors.New("unknown backup kind: " + string(b.Kind))
+	}
+}
@@ -0,0 +1,33 @@
+package stremio_backup
+
+import (
+	"testing"
+	"time"
+
+	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLibraryRestoreChanges(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	backupItems := []stremio_api.LibraryItem{
+		{Id: "tt1"},
+		{Id: "tt2", Removed: true},
+	}
+	currentItems := []stremio_api.LibraryItem{
+		{Id: "tt1"},
+		{Id: "tt2"},
+		{Id: "tt3"},
+		{Id: "tt4", Removed: true},
+		{Id: "tt5", Temp: true},
+	}
+
+	changes := getLibraryRestoreChanges(backupItems, currentItems, now)
+	removedById := map[string]bool{}
+	for _, item := range changes {
+		removedById[item.Id] = item.Removed
+		assert.Equal(t, now, item.MTime.Time)
+	}
+	assert.Equal(t, map[string]bool{"tt1": false, "tt2": true, "tt3": true}, removedById)
+	assert.False(t, currentItems[2].Removed)
+}
@@ -0,0 +1,211 @@
+package stremio_backup
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/rs/xid"
+)
+
+const TableName = "stremio_backup"
+
+type Kind string
+
+const (
+	KindAddons  Kind = "addons"
+	KindLibrary Kind = "library"
+)
+
+type StremioBackup struct {
+	Id        string
+	AccountId string
+	Kind      Kind
+	Hash      string
+	Count     int
+	Data      []byte
+	CAt       db.Timestamp
+}
+
+var Column = struct {
+	Id        string
+	AccountId string
+	Kind      string
+	Hash      string
+	Count     string
+	Data      string
+	CAt       string
+}{
+	Id:        "id",
+	AccountId: "account_id",
+	Kind:      "kind",
+	Hash:      "hash",
+	Count:     "count",
+	Data:      "data",
+	CAt:       "cat",
+}
+
+var columns = []string{
+	Column.Id,
+	Column.AccountId,
+	Column.Kind,
+	Column.Hash,
+	Column.Count,
+	Column.CAt,
+}
+
+func scanStremioBackups(rows *sql.Rows) ([]StremioBackup, error) {
+	items := []StremioBackup{}
+	for rows.Next() {
+		item := StremioBackup{}
+		if err := rows.Scan(&item.Id, &item.AccountId, &item.Kind, &item.Hash, &item.Count, &item.CAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+var query_get_by_id = fmt.Sprintf(
+	`SELECT %s, %s FROM %s WHERE %s = ?`,
+	db.JoinColumnNames(columns...),
+	Column.Data,
+	TableName,
+	Column.Id,
+)
+
+func GetById(id string) (*StremioBackup, error) {
+	item := StremioBackup{}
+	var data string
+	if err := db.QueryRow(query_get_by_id, id).Scan(&item.Id, &item.AccountId, &item.Kind, &item.Hash, &item.Count, &item.CAt, &data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	item.Data = []byte(data)
+	return &item, nil
+}
+
+var query_get_previous = fmt.Sprintf(
+	`SELECT %s, %s FROM %s WHERE %s = ? AND %s = ? AND %s < ? ORDER BY %s DESC LIMIT 1`,
+	db.JoinColumnNames(columns...),
+	Column.Data,
+	TableName,
+	Column.AccountId,
+	Column.Kind,
+	Column.Id,
+	Column.Id,
+)
+
+// GetPrevious returns the backup taken right before the given one.
+func GetPrevious(b *StremioBackup) (*StremioBackup, error) {
+	item := StremioBackup{}
+	var data string
+	if err := db.QueryRow(query_get_previous, b.AccountId, b.Kind, b.Id).Scan(&item.Id, &item.AccountId, &item.Kind, &item.Hash, &item.Count, &item.CAt, &data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	item.Data = []byte(data)
+	return &item, nil
+}
+
+var query_get_all_by_account_id = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? ORDER BY %s DESC`,
+	db.JoinColumnNames(columns...),
+	TableName,
+	Column.AccountId,
+	Column.Id,
+)
+
+// GetAllByAccountId returns the backups without data, latest first.
+func GetAllByAccountId(accountId string) ([]StremioBackup, error) {
+	rows, err := db.Query(query_get_all_by_account_id, accountId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanStremioBackups(rows)
+}
+
+var query_get_last_hash = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s = ? ORDER BY %s DESC LIMIT 1`,
+	Column.Hash,
+	TableName,
+	Column.AccountId,
+	Column.Kind,
+	Column.Id,
+)
+
+var query_insert = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES (?,?,?,?,?,?)`,
+	TableName,
+	db.JoinColumnNames(
+		Column.Id,
+		Column.AccountId,
+		Column.Kind,
+		Column.Hash,
+		Column.Count,
+		Column.Data,
+	),
+)
+
+// Create stores a new backup, unless it is same as the last one.
+func Create(accountId string, kind Kind, count int, data any) (bool, error) {
+	blob, err := json.Marshal(data)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(blob)
+	hash := hex.EncodeToString(sum[:])
+
+	var lastHash string
+	if err := db.QueryRow(query_get_last_hash, accountId, kind).Scan(&lastHash); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	if lastHash == hash {
+		return false, nil
+	}
+
+	_, err = db.Exec(query_insert, xid.New().String(), accountId, kind, hash, count, string(blob))
+	return err == nil, err
+}
+
+var query_prune = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s = ? AND %s NOT IN (SELECT %s FROM %s WHERE %s = ? AND %s = ? ORDER BY %s DESC LIMIT ?)`,
+	TableName,
+	Column.AccountId,
+	Column.Kind,
+	Column.Id,
+	Column.Id,
+	TableName,
+	Column.AccountId,
+	Column.Kind,
+	Column.Id,
+)
+
+// Prune keeps the latest `keep` backups.
+func Prune(accountId string, kind Kind, keep int) error {
+	_, err := db.Exec(query_prune, accountId, kind, accountId, kind, keep)
+	return err
+}
+
+var query_delete_by_account_id = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ?`,
+	TableName,
+	Column.AccountId,
+)
+
+func DeleteByAccountId(accountId string) error {
+	_, err := db.Exec(query_delete_by_account_id, accountId)
+	return err
+}
@@ -0,0 +1,122 @@
+package stremio_backup
+
+import (
+	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+func isActiveLibraryItem(item *stremio_api.LibraryItem) bool {
+	return !item.Removed && !item.Temp
+}
+
+func countLibraryItems(items []stremio_api.LibraryItem) int {
+	count := 0
+	for i := range items {
+		if isActiveLibraryItem(&items[i]) {
+			count++
+		}
+	}
+	return count
+}
+
+type AddonMove struct {
+	Addon stremio.Addon
+	From  int
+	To    int
+}
+
+type AddonsDiff struct {
+	Added   []stremio.Addon
+	Removed []stremio.Addon
+	Moved   []AddonMove
+}
+
+func (d *AddonsDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Moved) == 0
+}
+
+// DiffAddons compares addons by transport url. Positions in `Moved` are
+// 0-based, among the addons present in both.
+func DiffAddons(prev, curr []stremio.Addon) *AddonsDiff {
+	diff := &AddonsDiff{
+		Added:   []stremio.Addon{},
+		Removed: []stremio.Addon{},
+		Moved:   []AddonMove{},
+	}
+
+	currUrls := make(map[string]struct{}, len(curr))
+	for i := range curr {
+		currUrls[curr[i].TransportUrl] = struct{}{}
+	}
+	prevIdxByUrl := map[string]int{}
+	for i := range prev {
+		addon := &prev[i]
+		if _, ok := currUrls[addon.TransportUrl]; ok {
+			prevIdxByUrl[addon.TransportUrl] = len(prevIdxByUrl)
+		} else {
+			diff.Removed = append(diff.Removed, *addon)
+		}
+	}
+
+	currIdx := 0
+	for i := range curr {
+		addon := &curr[i]
+		prevIdx, ok := prevIdxByUrl[addon.TransportUrl]
+		if !ok {
+			diff.Added = append(diff.Added, *addon)
+			continue
+		}
+		if prevIdx != currIdx {
+			diff.Moved = append(diff.Moved, AddonMove{Addon: *addon, From: prevIdx, To: currIdx})
+		}
+		currIdx++
+	}
+
+	return diff
+}
+
+type LibraryDiff struct {
+	Added   []stremio_api.LibraryItem
+	Removed []stremio_api.LibraryItem
+}
+
+func (d *LibraryDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// DiffLibraryItems compares the library items that are not removed.
+func DiffLibraryItems(prev, curr []stremio_api.LibraryItem) *LibraryDiff {
+	diff := &LibraryDiff{
+		Added:   []stremio_api.LibraryItem{},
+		Removed: []stremio_api.LibraryItem{},
+	}
+
+	prevIds := map[string]struct{}{}
+	for i := range prev {
+		if isActiveLibraryItem(&prev[i]) {
+			prevIds[prev[i].Id] = struct{}{}
+		}
+	}
+	currIds := map[string]struct{}{}
+	for i := range curr {
+		item := &curr[i]
+		if !isActiveLibraryItem(item) {
+			continue
+		}
+		currIds[item.Id] = struct{}{}
+		if _, ok := prevIds[item.Id]; !ok {
+			diff.Added = append(diff.Added, *item)
+		}
+	}
+	for i := range prev {
+		item := &prev[i]
+		if !isActiveLibraryItem(item) {
+			continue
+		}
+		if _, ok := currIds[item.Id]; !ok {
+			diff.Removed = append(diff.Removed, *item)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,85 @@
+package stremio_backup
+
+import (
+	"testing"
+
+	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
+	"github.com/MunifTanjim/stremthru/stremio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffAddons(t *testing.T) {
+	toAddons := func(urls ...string) []stremio.Addon {
+		addons := []stremio.Addon{}
+		for _, url := range urls {
+			addons = append(addons, stremio.Addon{TransportUrl: url})
+		}
+		return addons
+	}
+	toUrls := func(addons []stremio.Addon) []string {
+		urls := []string{}
+		for _, addon := range addons {
+			urls = append(urls, addon.TransportUrl)
+		}
+		return urls
+	}
+	toMoves := func(moves []AddonMove) [][]any {
+		result := [][]any{}
+		for _, move := range moves {
+			result = append(result, []any{move.Addon.TransportUrl, move.From, move.To})
+		}
+		return result
+	}
+
+	for _, tc := range []struct {
+		name    string
+		prev    []string
+		curr    []string
+		added   []string
+		removed []string
+		moved   [][]any
+	}{
+		{"same", []string{"a", "b"}, []string{"a", "b"}, []string{}, []string{}, [][]any{}},
+		{"added", []string{"a"}, []string{"a", "b"}, []string{"b"}, []string{}, [][]any{}},
+		{"removed", []string{"a", "b", "c"}, []string{"a", "c"}, []string{}, []string{"b"}, [][]any{}},
+		{"moved", []string{"a", "b", "c"}, []string{"b", "a", "c"}, []string{}, []string{}, [][]any{{"b", 1, 0}, {"a", 0, 1}}},
+		{"wiped", []string{"a", "b"}, []string{}, []string{}, []string{"a", "b"}, [][]any{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := DiffAddons(toAddons(tc.prev...), toAddons(tc.curr...))
+			assert.Equal(t, tc.added, toUrls(diff.Added))
+			assert.Equal(t, tc.removed, toUrls(diff.Removed))
+			assert.Equal(t, tc.moved, toMoves(diff.Moved))
+			assert.Equal(t, len(tc.added)+len(tc.removed)+len(tc.moved) == 0, diff.IsEmpty())
+		})
+	}
+}
+
+func TestDiffLibraryItems(t *testing.T) {
+	prev := []stremio_api.LibraryItem{
+		{Id: "tt1"},
+		{Id: "tt2"},
+		{Id: "tt3", Removed: true},
+		{Id: "tt4", Temp: true},
+	}
+	curr := []stremio_api.LibraryItem{
+		{Id: "tt1"},
+		{Id: "tt2", Removed: true},
+		{Id: "tt3"},
+		{Id: "tt4", Temp: true},
+		{Id: "tt5"},
+	}
+
+	toIds := func(items []stremio_api.LibraryItem) []string {
+		ids := []string{}
+		for _, item := range items {
+			ids = append(ids, item.Id)
+		}
+		return ids
+	}
+
+	diff := DiffLibraryItems(prev, curr)
+	assert.Equal(t, []string{"tt3", "tt5"}, toIds(diff.Added))
+	assert.Equal(t, []string{"tt2"}, toIds(diff.Removed))
+	assert.Equal(t, 3, countLibraryItems(curr))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	stremio_addon "github.com/MunifTanjim/stremthru/internal/stremio/addon"
+	stremio_meta "github.com/MunifTanjim/stremthru/internal/stremio/meta"
 	"github.com/MunifTanjim/stremthru/stremio"
 	"golang.org/x/sync/singleflight"
 )
@@ -19,29 +20,63 @@ var metaCache = cache.NewCache[stremio.Meta](&cache.CacheConfig{
 })
 var fetchMetaGroup singleflight.Group
 
+// waited before falling back to local datasets
+var fallbackTimeout = 10 * time.Second
+
+func getLocalMeta(sType, imdbId string) *stremio.Meta {
+	m, err := stremio_meta.GetMeta(stremio.ContentType(sType), imdbId)
+	if err != nil || m == nil {
+		return nil
+	}
+	// series without episodes is not a usable replacement
+	if m.Type == stremio.ContentTypeSeries && len(m.Videos) == 0 {
+		return nil
+	}
+	return m
+}
+
 func FetchMeta(sType, imdbId string) (stremio.Meta, error) {
 	var meta stremio.Meta
 	cacheKey := sType + ":" + imdbId
 	if !metaCache.Get(cacheKey, &meta) {
-		m, err, _ := fetchMetaGroup.Do(cacheKey, func() (any, error) {
+		resultCh := fetchMetaGroup.DoChan(cacheKey, func() (any, error) {
 			r, err := client.FetchMeta(&stremio_addon.FetchMetaParams{
 				BaseURL: baseUrl,
 				Type:    sType,
 				Id:      imdbId + ".json",
 			})
-			return r.Data.Meta, err
+			if err != nil {
+				return r.Data.Meta, err
+			}
+			meta := r.Data.Meta
+			slices.SortFunc(meta.Videos, func(a, b stremio.MetaVideo) int {
+				if a.Season != b.Season {
+					return int(a.Season) - int(b.Season)
+				}
+				return int(a.Episode) - int(b.Episode)
+			})
+			metaCache.Add(cacheKey, meta)
+			return meta, nil
 		})
-		if err != nil {
-			return meta, err
+
+		var result singleflight.Result
+		select {
+		case result = <-resultCh:
+		case <-time.After(fallbackTimeout):
+			// cinemeta is slow, fallback to local datasets
+			if m := getLocalMeta(sType, imdbId); m != nil {
+				return *m, nil
+			}
+			result = <-resultCh
 		}
-		meta = m.(stremio.Meta)
-		slices.SortFunc(meta.Videos, func(a, b stremio.MetaVideo) int {
-			if a.Season != b.Season {
-				return int(a.Season) - int(b.Season)
+		if result.Err != nil {
+			// cinemeta is down, fallback to local datasets
+			if m := getLocalMeta(sType, imdbId); m != nil {
+				return *m, nil
 			}
-			return int(a.Episode) - int(b.Episode)
-		})
-		metaCache.Add(cacheKey, meta)
+			return meta, result.Err
+		}
+		meta = result.Val.(stremio.Meta)
 	}
 	return meta, nil
 }
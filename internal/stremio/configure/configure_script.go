@@ -1,6 +1,11 @@
 package configure
 
-import "html/template"
+import (
+	"html/template"
+	"strconv"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+)
 
 func GetScriptStoreTokenDescription(nameSelector, tokenSelector string) template.JS {
 	if nameSelector == "" {
@@ -11,6 +16,7 @@ func GetScriptStoreTokenDescription(nameSelector, tokenSelector string) template
 	}
 	return template.JS(`
 function onStoreNameChangeUpdateStoreTokenDescription(nameField) {
+  const isPublicInstance = ` + strconv.FormatBool(config.IsPublicInstance) + `;
   if (!nameField || !nameField.options[nameField.selectedIndex].textContent) {
     return;
   }
@@ -19,6 +25,7 @@ function onStoreNameChangeUpdateStoreTokenDescription(nameField) {
     debrider: "dr",
     debridlink: "dl",
     easydebrid: "ed",
+    local: "lo",
     offcloud: "oc",
     pikpak: "pp",
     premiumize: "pm",
@@ -40,6 +47,7 @@ function onStoreNameChangeUpdateStoreTokenDescription(nameField) {
       pp: "<a type='button' class='outline mb-0' style='font-size: 0.75rem; padding: 0.02em 0.5em;' target='_blank' href='https://mypikpak.com/drive/activity/invited?invitation-code=46013321'>Sign Up</a> Invitation Code: <a target='_blank' href='https://mypikpak.com/drive/activity/invited?invitation-code=46013321'><code>46013321</code></a>",
       rd: "<a type='button' class='outline mb-0' style='font-size: 0.75rem; padding: 0.02em 0.5em;' target='_blank' href='http://real-debrid.com/?id=12448969'>Sign Up<a>",
       tb: "<a type='button' class='outline mb-0' style='font-size: 0.75rem; padding: 0.02em 0.5em;' target='_blank' href='https://torbox.app/subscription?referral=fbe2c844-4b50-416a-9cd8-4e37925f5dfa'>Sign Up</a> Referral Code: <a target='_blank' href='https://torbox.app/subscription?referral=fbe2c844-4b50-416a-9cd8-4e37925f5dfa'><code>fbe2c844-4b50-416a-9cd8-4e37925f5dfa</code></a>",
+      lo: "Files from StremThru's data directory (🧪 Experimental)",
       p2p: "⚠️ Peer-to-Peer (🧪 Experimental)",
     };
     nameDescElem.innerHTML = descByStore[nameField.value] || descByStore[storeFallback[nameField.value]] || descByStore["*"] || "";
@@ -59,11 +67,12 @@ function onStoreNameChangeUpdateStoreTokenDescription(nameField) {
 			pp: "PikPak <a href='https://mypikpak.com/drive/account/basic' target='_blank'>credential</a> in <code>email:password</code> format, e.g. <code>john.doe@example.com:secret-password</code>",
 			rd: "RealDebrid <a href='https://real-debrid.com/apitoken' target='_blank'>API Token</a>",
 			tb: "TorBox <a href='https://torbox.app/settings' target='_blank'>API Key</a>",
-			p2p: "…",
+			lo: "Any non-empty value, used as the user identifier",
+			p2p: isPublicInstance ? "…" : "(Optional) StremThru Basic Auth Token (base64 encoded), to stream through StremThru instead of the BitTorrent client",
 		};
     tokenDescElem.innerHTML = descByStore[nameField.value] || descByStore[storeFallback[nameField.value]] || descByStore["*"] || "";
-    tokenField.disabled = nameField.value === "p2p";
-    if (nameField.value === "p2p") {
+    tokenField.disabled = isPublicInstance && nameField.value === "p2p";
+    if (tokenField.disabled) {
       tokenField.value = "";
     }
   }
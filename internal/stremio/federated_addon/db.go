@@ -0,0 +1,217 @@
+package stremio_federated_addon
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+const TableName = "stremio_federated_addon"
+
+type Status string
+
+const (
+	StatusUnknown   Status = "unknown"
+	StatusHealthy   Status = "healthy"
+	StatusUnhealthy Status = "unhealthy"
+)
+
+type Manifest struct {
+	stremio.Manifest
+}
+
+func (m Manifest) Value() (driver.Value, error) {
+	return db.JSONValue(m.Manifest)
+}
+
+func (m *Manifest) Scan(value any) error {
+	return db.JSONScan(value, &m.Manifest)
+}
+
+type FederatedAddon struct {
+	URL       string
+	IsPeer    bool
+	Manifest  Manifest
+	Status    Status
+	Error     string
+	CheckedAt db.Timestamp
+	SeenAt    db.Timestamp
+	CAt       db.Timestamp
+	UAt       db.Timestamp
+}
+
+func (fa *FederatedAddon) IsHealthy() bool {
+	return fa.Status == StatusHealthy
+}
+
+var Column = struct {
+	URL       string
+	IsPeer    string
+	Manifest  string
+	Status    string
+	Error     string
+	CheckedAt string
+	SeenAt    string
+	CAt       string
+	UAt       string
+}{
+	URL:       "url",
+	IsPeer:    "is_peer",
+	Manifest:  "manifest",
+	Status:    "status",
+	Error:     "error",
+	CheckedAt: "checked_at",
+	SeenAt:    "seen_at",
+	CAt:       "cat",
+	UAt:       "uat",
+}
+
+var columns = []string{
+	Column.URL,
+	Column.IsPeer,
+	Column.Manifest,
+	Column.Status,
+	Column.Error,
+	Column.CheckedAt,
+	Column.SeenAt,
+	Column.CAt,
+	Column.UAt,
+}
+
+func scanFederatedAddon(row interface{ Scan(dest ...any) error }) (*FederatedAddon, error) {
+	item := FederatedAddon{}
+	if err := row.Scan(
+		&item.URL,
+		&item.IsPeer,
+		&item.Manifest,
+		&item.Status,
+		&item.Error,
+		&item.CheckedAt,
+		&item.SeenAt,
+		&item.CAt,
+		&item.UAt,
+	); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+var query_get_all = fmt.Sprintf(
+	`SELECT %s FROM %s ORDER BY %s`,
+	db.JoinColumnNames(columns...),
+	TableName,
+	Column.CAt,
+)
+
+func GetAll() ([]FederatedAddon, error) {
+	rows, err := db.Query(query_get_all)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []FederatedAddon{}
+	for rows.Next() {
+		item, err := scanFederatedAddon(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+var query_get_by_url = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ?`,
+	db.JoinColumnNames(columns...),
+	TableName,
+	Column.URL,
+)
+
+func GetByURL(url string) (*FederatedAddon, error) {
+	item, err := scanFederatedAddon(db.QueryRow(query_get_by_url, url))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+var query_upsert = fmt.Sprintf(
+	`INSERT INTO %s AS fa (%s) VALUES (?,?,?,?,?,?,?) ON CONFLICT (%s) DO UPDATE SET %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = COALESCE(EXCLUDED.%s, fa.%s), %s = %s`,
+	TableName,
+	db.JoinColumnNames(
+		Column.URL,
+		Column.IsPeer,
+		Column.Manifest,
+		Column.Status,
+		Column.Error,
+		Column.CheckedAt,
+		Column.SeenAt,
+	),
+	Column.URL,
+	Column.IsPeer, Column.IsPeer,
+	Column.Manifest, Column.Manifest,
+	Column.Status, Column.Status,
+	Column.Error, Column.Error,
+	Column.CheckedAt, Column.CheckedAt,
+	Column.SeenAt, Column.SeenAt, Column.SeenAt,
+	Column.UAt, db.CurrentTimestamp,
+)
+
+func Upsert(fa *FederatedAddon) error {
+	_, err := db.Exec(query_upsert, fa.URL, fa.IsPeer, fa.Manifest, fa.Status, fa.Error, fa.CheckedAt, fa.SeenAt)
+	return err
+}
+
+var query_record_check_success = fmt.Sprintf(
+	`UPDATE %s SET %s = ?, %s = ?, %s = '', %s = %s, %s = %s, %s = %s WHERE %s = ?`,
+	TableName,
+	Column.Manifest,
+	Column.Status,
+	Column.Error,
+	Column.CheckedAt, db.CurrentTimestamp,
+	Column.SeenAt, db.CurrentTimestamp,
+	Column.UAt, db.CurrentTimestamp,
+	Column.URL,
+)
+
+var query_record_check_failure = fmt.Sprintf(
+	`UPDATE %s SET %s = ?, %s = ?, %s = %s, %s = %s WHERE %s = ?`,
+	TableName,
+	Column.Status,
+	Column.Error,
+	Column.CheckedAt, db.CurrentTimestamp,
+	Column.UAt, db.CurrentTimestamp,
+	Column.URL,
+)
+
+// RecordCheck stores the result of the health check. On failure, the last
+// seen manifest is kept.
+func RecordCheck(url string, manifest *stremio.Manifest, checkErr error) error {
+	if checkErr != nil {
+		_, err := db.Exec(query_record_check_failure, StatusUnhealthy, checkErr.Error(), url)
+		return err
+	}
+	_, err := db.Exec(query_record_check_success, Manifest{*manifest}, StatusHealthy, url)
+	return err
+}
+
+var query_delete = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ?`,
+	TableName,
+	Column.URL,
+)
+
+func Delete(url string) error {
+	_, err := db.Exec(query_delete, url)
+	return err
+}
@@ -0,0 +1,168 @@
+package stremio_federated_addon
+
+import (
+	"errors"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/peer"
+	stremio_addon "github.com/MunifTanjim/stremthru/internal/stremio/addon"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+var log = logger.Scoped("stremio/federated_addon")
+
+var client = stremio_addon.NewClient(&stremio_addon.ClientConfig{})
+
+var Peer = peer.NewAPIClient(&peer.APIClientConfig{
+	BaseURL: config.PeerURL,
+	APIKey:  config.PeerAuthToken,
+})
+
+func fetchManifest(manifestUrl string) (*stremio.Manifest, error) {
+	baseUrl, err := stremio_addon.ExtractBaseURL(manifestUrl)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.GetManifest(&stremio_addon.GetManifestParams{BaseURL: baseUrl})
+	if err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+var cachedCatalogAddons = cache.NewCachedValue(cache.CachedValueConfig[[]stremio.Addon]{
+	Get: func() ([]stremio.Addon, error) {
+		items, err := GetAll()
+		if err != nil {
+			return nil, err
+		}
+		addons := []stremio.Addon{}
+		for i := range items {
+			item := &items[i]
+			if !item.IsHealthy() {
+				continue
+			}
+			addons = append(addons, stremio.Addon{
+				Manifest:      item.Manifest.Manifest,
+				TransportName: "http",
+				TransportUrl:  item.URL,
+			})
+		}
+		return addons, nil
+	},
+	TTL: 5 * time.Minute,
+})
+
+// GetCatalogAddons returns the healthy federated addons, for the root addon
+// catalog.
+func GetCatalogAddons() []stremio.Addon {
+	addons, err := cachedCatalogAddons.Get()
+	if err != nil {
+		log.Error("failed to get federated addons", "error", err)
+	}
+	return addons
+}
+
+// Register fetches the manifest and adds the addon to the federation.
+func Register(manifestUrl string, isPeer bool) (*FederatedAddon, error) {
+	manifestUrl, err := stremio_addon.NormalizeManifestURL(manifestUrl)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := fetchManifest(manifestUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	now := db.Timestamp{Time: time.Now()}
+	fa := &FederatedAddon{
+		URL:       manifestUrl,
+		IsPeer:    isPeer,
+		Manifest:  Manifest{*manifest},
+		Status:    StatusHealthy,
+		CheckedAt: now,
+		SeenAt:    now,
+		CAt:       now,
+		UAt:       now,
+	}
+	if err := Upsert(fa); err != nil {
+		return nil, err
+	}
+	cachedCatalogAddons.Invalidate()
+	return GetByURL(manifestUrl)
+}
+
+func Unregister(manifestUrl string) error {
+	if err := Delete(manifestUrl); err != nil {
+		return err
+	}
+	cachedCatalogAddons.Invalidate()
+	return nil
+}
+
+// Check fetches the manifest of the addon and records its health.
+func Check(manifestUrl string) error {
+	manifest, checkErr := fetchManifest(manifestUrl)
+	if err := RecordCheck(manifestUrl, manifest, checkErr); err != nil {
+		return err
+	}
+	cachedCatalogAddons.Invalidate()
+	return checkErr
+}
+
+func CheckAll() error {
+	items, err := GetAll()
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		item := &items[i]
+		if err := Check(item.URL); err != nil {
+			log.Warn("health check failed", "error", err, "name", item.Manifest.Name)
+		}
+	}
+	return nil
+}
+
+// excludeRegistered drops the addons already in `registered`. The registered
+// urls are normalized, so the addon urls are normalized before comparing.
+func excludeRegistered(addons []stremio.Addon, registered []FederatedAddon) []stremio.Addon {
+	urls := make(map[string]struct{}, len(registered))
+	for i := range registered {
+		urls[registered[i].URL] = struct{}{}
+	}
+	result := []stremio.Addon{}
+	for _, addon := range addons {
+		manifestUrl, err := stremio_addon.NormalizeManifestURL(addon.TransportUrl)
+		if err != nil {
+			log.Warn("invalid peer addon url", "error", err, "url", addon.TransportUrl)
+			continue
+		}
+		if _, ok := urls[manifestUrl]; ok {
+			continue
+		}
+		result = append(result, addon)
+	}
+	return result
+}
+
+// DiscoverPeerAddons lists the addons served by the peer StremThru instance,
+// that are not registered yet.
+func DiscoverPeerAddons() ([]stremio.Addon, error) {
+	if !config.HasPeer {
+		return nil, errors.New("peer not configured")
+	}
+	res, err := Peer.FetchAddonCatalog(&peer.FetchAddonCatalogParams{})
+	if err != nil {
+		return nil, err
+	}
+	items, err := GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return excludeRegistered(res.Data.Addons, items), nil
+}
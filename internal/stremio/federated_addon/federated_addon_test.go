@@ -0,0 +1,40 @@
+package stremio_federated_addon
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/stremio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcludeRegistered(t *testing.T) {
+	registered := []FederatedAddon{
+		{URL: "https://a.example.com/manifest.json"},
+		{URL: "https://b.example.com/xyz/manifest.json"},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		url      string
+		excluded bool
+	}{
+		{"same url", "https://a.example.com/manifest.json", true},
+		{"stremio scheme", "stremio://a.example.com/manifest.json", true},
+		{"configure url", "https://b.example.com/xyz/configure", true},
+		{"not registered", "https://c.example.com/manifest.json", false},
+		{"different path", "https://b.example.com/abc/manifest.json", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			addons := excludeRegistered([]stremio.Addon{{TransportUrl: tc.url}}, registered)
+			if tc.excluded {
+				assert.Empty(t, addons)
+			} else {
+				assert.Len(t, addons, 1)
+			}
+		})
+	}
+
+	t.Run("invalid url", func(t *testing.T) {
+		assert.Empty(t, excludeRegistered([]stremio.Addon{{TransportUrl: "://bad"}}, registered))
+	})
+}
@@ -151,7 +151,12 @@ func getIMDBIdsForTMDBIds(tokenId string, tmdbMovieIds, tmdbShowIds []string) (m
 
 	if len(missingTMDBMovieIds) > 0 || len(missingTMDBShowIds) > 0 {
 		log.Debug("fetching external ids for tmdb", "movie_count", len(missingTMDBMovieIds), "show_count", len(missingTMDBShowIds))
-		tmdbClient := tmdb.GetAPIClient(tokenId)
+		var tmdbClient *tmdb.APIClient
+		if tokenId == "" {
+			tmdbClient = tmdb.GetSystemAPIClient()
+		} else {
+			tmdbClient = tmdb.GetAPIClient(tokenId)
+		}
 		movieGroup := tmdbMovieExternalIdsPool.NewGroup()
 		for _, movieId := range missingTMDBMovieIds {
 			movieGroup.SubmitErr(func() (*tmdb.GetMovieExternalIdsData, error) {
@@ -427,6 +432,53 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 			catalogItems = append(catalogItems, catalogItem{meta, item})
 		}
 
+	case "smart":
+		list := SmartList{Id: id}
+		if genre := getExtra(r).Genre; genre != "" {
+			query, err := decodeSmartListId(id)
+			if err != nil {
+				SendError(w, r, err)
+				return
+			}
+			list = SmartList{Query: withSmartListGenre(query, genre)}
+		}
+		if err := ud.FetchSmartList(&list); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		isMovieCatalog := catalogType == string(stremio.ContentTypeMovie)
+		isSeriesCatalog := catalogType == string(stremio.ContentTypeSeries)
+		for i := range list.Items {
+			item := &list.Items[i]
+			if (isMovieCatalog && item.Type != stremio.ContentTypeMovie) || (isSeriesCatalog && item.Type != stremio.ContentTypeSeries) {
+				continue
+			}
+
+			poster := item.Poster
+			if posterBaseUrl != "" {
+				poster = posterBaseUrl + item.IMDBId + ".jpg" + posterQueryParams
+			}
+			background := item.Background
+			if background == "" {
+				background = stremio_shared.GetCinemetaBackgroundURL(item.IMDBId)
+			}
+
+			meta := stremio.MetaPreview{
+				Id:          item.IMDBId,
+				Type:        item.Type,
+				Name:        item.Title,
+				Description: item.Description,
+				Poster:      poster,
+				PosterShape: stremio.MetaPosterShapePoster,
+				Background:  background,
+				Genres:      item.Genres,
+				ReleaseInfo: strconv.Itoa(item.Year),
+				IMDBRating:  strconv.FormatFloat(item.Rating, 'f', 1, 64),
+			}
+			catalogItems = append(catalogItems, catalogItem{meta, item})
+		}
+
 	case "tmdb":
 		list := tmdb.TMDBList{Id: id}
 		if err := ud.FetchTMDBList(&list); err != nil {
@@ -700,6 +752,11 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 			items = append(items, item.MetaPreview)
 		}
 
+	case "smart":
+		for i := range catalogItems {
+			items = append(items, catalogItems[i].MetaPreview)
+		}
+
 	case "tmdb":
 		tmdbMovieIds := make([]string, 0, len(catalogItems))
 		tmdbShowIds := make([]string, 0, len(catalogItems))
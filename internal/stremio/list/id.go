@@ -1,6 +1,7 @@
 package stremio_list
 
 import (
+	"encoding/base64"
 	"errors"
 	"strings"
 )
@@ -18,3 +19,15 @@ func parseCatalogId(catalogId string) (service, id string) {
 	service, id, _ = strings.Cut(id, ".")
 	return service, id
 }
+
+func encodeSmartListId(query string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(query))
+}
+
+func decodeSmartListId(id string) (query string, err error) {
+	blob, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return "", errors.New("invalid smart list id: " + id)
+	}
+	return string(blob), nil
+}
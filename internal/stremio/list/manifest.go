@@ -143,6 +143,47 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 				}
 				catalogs = append(catalogs, catalog)
 
+			case "smart":
+				list := SmartList{Id: idStr}
+				query, err := decodeSmartListId(idStr)
+				if err != nil {
+					return nil, err
+				}
+				list.Query = query
+				catalog := stremio.Catalog{
+					Type: "Smart",
+					Id:   "st.list.smart." + idStr,
+					Name: list.GetDisplayName(),
+					Extra: []stremio.CatalogExtra{
+						{
+							Name:    "genre",
+							Options: smartListGenreOptions,
+						},
+						{
+							Name: "skip",
+						},
+					},
+				}
+				if params, err := parseSmartListDiscoverParams(query); err == nil && len(params.mediaTypes) == 1 {
+					switch params.mediaTypes[0] {
+					case tmdb.MediaTypeMovie:
+						catalog.Type = string(stremio.ContentTypeMovie)
+					case tmdb.MediaTypeTVShow:
+						catalog.Type = string(stremio.ContentTypeSeries)
+					}
+				}
+				if hasListNames {
+					if name := ud.ListNames[idx]; name != "" {
+						catalog.Name = name
+					}
+				}
+				if hasListTypes {
+					if listType := ud.ListTypes[idx]; listType != "" {
+						catalog.Type = listType
+					}
+				}
+				catalogs = append(catalogs, catalog)
+
 			case "tmdb":
 				list := tmdb.TMDBList{Id: idStr}
 				if err := list.Fetch(ud.TMDBTokenId); err != nil {
@@ -0,0 +1,625 @@
+package stremio_list
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/imdb_title"
+	"github.com/MunifTanjim/stremthru/internal/mdblist"
+	"github.com/MunifTanjim/stremthru/internal/tmdb"
+	"github.com/MunifTanjim/stremthru/stremio"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/expr-lang/expr/vm"
+	"golang.org/x/sync/singleflight"
+)
+
+const smartListMaxItems = 1000
+const smartListDiscoverMaxPage = 5
+
+// the clauses not translated to sql can filter out some of the titles
+const smartListTitlesMaxPage = 5
+
+type SmartListEnv struct {
+	Title  string
+	Year   int
+	Type   string
+	Genre  []string
+	Rating float64
+}
+
+// The genres are matched by the MDBList names, the other spellings used by the
+// local datasets and TMDB are normalized to those.
+var smartListGenreByAlias = map[string]string{
+	"Film-Noir":  "Film Noir",
+	"Game-Show":  "Game Show",
+	"Kids":       "Children",
+	"Reality":    "Reality TV",
+	"Reality-TV": "Reality TV",
+	"Sci-Fi":     "Science Fiction",
+	"Talk":       "Talk Show",
+	"Talk-Show":  "Talk Show",
+}
+
+// TMDB TV genres are combined ones.
+var smartListGenresByTMDBGenre = map[string][]string{
+	"Action & Adventure": {"Action", "Adventure"},
+	"Sci-Fi & Fantasy":   {"Science Fiction", "Fantasy"},
+	"War & Politics":     {"War"},
+}
+
+func normalizeSmartListGenre(genre string) string {
+	if name, ok := smartListGenreByAlias[genre]; ok {
+		return name
+	}
+	return genre
+}
+
+func normalizeSmartListGenres(genres []string) []string {
+	result := make([]string, 0, len(genres))
+	seen := make(map[string]struct{}, len(genres))
+	add := func(genre string) {
+		genre = normalizeSmartListGenre(genre)
+		if _, ok := seen[genre]; !ok {
+			seen[genre] = struct{}{}
+			result = append(result, genre)
+		}
+	}
+	for _, genre := range genres {
+		if names, ok := smartListGenresByTMDBGenre[genre]; ok {
+			for _, name := range names {
+				add(name)
+			}
+		} else {
+			add(genre)
+		}
+	}
+	return result
+}
+
+var smartListGenreOptions = normalizeSmartListGenres(mdblist.GenreNames)
+
+// names of the genre, as stored in the local datasets
+var smartListGenreNamesByGenre = func() map[string][]string {
+	namesByGenre := map[string][]string{}
+	for alias, genre := range smartListGenreByAlias {
+		if _, ok := namesByGenre[genre]; !ok {
+			namesByGenre[genre] = []string{genre}
+		}
+		namesByGenre[genre] = append(namesByGenre[genre], alias)
+	}
+	for _, names := range namesByGenre {
+		slices.Sort(names[1:])
+	}
+	return namesByGenre
+}()
+
+func getSmartListGenreNames(genre string) []string {
+	if names, ok := smartListGenreNamesByGenre[genre]; ok {
+		return names
+	}
+	return []string{genre}
+}
+
+var smartListMovieGenreIdByGenre, smartListTVGenreIdByGenre = func() (map[string]int, map[string]int) {
+	movieIdByGenre := map[string]int{}
+	for _, name := range tmdb.MovieGenres {
+		for _, genre := range normalizeSmartListGenres([]string{name}) {
+			movieIdByGenre[genre] = tmdb.Genre(name).MovieId()
+		}
+	}
+	tvIdByGenre := map[string]int{}
+	for _, name := range tmdb.TVGenres {
+		for _, genre := range normalizeSmartListGenres([]string{name}) {
+			tvIdByGenre[genre] = tmdb.Genre(name).TVId()
+		}
+	}
+	return movieIdByGenre, tvIdByGenre
+}()
+
+// getSmartListTMDBGenreIds returns the TMDB genre ids for the media type, it
+// is not ok if any of the genres does not exist for the media type.
+func getSmartListTMDBGenreIds(mediaType tmdb.MediaType, genres []string) (ids []string, ok bool) {
+	idByGenre := smartListMovieGenreIdByGenre
+	if mediaType == tmdb.MediaTypeTVShow {
+		idByGenre = smartListTVGenreIdByGenre
+	}
+	ids = make([]string, 0, len(genres))
+	for _, genre := range genres {
+		id, ok := idByGenre[genre]
+		if !ok {
+			return nil, false
+		}
+		ids = append(ids, strconv.Itoa(id))
+	}
+	return ids, true
+}
+
+type smartListPatcher struct{}
+
+// Visit rewrites `Genre contains "X"` to `"X" in Genre`, and normalizes the
+// genre names.
+func (smartListPatcher) Visit(node *ast.Node) {
+	bin, ok := (*node).(*ast.BinaryNode)
+	if !ok {
+		return
+	}
+	switch bin.Operator {
+	case "contains":
+		if ident, ok := bin.Left.(*ast.IdentifierNode); ok && ident.Value == "Genre" {
+			if str, ok := bin.Right.(*ast.StringNode); ok {
+				str.Value = normalizeSmartListGenre(str.Value)
+			}
+			ast.Patch(node, &ast.BinaryNode{
+				Operator: "in",
+				Left:     bin.Right,
+				Right:    bin.Left,
+			})
+		}
+	case "in":
+		if ident, ok := bin.Right.(*ast.IdentifierNode); ok && ident.Value == "Genre" {
+			if str, ok := bin.Left.(*ast.StringNode); ok {
+				str.Value = normalizeSmartListGenre(str.Value)
+			}
+		}
+	}
+}
+
+func compileSmartListExpr(query string) (*vm.Program, error) {
+	return expr.Compile(query,
+		expr.Env(SmartListEnv{}),
+		expr.AsBool(),
+		expr.Patch(smartListPatcher{}),
+	)
+}
+
+type smartListDiscoverParams struct {
+	mediaTypes []tmdb.MediaType
+	genreNames []string
+	yearGte    int
+	yearLte    int
+	ratingGte  float64
+	ratingLte  float64
+}
+
+func collectSmartListClauses(node ast.Node, clauses []ast.Node) []ast.Node {
+	if bin, ok := node.(*ast.BinaryNode); ok && (bin.Operator == "&&" || bin.Operator == "and") {
+		clauses = collectSmartListClauses(bin.Left, clauses)
+		return collectSmartListClauses(bin.Right, clauses)
+	}
+	return append(clauses, node)
+}
+
+func getNumberNodeValue(node ast.Node) (float64, bool) {
+	switch n := node.(type) {
+	case *ast.IntegerNode:
+		return float64(n.Value), true
+	case *ast.FloatNode:
+		return n.Value, true
+	}
+	return 0, false
+}
+
+// parseSmartListDiscoverParams translates the top-level `&&` clauses of the
+// query to TMDB discover and local dataset parameters. Clauses that can not be
+// translated are ignored, the query is still used to filter the items.
+func parseSmartListDiscoverParams(query string) (*smartListDiscoverParams, error) {
+	tree, err := parser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &smartListDiscoverParams{
+		mediaTypes: []tmdb.MediaType{tmdb.MediaTypeMovie, tmdb.MediaTypeTVShow},
+	}
+	for _, clause := range collectSmartListClauses(tree.Node, nil) {
+		bin, ok := clause.(*ast.BinaryNode)
+		if !ok {
+			continue
+		}
+
+		if bin.Operator == "in" {
+			if ident, ok := bin.Right.(*ast.IdentifierNode); ok && ident.Value == "Genre" {
+				if str, ok := bin.Left.(*ast.StringNode); ok {
+					params.genreNames = append(params.genreNames, normalizeSmartListGenre(str.Value))
+				}
+			}
+			continue
+		}
+
+		ident, ok := bin.Left.(*ast.IdentifierNode)
+		if !ok {
+			continue
+		}
+
+		switch ident.Value {
+		case "Genre":
+			if str, ok := bin.Right.(*ast.StringNode); ok && bin.Operator == "contains" {
+				params.genreNames = append(params.genreNames, normalizeSmartListGenre(str.Value))
+			}
+		case "Type":
+			if str, ok := bin.Right.(*ast.StringNode); ok && bin.Operator == "==" {
+				switch stremio.ContentType(str.Value) {
+				case stremio.ContentTypeMovie:
+					params.mediaTypes = []tmdb.MediaType{tmdb.MediaTypeMovie}
+				case stremio.ContentTypeSeries:
+					params.mediaTypes = []tmdb.MediaType{tmdb.MediaTypeTVShow}
+				default:
+					params.mediaTypes = nil
+				}
+			}
+		case "Year":
+			value, ok := bin.Right.(*ast.IntegerNode)
+			if !ok {
+				continue
+			}
+			switch bin.Operator {
+			case ">":
+				params.yearGte = value.Value + 1
+			case ">=":
+				params.yearGte = value.Value
+			case "<":
+				params.yearLte = value.Value - 1
+			case "<=":
+				params.yearLte = value.Value
+			case "==":
+				params.yearGte = value.Value
+				params.yearLte = value.Value
+			}
+		case "Rating":
+			value, ok := getNumberNodeValue(bin.Right)
+			if !ok {
+				continue
+			}
+			switch bin.Operator {
+			case ">", ">=":
+				params.ratingGte = value
+			case "<", "<=":
+				params.ratingLte = value
+			}
+		}
+	}
+
+	return params, nil
+}
+
+type SmartListItem struct {
+	IMDBId      string              `json:"imdb_id"`
+	Type        stremio.ContentType `json:"type"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Year        int                 `json:"year"`
+	Genres      []string            `json:"genres"`
+	Rating      float64             `json:"rating"`
+	Poster      string              `json:"poster"`
+	Background  string              `json:"background"`
+}
+
+type SmartList struct {
+	Id    string
+	Query string
+	Items []SmartListItem
+}
+
+func (l *SmartList) GetURL() string {
+	return "smart:" + l.Query
+}
+
+func (l *SmartList) GetDisplayName() string {
+	return "Smart: " + l.Query
+}
+
+var smartListItemsCache = cache.NewCache[[]SmartListItem](&cache.CacheConfig{
+	Lifetime:      6 * time.Hour,
+	Name:          "stremio:list:smart",
+	LocalCapacity: 128,
+})
+var fetchSmartListGroup singleflight.Group
+
+func (l *SmartList) Fetch() error {
+	if l.Query == "" {
+		query, err := decodeSmartListId(l.Id)
+		if err != nil {
+			return err
+		}
+		l.Query = query
+	}
+	if l.Id == "" {
+		l.Id = encodeSmartListId(l.Query)
+	}
+
+	var items []SmartListItem
+	if !smartListItemsCache.Get(l.Id, &items) {
+		result, err, _ := fetchSmartListGroup.Do(l.Id, func() (any, error) {
+			items, isPartial, err := fetchSmartListItems(l.Query)
+			if err != nil {
+				return nil, err
+			}
+			if !isPartial {
+				if err := smartListItemsCache.Add(l.Id, items); err != nil {
+					log.Error("failed to cache smart list items", "error", err, "id", l.Id)
+				}
+			}
+			return items, nil
+		})
+		if err != nil {
+			return err
+		}
+		items = result.([]SmartListItem)
+	}
+
+	l.Items = items
+	return nil
+}
+
+// withSmartListGenre narrows down the query to the genre, so that the genre is
+// matched against all the titles, not just the ones within the max items.
+func withSmartListGenre(query, genre string) string {
+	return "(" + query + ") && " + strconv.Quote(genre) + " in Genre"
+}
+
+func getSmartListTitlesParams(params *smartListDiscoverParams) *imdb_title.ListTitlesWithMetaParams {
+	titleParams := &imdb_title.ListTitlesWithMetaParams{
+		YearGte:   params.yearGte,
+		YearLte:   params.yearLte,
+		RatingGte: int(math.Floor(params.ratingGte * 10)),
+		RatingLte: int(math.Ceil(params.ratingLte * 10)),
+		Limit:     smartListMaxItems,
+	}
+	for _, genre := range params.genreNames {
+		titleParams.Genres = append(titleParams.Genres, getSmartListGenreNames(genre))
+	}
+	switch len(params.mediaTypes) {
+	case 0:
+		return nil
+	case 1:
+		switch params.mediaTypes[0] {
+		case tmdb.MediaTypeMovie:
+			titleParams.Type = imdb_title.IMDBTitleSimpleTypeMovie
+		case tmdb.MediaTypeTVShow:
+			titleParams.Type = imdb_title.IMDBTitleSimpleTypeShow
+		}
+	}
+	return titleParams
+}
+
+func toSmartListItem(title *imdb_title.IMDBTitleWithMeta) *SmartListItem {
+	item := &SmartListItem{
+		IMDBId:      title.TId,
+		Title:       title.Title,
+		Description: title.Meta.Description,
+		Year:        title.Year,
+		Genres:      normalizeSmartListGenres(title.Meta.Genres),
+		Rating:      float64(title.Meta.Rating) / 10,
+		Poster:      title.Meta.Poster,
+		Background:  title.Meta.Backdrop,
+	}
+	switch imdb_title.IMDBTitleType(title.Type).ToSimple() {
+	case imdb_title.IMDBTitleSimpleTypeMovie:
+		item.Type = stremio.ContentTypeMovie
+	case imdb_title.IMDBTitleSimpleTypeShow:
+		item.Type = stremio.ContentTypeSeries
+	default:
+		return nil
+	}
+	return item
+}
+
+func fetchSmartListItems(query string) (items []SmartListItem, isPartial bool, err error) {
+	program, err := compileSmartListExpr(query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	match := func(item *SmartListItem) bool {
+		output, err := expr.Run(program, SmartListEnv{
+			Title:  item.Title,
+			Year:   item.Year,
+			Type:   string(item.Type),
+			Genre:  item.Genres,
+			Rating: item.Rating,
+		})
+		return err == nil && output.(bool)
+	}
+
+	params, err := parseSmartListDiscoverParams(query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	seen := map[string]struct{}{}
+	if titleParams := getSmartListTitlesParams(params); titleParams != nil {
+		for page := 0; page < smartListTitlesMaxPage && len(items) < smartListMaxItems; page++ {
+			titleParams.Offset = page * titleParams.Limit
+			titles, err := imdb_title.ListTitlesWithMeta(titleParams)
+			if err != nil {
+				return nil, false, err
+			}
+			for i := range titles {
+				if item := toSmartListItem(&titles[i]); item != nil && match(item) {
+					items = append(items, *item)
+					seen[item.IMDBId] = struct{}{}
+				}
+			}
+			if len(titles) < titleParams.Limit {
+				break
+			}
+		}
+	}
+
+	if TMDBEnabled {
+		discoveredItems, err := discoverSmartListItems(params)
+		if err != nil {
+			log.Error("failed to discover smart list items from tmdb", "error", err)
+			isPartial = true
+		}
+		for i := range discoveredItems {
+			item := &discoveredItems[i]
+			if _, ok := seen[item.IMDBId]; ok {
+				continue
+			}
+			if match(item) {
+				items = append(items, *item)
+				seen[item.IMDBId] = struct{}{}
+			}
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b SmartListItem) int {
+		return cmp.Or(cmp.Compare(b.Rating, a.Rating), cmp.Compare(b.Year, a.Year))
+	})
+	if len(items) > smartListMaxItems {
+		items = items[:smartListMaxItems]
+	}
+
+	return items, isPartial, nil
+}
+
+func discoverSmartListItems(params *smartListDiscoverParams) ([]SmartListItem, error) {
+	client := tmdb.GetSystemAPIClient()
+
+	// ratings with only a handful of votes are not meaningful
+	voteCountGte := 0
+	if params.ratingGte > 0 {
+		voteCountGte = 50
+	}
+
+	tmdbItems := []tmdb.TMDBItem{}
+	for _, mediaType := range params.mediaTypes {
+		// the local titles still cover the genres missing on tmdb
+		withGenres, ok := getSmartListTMDBGenreIds(mediaType, params.genreNames)
+		if !ok {
+			continue
+		}
+		for page := 1; page <= smartListDiscoverMaxPage; page++ {
+			totalPages := 0
+			switch mediaType {
+			case tmdb.MediaTypeMovie:
+				p := &tmdb.DiscoverMovieParams{
+					Page:           page,
+					SortBy:         "popularity.desc",
+					VoteAverageGte: params.ratingGte,
+					VoteAverageLte: params.ratingLte,
+					VoteCountGte:   voteCountGte,
+					WithGenres:     strings.Join(withGenres, ","),
+				}
+				if params.yearGte > 0 {
+					p.PrimaryReleaseDateGte = strconv.Itoa(params.yearGte) + "-01-01"
+				}
+				if params.yearLte > 0 {
+					p.PrimaryReleaseDateLte = strconv.Itoa(params.yearLte) + "-12-31"
+				}
+				res, err := client.DiscoverMovie(p)
+				if err != nil {
+					return nil, err
+				}
+				totalPages = res.Data.TotalPages
+				for i := range res.Data.Results {
+					movie := &res.Data.Results[i]
+					tmdbItems = append(tmdbItems, tmdb.TMDBItem{
+						Id:          movie.Id,
+						Type:        tmdb.MediaTypeMovie,
+						Title:       movie.Title,
+						Overview:    movie.Overview,
+						ReleaseDate: db.DateOnly{Time: movie.GetReleaseDate()},
+						Backdrop:    movie.BackdropPath,
+						Poster:      movie.PosterPath,
+						VoteAverage: movie.VoteAverage,
+						Genres:      movie.GenreIds,
+					})
+				}
+			case tmdb.MediaTypeTVShow:
+				p := &tmdb.DiscoverTVParams{
+					Page:           page,
+					SortBy:         "popularity.desc",
+					VoteAverageGte: params.ratingGte,
+					VoteAverageLte: params.ratingLte,
+					VoteCountGte:   voteCountGte,
+					WithGenres:     strings.Join(withGenres, ","),
+				}
+				if params.yearGte > 0 {
+					p.FirstAirDateGte = strconv.Itoa(params.yearGte) + "-01-01"
+				}
+				if params.yearLte > 0 {
+					p.FirstAirDateLte = strconv.Itoa(params.yearLte) + "-12-31"
+				}
+				res, err := client.DiscoverTV(p)
+				if err != nil {
+					return nil, err
+				}
+				totalPages = res.Data.TotalPages
+				for i := range res.Data.Results {
+					show := &res.Data.Results[i]
+					tmdbItems = append(tmdbItems, tmdb.TMDBItem{
+						Id:          show.Id,
+						Type:        tmdb.MediaTypeTVShow,
+						Title:       show.Name,
+						Overview:    show.Overview,
+						ReleaseDate: db.DateOnly{Time: show.GetFirstAirDate()},
+						Backdrop:    show.BackdropPath,
+						Poster:      show.PosterPath,
+						VoteAverage: show.VoteAverage,
+						Genres:      show.GenreIds,
+					})
+				}
+			}
+			if page >= totalPages {
+				break
+			}
+		}
+	}
+
+	tmdbMovieIds := []string{}
+	tmdbShowIds := []string{}
+	for i := range tmdbItems {
+		item := &tmdbItems[i]
+		switch item.Type {
+		case tmdb.MediaTypeMovie:
+			tmdbMovieIds = append(tmdbMovieIds, strconv.Itoa(item.Id))
+		case tmdb.MediaTypeTVShow:
+			tmdbShowIds = append(tmdbShowIds, strconv.Itoa(item.Id))
+		}
+	}
+
+	movieImdbIdByTmdbId, showImdbIdByTmdbId, err := getIMDBIdsForTMDBIds("", tmdbMovieIds, tmdbShowIds)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]SmartListItem, 0, len(tmdbItems))
+	for i := range tmdbItems {
+		titem := &tmdbItems[i]
+		item := SmartListItem{
+			Title:       titem.Title,
+			Description: titem.Overview,
+			Genres:      normalizeSmartListGenres(titem.GenreNames()),
+			Rating:      titem.VoteAverage,
+			Poster:      titem.PosterURL(tmdb.PosterSizeW500),
+			Background:  titem.BackdropURL(tmdb.BackdropSizeW1280),
+		}
+		if !titem.ReleaseDate.IsZero() {
+			item.Year = titem.ReleaseDate.Year()
+		}
+		switch titem.Type {
+		case tmdb.MediaTypeMovie:
+			item.Type = stremio.ContentTypeMovie
+			item.IMDBId = movieImdbIdByTmdbId[strconv.Itoa(titem.Id)]
+		case tmdb.MediaTypeTVShow:
+			item.Type = stremio.ContentTypeSeries
+			item.IMDBId = showImdbIdByTmdbId[strconv.Itoa(titem.Id)]
+		}
+		if item.IMDBId == "" {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,176 @@
+package stremio_list
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/internal/imdb_title"
+	"github.com/MunifTanjim/stremthru/internal/tmdb"
+	"github.com/MunifTanjim/stremthru/stremio"
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileSmartListExpr(t *testing.T) {
+	env := SmartListEnv{
+		Title:  "Talk to Me",
+		Year:   2022,
+		Type:   "movie",
+		Genre:  []string{"Horror", "Thriller"},
+		Rating: 7.1,
+	}
+
+	for _, tc := range []struct {
+		query    string
+		expected bool
+	}{
+		{`Genre contains "Horror" && Year >= 2020 && Rating > 7`, true},
+		{`"Horror" in Genre && Type == "movie"`, true},
+		{`Genre contains "Comedy"`, false},
+		{`Year < 2020 || Rating > 8`, false},
+		{`Title startsWith "Talk"`, true},
+		{`Genre contains "Sci-Fi"`, false},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			program, err := compileSmartListExpr(tc.query)
+			assert.NoError(t, err)
+			output, err := expr.Run(program, env)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+
+	_, err := compileSmartListExpr(`Year + 1`)
+	assert.Error(t, err)
+
+	program, err := compileSmartListExpr(`Genre contains "Sci-Fi" || "Reality-TV" in Genre`)
+	assert.NoError(t, err)
+	output, err := expr.Run(program, SmartListEnv{Genre: []string{"Science Fiction"}})
+	assert.NoError(t, err)
+	assert.Equal(t, true, output)
+}
+
+func TestParseSmartListDiscoverParams(t *testing.T) {
+	params, err := parseSmartListDiscoverParams(`Genre contains "Horror" && Year > 2019 && Year <= 2024 && Rating >= 7.5 && Type == "series"`)
+	assert.NoError(t, err)
+	assert.Equal(t, []tmdb.MediaType{tmdb.MediaTypeTVShow}, params.mediaTypes)
+	assert.Equal(t, []string{"Horror"}, params.genreNames)
+	assert.Equal(t, 2020, params.yearGte)
+	assert.Equal(t, 2024, params.yearLte)
+	assert.Equal(t, 7.5, params.ratingGte)
+
+	params, err = parseSmartListDiscoverParams(`Rating > 7 || Year > 2020`)
+	assert.NoError(t, err)
+	assert.Equal(t, []tmdb.MediaType{tmdb.MediaTypeMovie, tmdb.MediaTypeTVShow}, params.mediaTypes)
+	assert.Empty(t, params.genreNames)
+	assert.Zero(t, params.yearGte)
+	assert.Zero(t, params.ratingGte)
+
+	params, err = parseSmartListDiscoverParams(`"Sci-Fi" in Genre && Genre contains "Kids"`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Science Fiction", "Children"}, params.genreNames)
+}
+
+func TestSmartListId(t *testing.T) {
+	query := `Genre contains "Horror" && Year >= 2020`
+	id := encodeSmartListId(query)
+	assert.NotContains(t, id, ".")
+	decoded, err := decodeSmartListId(id)
+	assert.NoError(t, err)
+	assert.Equal(t, query, decoded)
+}
+
+func TestGetSmartListTitlesParams(t *testing.T) {
+	params, err := parseSmartListDiscoverParams(`Genre contains "Horror" && "Sci-Fi" in Genre && Year > 2019 && Rating >= 7.25 && Rating < 9 && Type == "series"`)
+	assert.NoError(t, err)
+	assert.Equal(t, &imdb_title.ListTitlesWithMetaParams{
+		Type:      imdb_title.IMDBTitleSimpleTypeShow,
+		Genres:    [][]string{{"Horror"}, {"Science Fiction", "Sci-Fi"}},
+		YearGte:   2020,
+		RatingGte: 72,
+		RatingLte: 90,
+		Limit:     smartListMaxItems,
+	}, getSmartListTitlesParams(params))
+
+	params, err = parseSmartListDiscoverParams(`Title startsWith "Talk"`)
+	assert.NoError(t, err)
+	assert.Equal(t, &imdb_title.ListTitlesWithMetaParams{
+		Limit: smartListMaxItems,
+	}, getSmartListTitlesParams(params))
+
+	params, err = parseSmartListDiscoverParams(`Type == "channel"`)
+	assert.NoError(t, err)
+	assert.Nil(t, getSmartListTitlesParams(params))
+}
+
+func TestWithSmartListGenre(t *testing.T) {
+	query := withSmartListGenre(`Year >= 2020 || Rating > 8`, `Sci-Fi "Classic"`)
+
+	params, err := parseSmartListDiscoverParams(query)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`Sci-Fi "Classic"`}, params.genreNames)
+	assert.Zero(t, params.yearGte)
+
+	program, err := compileSmartListExpr(query)
+	assert.NoError(t, err)
+	output, err := expr.Run(program, SmartListEnv{Year: 2021, Genre: []string{`Sci-Fi "Classic"`}})
+	assert.NoError(t, err)
+	assert.Equal(t, true, output)
+	output, err = expr.Run(program, SmartListEnv{Year: 2021, Genre: []string{"Drama"}})
+	assert.NoError(t, err)
+	assert.Equal(t, false, output)
+}
+
+func TestToSmartListItem(t *testing.T) {
+	title := &imdb_title.IMDBTitleWithMeta{
+		IMDBTitle: imdb_title.IMDBTitle{
+			TId:   "tt0944947",
+			Title: "Game of Thrones",
+			Year:  2011,
+			Type:  string(imdb_title.IMDBTitleTypeTvSeries),
+		},
+		Meta: imdb_title.IMDBTitleMeta{
+			Rating: 92,
+			Genres: []string{"Drama", "Fantasy"},
+		},
+	}
+	item := toSmartListItem(title)
+	assert.Equal(t, stremio.ContentTypeSeries, item.Type)
+	assert.Equal(t, 9.2, item.Rating)
+	assert.Equal(t, []string{"Drama", "Fantasy"}, item.Genres)
+
+	title.Type = string(imdb_title.IMDBTitleTypeTvEpisode)
+	assert.Nil(t, toSmartListItem(title))
+}
+
+func TestNormalizeSmartListGenres(t *testing.T) {
+	assert.Equal(t,
+		[]string{"Drama", "Science Fiction", "Fantasy", "Action", "Adventure"},
+		normalizeSmartListGenres([]string{"Drama", "Sci-Fi & Fantasy", "Action & Adventure", "Sci-Fi"}),
+	)
+	assert.Equal(t,
+		[]string{"Reality TV", "Talk Show", "Children"},
+		normalizeSmartListGenres([]string{"Reality-TV", "Reality", "Talk", "Kids"}),
+	)
+	assert.NotContains(t, smartListGenreOptions, "Sci-Fi")
+	assert.Contains(t, smartListGenreOptions, "Science Fiction")
+}
+
+func TestGetSmartListTMDBGenreIds(t *testing.T) {
+	for _, tc := range []struct {
+		mediaType tmdb.MediaType
+		genres    []string
+		ids       []string
+		ok        bool
+	}{
+		{tmdb.MediaTypeMovie, []string{"Action", "Science Fiction"}, []string{"28", "878"}, true},
+		{tmdb.MediaTypeTVShow, []string{"Action", "Science Fiction"}, []string{"10759", "10765"}, true},
+		{tmdb.MediaTypeTVShow, []string{"Children"}, []string{"10762"}, true},
+		{tmdb.MediaTypeMovie, []string{"Children"}, nil, false},
+		{tmdb.MediaTypeMovie, []string{"Biography"}, nil, false},
+		{tmdb.MediaTypeMovie, nil, []string{}, true},
+	} {
+		ids, ok := getSmartListTMDBGenreIds(tc.mediaType, tc.genres)
+		assert.Equal(t, tc.ok, ok)
+		assert.Equal(t, tc.ids, ids)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/MunifTanjim/stremthru/internal/anilist"
 	"github.com/MunifTanjim/stremthru/internal/config"
@@ -66,6 +67,7 @@ type Base = stremio_template.BaseData
 
 type TemplateDataList struct {
 	Id      string
+	IsSmart bool
 	URL     string
 	Name    string
 	Type    string
@@ -335,6 +337,14 @@ func getTemplateData(ud *UserData, udError userDataError, isAuthed bool, r *http
 						list.URL = l.GetURL()
 					}
 
+				case "smart":
+					if query, err := decodeSmartListId(id); err != nil {
+						list.Error.URL = "Failed to Parse List ID: " + listId
+					} else {
+						l := SmartList{Id: id, Query: query}
+						list.URL = l.GetURL()
+					}
+
 				case "tmdb":
 					if td.TMDBTokenId.Error == "" {
 						l := tmdb.TMDBList{Id: id}
@@ -377,6 +387,7 @@ func getTemplateData(ud *UserData, udError userDataError, isAuthed bool, r *http
 		if list.URL == "" && list.Error.URL == "" {
 			list.Error.URL = "Missing List URL"
 		}
+		list.IsSmart = strings.HasPrefix(list.URL, "smart:")
 		td.Lists = append(td.Lists, list)
 	}
 
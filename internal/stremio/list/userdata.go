@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/MunifTanjim/stremthru/internal/anilist"
-	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/letterboxd"
 	"github.com/MunifTanjim/stremthru/internal/mdblist"
 	"github.com/MunifTanjim/stremthru/internal/oauth"
@@ -54,6 +53,7 @@ type UserData struct {
 	tmdbById       map[string]tmdb.TMDBList             `json:"-"`
 	tvdbById       map[string]tvdb.TVDBList             `json:"-"`
 	letterboxdById map[string]letterboxd.LetterboxdList `json:"-"`
+	smartById      map[string]SmartList                 `json:"-"`
 }
 
 func (ud UserData) StripSecrets() UserData {
@@ -210,7 +210,7 @@ func getUserData(r *http.Request, isAuthed bool) (*UserData, error) {
 		}
 
 		if ud.TopPostersAPIKey != "" && udErr.top_posters_api_key == "" {
-			if err := verifyTopPostersAPIKey(ud.TopPostersAPIKey); err != nil {
+			if err := stremio_shared.VerifyTopPostersAPIKey(ud.TopPostersAPIKey); err != nil {
 				udErr.top_posters_api_key = "Failed to Verify: " + err.Error()
 			}
 		}
@@ -265,6 +265,20 @@ func getUserData(r *http.Request, isAuthed bool) (*UserData, error) {
 				continue
 			}
 
+			if query, ok := strings.CutPrefix(listUrlStr, "smart:"); ok {
+				query = strings.TrimSpace(query)
+				if query == "" {
+					udErr.list_urls[idx] = "Missing Smart List Query"
+					continue
+				}
+				if _, err := compileSmartListExpr(query); err != nil {
+					udErr.list_urls[idx] = "Invalid Smart List Query: " + err.Error()
+					continue
+				}
+				ud.Lists[idx] = "smart:" + encodeSmartListId(query)
+				continue
+			}
+
 			listUrl, err := url.Parse(listUrlStr)
 			if err != nil {
 				udErr.list_urls[idx] = "Invalid List URL: " + err.Error()
@@ -627,26 +641,10 @@ func (ud *UserData) getTraktToken() (*oauth.OAuthToken, error) {
 		return ud.traktToken, nil
 	}
 
-	otok, err := oauth.GetOAuthTokenById(ud.TraktTokenId)
+	otok, err := trakt.GetOAuthToken(ud.TraktTokenId)
 	if err != nil {
 		ud.TraktTokenId = ""
-		return nil, errors.New("failed to retrieve token: " + err.Error())
-	} else if otok != nil && otok.IsExpired() {
-		traktClient := trakt.GetAPIClient(otok.Id)
-		settings, err := traktClient.RetrieveSettings(&trakt.RetrieveSettingsParams{})
-		if err != nil || settings.Data.User.Ids.Slug != otok.UserId {
-			otok.AccessToken = ""
-			otok.RefreshToken = ""
-			err = oauth.SaveOAuthToken(otok)
-			if err != nil {
-				log.Error("failed to delete trakt token", "error", err, "id", otok.Id)
-			}
-			otok = nil
-		}
-	}
-	if otok == nil {
-		ud.TraktTokenId = ""
-		return nil, errors.New("Invalid or Revoked")
+		return nil, err
 	}
 
 	ud.traktToken = otok
@@ -706,18 +704,6 @@ func (ud *UserData) FetchLetterboxdList(list *letterboxd.LetterboxdList) error {
 	return nil
 }
 
-func verifyTopPostersAPIKey(apiKey string) error {
-	resp, err := config.DefaultHTTPClient.Get("https://api.top-streaming.stream/auth/verify/" + apiKey)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("Invalid API key")
-	}
-	return nil
-}
-
 func (ud *UserData) getMDBListUser() (*mdblist.GetMyLimitsData, error) {
 	if ud.mdblistUser == nil {
 		userParams := mdblist.GetMyLimitsParams{}
@@ -847,3 +833,21 @@ func (ud *UserData) FetchTVDBList(list *tvdb.TVDBList) error {
 	ud.tvdbById[list.Id] = *list
 	return nil
 }
+
+func (ud *UserData) FetchSmartList(list *SmartList) error {
+	if ud.smartById == nil {
+		ud.smartById = map[string]SmartList{}
+	}
+	if list.Id != "" {
+		if l, ok := ud.smartById[list.Id]; ok {
+			*list = l
+			return nil
+		}
+	}
+	if err := list.Fetch(); err != nil {
+		return err
+	}
+
+	ud.smartById[list.Id] = *list
+	return nil
+}
@@ -0,0 +1,88 @@
+package stremio_meta
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/internal/stremio/configure"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+)
+
+func getManifestURL(r *http.Request, eud string) string {
+	if eud == "" {
+		return ExtractRequestBaseURL(r).JoinPath("/stremio/meta/manifest.json").String()
+	}
+	return ExtractRequestBaseURL(r).JoinPath("/stremio/meta/" + eud + "/manifest.json").String()
+}
+
+func handleConfigure(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) && !IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	td := getTemplateData(ud)
+
+	if IsMethod(r, http.MethodGet) {
+		td.ManifestURL = getManifestURL(r, ud.encoded)
+
+		page, err := getPage(td)
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+		SendHTML(w, 200, page)
+		return
+	}
+
+	var rpdb_config *configure.Config
+	var top_posters_config *configure.Config
+	for i := range td.Configs {
+		conf := &td.Configs[i]
+		switch conf.Key {
+		case "rpdb_api_key":
+			rpdb_config = conf
+		case "top_posters_api_key":
+			top_posters_config = conf
+		}
+	}
+
+	if ud.RPDBAPIKey != "" && ud.TopPostersAPIKey != "" {
+		err := "Only one poster provider can be used at a time"
+		rpdb_config.Error = err
+		top_posters_config.Error = err
+	} else if ud.TopPostersAPIKey != "" {
+		if err := stremio_shared.VerifyTopPostersAPIKey(ud.TopPostersAPIKey); err != nil {
+			top_posters_config.Error = "Failed to Verify: " + err.Error()
+		}
+	}
+
+	if td.HasError() {
+		page, err := getPage(td)
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+		SendHTML(w, 200, page)
+		return
+	}
+
+	eud, err := ud.GetEncoded()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	url := ExtractRequestBaseURL(r).JoinPath("/stremio/meta/" + eud + "/configure")
+	q := url.Query()
+	q.Set("try_install", "1")
+	url.RawQuery = q.Encode()
+
+	http.Redirect(w, r, url.String(), http.StatusFound)
+}
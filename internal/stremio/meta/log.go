@@ -0,0 +1,10 @@
+package stremio_meta
+
+import (
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+)
+
+var log = logger.Scoped("stremio/meta")
+
+var LogError = stremio_shared.LogError
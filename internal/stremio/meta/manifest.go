@@ -0,0 +1,57 @@
+package stremio_meta
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+var AnimeEnabled = config.Feature.IsEnabled(config.FeatureAnime)
+
+func GetManifest(r *http.Request, ud *UserData) *stremio.Manifest {
+	idPrefixes := []string{"tt", "tmdb:", "tvdb:"}
+	types := []stremio.ContentType{stremio.ContentTypeMovie, stremio.ContentTypeSeries}
+	if AnimeEnabled {
+		idPrefixes = append(idPrefixes, "anidb:", "anilist:", "kitsu:", "mal:")
+		types = append(types, stremio.ContentTypeAnime)
+	}
+
+	manifest := &stremio.Manifest{
+		ID:          shared.GetReversedHostname(r) + ".meta",
+		Name:        "StremThru Meta",
+		Description: "Metadata for Movies, Series and Anime, from the datasets of StremThru.",
+		Version:     config.Version,
+		Logo:        "https://emojiapi.dev/api/v1/sparkles/256.png",
+		Resources: []stremio.Resource{
+			{
+				Name:       stremio.ResourceNameMeta,
+				Types:      types,
+				IDPrefixes: idPrefixes,
+			},
+		},
+		Types:    types,
+		Catalogs: []stremio.Catalog{},
+		BehaviorHints: &stremio.BehaviorHints{
+			Configurable: true,
+		},
+	}
+
+	return manifest
+}
+
+func handleManifest(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendResponse(w, r, 200, GetManifest(r, ud))
+}
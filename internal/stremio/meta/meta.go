@@ -0,0 +1,693 @@
+package stremio_meta
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/anidb"
+	"github.com/MunifTanjim/stremthru/internal/anilist"
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/imdb_title"
+	"github.com/MunifTanjim/stremthru/internal/meta"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+	"github.com/MunifTanjim/stremthru/internal/tmdb"
+	"github.com/MunifTanjim/stremthru/internal/tvdb"
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/stremio"
+	"golang.org/x/sync/singleflight"
+)
+
+var TMDBEnabled = config.Integration.TMDB.IsEnabled()
+var TVDBEnabled = config.Integration.TVDB.IsEnabled()
+
+var metaCache = cache.NewCache[stremio.Meta](&cache.CacheConfig{
+	Lifetime: 6 * time.Hour,
+	Name:     "stremio:meta:meta",
+})
+var getMetaGroup singleflight.Group
+
+type metaBuilder struct {
+	m          stremio.Meta
+	idProvider meta.IdProvider
+	idMap      *meta.IdMap
+	isSeries   bool
+	isPartial  bool
+}
+
+func setIfEmpty(dst *string, value string) {
+	if *dst == "" {
+		*dst = value
+	}
+}
+
+func toRuntime(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+	return strconv.Itoa(minutes) + " min"
+}
+
+func toReleased(date string) *time.Time {
+	if date == "" {
+		return nil
+	}
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func toTrailer(trailerUrl string) *stremio.MetaTrailer {
+	if trailerUrl == "" {
+		return nil
+	}
+	trailer, err := url.Parse(trailerUrl)
+	if err != nil || !strings.HasSuffix(trailer.Host, "youtube.com") {
+		return nil
+	}
+	source := trailer.Query().Get("v")
+	if source == "" {
+		return nil
+	}
+	return &stremio.MetaTrailer{
+		Source: source,
+		Type:   stremio.MetaTrailerTypeTrailer,
+	}
+}
+
+func (b *metaBuilder) setTrailer(trailerUrl string) {
+	if len(b.m.Trailers) > 0 {
+		return
+	}
+	if trailer := toTrailer(trailerUrl); trailer != nil {
+		b.m.Trailers = append(b.m.Trailers, *trailer)
+	}
+}
+
+func (b *metaBuilder) setGenres(genres []string) {
+	if len(b.m.Genres) == 0 && len(genres) > 0 {
+		b.m.Genres = genres
+	}
+}
+
+func (b *metaBuilder) setYear(year int) {
+	if year > 0 {
+		setIfEmpty(&b.m.ReleaseInfo, strconv.Itoa(year))
+	}
+}
+
+func (b *metaBuilder) isAnime() bool {
+	return b.idProvider.IsAnime()
+}
+
+func (b *metaBuilder) getVideoId(season, episode int) string {
+	if b.isAnime() {
+		return b.m.Id + ":" + strconv.Itoa(episode)
+	}
+	return b.m.Id + ":" + strconv.Itoa(season) + ":" + strconv.Itoa(episode)
+}
+
+func (b *metaBuilder) hasGaps() bool {
+	return b.m.Name == "" || b.m.Description == "" || b.m.Poster == ""
+}
+
+func (b *metaBuilder) needsVideos() bool {
+	return b.isSeries && len(b.m.Videos) == 0
+}
+
+func (b *metaBuilder) applyAniListMedia(media *anilist.AniListMedia) {
+	setIfEmpty(&b.m.Name, media.Title)
+	setIfEmpty(&b.m.Description, media.Description)
+	setIfEmpty(&b.m.Poster, media.Cover)
+	setIfEmpty(&b.m.Background, media.Banner)
+	setIfEmpty(&b.m.Runtime, toRuntime(media.Duration))
+	b.setGenres(media.Genres)
+	b.setYear(media.StartYear)
+}
+
+func (b *metaBuilder) fromAniListItem(anilistId string) error {
+	media, err := anilist.GetMediaById(util.SafeParseInt(anilistId, 0))
+	if err != nil || media == nil {
+		return err
+	}
+	b.applyAniListMedia(media)
+	return nil
+}
+
+func (b *metaBuilder) fromAniList(anilistId string) error {
+	media, err := anilist.GetMedia(util.SafeParseInt(anilistId, 0))
+	if err != nil || media == nil {
+		return err
+	}
+	b.applyAniListMedia(media)
+	return nil
+}
+
+func (b *metaBuilder) applyTVDBItem(item *tvdb.TVDBItem) {
+	setIfEmpty(&b.m.Name, item.Name)
+	setIfEmpty(&b.m.Description, item.Overview)
+	setIfEmpty(&b.m.Poster, item.Poster)
+	setIfEmpty(&b.m.Background, item.Background)
+	setIfEmpty(&b.m.Runtime, toRuntime(item.Runtime))
+	b.setTrailer(item.Trailer)
+	b.setGenres(item.GenreNames())
+	b.setYear(item.Year)
+}
+
+func (b *metaBuilder) fromTVDBItem(tvdbId string) error {
+	itemType := tvdb.TVDBItemTypeMovie
+	if b.isSeries {
+		itemType = tvdb.TVDBItemTypeSeries
+	}
+	id := util.SafeParseInt(tvdbId, 0)
+	itemById, err := tvdb.GetItemsById(itemType, id)
+	if err != nil {
+		return err
+	}
+	if item, ok := itemById[id]; ok {
+		b.applyTVDBItem(item)
+	}
+	return nil
+}
+
+func (b *metaBuilder) fromTVDBMovie(tvdbId string) error {
+	item := tvdb.TVDBItem{
+		Id:   util.SafeParseInt(tvdbId, 0),
+		Type: tvdb.TVDBItemTypeMovie,
+	}
+	if err := item.Fetch(tvdb.GetAPIClient(), false); err != nil {
+		return err
+	}
+	b.applyTVDBItem(&item)
+	return nil
+}
+
+func (b *metaBuilder) addTVDBEpisodes(episodes []tvdb.Episode, episodeMaps anidb.AniDBTVDBEpisodeMaps) {
+	for i := range episodes {
+		ep := &episodes[i]
+		released := toReleased(ep.Aired)
+		if released == nil {
+			continue
+		}
+		video := stremio.MetaVideo{
+			Title:     ep.Name,
+			Released:  *released,
+			Thumbnail: ep.Image,
+			Overview:  ep.Overview,
+			Season:    stremio.ZeroIndexedInt(ep.SeasonNumber),
+			Episode:   stremio.ZeroIndexedInt(ep.Number),
+			TVDBId:    ep.Id,
+		}
+		if strings.HasPrefix(video.Thumbnail, "/") {
+			video.Thumbnail = tvdb.ArtworkBaseURL + video.Thumbnail
+		}
+		if b.isAnime() {
+			anidbId, anidbEpisode := episodeMaps.GetAniDBEpisode(ep.SeasonNumber, ep.Number)
+			if anidbId != b.idMap.Anime.AniDB || anidbEpisode < 1 {
+				continue
+			}
+			video.Season = 1
+			video.Episode = stremio.ZeroIndexedInt(anidbEpisode)
+		}
+		video.Id = b.getVideoId(int(video.Season), int(video.Episode))
+		b.m.Videos = append(b.m.Videos, video)
+	}
+}
+
+func (b *metaBuilder) fromTVDBSeries(tvdbId string) error {
+	res, err := tvdb.GetAPIClient().FetchSeries(&tvdb.FetchSeriesParams{
+		Id: util.SafeParseInt(tvdbId, 0),
+	})
+	if err != nil {
+		return err
+	}
+	series := &res.Data
+
+	setIfEmpty(&b.m.Name, series.Name)
+	setIfEmpty(&b.m.Description, series.Translations.GetOverview())
+	setIfEmpty(&b.m.Description, series.Overview)
+	setIfEmpty(&b.m.Poster, series.GetPoster())
+	setIfEmpty(&b.m.Background, series.GetBackground())
+	setIfEmpty(&b.m.Logo, series.GetClearLogo())
+	setIfEmpty(&b.m.Runtime, toRuntime(series.AverageRuntime))
+	setIfEmpty(&b.m.Status, series.Status.Name)
+	b.setTrailer(series.GetTrailer())
+	genres := make([]string, len(series.Genres))
+	for i := range series.Genres {
+		genres[i] = series.Genres[i].Name
+	}
+	b.setGenres(genres)
+	if series.Year != "" {
+		releaseInfo := series.Year + "–"
+		if series.Status.Id == tvdb.SeriesStatusEnded && len(series.LastAired) >= 4 && series.LastAired[:4] != series.Year {
+			releaseInfo += series.LastAired[:4]
+		}
+		// the local datasets only know the start year
+		if b.m.ReleaseInfo == "" || b.m.ReleaseInfo == series.Year {
+			b.m.ReleaseInfo = releaseInfo
+		}
+	}
+	if b.m.Released == nil {
+		b.m.Released = toReleased(series.FirstAired)
+	}
+
+	if !b.needsVideos() {
+		return nil
+	}
+
+	var episodeMaps anidb.AniDBTVDBEpisodeMaps
+	if b.isAnime() {
+		if b.idMap.Anime == nil || !isValidId(b.idMap.Anime.AniDB) {
+			return nil
+		}
+		episodeMaps, err = anidb.GetTVDBEpisodeMapsByTVDBId(tvdbId)
+		if err != nil {
+			return err
+		}
+		if len(episodeMaps) == 0 {
+			return nil
+		}
+	}
+
+	b.addTVDBEpisodes(series.Episodes, episodeMaps)
+	return nil
+}
+
+func (b *metaBuilder) applyTMDBItem(item *tmdb.TMDBItem) {
+	setIfEmpty(&b.m.Name, item.Title)
+	setIfEmpty(&b.m.Description, item.Overview)
+	if item.Poster != "" {
+		setIfEmpty(&b.m.Poster, item.PosterURL(tmdb.PosterSizeW500))
+	}
+	if item.Backdrop != "" {
+		setIfEmpty(&b.m.Background, item.BackdropURL(tmdb.BackdropSizeW1280))
+	}
+	genres := make([]string, 0, len(item.Genres))
+	for _, genre := range item.GenreNames() {
+		if genre != "" {
+			genres = append(genres, genre)
+		}
+	}
+	b.setGenres(genres)
+	if !item.ReleaseDate.IsZero() {
+		if b.m.Released == nil {
+			released := item.ReleaseDate.Time
+			b.m.Released = &released
+		}
+		b.setYear(item.ReleaseDate.Year())
+	}
+	if b.m.MovieDBId == 0 {
+		b.m.MovieDBId = item.Id
+	}
+}
+
+func (b *metaBuilder) fromTMDBItem(tmdbId string) error {
+	itemType := tmdb.MediaTypeMovie
+	if b.isSeries {
+		itemType = tmdb.MediaTypeTVShow
+	}
+	id := util.SafeParseInt(tmdbId, 0)
+	itemById, err := tmdb.GetItemsById(itemType, id)
+	if err != nil {
+		return err
+	}
+	if item, ok := itemById[id]; ok {
+		b.applyTMDBItem(item)
+	}
+	return nil
+}
+
+func (b *metaBuilder) addTMDBEpisodes(episodes []tmdb.TVEpisode) {
+	for i := range episodes {
+		ep := &episodes[i]
+		released := toReleased(ep.AirDate)
+		if released == nil {
+			continue
+		}
+		video := stremio.MetaVideo{
+			Id:        b.getVideoId(ep.SeasonNumber, ep.EpisodeNumber),
+			Title:     ep.Name,
+			Released:  *released,
+			Overview:  ep.Overview,
+			Season:    stremio.ZeroIndexedInt(ep.SeasonNumber),
+			Episode:   stremio.ZeroIndexedInt(ep.EpisodeNumber),
+			MovieDBId: ep.Id,
+		}
+		if ep.StillPath != "" {
+			video.Thumbnail = tmdb.IMAGE_BASE_URL + string(tmdb.StillSizeW300) + ep.StillPath
+		}
+		b.m.Videos = append(b.m.Videos, video)
+	}
+}
+
+func (b *metaBuilder) fromTMDB(tmdbId string) error {
+	client := tmdb.GetSystemAPIClient()
+
+	if !b.isSeries {
+		res, err := client.GetMovieDetails(&tmdb.GetMovieDetailsParams{
+			MovieId: tmdbId,
+		})
+		if err != nil {
+			return err
+		}
+		movie := &res.Data
+		setIfEmpty(&b.m.Name, movie.Title)
+		setIfEmpty(&b.m.Description, movie.Overview)
+		if movie.PosterPath != "" {
+			setIfEmpty(&b.m.Poster, tmdb.IMAGE_BASE_URL+string(tmdb.PosterSizeW500)+movie.PosterPath)
+		}
+		if movie.BackdropPath != "" {
+			setIfEmpty(&b.m.Background, tmdb.IMAGE_BASE_URL+string(tmdb.BackdropSizeW1280)+movie.BackdropPath)
+		}
+		setIfEmpty(&b.m.Runtime, toRuntime(movie.Runtime))
+		genres := make([]string, len(movie.Genres))
+		for i := range movie.Genres {
+			genres[i] = movie.Genres[i].Name
+		}
+		b.setGenres(genres)
+		if b.m.Released == nil {
+			b.m.Released = toReleased(movie.ReleaseDate)
+		}
+		if b.m.ReleaseInfo == "" && len(movie.ReleaseDate) >= 4 {
+			b.m.ReleaseInfo = movie.ReleaseDate[:4]
+		}
+		if b.m.MovieDBId == 0 {
+			b.m.MovieDBId = movie.Id
+		}
+		return nil
+	}
+
+	res, err := client.GetTVDetails(&tmdb.GetTVDetailsParams{
+		SeriesId: tmdbId,
+	})
+	if err != nil {
+		return err
+	}
+	series := &res.Data
+	setIfEmpty(&b.m.Name, series.Name)
+	setIfEmpty(&b.m.Description, series.Overview)
+	if series.PosterPath != "" {
+		setIfEmpty(&b.m.Poster, tmdb.IMAGE_BASE_URL+string(tmdb.PosterSizeW500)+series.PosterPath)
+	}
+	if series.BackdropPath != "" {
+		setIfEmpty(&b.m.Background, tmdb.IMAGE_BASE_URL+string(tmdb.BackdropSizeW1280)+series.BackdropPath)
+	}
+	if len(series.EpisodeRunTime) > 0 {
+		setIfEmpty(&b.m.Runtime, toRuntime(series.EpisodeRunTime[0]))
+	}
+	setIfEmpty(&b.m.Status, series.Status)
+	genres := make([]string, len(series.Genres))
+	for i := range series.Genres {
+		genres[i] = series.Genres[i].Name
+	}
+	b.setGenres(genres)
+	if b.m.Released == nil {
+		b.m.Released = toReleased(series.FirstAirDate)
+	}
+	if len(series.FirstAirDate) >= 4 && (b.m.ReleaseInfo == "" || b.m.ReleaseInfo == series.FirstAirDate[:4]) {
+		b.m.ReleaseInfo = series.FirstAirDate[:4] + "–"
+	}
+	if b.m.MovieDBId == 0 {
+		b.m.MovieDBId = series.Id
+	}
+
+	// anime episodes can not be mapped without tvdb
+	if !b.needsVideos() || b.isAnime() {
+		return nil
+	}
+
+	seasonNumbers := []int{}
+	for _, season := range series.Seasons {
+		if season.EpisodeCount > 0 {
+			seasonNumbers = append(seasonNumbers, season.SeasonNumber)
+		}
+	}
+	// the failed seasons are skipped, the rest are still usable
+	var seasonsErr error
+	for seasons := range slices.Chunk(seasonNumbers, tmdb.MaxAppendToResponse) {
+		res, err := client.GetTVDetails(&tmdb.GetTVDetailsParams{
+			SeriesId:      tmdbId,
+			AppendSeasons: seasons,
+		})
+		if err != nil {
+			seasonsErr = errors.Join(seasonsErr, err)
+			continue
+		}
+		for _, seasonNumber := range seasons {
+			if season, ok := res.Data.SeasonDetails[seasonNumber]; ok {
+				b.addTMDBEpisodes(season.Episodes)
+			}
+		}
+	}
+	return seasonsErr
+}
+
+func (b *metaBuilder) applyIMDBTitle(title *imdb_title.IMDBTitle, titleMeta *imdb_title.IMDBTitleMeta) {
+	setIfEmpty(&b.m.Name, title.Title)
+	b.setYear(title.Year)
+	if titleMeta == nil {
+		return
+	}
+	setIfEmpty(&b.m.Description, titleMeta.Description)
+	setIfEmpty(&b.m.Poster, titleMeta.Poster)
+	setIfEmpty(&b.m.Background, titleMeta.Backdrop)
+	setIfEmpty(&b.m.Runtime, toRuntime(titleMeta.Runtime))
+	b.setTrailer(titleMeta.Trailer)
+	b.setGenres(titleMeta.Genres)
+}
+
+func (b *metaBuilder) fromIMDB(imdbId string) error {
+	title, err := imdb_title.Get(imdbId)
+	if err != nil || title == nil {
+		return err
+	}
+	metas, err := imdb_title.GetMetasByIds([]string{imdbId})
+	if err != nil {
+		return err
+	}
+	var titleMeta *imdb_title.IMDBTitleMeta
+	if len(metas) > 0 {
+		titleMeta = &metas[0]
+	}
+	b.applyIMDBTitle(title, titleMeta)
+	return nil
+}
+
+// buildMeta returns partial meta, without error, if any of the sources failed.
+//
+// The local datasets are used first, TVDB, TMDB and AniList are only asked
+// for the missing fields and the episodes.
+func buildMeta(sType stremio.ContentType, id string) (m *stremio.Meta, isPartial bool, err error) {
+	idProvider, rawId := meta.ParseId(id)
+	if idProvider == "" {
+		return nil, false, nil
+	}
+
+	idType := meta.IdTypeUnknown
+	switch sType {
+	case stremio.ContentTypeMovie:
+		idType = meta.IdTypeMovie
+	case stremio.ContentTypeSeries:
+		idType = meta.IdTypeShow
+	}
+
+	idMap, err := meta.GetIdMap(idType, id)
+	if err != nil {
+		if errors.Is(err, meta.ErrorUnsupportedId) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	switch idProvider {
+	case meta.IdProviderTMDB:
+		idMap.TMDB = rawId
+	case meta.IdProviderTVDB:
+		idMap.TVDB = rawId
+	}
+	if !strings.HasPrefix(idMap.IMDB, "tt") {
+		idMap.IMDB = ""
+	}
+
+	b := metaBuilder{
+		m: stremio.Meta{
+			Id:     id,
+			Type:   sType,
+			IMDBId: idMap.IMDB,
+		},
+		idProvider: idProvider,
+		idMap:      idMap,
+	}
+	switch sType {
+	case stremio.ContentTypeMovie:
+		b.isSeries = false
+	case stremio.ContentTypeSeries:
+		b.isSeries = true
+	default:
+		b.isSeries = idMap.Type != meta.IdTypeMovie
+	}
+
+	hasAniList := b.isAnime() && idMap.Anime != nil && isValidId(idMap.Anime.AniList)
+
+	if hasAniList {
+		if err := b.fromAniListItem(idMap.Anime.AniList); err != nil {
+			log.Error("failed to get meta from local anilist", "error", err, "id", id)
+			b.isPartial = true
+		}
+	}
+	if isValidId(idMap.TVDB) {
+		if err := b.fromTVDBItem(idMap.TVDB); err != nil {
+			log.Error("failed to get meta from local tvdb", "error", err, "id", id)
+			b.isPartial = true
+		}
+	}
+	if isValidId(idMap.TMDB) {
+		if err := b.fromTMDBItem(idMap.TMDB); err != nil {
+			log.Error("failed to get meta from local tmdb", "error", err, "id", id)
+			b.isPartial = true
+		}
+	}
+	if idMap.IMDB != "" {
+		if err := b.fromIMDB(idMap.IMDB); err != nil {
+			log.Error("failed to get meta from imdb", "error", err, "id", id)
+			b.isPartial = true
+		}
+	}
+
+	if hasAniList && b.hasGaps() {
+		if err := b.fromAniList(idMap.Anime.AniList); err != nil {
+			log.Error("failed to get meta from anilist", "error", err, "id", id)
+			b.isPartial = true
+		}
+	}
+	if TVDBEnabled && isValidId(idMap.TVDB) && (b.hasGaps() || b.needsVideos()) {
+		if b.isSeries {
+			err = b.fromTVDBSeries(idMap.TVDB)
+		} else {
+			err = b.fromTVDBMovie(idMap.TVDB)
+		}
+		if err != nil {
+			log.Error("failed to get meta from tvdb", "error", err, "id", id)
+			b.isPartial = true
+		}
+	}
+	if TMDBEnabled && isValidId(idMap.TMDB) && (b.hasGaps() || (b.needsVideos() && !b.isAnime())) {
+		if err := b.fromTMDB(idMap.TMDB); err != nil {
+			log.Error("failed to get meta from tmdb", "error", err, "id", id)
+			b.isPartial = true
+		}
+	}
+
+	if idMap.IMDB != "" {
+		setIfEmpty(&b.m.Poster, stremio_shared.GetCinemetaPosterURL(idMap.IMDB))
+		setIfEmpty(&b.m.Background, stremio_shared.GetCinemetaBackgroundURL(idMap.IMDB))
+	}
+
+	if b.m.Name == "" {
+		return nil, b.isPartial, nil
+	}
+
+	if tvdbId := util.SafeParseInt(idMap.TVDB, 0); tvdbId != 0 {
+		b.m.TVDBId = stremio.Number(strconv.Itoa(tvdbId))
+	}
+
+	if b.isSeries {
+		slices.SortFunc(b.m.Videos, func(a, b stremio.MetaVideo) int {
+			if a.Season != b.Season {
+				return int(a.Season) - int(b.Season)
+			}
+			return int(a.Episode) - int(b.Episode)
+		})
+	} else {
+		b.m.BehaviorHints = &stremio.MetaBehaviorHints{
+			DefaultVideoId: id,
+		}
+	}
+
+	return &b.m, b.isPartial, nil
+}
+
+// GetMeta builds the meta for movie, series and anime from the local datasets,
+// filling the gaps from TVDB, TMDB and AniList. Returns nil if not found.
+func GetMeta(sType stremio.ContentType, id string) (*stremio.Meta, error) {
+	cacheKey := string(sType) + ":" + id
+
+	var m stremio.Meta
+	if !metaCache.Get(cacheKey, &m) {
+		result, err, _ := getMetaGroup.Do(cacheKey, func() (any, error) {
+			m, isPartial, err := buildMeta(sType, id)
+			if err != nil {
+				return nil, err
+			}
+			if m == nil {
+				m = &stremio.Meta{}
+			}
+			if !isPartial {
+				if err := metaCache.Add(cacheKey, *m); err != nil {
+					return nil, err
+				}
+			}
+			return *m, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = result.(stremio.Meta)
+	}
+
+	if m.Id == "" {
+		return nil, nil
+	}
+	return &m, nil
+}
+
+func handleMeta(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	contentType := stremio.ContentType(r.PathValue("contentType"))
+	switch contentType {
+	case stremio.ContentTypeMovie, stremio.ContentTypeSeries:
+	case stremio.ContentTypeAnime:
+		if !AnimeEnabled {
+			shared.ErrorBadRequest(r, "unsupported type: "+string(contentType)).Send(w, r)
+			return
+		}
+	default:
+		shared.ErrorBadRequest(r, "unsupported type: "+string(contentType)).Send(w, r)
+		return
+	}
+
+	m, err := GetMeta(contentType, GetPathValue(r, "id"))
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if m == nil {
+		shared.ErrorNotFound(r).Send(w, r)
+		return
+	}
+
+	if poster := ud.getPosterURL(m.IMDBId); poster != "" {
+		m.Poster = poster
+	}
+
+	SendResponse(w, r, 200, stremio.MetaHandlerResponse{
+		Meta: *m,
+	})
+}
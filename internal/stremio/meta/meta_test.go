@@ -0,0 +1,156 @@
+package stremio_meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/anidb"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/imdb_title"
+	"github.com/MunifTanjim/stremthru/internal/meta"
+	"github.com/MunifTanjim/stremthru/internal/tmdb"
+	"github.com/MunifTanjim/stremthru/internal/tvdb"
+	"github.com/MunifTanjim/stremthru/stremio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToTrailer(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		url     string
+		trailer *stremio.MetaTrailer
+	}{
+		{"empty", "", nil},
+		{"youtube", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", &stremio.MetaTrailer{
+			Source: "dQw4w9WgXcQ",
+			Type:   stremio.MetaTrailerTypeTrailer,
+		}},
+		{"youtube without video", "https://www.youtube.com/channel/xyz", nil},
+		{"other host", "https://vimeo.com/123", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.trailer, toTrailer(tc.url))
+		})
+	}
+}
+
+func TestGetVideoId(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		id         string
+		idProvider meta.IdProvider
+		videoId    string
+	}{
+		{"imdb", "tt0944947", meta.IdProviderIMDB, "tt0944947:1:2"},
+		{"tvdb", "tvdb:121361", meta.IdProviderTVDB, "tvdb:121361:1:2"},
+		{"kitsu", "kitsu:1", meta.IdProviderKitsu, "kitsu:1:2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := metaBuilder{
+				m:          stremio.Meta{Id: tc.id},
+				idProvider: tc.idProvider,
+			}
+			assert.Equal(t, tc.videoId, b.getVideoId(1, 2))
+		})
+	}
+}
+
+func TestApplyLocalDatasets(t *testing.T) {
+	b := metaBuilder{
+		m:          stremio.Meta{Id: "tt0110912"},
+		idProvider: meta.IdProviderIMDB,
+	}
+	b.applyIMDBTitle(&imdb_title.IMDBTitle{
+		Title: "Pulp Fiction",
+		Year:  1994,
+	}, &imdb_title.IMDBTitleMeta{
+		Description: "imdb description",
+		Runtime:     154,
+		Poster:      "https://example.com/imdb-poster.jpg",
+		Trailer:     "https://www.youtube.com/watch?v=s7EdQ4FqbhY",
+		Genres:      []string{"Crime", "Drama"},
+	})
+	b.applyTMDBItem(&tmdb.TMDBItem{
+		Id:          680,
+		Title:       "Pulp Fiction (TMDB)",
+		Overview:    "tmdb description",
+		ReleaseDate: db.DateOnly{Time: time.Date(1994, 9, 10, 0, 0, 0, 0, time.UTC)},
+		Backdrop:    "/backdrop.jpg",
+		Poster:      "/poster.jpg",
+	})
+
+	assert.Equal(t, "Pulp Fiction", b.m.Name)
+	assert.Equal(t, "imdb description", b.m.Description)
+	assert.Equal(t, "https://example.com/imdb-poster.jpg", b.m.Poster)
+	assert.Equal(t, tmdb.IMAGE_BASE_URL+string(tmdb.BackdropSizeW1280)+"/backdrop.jpg", b.m.Background)
+	assert.Equal(t, "154 min", b.m.Runtime)
+	assert.Equal(t, "1994", b.m.ReleaseInfo)
+	assert.Equal(t, []string{"Crime", "Drama"}, b.m.Genres)
+	assert.Equal(t, []stremio.MetaTrailer{{Source: "s7EdQ4FqbhY", Type: stremio.MetaTrailerTypeTrailer}}, b.m.Trailers)
+	assert.Equal(t, 680, b.m.MovieDBId)
+	assert.Equal(t, "1994-09-10", b.m.Released.Format(time.DateOnly))
+	assert.False(t, b.hasGaps())
+	assert.False(t, b.needsVideos())
+}
+
+func TestAddTMDBEpisodes(t *testing.T) {
+	b := metaBuilder{
+		m:          stremio.Meta{Id: "tmdb:1399"},
+		idProvider: meta.IdProviderTMDB,
+		isSeries:   true,
+	}
+	assert.True(t, b.needsVideos())
+
+	b.addTMDBEpisodes([]tmdb.TVEpisode{
+		{Id: 63056, Name: "Winter Is Coming", AirDate: "2011-04-17", SeasonNumber: 1, EpisodeNumber: 1, StillPath: "/still.jpg"},
+		{Id: 63057, Name: "The Kingsroad", AirDate: "2011-04-24", SeasonNumber: 1, EpisodeNumber: 2},
+		{Id: 99999, Name: "Unaired", SeasonNumber: 9, EpisodeNumber: 1},
+	})
+
+	assert.Len(t, b.m.Videos, 2)
+	assert.Equal(t, "tmdb:1399:1:1", b.m.Videos[0].Id)
+	assert.Equal(t, tmdb.IMAGE_BASE_URL+string(tmdb.StillSizeW300)+"/still.jpg", b.m.Videos[0].Thumbnail)
+	assert.Equal(t, 63056, b.m.Videos[0].MovieDBId)
+	assert.Equal(t, "tmdb:1399:1:2", b.m.Videos[1].Id)
+	assert.Empty(t, b.m.Videos[1].Thumbnail)
+	assert.False(t, b.needsVideos())
+}
+
+func TestAddTVDBEpisodes(t *testing.T) {
+	episodes := []tvdb.Episode{
+		{Id: 1, Name: "S1E12", Aired: "2020-03-20", SeasonNumber: 1, Number: 12, Image: "/banners/episodes/1000/1.jpg"},
+		{Id: 2, Name: "S1E13", Aired: "2020-07-03", SeasonNumber: 1, Number: 13},
+		{Id: 3, Name: "S1E14", SeasonNumber: 1, Number: 14},
+	}
+
+	t.Run("series", func(t *testing.T) {
+		b := metaBuilder{
+			m:          stremio.Meta{Id: "tvdb:1000"},
+			idProvider: meta.IdProviderTVDB,
+			isSeries:   true,
+		}
+		b.addTVDBEpisodes(episodes, nil)
+		assert.Len(t, b.m.Videos, 2)
+		assert.Equal(t, "tvdb:1000:1:12", b.m.Videos[0].Id)
+		assert.Equal(t, tvdb.ArtworkBaseURL+"/banners/episodes/1000/1.jpg", b.m.Videos[0].Thumbnail)
+		assert.Equal(t, "tvdb:1000:1:13", b.m.Videos[1].Id)
+	})
+
+	t.Run("anime", func(t *testing.T) {
+		b := metaBuilder{
+			m:          stremio.Meta{Id: "kitsu:1"},
+			idProvider: meta.IdProviderKitsu,
+			idMap:      &meta.IdMap{Anime: &meta.IdMapAnime{AniDB: "101"}},
+			isSeries:   true,
+		}
+		b.addTVDBEpisodes(episodes, anidb.AniDBTVDBEpisodeMaps{
+			{AniDBId: "100", TVDBId: "1000", AniDBSeason: 1, TVDBSeason: 1},
+			{AniDBId: "101", TVDBId: "1000", AniDBSeason: 1, TVDBSeason: 1, Offset: 12},
+		})
+		assert.Len(t, b.m.Videos, 1)
+		assert.Equal(t, "kitsu:1:1", b.m.Videos[0].Id)
+		assert.Equal(t, stremio.ZeroIndexedInt(1), b.m.Videos[0].Season)
+		assert.Equal(t, stremio.ZeroIndexedInt(1), b.m.Videos[0].Episode)
+		assert.Equal(t, 2, b.m.Videos[0].TVDBId)
+	})
+}
@@ -0,0 +1,40 @@
+package stremio_meta
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/server"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+)
+
+func handleRoot(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/stremio/meta/configure", http.StatusFound)
+}
+
+func commonMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := server.GetReqCtx(r)
+		ctx.Log = log.WithCtx(r.Context(), "req.id", ctx.RequestId)
+		next.ServeHTTP(w, r)
+		ctx.RedactURLPathValues(r, "userData")
+	})
+}
+
+func AddEndpoints(mux *http.ServeMux) {
+	withCors := shared.Middleware(shared.EnableCORS)
+
+	router := http.NewServeMux()
+
+	router.HandleFunc("/{$}", handleRoot)
+
+	router.HandleFunc("/manifest.json", withCors(handleManifest))
+	router.HandleFunc("/{userData}/manifest.json", withCors(handleManifest))
+
+	router.HandleFunc("/configure", handleConfigure)
+	router.HandleFunc("/{userData}/configure", handleConfigure)
+
+	router.HandleFunc("/meta/{contentType}/{idJson}", withCors(handleMeta))
+	router.HandleFunc("/{userData}/meta/{contentType}/{idJson}", withCors(handleMeta))
+
+	mux.Handle("/stremio/meta/", http.StripPrefix("/stremio/meta", commonMiddleware(router)))
+}
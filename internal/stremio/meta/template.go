@@ -0,0 +1,41 @@
+package stremio_meta
+
+import (
+	"bytes"
+
+	"github.com/MunifTanjim/stremthru/internal/stremio/configure"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+)
+
+func getTemplateData(ud *UserData) *configure.TemplateData {
+	return &configure.TemplateData{
+		Base: configure.Base{
+			Title:       "StremThru Meta",
+			Description: "Metadata for Movies, Series and Anime",
+			NavTitle:    "Meta",
+		},
+		Configs: []configure.Config{
+			{
+				Key:          "rpdb_api_key",
+				Type:         configure.ConfigTypePassword,
+				Default:      ud.RPDBAPIKey,
+				Title:        "RPDB API Key",
+				Description:  `Rating Poster Database <a href="https://ratingposterdb.com/api-key/" target="_blank">API Key</a>`,
+				Autocomplete: "off",
+			},
+			{
+				Key:          "top_posters_api_key",
+				Type:         configure.ConfigTypePassword,
+				Default:      ud.TopPostersAPIKey,
+				Title:        "Top Posters API Key",
+				Description:  `Top Posters <a href="https://api.top-streaming.stream/user/dashboard" target="_blank">API Key</a>`,
+				Autocomplete: "off",
+			},
+		},
+	}
+}
+
+func getPage(td *configure.TemplateData) (bytes.Buffer, error) {
+	td.StremThruAddons = stremio_shared.GetStremThruAddons()
+	return configure.GetPage(td)
+}
@@ -0,0 +1,63 @@
+package stremio_meta
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/core"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+)
+
+type UserData struct {
+	RPDBAPIKey       string `json:"rpdb_api_key,omitempty"`
+	TopPostersAPIKey string `json:"top_posters_api_key,omitempty"`
+	encoded          string `json:"-"`
+}
+
+func (ud UserData) GetEncoded() (string, error) {
+	if ud.encoded != "" {
+		return ud.encoded, nil
+	}
+
+	blob, err := json.Marshal(ud)
+	if err != nil {
+		return "", err
+	}
+	return core.Base64Encode(string(blob)), nil
+}
+
+func (ud UserData) getPosterURL(imdbId string) string {
+	if imdbId == "" {
+		return ""
+	}
+	return stremio_shared.GetRatingPosterURL(ud.RPDBAPIKey, ud.TopPostersAPIKey, imdbId)
+}
+
+func getUserData(r *http.Request) (*UserData, error) {
+	data := &UserData{}
+
+	if IsMethod(r, http.MethodGet) || IsMethod(r, http.MethodHead) {
+		data.encoded = r.PathValue("userData")
+		if data.encoded == "" {
+			return data, nil
+		}
+		blob, err := core.Base64DecodeToByte(data.encoded)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(blob, data)
+		return data, err
+	}
+
+	if IsMethod(r, http.MethodPost) {
+		data.RPDBAPIKey = r.FormValue("rpdb_api_key")
+		data.TopPostersAPIKey = r.FormValue("top_posters_api_key")
+		encoded, err := data.GetEncoded()
+		if err != nil {
+			return nil, err
+		}
+		data.encoded = encoded
+	}
+
+	return data, nil
+}
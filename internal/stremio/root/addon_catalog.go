@@ -5,7 +5,9 @@ import (
 
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/shared"
+	stremio_federated_addon "github.com/MunifTanjim/stremthru/internal/stremio/federated_addon"
 	stremio_list "github.com/MunifTanjim/stremthru/internal/stremio/list"
+	stremio_meta "github.com/MunifTanjim/stremthru/internal/stremio/meta"
 	stremio_sidekick "github.com/MunifTanjim/stremthru/internal/stremio/sidekick"
 	stremio_store "github.com/MunifTanjim/stremthru/internal/stremio/store"
 	stremio_torz "github.com/MunifTanjim/stremthru/internal/stremio/torz"
@@ -24,6 +26,13 @@ func getAddonCatalog(r *http.Request) *stremio.AddonCatalogHandlerResponse {
 			TransportUrl:  shared.ExtractRequestBaseURL(r).JoinPath("stremio/list/manifest.json").String(),
 		})
 	}
+	if config.Feature.IsEnabled(config.FeatureStremioMeta) {
+		addons = append(addons, stremio.Addon{
+			Manifest:      *stremio_meta.GetManifest(r, &stremio_meta.UserData{}),
+			TransportName: "http",
+			TransportUrl:  shared.ExtractRequestBaseURL(r).JoinPath("stremio/meta/manifest.json").String(),
+		})
+	}
 	if config.Feature.IsEnabled(config.FeatureStremioWrap) {
 		addons = append(addons, stremio.Addon{
 			Manifest:      *stremio_wrap.GetManifest(r, []stremio.Manifest{}, &stremio_wrap.UserData{}),
@@ -54,5 +63,7 @@ func getAddonCatalog(r *http.Request) *stremio.AddonCatalogHandlerResponse {
 		})
 	}
 
+	addons = append(addons, stremio_federated_addon.GetCatalogAddons()...)
+
 	return &stremio.AddonCatalogHandlerResponse{Addons: addons}
 }
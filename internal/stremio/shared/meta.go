@@ -1,5 +1,12 @@
 package stremio_shared
 
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+)
+
 func GetCinemetaPosterURL(imdbId string) string {
 	return "https://images.metahub.space/poster/small/" + imdbId + "/img"
 }
@@ -7,3 +14,27 @@ func GetCinemetaPosterURL(imdbId string) string {
 func GetCinemetaBackgroundURL(imdbId string) string {
 	return "https://images.metahub.space/background/medium/" + imdbId + "/img"
 }
+
+// GetRatingPosterURL returns the poster with ratings from RPDB or Top Posters,
+// for whichever api key is provided. Returns empty string if none is.
+func GetRatingPosterURL(rpdbAPIKey, topPostersAPIKey, imdbId string) string {
+	if rpdbAPIKey != "" {
+		return "https://api.ratingposterdb.com/" + rpdbAPIKey + "/imdb/poster-default/" + imdbId + ".jpg?fallback=true"
+	}
+	if topPostersAPIKey != "" {
+		return "https://api.top-streaming.stream/" + topPostersAPIKey + "/imdb/poster-default/" + imdbId + ".jpg"
+	}
+	return ""
+}
+
+func VerifyTopPostersAPIKey(apiKey string) error {
+	resp, err := config.DefaultHTTPClient.Get("https://api.top-streaming.stream/auth/verify/" + apiKey)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("Invalid API key")
+	}
+	return nil
+}
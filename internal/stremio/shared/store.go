@@ -29,6 +29,12 @@ func GetStoreCodeOptions(includeP2P bool) []configure.ConfigOption {
 		options[0].Disabled = true
 		options[0].Label = ""
 	}
+	if config.Feature.HasStoreLocal() {
+		options = append(options, configure.ConfigOption{
+			Value: "lo",
+			Label: "Local 🧪",
+		})
+	}
 	if P2PEnabled && includeP2P {
 		options = append(options, configure.ConfigOption{
 			Value: "p2p",
@@ -0,0 +1,68 @@
+package stremio_shared
+
+import (
+	"html/template"
+
+	"github.com/MunifTanjim/stremthru/internal/stremio/configure"
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+)
+
+const StreamSortConfigDescription template.HTML = "Comma separated fields: <code>resolution</code>, <code>quality</code>, <code>size</code>, <code>hdr</code>, <code>codec</code>, <code>seeders</code>, <code>bitdepth</code>, <code>store_is_cached</code>, <code>reputation</code>, <code>language</code> (preferred languages as <code>language:en|ja</code>), or an expression returning a score, e.g. <code>(Resolution == \"2160p\" ? 100 : 0) + Seeders / 10</code>. Prefix with <code>-</code> for reverse sort. Later entries are used as tie-breakers. Default: <code>" + stremio_transformer.StreamDefaultSortConfig + "</code>"
+
+func getStreamPresetOptions(store *stremio_transformer.StreamPresetStore) []configure.ConfigOption {
+	options := []configure.ConfigOption{
+		{Value: "", Label: "None"},
+	}
+	ids, err := store.ListIds()
+	if err != nil {
+		log.Error("failed to list stream presets", "error", err)
+		return options
+	}
+	for _, id := range ids {
+		options = append(options, configure.ConfigOption{Value: id, Label: id})
+	}
+	return options
+}
+
+func GetStreamQualityProfileConfig(profileId string) configure.Config {
+	options := []configure.ConfigOption{
+		{Value: "", Label: "None"},
+	}
+	ids, err := stremio_transformer.StreamQualityProfiles.ListIds()
+	if err != nil {
+		log.Error("failed to list stream quality profiles", "error", err)
+	}
+	for _, id := range ids {
+		options = append(options, configure.ConfigOption{Value: id, Label: id})
+	}
+	return configure.Config{
+		Key:         "quality_profile",
+		Type:        configure.ConfigTypeSelect,
+		Default:     profileId,
+		Title:       "Quality Profile",
+		Description: "Drops streams not matching the profile, and overrides Stream Sort when selected",
+		Options:     options,
+	}
+}
+
+func GetStreamSortPresetConfig(presetId string) configure.Config {
+	return configure.Config{
+		Key:         "sort_preset",
+		Type:        configure.ConfigTypeSelect,
+		Default:     presetId,
+		Title:       "Stream Sort Preset",
+		Description: "Overrides Stream Sort when selected",
+		Options:     getStreamPresetOptions(stremio_transformer.StreamSortPresets),
+	}
+}
+
+func GetStreamFilterPresetConfig(presetId string) configure.Config {
+	return configure.Config{
+		Key:         "filter_preset",
+		Type:        configure.ConfigTypeSelect,
+		Default:     presetId,
+		Title:       "🧪 Stream Filter Preset",
+		Description: "Overrides Stream Filter when selected",
+		Options:     getStreamPresetOptions(stremio_transformer.StreamFilterPresets),
+	}
+}
@@ -18,6 +18,12 @@ func GetStremThruAddons() []stremio_template.BaseDataStremThruAddon {
 			URL:  "/stremio/list",
 		})
 	}
+	if config.Feature.IsEnabled(config.FeatureStremioMeta) {
+		addons = append(addons, stremio_template.BaseDataStremThruAddon{
+			Name: "Meta",
+			URL:  "/stremio/meta",
+		})
+	}
 	if config.Feature.IsEnabled(config.FeatureStremioWrap) {
 		addons = append(addons, stremio_template.BaseDataStremThruAddon{
 			Name: "Wrap",
@@ -0,0 +1,158 @@
+package stremio_shared
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/oauth"
+	"github.com/MunifTanjim/stremthru/internal/stremio/configure"
+	"github.com/MunifTanjim/stremthru/internal/trakt"
+	"github.com/google/uuid"
+)
+
+var TraktScrobbleEnabled = config.Integration.Trakt.IsEnabled()
+
+func GetTraktScrobbleConfig(tokenId string) configure.Config {
+	conf := configure.Config{
+		Key:          "trakt_token_id",
+		Type:         configure.ConfigTypePassword,
+		Default:      tokenId,
+		Title:        "Trakt.tv Scrobble",
+		Description:  "Auth Code, to show playback as watching on Trakt.tv",
+		Autocomplete: "off",
+		Action: configure.ConfigAction{
+			Visible: tokenId == "",
+			Label:   "Authorize",
+			OnClick: template.JS(`window.open("` + oauth.TraktOAuthConfig.AuthCodeURL(uuid.NewString()) + `", "_blank")`),
+		},
+		Hidden: !TraktScrobbleEnabled,
+	}
+	if TraktScrobbleEnabled && tokenId != "" {
+		if otok, err := trakt.GetOAuthToken(tokenId); err != nil {
+			conf.Error = err.Error()
+			conf.Action.Visible = true
+		} else {
+			conf.Title += " (" + otok.UserName + ")"
+		}
+	}
+	return conf
+}
+
+// the current playback of the user, it is ended when the next one starts.
+type traktScrobbleSession struct {
+	StremId   string    `json:"sid"`
+	StartedAt time.Time `json:"sat"`
+	Runtime   int       `json:"rt"` // in minutes
+}
+
+var traktScrobbleSessionCache = cache.NewCache[traktScrobbleSession](&cache.CacheConfig{
+	Name:     "stremio:trakt:scrobble:session",
+	Lifetime: 12 * time.Hour,
+})
+
+// the same item is not scrobbled again within this window, e.g. when the
+// player requests the stream again.
+const traktScrobbleRepeatWindow = 15 * time.Minute
+
+func newTraktScrobbleParams(stremId string) *trakt.ScrobbleParams {
+	if !strings.HasPrefix(stremId, "tt") {
+		return nil
+	}
+	params := &trakt.ScrobbleParams{}
+	parts := strings.Split(stremId, ":")
+	switch len(parts) {
+	case 1:
+		params.Movie = &trakt.ScrobbleParamsItem{Ids: trakt.ListItemIds{IMDB: parts[0]}}
+	case 3:
+		season, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil
+		}
+		episode, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil
+		}
+		params.Show = &trakt.ScrobbleParamsItem{Ids: trakt.ListItemIds{IMDB: parts[0]}}
+		params.Episode = &trakt.ScrobbleParamsEpisode{Season: season, Number: episode}
+	default:
+		return nil
+	}
+	return params
+}
+
+// getTraktScrobbleEnd returns the action and the progress to end the session
+// with. The addons do not see the player, so the progress is estimated from
+// the time since the start. Trakt marks it as watched on `stop` with progress
+// of 80% or more, otherwise it is paused.
+func getTraktScrobbleEnd(session *traktScrobbleSession, now time.Time) (trakt.ScrobbleAction, float64) {
+	if session.Runtime <= 0 {
+		return trakt.ScrobbleActionPause, 0
+	}
+	progress := min(100, 100*now.Sub(session.StartedAt).Minutes()/float64(session.Runtime))
+	if progress >= 80 {
+		return trakt.ScrobbleActionStop, progress
+	}
+	return trakt.ScrobbleActionPause, progress
+}
+
+func fetchTraktRuntime(client *trakt.APIClient, params *trakt.ScrobbleParams) (int, error) {
+	if params.Movie != nil {
+		res, err := client.FetchMovie(&trakt.FetchMovieParams{Id: params.Movie.Ids.IMDB})
+		return res.Data.Runtime, err
+	}
+	res, err := client.FetchShow(&trakt.FetchShowParams{Id: params.Show.Ids.IMDB})
+	return res.Data.Runtime, err
+}
+
+// ScrobbleTrakt starts the scrobble for `stremId` on Trakt.tv, and ends the
+// previous one of the user, as paused or watched by the estimated progress.
+func ScrobbleTrakt(tokenId, stremId string, log *logger.Logger) {
+	if !TraktScrobbleEnabled || tokenId == "" {
+		return
+	}
+
+	params := newTraktScrobbleParams(stremId)
+	if params == nil {
+		return
+	}
+
+	now := time.Now()
+	session := traktScrobbleSession{}
+	hasSession := traktScrobbleSessionCache.Get(tokenId, &session)
+	if hasSession && session.StremId == stremId && now.Sub(session.StartedAt) < traktScrobbleRepeatWindow {
+		return
+	}
+
+	otok, err := trakt.GetOAuthToken(tokenId)
+	if err != nil {
+		log.Warn("failed to scrobble to trakt, invalid token", "error", err)
+		return
+	}
+	client := trakt.GetAPIClient(otok.Id)
+
+	if hasSession && session.StremId != stremId {
+		if endParams := newTraktScrobbleParams(session.StremId); endParams != nil {
+			endParams.Action, endParams.Progress = getTraktScrobbleEnd(&session, now)
+			if _, err := client.Scrobble(endParams); err != nil {
+				log.Warn("failed to end scrobble on trakt", "error", err, "id", session.StremId, "action", endParams.Action)
+			}
+		}
+	}
+
+	params.Action = trakt.ScrobbleActionStart
+	if _, err := client.Scrobble(params); err != nil {
+		log.Error("failed to scrobble to trakt", "error", err, "id", stremId)
+		return
+	}
+
+	session = traktScrobbleSession{StremId: stremId, StartedAt: now}
+	if session.Runtime, err = fetchTraktRuntime(client, params); err != nil {
+		log.Warn("failed to fetch runtime from trakt", "error", err, "id", stremId)
+	}
+	traktScrobbleSessionCache.Add(tokenId, session)
+}
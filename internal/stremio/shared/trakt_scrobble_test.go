@@ -0,0 +1,48 @@
+package stremio_shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/trakt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTraktScrobbleParams(t *testing.T) {
+	params := newTraktScrobbleParams("tt0111161")
+	require.NotNil(t, params)
+	assert.Equal(t, "tt0111161", params.Movie.Ids.IMDB)
+	assert.Nil(t, params.Show)
+
+	params = newTraktScrobbleParams("tt0903747:2:5")
+	require.NotNil(t, params)
+	assert.Nil(t, params.Movie)
+	assert.Equal(t, "tt0903747", params.Show.Ids.IMDB)
+	assert.Equal(t, &trakt.ScrobbleParamsEpisode{Season: 2, Number: 5}, params.Episode)
+
+	for _, sid := range []string{"kitsu:1:2", "tt0903747:2", "tt0903747:x:5", ""} {
+		assert.Nil(t, newTraktScrobbleParams(sid), sid)
+	}
+}
+
+func TestGetTraktScrobbleEnd(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name     string
+		session  traktScrobbleSession
+		action   trakt.ScrobbleAction
+		progress float64
+	}{
+		{"unknown runtime", traktScrobbleSession{StartedAt: now.Add(-time.Hour)}, trakt.ScrobbleActionPause, 0},
+		{"stopped early", traktScrobbleSession{StartedAt: now.Add(-30 * time.Minute), Runtime: 120}, trakt.ScrobbleActionPause, 25},
+		{"watched", traktScrobbleSession{StartedAt: now.Add(-40 * time.Minute), Runtime: 45}, trakt.ScrobbleActionStop, 100 * 40.0 / 45},
+		{"past runtime", traktScrobbleSession{StartedAt: now.Add(-3 * time.Hour), Runtime: 45}, trakt.ScrobbleActionStop, 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			action, progress := getTraktScrobbleEnd(&tc.session, now)
+			assert.Equal(t, tc.action, action)
+			assert.InDelta(t, tc.progress, progress, 0.01)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package stremio_sidekick
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	stremio_account "github.com/MunifTanjim/stremthru/internal/stremio/account"
+	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
+	stremio_backup "github.com/MunifTanjim/stremthru/internal/stremio/backup"
+)
+
+var backupAccountIdCache = cache.NewCache[string](&cache.CacheConfig{
+	Name:     "stremio:sidekick:backup_account_id",
+	Lifetime: 15 * time.Minute,
+})
+
+// getBackupAccountId returns the id of the vault account for the logged in
+// user, empty if the account is not in the vault.
+func getBackupAccountId(cookie *CookieValue) (string, error) {
+	authKey := cookie.AuthKey()
+	sum := sha256.Sum256([]byte(authKey))
+	cacheKey := hex.EncodeToString(sum[:])
+
+	accountId := ""
+	if backupAccountIdCache.Get(cacheKey, &accountId) {
+		return accountId, nil
+	}
+
+	params := &stremio_api.GetUserParams{}
+	params.APIKey = authKey
+	res, err := client.GetUser(params)
+	if err != nil {
+		return "", err
+	}
+
+	account, err := stremio_account.GetById(res.Data.Id)
+	if err != nil {
+		return "", err
+	}
+	if account != nil {
+		accountId = account.Id
+	}
+
+	backupAccountIdCache.Add(cacheKey, accountId)
+	return accountId, nil
+}
+
+func getBackupDiff(b *stremio_backup.StremioBackup) (*BackupDiff, error) {
+	diff := &BackupDiff{Id: b.Id, Lines: []BackupDiffLine{}}
+
+	prev, err := stremio_backup.GetPrevious(b)
+	if err != nil {
+		return nil, err
+	}
+	if prev == nil {
+		diff.IsFirst = true
+		return diff, nil
+	}
+
+	switch b.Kind {
+	case stremio_backup.KindAddons:
+		prevAddons, err := prev.GetAddons()
+		if err != nil {
+			return nil, err
+		}
+		currAddons, err := b.GetAddons()
+		if err != nil {
+			return nil, err
+		}
+		d := stremio_backup.DiffAddons(prevAddons, currAddons)
+		for _, addon := range d.Added {
+			diff.Lines = append(diff.Lines, BackupDiffLine{Sign: "+", Text: addon.Manifest.Name})
+		}
+		for _, addon := range d.Removed {
+			diff.Lines = append(diff.Lines, BackupDiffLine{Sign: "-", Text: addon.Manifest.Name})
+		}
+		for _, move := range d.Moved {
+			diff.Lines = append(diff.Lines, BackupDiffLine{
+				Sign: "~",
+				Text: move.Addon.Manifest.Name + " (#" + strconv.Itoa(move.From+1) + " → #" + strconv.Itoa(move.To+1) + ")",
+			})
+		}
+	case stremio_backup.KindLibrary:
+		prevItems, err := prev.GetLibraryItems()
+		if err != nil {
+			return nil, err
+		}
+		currItems, err := b.GetLibraryItems()
+		if err != nil {
+			return nil, err
+		}
+		d := stremio_backup.DiffLibraryItems(prevItems, currItems)
+		for _, item := range d.Added {
+			diff.Lines = append(diff.Lines, BackupDiffLine{Sign: "+", Text: item.Name + " (" + item.Type + ")"})
+		}
+		for _, item := range d.Removed {
+			diff.Lines = append(diff.Lines, BackupDiffLine{Sign: "-", Text: item.Name + " (" + item.Type + ")"})
+		}
+	}
+
+	return diff, nil
+}
+
+func sendBackupsSection(w http.ResponseWriter, r *http.Request, td *TemplateData, accountId string) {
+	if accountId != "" {
+		td.Backups.IsLinked = true
+
+		backups, err := stremio_backup.GetAllByAccountId(accountId)
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+		td.Backups.Items = make([]BackupItem, len(backups))
+		for i := range backups {
+			b := &backups[i]
+			td.Backups.Items[i] = BackupItem{
+				Id:        b.Id,
+				Kind:      string(b.Kind),
+				Count:     b.Count,
+				CreatedAt: b.CAt.Format(time.DateTime),
+			}
+		}
+	}
+
+	buf, err := executeTemplate(td, "sidekick_backups_section.html")
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	SendHTML(w, 200, buf)
+}
+
+func prepareBackupRequest(w http.ResponseWriter, r *http.Request) (*CookieValue, *TemplateData, string, bool) {
+	cookie, err := getCookieValue(w, r)
+	if err != nil {
+		SendError(w, r, err)
+		return nil, nil, "", false
+	}
+
+	td := getTemplateData(cookie, w, r)
+	if !td.CanBackup {
+		shared.ErrorForbidden(r).Send(w, r)
+		return nil, nil, "", false
+	}
+
+	accountId, err := getBackupAccountId(cookie)
+	if err != nil {
+		SendError(w, r, err)
+		return nil, nil, "", false
+	}
+
+	return cookie, td, accountId, true
+}
+
+func getAccountBackup(w http.ResponseWriter, r *http.Request, accountId string) *stremio_backup.StremioBackup {
+	b, err := stremio_backup.GetById(r.PathValue("backupId"))
+	if err != nil {
+		SendError(w, r, err)
+		return nil
+	}
+	if b == nil || accountId == "" || b.AccountId != accountId {
+		shared.ErrorNotFound(r).Send(w, r)
+		return nil
+	}
+	return b
+}
+
+func handleBackups(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) && !IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	cookie, td, accountId, ok := prepareBackupRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if IsMethod(r, http.MethodPost) && accountId != "" {
+		if err := stremio_backup.Snapshot(accountId, cookie.AuthKey(), config.Stremio.Sidekick.BackupKeepCount); err != nil {
+			td.Backups.HasError = true
+			td.Backups.Message = "Failed to backup: " + err.Error()
+		} else {
+			td.Backups.Message = "Successfully Backed Up"
+		}
+	}
+
+	sendBackupsSection(w, r, td, accountId)
+}
+
+func handleBackupDiff(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	_, td, accountId, ok := prepareBackupRequest(w, r)
+	if !ok {
+		return
+	}
+
+	b := getAccountBackup(w, r, accountId)
+	if b == nil {
+		return
+	}
+
+	diff, err := getBackupDiff(b)
+	if err != nil {
+		td.Backups.HasError = true
+		td.Backups.Message = "Failed to compare: " + err.Error()
+	} else {
+		td.Backups.Diff = diff
+	}
+
+	sendBackupsSection(w, r, td, accountId)
+}
+
+func handleBackupRestore(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	cookie, td, accountId, ok := prepareBackupRequest(w, r)
+	if !ok {
+		return
+	}
+
+	b := getAccountBackup(w, r, accountId)
+	if b == nil {
+		return
+	}
+
+	if err := stremio_backup.Restore(b, cookie.AuthKey()); err != nil {
+		td.Backups.HasError = true
+		td.Backups.Message = "Failed to restore: " + err.Error()
+	} else {
+		td.Backups.Message = "Successfully Restored " + string(b.Kind) + " from " + b.CAt.Format(time.DateTime)
+	}
+
+	sendBackupsSection(w, r, td, accountId)
+}
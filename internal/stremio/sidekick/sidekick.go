@@ -1271,5 +1271,9 @@ func AddStremioSidekickEndpoints(mux *http.ServeMux) {
 	router.HandleFunc("/library/restore", handleLibraryRestore)
 	router.HandleFunc("/library/reset", handleLibraryReset)
 
+	router.HandleFunc("/backups", handleBackups)
+	router.HandleFunc("/backups/{backupId}/diff", handleBackupDiff)
+	router.HandleFunc("/backups/{backupId}/restore", handleBackupRestore)
+
 	mux.Handle("/stremio/sidekick/", http.StripPrefix("/stremio/sidekick", commonMiddleware(router)))
 }
@@ -54,6 +54,15 @@ type TemplateData struct {
 		}
 	}
 
+	CanBackup bool
+	Backups   struct {
+		IsLinked bool
+		Items    []BackupItem
+		Diff     *BackupDiff
+		HasError bool
+		Message  string
+	}
+
 	CanAuthAdmin   bool
 	HasAuthAdmin   bool
 	AuthAdminError string
@@ -61,6 +70,24 @@ type TemplateData struct {
 	VaultAccounts []VaultAccount
 }
 
+type BackupItem struct {
+	Id        string
+	Kind      string
+	Count     int
+	CreatedAt string
+}
+
+type BackupDiffLine struct {
+	Sign string
+	Text string
+}
+
+type BackupDiff struct {
+	Id      string
+	IsFirst bool
+	Lines   []BackupDiffLine
+}
+
 type VaultAccount struct {
 	Id    string
 	Email string
@@ -82,6 +109,7 @@ func getTemplateData(cookie *CookieValue, w http.ResponseWriter, r *http.Request
 	if cookie != nil && !cookie.IsExpired {
 		td.IsAuthed = true
 		td.Email = cookie.Email()
+		td.CanBackup = config.Feature.HasVault() && config.Stremio.Sidekick.BackupKeepCount > 0
 	}
 	if !td.IsAuthed {
 		td.Login.Email = ""
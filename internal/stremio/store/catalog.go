@@ -13,7 +13,6 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/logger"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
-	stremio_store_usenet "github.com/MunifTanjim/stremthru/internal/stremio/store/usenet"
 	stremio_store_webdl "github.com/MunifTanjim/stremthru/internal/stremio/store/webdl"
 	"github.com/MunifTanjim/stremthru/internal/torrent_info"
 	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
@@ -56,17 +55,22 @@ func getUsenetCatalogItems(s store.Store, storeToken string, clientIp string, id
 	if !catalogCache.Get(cacheKey, &items) {
 		storeName := s.GetName()
 
+		us, ok := s.(store.UsenetStore)
+		if !ok {
+			return items
+		}
+
 		offset := 0
 		hasMore := true
 		for hasMore && offset < max_fetch_list_items {
 			start := time.Now()
-			params := &stremio_store_usenet.ListNewsParams{
+			params := &store.ListNZBsParams{
 				Limit:    fetch_list_limit,
 				Offset:   offset,
 				ClientIP: clientIp,
 			}
 			params.APIKey = storeToken
-			res, err := stremio_store_usenet.ListNews(params, storeName)
+			res, err := us.ListNZBs(params)
 			if err != nil {
 				log.Error("failed to list news", "error", err, "duration", time.Since(start).String(), "store.name", storeName, "offset", offset)
 				break
@@ -300,6 +304,11 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 	}
 
 	catalogId := getId(r)
+	if catalogId == getHistoryCatalogId() {
+		handleHistoryCatalog(w, r, ud)
+		return
+	}
+
 	idr, err := parseId(catalogId)
 	if err != nil {
 		SendError(w, r, err)
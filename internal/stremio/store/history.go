@@ -0,0 +1,147 @@
+package stremio_store
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/server"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+	stremio_watch_history "github.com/MunifTanjim/stremthru/internal/stremio/watch_history"
+	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+const historyAddonName = "store"
+
+const max_history_items = 500
+
+func getHistoryCatalogId() string {
+	return getCatalogId("history")
+}
+
+func getHistoryManifestCatalog() stremio.Catalog {
+	return stremio.Catalog{
+		Id:   getHistoryCatalogId(),
+		Name: "Continue Watching",
+		Type: ContentTypeOther,
+		Extra: []stremio.CatalogExtra{
+			{
+				Name: "skip",
+			},
+		},
+	}
+}
+
+// history is keyed by store credentials instead of the full encoded userdata,
+// so toggling other options does not reset it.
+func getHistoryKey(ud *UserData) string {
+	return stremio_watch_history.GetKey(ud.StoreName + ":" + ud.StoreToken)
+}
+
+// recordPlayback records the watch history and scrobbles to Trakt.tv, as
+// enabled in userdata.
+func recordPlayback(cInfo *contentInfo, ud *UserData, idr *ParsedId, videoId, link, fileName string, log *logger.Logger) {
+	if cInfo.Hash == "" {
+		return
+	}
+
+	item := &stremio_watch_history.WatchHistory{
+		Addon: historyAddonName,
+		Key:   getHistoryKey(ud),
+		Hash:  cInfo.Hash,
+		File:  fileName,
+		MId:   getIdPrefix(idr.getStoreCode()) + videoId,
+	}
+
+	var file *store.MagnetFile
+	for i := range cInfo.Files {
+		f := &cInfo.Files[i]
+		if (link != "" && f.Link == link) || (fileName != "" && f.Name == fileName) {
+			file = f
+			break
+		}
+	}
+	if file != nil {
+		item.File = file.Name
+		if !idr.isUsenet && !idr.isWebDL {
+			filesByHash, err := torrent_stream.GetFilesByHashes([]string{cInfo.Hash})
+			if err != nil {
+				log.Error("failed to get files by hash", "error", err)
+			}
+			for _, f := range filesByHash[cInfo.Hash] {
+				if strings.HasPrefix(f.SId, "tt") && (f.Path == file.Path || (file.Idx != -1 && f.Idx == file.Idx)) {
+					item.SId = f.SId
+					break
+				}
+			}
+		}
+	}
+
+	if ud.EnableHistory {
+		if err := stremio_watch_history.Record(item); err != nil {
+			log.Error("failed to record history", "error", err)
+		}
+	}
+
+	if ud.TraktTokenId != "" && item.SId != "" {
+		stremio_shared.ScrobbleTrakt(ud.TraktTokenId, item.SId, log)
+	}
+}
+
+func getHistoryCatalogItems(ud *UserData, log *logger.Logger) ([]stremio.MetaPreview, error) {
+	return stremio_watch_history.ListCatalogItems(historyAddonName, getHistoryKey(ud), max_history_items, ContentTypeOther, log)
+}
+
+func handleHistoryCatalog(w http.ResponseWriter, r *http.Request, ud *UserData) {
+	if !ud.EnableHistory {
+		shared.ErrorBadRequest(r, "watch history is not enabled").Send(w, r)
+		return
+	}
+
+	log := server.GetReqCtx(r).Log
+
+	metas, err := getHistoryCatalogItems(ud, log)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	extra := getExtra(r)
+	limit := 100
+	totalItems := len(metas)
+	metas = metas[min(extra.Skip, totalItems):min(extra.Skip+limit, totalItems)]
+
+	SendResponse(w, r, 200, stremio.CatalogHandlerResponse{
+		Metas: metas,
+	})
+}
+
+func handleHistoryExport(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if !ud.HasRequiredValues() {
+		shared.ErrorBadRequest(r, "missing userdata").Send(w, r)
+		return
+	}
+
+	data, err := stremio_watch_history.Export(historyAddonName, getHistoryKey(ud))
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="stremthru-store-history.json"`)
+	SendResponse(w, r, 200, data)
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
-	stremio_store_usenet "github.com/MunifTanjim/stremthru/internal/stremio/store/usenet"
 	"github.com/MunifTanjim/stremthru/store"
 	"github.com/MunifTanjim/stremthru/stremio"
 )
@@ -92,7 +91,7 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 						idPrefixes = append(idPrefixes, getIdPrefix(code))
 						catalogs = append(catalogs, getManifestCatalog(code, ud.HideCatalog))
 
-						if ud.EnableUsenet && stremio_store_usenet.IsSupported(storeCode) && user.HasUsenet {
+						if ud.EnableUsenet && shared.GetUsenetStore(string(storeName)) != nil && user.HasUsenet {
 							usenetCode := code + "-usenet"
 							idPrefixes = append(idPrefixes, getIdPrefix(usenetCode))
 							catalogs = append(catalogs, getManifestCatalog(usenetCode, ud.HideCatalog))
@@ -138,7 +137,7 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 			idPrefixes = append(idPrefixes, getIdPrefix(storeCode))
 			catalogs = append(catalogs, getManifestCatalog(storeCode, ud.HideCatalog))
 
-			if ud.EnableUsenet && stremio_store_usenet.IsSupported(storeName.Code()) && user.HasUsenet {
+			if ud.EnableUsenet && shared.GetUsenetStore(string(storeName)) != nil && user.HasUsenet {
 				usenetCode := storeCode + "-usenet"
 				idPrefixes = append(idPrefixes, getIdPrefix(usenetCode))
 				catalogs = append(catalogs, getManifestCatalog(usenetCode, ud.HideCatalog))
@@ -152,6 +151,10 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 				}
 			}
 		}
+
+		if ud.EnableHistory {
+			catalogs = append(catalogs, getHistoryManifestCatalog())
+		}
 	} else {
 		name = "StremThru Store"
 	}
@@ -13,7 +13,6 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/logger"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_addon "github.com/MunifTanjim/stremthru/internal/stremio/addon"
-	stremio_store_usenet "github.com/MunifTanjim/stremthru/internal/stremio/store/usenet"
 	stremio_store_webdl "github.com/MunifTanjim/stremthru/internal/stremio/store/webdl"
 	"github.com/MunifTanjim/stremthru/internal/torrent_info"
 	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
@@ -245,15 +244,17 @@ type contentInfo struct {
 
 func getStoreContentInfo(s store.Store, storeToken string, id string, clientIp string, idr *ParsedId) (*contentInfo, error) {
 	if idr.isUsenet {
-		if s.GetName() != store.StoreNameTorBox {
+		us, ok := s.(store.UsenetStore)
+		if !ok {
 			return nil, nil
 		}
 
-		params := &stremio_store_usenet.GetNewsParams{
-			Id: id,
+		params := &store.GetNZBParams{
+			Id:       id,
+			ClientIP: clientIp,
 		}
 		params.APIKey = storeToken
-		news, err := stremio_store_usenet.GetNews(params, s.GetName())
+		news, err := us.GetNZB(params)
 		if err != nil {
 			return nil, err
 		}
@@ -264,16 +265,7 @@ func getStoreContentInfo(s store.Store, storeToken string, id string, clientIp s
 			Name:    news.Name,
 			Size:    news.Size,
 			Status:  news.Status,
-		}
-		for _, f := range news.Files {
-			cInfo.Files = append(cInfo.Files, store.MagnetFile{
-				Idx:       f.Idx,
-				Name:      f.Name,
-				Size:      f.Size,
-				Link:      f.Link,
-				VideoHash: f.VideoHash,
-			})
-
+			Files:   news.Files,
 		}
 		return &contentInfo{cInfo, news.GetLargestFileName()}, nil
 	}
@@ -2,14 +2,15 @@ package stremio_store
 
 import (
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/context"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	store_video "github.com/MunifTanjim/stremthru/internal/store/video"
-	stremio_store_usenet "github.com/MunifTanjim/stremthru/internal/stremio/store/usenet"
 	stremio_store_webdl "github.com/MunifTanjim/stremthru/internal/stremio/store/webdl"
 )
 
@@ -65,6 +66,14 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if IsMethod(r, http.MethodGet) && videoId != WEBDL_META_ID_INDICATOR {
+		shouldRecord := ud.EnableHistory || ud.TraktTokenId != ""
+		shouldPrefetch := ud.PrefetchNext && !idr.isUsenet && !idr.isWebDL
+		if shouldRecord || shouldPrefetch {
+			go onPlayback(ctx, shared.ExtractRequestBaseURL(r), ud, idr, videoId, link, r.PathValue("fileName"), shouldRecord, shouldPrefetch)
+		}
+	}
+
 	cacheKey := strings.Join([]string{ctx.ClientIP, idr.getStoreCode(), ctx.StoreAuthToken, url}, ":")
 
 	stremLink := ""
@@ -75,36 +84,15 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if idr.isUsenet {
-		storeName := ctx.Store.GetName()
-		rParams := &stremio_store_usenet.GenerateLinkParams{
-			Link:     link,
-			CLientIP: ctx.ClientIP,
-		}
-		rParams.APIKey = ctx.StoreAuthToken
-		var lerr error
-		data, err := stremio_store_usenet.GenerateLink(rParams, storeName)
-		if err == nil {
-			if config.StoreContentProxy.IsEnabled(string(storeName)) && ctx.StoreAuthToken == config.StoreAuthToken.GetToken(ctx.ProxyAuthUser, string(storeName)) {
-				if ctx.IsProxyAuthorized {
-					tunnelType := config.StoreTunnel.GetTypeForStream(string(ctx.Store.GetName()))
-					if proxyLink, err := shared.CreateProxyLink(r, data.Link, nil, tunnelType, 12*time.Hour, ctx.ProxyAuthUser, ctx.ProxyAuthPassword, true, ""); err == nil {
-						data.Link = proxyLink
-					} else {
-						lerr = err
-					}
-				}
-			}
-		} else {
-			lerr = err
-		}
-		if lerr != nil {
-			LogError(r, "failed to generate stremthru link", lerr)
+		stLink, err := shared.GenerateStremThruNZBLink(r, ctx, link)
+		if err != nil {
+			LogError(r, "failed to generate stremthru link", err)
 			store_video.Redirect("500", w, r)
 			return
 		}
 
-		stremLinkCache.Add(cacheKey, data.Link)
-		http.Redirect(w, r, data.Link, http.StatusFound)
+		stremLinkCache.Add(cacheKey, stLink.Link)
+		http.Redirect(w, r, stLink.Link, http.StatusFound)
 	} else if idr.isWebDL || videoId == WEBDL_META_ID_INDICATOR {
 		storeName := ctx.Store.GetName()
 		rParams := &stremio_store_webdl.GenerateLinkParams{
@@ -152,3 +140,24 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, stLink.Link, http.StatusFound)
 	}
 }
+
+// onPlayback runs in the background, after the strem handler has returned, so
+// it only gets the values it needs from the request. The content info lookup
+// is shared by the watch history and the next episode prefetch.
+func onPlayback(ctx *context.StoreContext, baseURL *url.URL, ud *UserData, idr *ParsedId, videoId, link, fileName string, shouldRecord, shouldPrefetch bool) {
+	cInfo, err := getStoreContentInfo(ctx.Store, ctx.StoreAuthToken, videoId, ctx.ClientIP, idr)
+	if err != nil || cInfo == nil {
+		if err != nil {
+			ctx.Log.Error("failed to get content info", "error", err)
+		}
+		return
+	}
+
+	if shouldRecord {
+		recordPlayback(cInfo, ud, idr, videoId, link, fileName, ctx.Log)
+	}
+
+	if shouldPrefetch {
+		prefetchNextEpisode(ctx, baseURL, idr, cInfo, link)
+	}
+}
@@ -0,0 +1,71 @@
+package stremio_store
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+// the store catalog only has content already in the store, so the next
+// episode is looked up in the same torrent (season pack) and its link is
+// generated ahead of time.
+func findNextEpisodeFile(files []store.MagnetFile, link string) *store.MagnetFile {
+	season, episode := -1, -1
+	for i := range files {
+		f := &files[i]
+		if f.Link == link {
+			data := stremio_shared.ParseSeasonEpisodeFromName(f.Name, false)
+			season, episode = data.Season, data.Episode
+			break
+		}
+	}
+	if season == -1 || episode == -1 {
+		return nil
+	}
+
+	var nextSeasonFile *store.MagnetFile
+	for i := range files {
+		f := &files[i]
+		if f.Link == "" || !core.HasVideoExtension(f.Name) {
+			continue
+		}
+		data := stremio_shared.ParseSeasonEpisodeFromName(f.Name, false)
+		if data.Season == season && data.Episode == episode+1 {
+			return f
+		}
+		if data.Season == season+1 && data.Episode == 1 {
+			nextSeasonFile = f
+		}
+	}
+	return nextSeasonFile
+}
+
+func prefetchNextEpisode(ctx *context.StoreContext, baseURL *url.URL, idr *ParsedId, cInfo *contentInfo, link string) {
+	log := ctx.Log
+
+	file := findNextEpisodeFile(cInfo.Files, link)
+	if file == nil {
+		return
+	}
+
+	cacheKey := strings.Join([]string{ctx.ClientIP, idr.getStoreCode(), ctx.StoreAuthToken, file.Link}, ":")
+
+	stremLink := ""
+	if stremLinkCache.Get(cacheKey, &stremLink) {
+		return
+	}
+
+	stLink, err := shared.GenerateStremThruLinkForBaseURL(baseURL, ctx, file.Link)
+	if err != nil {
+		log.Warn("prefetch: failed to generate stremthru link", "error", err, "filename", file.Name)
+		return
+	}
+
+	stremLinkCache.Add(cacheKey, stLink.Link)
+	log.Debug("prefetch: warmed next episode link", "filename", file.Name)
+}
@@ -0,0 +1,37 @@
+package stremio_store
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNextEpisodeFile(t *testing.T) {
+	files := []store.MagnetFile{
+		{Idx: 0, Name: "Show.S01E01.1080p.mkv", Link: "l0"},
+		{Idx: 1, Name: "Show.S01E02.1080p.mkv", Link: "l1"},
+		{Idx: 2, Name: "Show.S02E01.1080p.mkv", Link: "l2"},
+		{Idx: 3, Name: "Show.S02E01.1080p.srt", Link: "l3"},
+	}
+
+	for _, tc := range []struct {
+		name string
+		link string
+		next string
+	}{
+		{"same season", "l0", "l1"},
+		{"next season", "l1", "l2"},
+		{"last episode", "l2", ""},
+		{"unknown link", "lx", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			file := findNextEpisodeFile(files, tc.link)
+			if tc.next == "" {
+				assert.Nil(t, file)
+			} else if assert.NotNil(t, file) {
+				assert.Equal(t, tc.next, file.Link)
+			}
+		})
+	}
+}
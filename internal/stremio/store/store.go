@@ -41,6 +41,7 @@ func AddStremioStoreEndpoints(mux *http.ServeMux) {
 	router.HandleFunc("/{userData}/stream/{contentType}/{idJson}", withCors(handleStream))
 
 	router.HandleFunc("/{userData}/_/action/{actionId}", withCors(handleAction))
+	router.HandleFunc("/{userData}/_/history.json", withCors(handleHistoryExport))
 	router.HandleFunc("/{userData}/_/strem/{videoId}/{$}", withCors(handleStrem))
 	router.HandleFunc("/{userData}/_/strem/{videoId}/{fileName}", withCors(handleStrem))
 
@@ -25,6 +25,9 @@ func getStoreNameConfig(defaultValue string) configure.Config {
 		options[0].Disabled = true
 		options[0].Label = ""
 	}
+	if config.Feature.HasStoreLocal() {
+		options = append(options, configure.ConfigOption{Value: "local", Label: "Local 🧪"})
+	}
 	config := configure.Config{
 		Key:      "store_name",
 		Type:     "select",
@@ -69,6 +72,22 @@ func getTemplateData(ud *UserData) *configure.TemplateData {
 	if ud.EnableUsenet {
 		enableUsenetConfig.Default = "checked"
 	}
+	enableHistoryConfig := configure.Config{
+		Key:   "enable_history",
+		Type:  configure.ConfigTypeCheckbox,
+		Title: "Enable Watch History",
+	}
+	if ud.EnableHistory {
+		enableHistoryConfig.Default = "checked"
+	}
+	prefetchNextConfig := configure.Config{
+		Key:   "prefetch_next",
+		Type:  configure.ConfigTypeCheckbox,
+		Title: "Prefetch Next Episode",
+	}
+	if ud.PrefetchNext {
+		prefetchNextConfig.Default = "checked"
+	}
 	return &configure.TemplateData{
 		Base: configure.Base{
 			Title:       "StremThru Store",
@@ -89,6 +108,9 @@ func getTemplateData(ud *UserData) *configure.TemplateData {
 			hideStreamConfig,
 			enableWebDLConfig,
 			enableUsenetConfig,
+			enableHistoryConfig,
+			prefetchNextConfig,
+			stremio_shared.GetTraktScrobbleConfig(ud.TraktTokenId),
 		},
 		Script: configure.GetScriptStoreTokenDescription("'#store_name'", "'#store_token'"),
 	}
@@ -14,13 +14,16 @@ import (
 )
 
 type UserData struct {
-	StoreName    string `json:"store_name"`
-	StoreToken   string `json:"store_token"`
-	HideCatalog  bool   `json:"hide_catalog,omitempty"`
-	HideStream   bool   `json:"hide_stream,omitempty"`
-	EnableWebDL  bool   `json:"webdl,omitempty"`
-	EnableUsenet bool   `json:"usenet,omitempty"`
-	encoded      string `json:"-"`
+	StoreName     string `json:"store_name"`
+	StoreToken    string `json:"store_token"`
+	HideCatalog   bool   `json:"hide_catalog,omitempty"`
+	HideStream    bool   `json:"hide_stream,omitempty"`
+	EnableWebDL   bool   `json:"webdl,omitempty"`
+	EnableUsenet  bool   `json:"usenet,omitempty"`
+	EnableHistory bool   `json:"history,omitempty"`
+	PrefetchNext  bool   `json:"prefetch,omitempty"`
+	TraktTokenId  string `json:"trakt_token_id,omitempty"`
+	encoded       string `json:"-"`
 
 	idPrefixes []string `json:"-"`
 }
@@ -50,10 +53,11 @@ func (ud *UserData) getIdPrefixes() []string {
 						storeName := store.StoreName(name)
 						storeCode := "st-" + string(storeName.Code())
 						ud.idPrefixes = append(ud.idPrefixes, getIdPrefix(storeCode))
-						if storeName == store.StoreNameTorBox {
+						if shared.GetUsenetStore(name) != nil {
 							code := storeCode + "-usenet"
 							ud.idPrefixes = append(ud.idPrefixes, getIdPrefix(code))
-
+						}
+						if storeName == store.StoreNameTorBox {
 							if ud.EnableWebDL {
 								code := storeCode + "-webdl"
 								ud.idPrefixes = append(ud.idPrefixes, getIdPrefix(code))
@@ -66,10 +70,11 @@ func (ud *UserData) getIdPrefixes() []string {
 			storeName := store.StoreName(ud.StoreName)
 			storeCode := string(storeName.Code())
 			ud.idPrefixes = append(ud.idPrefixes, getIdPrefix(storeCode))
-			if storeName == store.StoreNameTorBox {
+			if shared.GetUsenetStore(ud.StoreName) != nil {
 				code := storeCode + "-usenet"
 				ud.idPrefixes = append(ud.idPrefixes, getIdPrefix(code))
-
+			}
+			if storeName == store.StoreNameTorBox {
 				if ud.EnableWebDL {
 					code := storeCode + "-webdl"
 					ud.idPrefixes = append(ud.idPrefixes, getIdPrefix(code))
@@ -161,6 +166,9 @@ func getUserData(r *http.Request) (*UserData, error) {
 		data.HideStream = r.FormValue("hide_stream") == "on"
 		data.EnableWebDL = r.FormValue("enable_webdl") == "on"
 		data.EnableUsenet = r.FormValue("enable_usenet") == "on"
+		data.EnableHistory = r.FormValue("enable_history") == "on"
+		data.PrefetchNext = r.FormValue("prefetch_next") == "on"
+		data.TraktTokenId = r.FormValue("trakt_token_id")
 		encoded, err := data.GetEncoded()
 		if err != nil {
 			return nil, err
@@ -28,6 +28,18 @@ func handleConfigure(w http.ResponseWriter, r *http.Request) {
 			if ud.CachedOnly {
 				conf.Default = "checked"
 			}
+		case "history":
+			if ud.EnableHistory {
+				conf.Default = "checked"
+			}
+		case "prefetch":
+			if ud.PrefetchNext {
+				conf.Default = "checked"
+			}
+		case "local":
+			if ud.LocalOnly {
+				conf.Default = "checked"
+			}
 		}
 	}
 
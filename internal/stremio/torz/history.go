@@ -0,0 +1,136 @@
+package stremio_torz
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/server"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+	stremio_watch_history "github.com/MunifTanjim/stremthru/internal/stremio/watch_history"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+const historyAddonName = "torz"
+
+const max_history_items = 500
+
+const historyCatalogId = "st.torz.history"
+
+const historyCatalogType = "other"
+
+func getHistoryManifestCatalog() stremio.Catalog {
+	return stremio.Catalog{
+		Id:   historyCatalogId,
+		Name: "Continue Watching",
+		Type: historyCatalogType,
+		Extra: []stremio.CatalogExtra{
+			{
+				Name: "skip",
+			},
+		},
+	}
+}
+
+// unsaved userdata is keyed by store credentials, so toggling other options
+// does not reset the history.
+func getHistoryKey(ud *UserData) string {
+	if udManager.IsSaved(ud) {
+		return stremio_watch_history.GetKey(ud.GetEncoded())
+	}
+	var key strings.Builder
+	for i := range ud.Stores {
+		s := &ud.Stores[i]
+		if s.Token == "" {
+			return stremio_watch_history.GetKey(ud.GetEncoded())
+		}
+		key.WriteString(string(s.Code) + ":" + s.Token + ",")
+	}
+	return stremio_watch_history.GetKey(key.String())
+}
+
+func recordHistory(ud *UserData, sid, hash, fileName string, log *logger.Logger) {
+	item := &stremio_watch_history.WatchHistory{
+		Addon: historyAddonName,
+		Key:   getHistoryKey(ud),
+		Hash:  hash,
+		File:  fileName,
+	}
+	if strings.HasPrefix(sid, "tt") {
+		item.SId = sid
+	}
+	if err := stremio_watch_history.Record(item); err != nil {
+		log.Error("failed to record history", "error", err)
+	}
+}
+
+func handleCatalog(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if stremio_shared.GetPathValue(r, "id") != historyCatalogId {
+		shared.ErrorNotFound(r).Send(w, r)
+		return
+	}
+
+	if !ud.EnableHistory {
+		shared.ErrorBadRequest(r, "watch history is not enabled").Send(w, r)
+		return
+	}
+
+	metas, err := stremio_watch_history.ListCatalogItems(historyAddonName, getHistoryKey(ud), max_history_items, historyCatalogType, server.GetReqCtx(r).Log)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	skip := 0
+	if q, err := url.ParseQuery(stremio_shared.GetPathValue(r, "extra")); err == nil {
+		skip, _ = strconv.Atoi(q.Get("skip"))
+	}
+	limit := 100
+	totalItems := len(metas)
+	metas = metas[min(max(skip, 0), totalItems):min(max(skip, 0)+limit, totalItems)]
+
+	SendResponse(w, r, 200, stremio.CatalogHandlerResponse{
+		Metas: metas,
+	})
+}
+
+func handleHistoryExport(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if !ud.HasRequiredValues() {
+		shared.ErrorBadRequest(r, "missing userdata").Send(w, r)
+		return
+	}
+
+	data, err := stremio_watch_history.Export(historyAddonName, getHistoryKey(ud))
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="stremthru-torz-history.json"`)
+	SendResponse(w, r, 200, data)
+}
@@ -50,6 +50,11 @@ func GetManifest(r *http.Request, ud *UserData) *stremio.Manifest {
 		streamResource.IDPrefixes = append(streamResource.IDPrefixes, "kitsu:", "mal:")
 	}
 
+	catalogs := []stremio.Catalog{}
+	if isConfigured && ud.EnableHistory {
+		catalogs = append(catalogs, getHistoryManifestCatalog())
+	}
+
 	manifest := &stremio.Manifest{
 		ID:          id,
 		Name:        name,
@@ -59,7 +64,7 @@ func GetManifest(r *http.Request, ud *UserData) *stremio.Manifest {
 			streamResource,
 		},
 		Types:    []stremio.ContentType{},
-		Catalogs: []stremio.Catalog{},
+		Catalogs: catalogs,
 		Logo:     "https://emojiapi.dev/api/v1/sparkles/256.png",
 		BehaviorHints: &stremio.BehaviorHints{
 			Configurable:          true,
@@ -3,6 +3,7 @@ package stremio_torz
 import (
 	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/buddy"
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/release_group_reputation"
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	store_video "github.com/MunifTanjim/stremthru/internal/store/video"
@@ -41,6 +43,201 @@ type stremResult struct {
 	error_video string
 }
 
+type stremParams struct {
+	sid         string
+	magnetHash  string
+	encodedLink string
+	fileIdx     int
+	fileName    string
+	cacheKey    string
+}
+
+// getStremCacheKey is also used to warm up the link for the next episode, so
+// it must only depend on the values in the strem url.
+func getStremCacheKey(ctx *RequestContext, storeCode store.StoreCode, sid, magnetHash string, fileIdx int, fileName string) string {
+	return strings.Join([]string{ctx.ClientIP, string(storeCode), ctx.StoreAuthToken, sid, magnetHash, strconv.Itoa(fileIdx), fileName}, ":")
+}
+
+func resolveStremLink(baseURL *url.URL, ctx *RequestContext, p *stremParams) (*stremResult, error) {
+	log := ctx.Log
+	storeCode := ctx.Store.GetName().Code()
+
+	log.Debug("creating stream link")
+	amParams := &store.AddMagnetParams{
+		ClientIP: ctx.ClientIP,
+	}
+	amParams.APIKey = ctx.StoreAuthToken
+	if p.encodedLink == "" {
+		amParams.Magnet = p.magnetHash
+	} else {
+		link, err := core.Base64Decode(p.encodedLink)
+		if err != nil {
+			return &stremResult{
+				error_level: logger.LevelError,
+				error_log:   "failed to decode torrent link",
+				error_video: store_video.StoreVideoName500,
+			}, err
+		}
+		fileHeader, err := shared.FetchTorrentFile(link, 1024*1024)
+		if err != nil {
+			return &stremResult{
+				error_level: logger.LevelError,
+				error_log:   "failed to fetch torrent file",
+				error_video: store_video.StoreVideoName500,
+			}, err
+		}
+		amParams.Torrent = fileHeader
+		if _, _, err := amParams.GetTorrentMeta(); err != nil {
+			return &stremResult{
+				error_level: logger.LevelError,
+				error_log:   "invalid torrent file",
+				error_video: store_video.StoreVideoName500,
+			}, err
+		}
+	}
+	amRes, err := ctx.Store.AddMagnet(amParams)
+	if err != nil {
+		result := &stremResult{
+			error_level: logger.LevelError,
+			error_log:   "failed to add magnet",
+			error_video: store_video.StoreVideoNameDownloadFailed,
+		}
+		var uerr *core.UpstreamError
+		if errors.As(err, &uerr) {
+			switch uerr.Code {
+			case core.ErrorCodeUnauthorized:
+				result.error_level = logger.LevelWarn
+				result.error_log = "unauthorized"
+				result.error_video = store_video.StoreVideoName401
+			case core.ErrorCodeTooManyRequests:
+				result.error_level = logger.LevelWarn
+				result.error_log = "too many requests"
+				result.error_video = store_video.StoreVideoName429
+			case core.ErrorCodeUnavailableForLegalReasons:
+				result.error_level = logger.LevelWarn
+				result.error_log = "unavaiable for legal reason"
+				result.error_video = store_video.StoreVideoName451
+			case core.ErrorCodePaymentRequired:
+				result.error_level = logger.LevelWarn
+				result.error_log = "payment required"
+				result.error_video = store_video.StoreVideoNamePaymentRequired
+			case core.ErrorCodeStoreLimitExceeded:
+				result.error_log = "store limit exceeded"
+				result.error_video = store_video.StoreVideoNameStoreLimitExceeded
+			case core.ErrorCodeStoreServerDown:
+				result.error_level = logger.LevelWarn
+				result.error_log = "store server down"
+				result.error_video = store_video.StoreVideoName500
+			}
+		}
+		return result, err
+	}
+
+	stremio_store.InvalidateCatalogCache(storeCode, ctx.StoreAuthToken)
+
+	magnet := &store.GetMagnetData{
+		Id:      amRes.Id,
+		Name:    amRes.Name,
+		Hash:    amRes.Hash,
+		Status:  amRes.Status,
+		Files:   amRes.Files,
+		AddedAt: amRes.AddedAt,
+	}
+
+	isIMDBId := strings.HasPrefix(p.sid, "tt")
+	isKitsuId := strings.HasPrefix(p.sid, "kitsu:")
+	isMALId := strings.HasPrefix(p.sid, "mal:")
+	isAnimeId := isKitsuId || isMALId
+	shouldTagStream := isIMDBId || isAnimeId
+
+	magnet, err = stremio_shared.WaitForMagnetStatus(ctx.StoreContext, magnet, store.MagnetStatusDownloaded, 3, 5*time.Second)
+	if err != nil {
+		strem := &stremResult{
+			error_level: logger.LevelError,
+			error_log:   "failed wait for magnet status",
+			error_video: store_video.StoreVideoName500,
+		}
+		switch magnet.Status {
+		case store.MagnetStatusQueued, store.MagnetStatusDownloading, store.MagnetStatusProcessing:
+			strem.error_level = logger.LevelWarn
+			strem.error_video = store_video.StoreVideoNameDownloading
+		case store.MagnetStatusFailed, store.MagnetStatusInvalid, store.MagnetStatusUnknown:
+			strem.error_level = logger.LevelWarn
+			strem.error_video = store_video.StoreVideoNameDownloadFailed
+			recordMagnetStatusReputation(p.magnetHash, magnet.Status)
+		}
+		return strem, err
+	}
+
+	go buddy.TrackMagnet(ctx.Store, magnet.Hash, magnet.Name, magnet.Size, magnet.Private, magnet.Files, torrent_info.GetCategoryFromStremId(p.sid, ""), magnet.Status != store.MagnetStatusDownloaded, ctx.StoreAuthToken)
+
+	videoFiles := []store.MagnetFile{}
+	for i := range magnet.Files {
+		f := &magnet.Files[i]
+		if core.HasVideoExtension(f.Name) {
+			videoFiles = append(videoFiles, *f)
+		}
+	}
+
+	var file *store.MagnetFile
+	if strings.Contains(p.sid, ":") {
+		if file = stremio_shared.MatchFileByStremId(videoFiles, p.sid, p.magnetHash, storeCode); file != nil {
+			log.Debug("matched file using strem id", "sid", p.sid, "filename", file.Name)
+		}
+	}
+	if file == nil && p.fileName != "" {
+		if file = stremio_shared.MatchFileByName(videoFiles, p.fileName); file != nil {
+			log.Debug("matched file using filename", "filename", file.Name)
+		}
+	}
+	if file == nil {
+		if file = stremio_shared.MatchFileByIdx(videoFiles, p.fileIdx, storeCode); file != nil {
+			log.Debug("matched file using fileidx", "fileidx", file.Idx, "filename", file.Name)
+		}
+	}
+	if file == nil && isIMDBId && (!strings.Contains(p.sid, ":") || len(videoFiles) == 1) {
+		if file = stremio_shared.MatchFileByLargestSize(videoFiles); file != nil {
+			log.Debug("matched file using largest size", "filename", file.Name)
+			shouldTagStream = len(videoFiles) == 1
+		}
+	}
+
+	link := ""
+	if file != nil {
+		link = file.Link
+	}
+	if link == "" {
+		return &stremResult{
+			error_level: logger.LevelWarn,
+			error_log:   "no matching file found for (" + p.sid + " - " + magnet.Hash + ")",
+			error_video: store_video.StoreVideoNameNoMatchingFile,
+		}, nil
+	}
+
+	if shouldTagStream {
+		if isIMDBId {
+			torrent_stream.TagStremId(magnet.Hash, file.Path, p.sid)
+		} else if isAnimeId {
+			go torrent_stream.TagAnimeStremId(magnet.Hash, file.Path, p.sid)
+		}
+	}
+
+	glRes, err := shared.GenerateStremThruLinkForBaseURL(baseURL, ctx.StoreContext, link)
+	if err != nil {
+		return &stremResult{
+			error_level: logger.LevelError,
+			error_log:   "failed to generate stremthru link",
+			error_video: store_video.StoreVideoName500,
+		}, err
+	}
+
+	stremLinkCache.Add(p.cacheKey, glRes.Link)
+
+	return &stremResult{
+		link: glRes.Link,
+	}, nil
+}
+
 func handleStrem(w http.ResponseWriter, r *http.Request) {
 	if !IsMethod(r, http.MethodGet) && !IsMethod(r, http.MethodHead) {
 		shared.ErrorMethodNotAllowed(r).Send(w, r)
@@ -75,189 +272,39 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 	ctx.Store, ctx.StoreAuthToken = s.Store, s.AuthToken
 	storeCode := s.Store.GetName().Code()
 
-	cacheKey := strings.Join([]string{ctx.ClientIP, string(storeCode), ctx.StoreAuthToken, sid, magnetHash, strconv.Itoa(fileIdx), fileName}, ":")
+	cacheKey := getStremCacheKey(ctx, storeCode, sid, magnetHash, fileIdx, fileName)
+	baseURL := shared.ExtractRequestBaseURL(r)
+
+	shouldRecordHistory := ud.EnableHistory && IsMethod(r, http.MethodGet)
+	shouldPrefetchNext := ud.PrefetchNext && IsMethod(r, http.MethodGet) && strings.HasPrefix(sid, "tt") && strings.Contains(sid, ":")
+	shouldScrobble := ud.TraktTokenId != "" && IsMethod(r, http.MethodGet)
 
 	stremLink := ""
 	if stremLinkCache.Get(cacheKey, &stremLink) {
 		log.Debug("redirecting to cached stream link")
+		if shouldRecordHistory {
+			go recordHistory(ud, sid, magnetHash, fileName, log)
+		}
+		if shouldPrefetchNext {
+			go prefetchNextEpisode(baseURL, ctx, ud, sid, magnetHash)
+		}
+		if shouldScrobble {
+			go stremio_shared.ScrobbleTrakt(ud.TraktTokenId, sid, log)
+		}
 		http.Redirect(w, r, stremLink, http.StatusFound)
 		return
 	}
 
+	p := &stremParams{
+		sid:         sid,
+		magnetHash:  magnetHash,
+		encodedLink: encodedLink,
+		fileIdx:     fileIdx,
+		fileName:    fileName,
+		cacheKey:    cacheKey,
+	}
 	result, err, _ := stremGroup.Do(cacheKey, func() (any, error) {
-		log.Debug("creating stream link")
-		amParams := &store.AddMagnetParams{
-			ClientIP: ctx.ClientIP,
-		}
-		amParams.APIKey = ctx.StoreAuthToken
-		if encodedLink == "" {
-			amParams.Magnet = magnetHash
-		} else {
-			link, err := core.Base64Decode(encodedLink)
-			if err != nil {
-				return &stremResult{
-					error_level: logger.LevelError,
-					error_log:   "failed to decode torrent link",
-					error_video: store_video.StoreVideoName500,
-				}, err
-			}
-			fileHeader, err := shared.FetchTorrentFile(link, 1024*1024)
-			if err != nil {
-				return &stremResult{
-					error_level: logger.LevelError,
-					error_log:   "failed to fetch torrent file",
-					error_video: store_video.StoreVideoName500,
-				}, err
-			}
-			amParams.Torrent = fileHeader
-			if _, _, err := amParams.GetTorrentMeta(); err != nil {
-				return &stremResult{
-					error_level: logger.LevelError,
-					error_log:   "invalid torrent file",
-					error_video: store_video.StoreVideoName500,
-				}, err
-			}
-		}
-		amRes, err := ctx.Store.AddMagnet(amParams)
-		if err != nil {
-			result := &stremResult{
-				error_level: logger.LevelError,
-				error_log:   "failed to add magnet",
-				error_video: store_video.StoreVideoNameDownloadFailed,
-			}
-			var uerr *core.UpstreamError
-			if errors.As(err, &uerr) {
-				switch uerr.Code {
-				case core.ErrorCodeUnauthorized:
-					result.error_level = logger.LevelWarn
-					result.error_log = "unauthorized"
-					result.error_video = store_video.StoreVideoName401
-				case core.ErrorCodeTooManyRequests:
-					result.error_level = logger.LevelWarn
-					result.error_log = "too many requests"
-					result.error_video = store_video.StoreVideoName429
-				case core.ErrorCodeUnavailableForLegalReasons:
-					result.error_level = logger.LevelWarn
-					result.error_log = "unavaiable for legal reason"
-					result.error_video = store_video.StoreVideoName451
-				case core.ErrorCodePaymentRequired:
-					result.error_level = logger.LevelWarn
-					result.error_log = "payment required"
-					result.error_video = store_video.StoreVideoNamePaymentRequired
-				case core.ErrorCodeStoreLimitExceeded:
-					result.error_log = "store limit exceeded"
-					result.error_video = store_video.StoreVideoNameStoreLimitExceeded
-				case core.ErrorCodeStoreServerDown:
-					result.error_level = logger.LevelWarn
-					result.error_log = "store server down"
-					result.error_video = store_video.StoreVideoName500
-				}
-			}
-			return result, err
-		}
-
-		stremio_store.InvalidateCatalogCache(storeCode, ctx.StoreAuthToken)
-
-		magnet := &store.GetMagnetData{
-			Id:      amRes.Id,
-			Name:    amRes.Name,
-			Hash:    amRes.Hash,
-			Status:  amRes.Status,
-			Files:   amRes.Files,
-			AddedAt: amRes.AddedAt,
-		}
-
-		isIMDBId := strings.HasPrefix(sid, "tt")
-		isKitsuId := strings.HasPrefix(sid, "kitsu:")
-		isMALId := strings.HasPrefix(sid, "mal:")
-		isAnimeId := isKitsuId || isMALId
-		shouldTagStream := isIMDBId || isAnimeId
-
-		magnet, err = stremio_shared.WaitForMagnetStatus(ctx.StoreContext, magnet, store.MagnetStatusDownloaded, 3, 5*time.Second)
-		if err != nil {
-			strem := &stremResult{
-				error_level: logger.LevelError,
-				error_log:   "failed wait for magnet status",
-				error_video: store_video.StoreVideoName500,
-			}
-			switch magnet.Status {
-			case store.MagnetStatusQueued, store.MagnetStatusDownloading, store.MagnetStatusProcessing:
-				strem.error_level = logger.LevelWarn
-				strem.error_video = store_video.StoreVideoNameDownloading
-			case store.MagnetStatusFailed, store.MagnetStatusInvalid, store.MagnetStatusUnknown:
-				strem.error_level = logger.LevelWarn
-				strem.error_video = store_video.StoreVideoNameDownloadFailed
-			}
-			return strem, err
-		}
-
-		go buddy.TrackMagnet(ctx.Store, magnet.Hash, magnet.Name, magnet.Size, magnet.Private, magnet.Files, torrent_info.GetCategoryFromStremId(sid, ""), magnet.Status != store.MagnetStatusDownloaded, ctx.StoreAuthToken)
-
-		videoFiles := []store.MagnetFile{}
-		for i := range magnet.Files {
-			f := &magnet.Files[i]
-			if core.HasVideoExtension(f.Name) {
-				videoFiles = append(videoFiles, *f)
-			}
-		}
-
-		var file *store.MagnetFile
-		if strings.Contains(sid, ":") {
-			if file = stremio_shared.MatchFileByStremId(videoFiles, sid, magnetHash, storeCode); file != nil {
-				log.Debug("matched file using strem id", "sid", sid, "filename", file.Name)
-			}
-		}
-		if file == nil && fileName != "" {
-			if file = stremio_shared.MatchFileByName(videoFiles, fileName); file != nil {
-				log.Debug("matched file using filename", "filename", file.Name)
-			}
-		}
-		if file == nil {
-			if file = stremio_shared.MatchFileByIdx(videoFiles, fileIdx, storeCode); file != nil {
-				log.Debug("matched file using fileidx", "fileidx", file.Idx, "filename", file.Name)
-			}
-		}
-		if file == nil && isIMDBId && (!strings.Contains(sid, ":") || len(videoFiles) == 1) {
-			if file = stremio_shared.MatchFileByLargestSize(videoFiles); file != nil {
-				log.Debug("matched file using largest size", "filename", file.Name)
-				shouldTagStream = len(videoFiles) == 1
-			}
-		}
-
-		link := ""
-		if file != nil {
-			link = file.Link
-		}
-		if link == "" {
-			return &stremResult{
-				error_level: logger.LevelWarn,
-				error_log:   "no matching file found for (" + sid + " - " + magnet.Hash + ")",
-				error_video: store_video.StoreVideoNameNoMatchingFile,
-			}, nil
-		}
-
-		if shouldTagStream {
-			if isIMDBId {
-				torrent_stream.TagStremId(magnet.Hash, file.Path, sid)
-			} else if isAnimeId {
-				go torrent_stream.TagAnimeStremId(magnet.Hash, file.Path, sid)
-			}
-		}
-
-		glRes, err := shared.GenerateStremThruLink(r, ctx.StoreContext, link)
-		if err != nil {
-			return &stremResult{
-				error_level: logger.LevelError,
-				error_log:   "failed to generate stremthru link",
-				error_video: store_video.StoreVideoName500,
-			}, err
-		}
-
-		stremLinkCache.Add(cacheKey, glRes.Link)
-
-		return &stremResult{
-			link: glRes.Link,
-		}, nil
+		return resolveStremLink(baseURL, ctx, p)
 	})
 
 	strem := result.(*stremResult)
@@ -269,5 +316,26 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Debug("redirecting to stream link")
+	if IsMethod(r, http.MethodGet) {
+		go release_group_reputation.RecordByHash(magnetHash, release_group_reputation.SignalSuccess)
+	}
+	if shouldRecordHistory {
+		go recordHistory(ud, sid, magnetHash, fileName, log)
+	}
+	if shouldPrefetchNext {
+		go prefetchNextEpisode(baseURL, ctx, ud, sid, magnetHash)
+	}
+	if shouldScrobble {
+		go stremio_shared.ScrobbleTrakt(ud.TraktTokenId, sid, log)
+	}
 	http.Redirect(w, r, strem.link, http.StatusFound)
 }
+
+func recordMagnetStatusReputation(hash string, status store.MagnetStatus) {
+	switch status {
+	case store.MagnetStatusFailed:
+		go release_group_reputation.RecordByHash(hash, release_group_reputation.SignalFailed)
+	case store.MagnetStatusInvalid:
+		go release_group_reputation.RecordByHash(hash, release_group_reputation.SignalInvalid)
+	}
+}
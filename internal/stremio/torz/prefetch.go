@@ -0,0 +1,131 @@
+package stremio_torz
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	stremio_watch_history "github.com/MunifTanjim/stremthru/internal/stremio/watch_history"
+	"github.com/MunifTanjim/stremthru/internal/torrent_info"
+	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+var prefetchCache = cache.NewCache[bool](&cache.CacheConfig{
+	Name:     "stremio:torz:prefetch",
+	Lifetime: 6 * time.Hour,
+})
+
+// picks the stream for the next episode: the same torrent when it is a season
+// pack, otherwise the best stream by user's filter and sort.
+func pickNextEpisodeStream(ctx *RequestContext, ud *UserData, sid, currentHash string) (*WrappedStream, error) {
+	nsid, err := torrent_stream.NormalizeStreamId(sid)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes, err := torrent_info.ListHashesByStremId(sid)
+	if err != nil {
+		return nil, err
+	}
+	hashes = append([]string{currentHash}, hashes...)
+
+	wrappedStreams, err := GetStreamsForHashes(string(stremio.ContentTypeSeries), sid, hashes, nsid)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range wrappedStreams {
+		wStream := &wrappedStreams[i]
+		if wStream.R.Hash == currentHash && wStream.R.File.Idx != -1 {
+			return wStream, nil
+		}
+	}
+
+	storeCode := ctx.Store.GetName().Code()
+	cmRes := ud.CheckMagnet(&store.CheckMagnetParams{
+		Magnets:  hashes,
+		ClientIP: ctx.ClientIP,
+		SId:      sid,
+	}, ctx.Log)
+	for i := range wrappedStreams {
+		if code := cmRes.ByHash[wrappedStreams[i].R.Hash]; strings.EqualFold(code, string(storeCode)) {
+			wrappedStreams[i].R.Store.IsCached = true
+		}
+	}
+
+	wrappedStreams = processStreams(ud, wrappedStreams, nil)
+
+	var best *WrappedStream
+	for i := range wrappedStreams {
+		wStream := &wrappedStreams[i]
+		if wStream.R.IsPrivate {
+			continue
+		}
+		if wStream.R.Store.IsCached {
+			return wStream, nil
+		}
+		if best == nil && !ud.CachedOnly {
+			best = wStream
+		}
+	}
+	return best, nil
+}
+
+func prefetchNextEpisode(baseURL *url.URL, ctx *RequestContext, ud *UserData, sid, currentHash string) {
+	log := ctx.Log
+
+	next, err := stremio_watch_history.GetNextEpisode(currentHash, sid)
+	if err != nil {
+		log.Warn("prefetch: failed to get next episode", "error", err, "sid", sid)
+	}
+	if next == nil {
+		return
+	}
+
+	storeCode := ctx.Store.GetName().Code()
+
+	prefetchKey := strings.Join([]string{string(storeCode), ctx.StoreAuthToken, next.StremId}, ":")
+	var prefetched bool
+	if prefetchCache.Get(prefetchKey, &prefetched) {
+		return
+	}
+	prefetchCache.Add(prefetchKey, true)
+
+	// the file is picked the same way as for the stream list, so that the
+	// cache key matches the one for the strem url of the next episode.
+	wStream, err := pickNextEpisodeStream(ctx, ud, next.StremId, currentHash)
+	if err != nil {
+		log.Warn("prefetch: failed to pick stream", "error", err, "sid", next.StremId)
+		return
+	}
+	if wStream == nil {
+		log.Debug("prefetch: no stream found", "sid", next.StremId)
+		return
+	}
+
+	p := &stremParams{
+		sid:        next.StremId,
+		magnetHash: wStream.R.Hash,
+		fileIdx:    wStream.R.File.Idx,
+		fileName:   wStream.R.File.Name,
+	}
+	p.cacheKey = getStremCacheKey(ctx, storeCode, p.sid, p.magnetHash, p.fileIdx, p.fileName)
+
+	stremLink := ""
+	if stremLinkCache.Get(p.cacheKey, &stremLink) {
+		return
+	}
+
+	log.Debug("prefetch: resolving next episode", "sid", p.sid, "hash", p.magnetHash)
+	result, err, _ := stremGroup.Do(p.cacheKey, func() (any, error) {
+		return resolveStremLink(baseURL, ctx, p)
+	})
+	if strem := result.(*stremResult); strem.error_log != "" {
+		log.Debug("prefetch: "+strem.error_log, "error", err, "sid", p.sid, "hash", p.magnetHash)
+		return
+	}
+	log.Debug("prefetch: warmed next episode link", "sid", p.sid, "hash", p.magnetHash)
+}
@@ -0,0 +1,102 @@
+package stremio_torz
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+func (s WrappedStream) ToPreviewItem() *stremio_transformer.StreamPreviewItem {
+	item := &stremio_transformer.StreamPreviewItem{
+		Source:      s.R.Addon.Name,
+		Name:        s.Name,
+		Description: s.Description,
+		Fields:      s.R,
+	}
+	item.Raw.Name = s.Name
+	item.Raw.Description = s.Description
+
+	stream := *s.Stream
+	if rendered, err := streamTemplate.Execute(&stream, s.R); err != nil {
+		item.AddError(stremio_transformer.StreamPreviewStageTemplate, err)
+	} else {
+		item.Name = rendered.Name
+		item.Description = rendered.Description
+	}
+	return item
+}
+
+// handleConfigurePreview runs the stream pipeline for a sample id with the
+// (draft) userdata from the configure page.
+func handleConfigurePreview(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) && !IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	id := strings.TrimSuffix(r.URL.Query().Get("id"), ".json")
+	if !strings.HasPrefix(id, "tt") {
+		shared.ErrorBadRequest(r, "invalid id, expected imdb id").Send(w, r)
+		return
+	}
+	rType := r.URL.Query().Get("type")
+	if rType == "" {
+		rType = string(stremio.ContentTypeMovie)
+		if strings.Contains(id, ":") {
+			rType = string(stremio.ContentTypeSeries)
+		}
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	ctx, err := ud.GetRequestContext(r)
+	if err != nil {
+		shared.ErrorBadRequest(r, "failed to get request context: "+err.Error()).Send(w, r)
+		return
+	}
+
+	nsid, err := torrent_stream.NormalizeStreamId(id)
+	if err != nil {
+		if errors.Is(err, torrent_stream.ErrUnsupportedStremId) {
+			shared.ErrorBadRequest(r, "unsupported id: "+id).Send(w, r)
+			return
+		}
+		SendError(w, r, err)
+		return
+	}
+
+	trace := stremio_transformer.NewStreamTrace[WrappedStream]()
+
+	wrappedStreams, hashes, err := fetchStreams(ctx, ud, rType, id, nsid)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if !ud.IsP2P() {
+		isCachedByHash, _, err := checkStreams(ctx, ud, id, hashes, wrappedStreams)
+		if err != nil {
+			trace.AddError(stremio_transformer.StreamPreviewStageFetch, err)
+		}
+		for i := range wrappedStreams {
+			if storeCode := isCachedByHash[wrappedStreams[i].R.Hash]; storeCode != "" {
+				wrappedStreams[i].R.Store.Code = storeCode
+				wrappedStreams[i].R.Store.Name = string(store.StoreCode(strings.ToLower(storeCode)).Name())
+			}
+		}
+	}
+
+	wrappedStreams = processStreams(ud, wrappedStreams, trace)
+
+	SendResponse(w, r, 200, stremio_transformer.PreviewStreams(wrappedStreams, trace))
+}
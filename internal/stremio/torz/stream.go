@@ -15,6 +15,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/buddy"
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/imdb_title"
+	"github.com/MunifTanjim/stremthru/internal/p2p"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
@@ -36,26 +37,43 @@ type WrappedStream struct {
 	*stremio.Stream
 	R           *stremio_transformer.StreamExtractorResult
 	torrentLink string
+	seenAt      time.Time
 }
 
 func (s WrappedStream) IsSortable() bool {
 	return s.R != nil
 }
 
-func (s WrappedStream) GetQuality() string {
-	return s.R.Quality
+func (s WrappedStream) GetExtractorResult() *stremio_transformer.StreamExtractorResult {
+	return s.R
 }
 
-func (s WrappedStream) GetResolution() string {
-	return s.R.Resolution
+func (s WrappedStream) GetSeenAt() time.Time {
+	return s.seenAt
 }
 
-func (s WrappedStream) GetSize() string {
-	return s.R.Size
+// sortStreams ranks the streams with the quality profile when selected,
+// otherwise sorts them with the sort config.
+func sortStreams(ud *UserData, streams []WrappedStream, trace *stremio_transformer.StreamTrace[WrappedStream]) []WrappedStream {
+	if ud.QualityProfileId != "" {
+		profile, err := stremio_transformer.StreamQualityProfiles.Get(ud.QualityProfileId)
+		if err != nil {
+			log.Warn("failed to fetch quality profile", "error", err, "id", ud.QualityProfileId)
+			trace.AddError(stremio_transformer.StreamPreviewStageSort, err)
+		} else if profile != nil {
+			return stremio_transformer.RankStreams(streams, profile, trace)
+		}
+	}
+	stremio_transformer.SortStreams(streams, stremio_transformer.StreamSortPresets.Resolve(ud.SortPresetId, ud.Sort), trace)
+	return streams
 }
 
-func (s WrappedStream) GetHDR() string {
-	return strings.Join(s.R.HDR, "|")
+// processStreams runs the reputation → filter → sort stages over the
+// streams, after the store cache is checked.
+func processStreams(ud *UserData, streams []WrappedStream, trace *stremio_transformer.StreamTrace[WrappedStream]) []WrappedStream {
+	stremio_transformer.SetStreamReputation(streams)
+	streams = stremio_transformer.FilterStreams(streams, stremio_transformer.StreamFilterPresets.Resolve(ud.FilterPresetId, ud.Filter), trace)
+	return sortStreams(ud, streams, trace)
 }
 
 type indexerSearchQueryMeta struct {
@@ -609,7 +627,8 @@ func GetStreamsForHashes(stremType, stremId string, hashes []string, nsid *torre
 			data.File.Size = util.ToSize(fSize)
 		}
 		wrappedStreams = append(wrappedStreams, WrappedStream{
-			R: data,
+			R:      data,
+			seenAt: tInfo.UpdatedAt.Time,
 			Stream: &stremio.Stream{
 				Name:        data.Addon.Name,
 				Description: data.TTitle,
@@ -627,52 +646,12 @@ func GetStreamsForHashes(stremType, stremId string, hashes []string, nsid *torre
 	return wrappedStreams, nil
 }
 
-func handleStream(w http.ResponseWriter, r *http.Request) {
-	if !IsMethod(r, http.MethodGet) {
-		shared.ErrorMethodNotAllowed(r).Send(w, r)
-		return
-	}
-
-	ud, err := getUserData(r)
-	if err != nil {
-		SendError(w, r, err)
-		return
-	}
-
-	ctx, err := ud.GetRequestContext(r)
-	if err != nil {
-		shared.ErrorBadRequest(r, "failed to get request context: "+err.Error()).Send(w, r)
-		return
-	}
-
-	contentType := r.PathValue("contentType")
-	id := stremio_shared.GetPathValue(r, "id")
-
-	isImdbId := strings.HasPrefix(id, "tt")
-	isKitsuId := strings.HasPrefix(id, "kitsu:")
-	isMALId := strings.HasPrefix(id, "mal:")
-	isAnime := isKitsuId || isMALId
-
-	if isImdbId {
-		if contentType != string(stremio.ContentTypeMovie) && contentType != string(stremio.ContentTypeSeries) {
-			shared.ErrorBadRequest(r, "unsupported type: "+contentType).Send(w, r)
-			return
-		}
-	} else if isAnime {
-		if contentType != string(stremio.ContentTypeMovie) && contentType != string(stremio.ContentTypeSeries) && contentType != "anime" {
-			shared.ErrorBadRequest(r, "unsupported type: "+contentType).Send(w, r)
-			return
-		}
-	} else {
-		shared.ErrorBadRequest(r, "unsupported id: "+id).Send(w, r)
-		return
-	}
-
-	eud := ud.GetEncoded()
-
+// fetchStreams collects the streams for the torrents in the local database
+// and, unless local only, from the indexers. It also returns the hashes to
+// check in the stores.
+func fetchStreams(ctx *RequestContext, ud *UserData, contentType, id string, nsid *torrent_stream.NormalizedStremId) ([]WrappedStream, []string, error) {
 	pulledHashes := []string{}
-	nsid, err := torrent_stream.NormalizeStreamId(id)
-	if err == nil {
+	if !ud.LocalOnly {
 		cleanSId := nsid.ToClean()
 		if torzLazyPull {
 			go buddy.PullTorrentsByStremId(cleanSId, "")
@@ -684,19 +663,11 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 		worker_queue.TorznabIndexerSyncerQueue.Queue(worker_queue.TorznabIndexerSyncerQueueItem{
 			SId: nsid.String(),
 		})
-	} else if !errors.Is(err, torrent_stream.ErrUnsupportedStremId) {
-		log.Error("failed to normalize strem id", "error", err, "id", id)
-		shared.ErrorInternalServerError(r, "failed to normalize strem id").WithCause(err).Send(w, r)
-		return
-	} else {
-		shared.ErrorBadRequest(r, "unsupported strem id: "+id).Send(w, r)
-		return
 	}
 
 	hashes, err := torrent_info.ListHashesByStremId(id)
 	if err != nil {
-		SendError(w, r, err)
-		return
+		return nil, nil, err
 	}
 
 	hashSet := util.NewSet[string]()
@@ -724,32 +695,33 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 	var wrappedStreamsFromIndexers []WrappedStream
 	var hashesFromIndexers []string
 	var getStreamsFromIndexersError error
-	wg.Go(func() {
-		var streams []WrappedStream
-		var hashes []string
-		var err error
-		done := make(chan struct{})
-		go func() {
-			streams, hashes, err = GetStreamsFromIndexers(ctx, contentType, id)
-			close(done)
-		}()
-
-		select {
-		case <-done:
-			wrappedStreamsFromIndexers = streams
-			hashesFromIndexers = hashes
-			getStreamsFromIndexersError = err
-			log.Debug("fetched streams from indexers", "count", len(streams))
-		case <-time.After(config.Stremio.Torz.IndexerMaxTimeout):
-			log.Warn("fetching streams from indexers timed out")
-		}
-	})
+	if !ud.LocalOnly {
+		wg.Go(func() {
+			var streams []WrappedStream
+			var hashes []string
+			var err error
+			done := make(chan struct{})
+			go func() {
+				streams, hashes, err = GetStreamsFromIndexers(ctx, contentType, id)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				wrappedStreamsFromIndexers = streams
+				hashesFromIndexers = hashes
+				getStreamsFromIndexersError = err
+				log.Debug("fetched streams from indexers", "count", len(streams))
+			case <-time.After(config.Stremio.Torz.IndexerMaxTimeout):
+				log.Warn("fetching streams from indexers timed out")
+			}
+		})
+	}
 
 	wg.Wait()
 
 	if getStreamsError != nil {
-		SendError(w, r, getStreamsError)
-		return
+		return nil, nil, getStreamsError
 	}
 
 	if getStreamsFromIndexersError != nil {
@@ -764,40 +736,105 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	isP2P := ud.IsP2P()
+	return wrappedStreams, hashes, nil
+}
 
-	var isCachedByHash map[string]string
-	var hasErrByStoreCode map[string]struct{}
-	var checkMagnetError error
-	if !isP2P && len(hashes) > 0 {
-		cmRes := ud.CheckMagnet(&store.CheckMagnetParams{
-			Magnets:  hashes,
-			ClientIP: ctx.ClientIP,
-			SId:      id,
-		}, ctx.Log)
-		if cmRes.HasErr && len(cmRes.ByHash) == 0 {
-			checkMagnetError = errors.Join(cmRes.Err...)
-		} else {
-			isCachedByHash = cmRes.ByHash
-			hasErrByStoreCode = cmRes.HasErrByStoreCode
+// checkStreams checks the hashes in the stores, and marks the cached streams.
+func checkStreams(ctx *RequestContext, ud *UserData, id string, hashes []string, streams []WrappedStream) (isCachedByHash map[string]string, hasErrByStoreCode map[string]struct{}, err error) {
+	if len(hashes) == 0 {
+		return nil, nil, nil
+	}
+
+	cmRes := ud.CheckMagnet(&store.CheckMagnetParams{
+		Magnets:  hashes,
+		ClientIP: ctx.ClientIP,
+		SId:      id,
+	}, ctx.Log)
+	if cmRes.HasErr && len(cmRes.ByHash) == 0 {
+		return nil, nil, errors.Join(cmRes.Err...)
+	}
+
+	for i := range streams {
+		if storeCode := cmRes.ByHash[streams[i].R.Hash]; storeCode != "" {
+			streams[i].R.Store.IsCached = true
 		}
 	}
+	return cmRes.ByHash, cmRes.HasErrByStoreCode, nil
+}
 
-	if checkMagnetError != nil {
-		SendError(w, r, checkMagnetError)
+func handleStream(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
 		return
 	}
 
-	if ud.Filter != "" {
-		filter, err := stremio_transformer.StreamFilterBlob(ud.Filter).Parse()
-		if err == nil {
-			wrappedStreams = filterStreams(wrappedStreams, filter)
-		} else {
-			log.Warn("failed to parse filter expression", "error", err)
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	ctx, err := ud.GetRequestContext(r)
+	if err != nil {
+		shared.ErrorBadRequest(r, "failed to get request context: "+err.Error()).Send(w, r)
+		return
+	}
+
+	contentType := r.PathValue("contentType")
+	id := stremio_shared.GetPathValue(r, "id")
+
+	isImdbId := strings.HasPrefix(id, "tt")
+	isKitsuId := strings.HasPrefix(id, "kitsu:")
+	isMALId := strings.HasPrefix(id, "mal:")
+	isAnime := isKitsuId || isMALId
+
+	if isImdbId {
+		if contentType != string(stremio.ContentTypeMovie) && contentType != string(stremio.ContentTypeSeries) {
+			shared.ErrorBadRequest(r, "unsupported type: "+contentType).Send(w, r)
+			return
+		}
+	} else if isAnime {
+		if contentType != string(stremio.ContentTypeMovie) && contentType != string(stremio.ContentTypeSeries) && contentType != "anime" {
+			shared.ErrorBadRequest(r, "unsupported type: "+contentType).Send(w, r)
+			return
 		}
+	} else {
+		shared.ErrorBadRequest(r, "unsupported id: "+id).Send(w, r)
+		return
 	}
 
-	stremio_transformer.SortStreams(wrappedStreams, ud.Sort)
+	eud := ud.GetEncoded()
+
+	nsid, err := torrent_stream.NormalizeStreamId(id)
+	if err != nil {
+		if !errors.Is(err, torrent_stream.ErrUnsupportedStremId) {
+			log.Error("failed to normalize strem id", "error", err, "id", id)
+			shared.ErrorInternalServerError(r, "failed to normalize strem id").WithCause(err).Send(w, r)
+			return
+		}
+		shared.ErrorBadRequest(r, "unsupported strem id: "+id).Send(w, r)
+		return
+	}
+
+	wrappedStreams, hashes, err := fetchStreams(ctx, ud, contentType, id, nsid)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	isP2P := ud.IsP2P()
+
+	var isCachedByHash map[string]string
+	var hasErrByStoreCode map[string]struct{}
+	if !isP2P {
+		isCachedByHash, hasErrByStoreCode, err = checkStreams(ctx, ud, id, hashes, wrappedStreams)
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+	}
+
+	wrappedStreams = processStreams(ud, wrappedStreams, nil)
 
 	streamBaseUrl := ExtractRequestBaseURL(r).JoinPath("/stremio/torz", eud, "_/strem", id)
 
@@ -812,11 +849,30 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 
 			wStream.R.Store.Code = "P2P"
 			wStream.R.Store.Name = "P2P"
+			wStream.R.Store.IsProxied = ctx.IsProxyAuthorized
 			stream, err := streamTemplate.Execute(wStream.Stream, wStream.R)
 			if err != nil {
 				SendError(w, r, err)
 				return
 			}
+			if ctx.IsProxyAuthorized {
+				trackers := []string{}
+				for _, source := range stream.Sources {
+					if tracker, ok := strings.CutPrefix(source, "tracker:"); ok {
+						trackers = append(trackers, tracker)
+					}
+				}
+				link := p2p.CreateLink(hash, wStream.FileIndex, trackers)
+				proxyLink, err := shared.CreateProxyLink(r, link, nil, config.TUNNEL_TYPE_NONE, 12*time.Hour, ctx.ProxyAuthUser, ctx.ProxyAuthPassword, true, wStream.R.File.Name)
+				if err != nil {
+					SendError(w, r, err)
+					return
+				}
+				stream.URL = proxyLink
+				stream.InfoHash = ""
+				stream.FileIndex = 0
+				stream.Sources = nil
+			}
 			uncachedStreams = append(uncachedStreams, *stream)
 		} else if storeCode, isCached := isCachedByHash[hash]; isCached && storeCode != "" {
 			storeName := store.StoreCode(strings.ToLower(storeCode)).Name()
@@ -896,14 +952,3 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 		Streams: streams,
 	})
 }
-
-func filterStreams(streams []WrappedStream, filter *stremio_transformer.StreamFilter) []WrappedStream {
-	result := make([]WrappedStream, 0, len(streams))
-	for i := range streams {
-		stream := &streams[i]
-		if stream.R == nil || filter.Match(stream.R) {
-			result = append(result, *stream)
-		}
-	}
-	return result
-}
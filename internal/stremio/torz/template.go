@@ -10,7 +10,6 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/stremio/configure"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	stremio_template "github.com/MunifTanjim/stremthru/internal/stremio/template"
-	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
 	stremio_userdata "github.com/MunifTanjim/stremthru/internal/stremio/userdata"
 )
 
@@ -54,8 +53,12 @@ type TemplateData struct {
 	IsAuthed     bool
 	AuthError    string
 
-	SortConfig   configure.Config
-	FilterConfig configure.Config
+	SortConfig         configure.Config
+	SortPresetConfig   configure.Config
+	FilterConfig       configure.Config
+	FilterPresetConfig configure.Config
+
+	QualityProfileConfig configure.Config
 }
 
 func (td *TemplateData) HasIndexerError() bool {
@@ -151,6 +154,22 @@ func getTemplateData(ud *UserData, w http.ResponseWriter, r *http.Request) *Temp
 				Type:  configure.ConfigTypeCheckbox,
 				Title: "Only Show Cached Content",
 			},
+			{
+				Key:   "history",
+				Type:  configure.ConfigTypeCheckbox,
+				Title: "Enable Watch History",
+			},
+			{
+				Key:   "prefetch",
+				Type:  configure.ConfigTypeCheckbox,
+				Title: "Prefetch Next Episode",
+			},
+			{
+				Key:   "local",
+				Type:  configure.ConfigTypeCheckbox,
+				Title: "Only Local Torrent Database (skip Indexers)",
+			},
+			stremio_shared.GetTraktScrobbleConfig(ud.TraktTokenId),
 		},
 		Script: configure.GetScriptStoreTokenDescription("", ""),
 		SortConfig: configure.Config{
@@ -158,8 +177,9 @@ func getTemplateData(ud *UserData, w http.ResponseWriter, r *http.Request) *Temp
 			Type:        "text",
 			Default:     ud.Sort,
 			Title:       "Stream Sort",
-			Description: "Comma separated fields: <code>resolution</code>, <code>quality</code>, <code>size</code>, <code>hdr</code>. Prefix with <code>-</code> for reverse sort. Default: <code>" + stremio_transformer.StreamDefaultSortConfig + "</code>",
+			Description: stremio_shared.StreamSortConfigDescription,
 		},
+		SortPresetConfig: stremio_shared.GetStreamSortPresetConfig(ud.SortPresetId),
 		FilterConfig: configure.Config{
 			Key:         "filter",
 			Type:        "textarea",
@@ -167,6 +187,9 @@ func getTemplateData(ud *UserData, w http.ResponseWriter, r *http.Request) *Temp
 			Title:       "🧪 Stream Filter",
 			Description: `Filter expression, check <a href="https://github.com/MunifTanjim/stremthru/wiki/Stream-Filter" target="_blank">documentation</a>.`,
 		},
+		FilterPresetConfig: stremio_shared.GetStreamFilterPresetConfig(ud.FilterPresetId),
+
+		QualityProfileConfig: stremio_shared.GetStreamQualityProfileConfig(ud.QualityProfileId),
 	}
 
 	if cookie, err := stremio_shared.GetAdminCookieValue(w, r); err == nil && !cookie.IsExpired {
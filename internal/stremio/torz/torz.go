@@ -37,9 +37,16 @@ func AddStremioTorzEndpoints(mux *http.ServeMux) {
 
 	router.HandleFunc("/configure", handleConfigure)
 	router.HandleFunc("/{userData}/configure", handleConfigure)
+	router.HandleFunc("/configure/preview", handleConfigurePreview)
+	router.HandleFunc("/{userData}/configure/preview", handleConfigurePreview)
+
+	router.HandleFunc("/{userData}/catalog/{contentType}/{idJson}", withCors(handleCatalog))
+	router.HandleFunc("/{userData}/catalog/{contentType}/{id}/{extraJson}", withCors(handleCatalog))
 
 	router.HandleFunc("/{userData}/stream/{contentType}/{idJson}", withCors(handleStream))
 
+	router.HandleFunc("/{userData}/_/history.json", withCors(handleHistoryExport))
+
 	router.HandleFunc("/{userData}/_/strem/{stremId}/{storeCode}/{magnetHash}/{fileIdx}/{$}", withCors(handleStrem))
 	router.HandleFunc("/{userData}/_/strem/{stremId}/{storeCode}/{magnetHash}/{fileIdx}/{fileName}", withCors(handleStrem))
 
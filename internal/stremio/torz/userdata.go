@@ -17,9 +17,19 @@ type UserData struct {
 	stremio_userdata.UserDataIndexers
 	IncludeUncachedPrivate bool `json:"unc_prvt,omitempty"`
 	stremio_userdata.UserDataStores
-	CachedOnly bool   `json:"cached,omitempty"`
-	Sort       string `json:"sort,omitempty"`
-	Filter     string `json:"filter,omitempty"`
+	CachedOnly    bool   `json:"cached,omitempty"`
+	EnableHistory bool   `json:"history,omitempty"`
+	PrefetchNext  bool   `json:"prefetch,omitempty"`
+	LocalOnly     bool   `json:"local,omitempty"`
+	Sort          string `json:"sort,omitempty"`
+	Filter        string `json:"filter,omitempty"`
+
+	SortPresetId   string `json:"sort_preset,omitempty"`
+	FilterPresetId string `json:"filter_preset,omitempty"`
+
+	QualityProfileId string `json:"quality_profile,omitempty"`
+
+	TraktTokenId string `json:"trakt_token_id,omitempty"`
 
 	encoded string `json:"-"` // correctly configured
 }
@@ -27,6 +37,7 @@ type UserData struct {
 func (ud UserData) StripSecrets() UserData {
 	ud.UserDataIndexers = ud.UserDataIndexers.StripSecrets()
 	ud.UserDataStores = ud.UserDataStores.StripSecrets()
+	ud.TraktTokenId = ""
 	return ud
 }
 
@@ -217,6 +228,9 @@ func getUserData(r *http.Request) (*UserData, error) {
 		}
 
 		data.CachedOnly = r.Form.Get("cached") == "on"
+		data.EnableHistory = r.Form.Get("history") == "on"
+		data.PrefetchNext = r.Form.Get("prefetch") == "on"
+		data.LocalOnly = r.Form.Get("local") == "on"
 
 		for i := range util.SafeParseInt(r.Form.Get("indexers_length"), 1) {
 			idx := strconv.Itoa(i)
@@ -233,6 +247,10 @@ func getUserData(r *http.Request) (*UserData, error) {
 
 		data.Sort = r.Form.Get("sort")
 		data.Filter = r.Form.Get("filter")
+		data.SortPresetId = r.Form.Get("sort_preset")
+		data.FilterPresetId = r.Form.Get("filter_preset")
+		data.QualityProfileId = r.Form.Get("quality_profile")
+		data.TraktTokenId = r.Form.Get("trakt_token_id")
 		data.IncludeUncachedPrivate = r.Form.Get("uncached_private") == "on"
 	}
 
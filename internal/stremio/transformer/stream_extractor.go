@@ -134,11 +134,14 @@ type StreamExtractorResult struct {
 	Hash      string
 	IsPrivate bool
 	Raw       StreamExtractorResultRaw
-	Season    int
-	Seeders   int
-	Store     StreamExtractorResultStore
-	TTitle    string `expr:"-"`
-	Indexer   string `expr:"-"`
+	// release group and uploader reputation score, between 0 and 100
+	Reputation int
+	Season     int
+	Seeders    int
+	Sources    []string
+	Store      StreamExtractorResultStore
+	TTitle     string `expr:"-"`
+	Indexer    string `expr:"-"`
 }
 
 var language_to_code = map[string]string{
@@ -5,6 +5,7 @@ import (
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
 	"github.com/expr-lang/expr/vm"
 )
 
@@ -91,6 +92,23 @@ func (ValuePatcher) Visit(node *ast.Node) {
 	}
 }
 
+func compileStreamExpr(input string, opts ...expr.Option) (*vm.Program, error) {
+	return expr.Compile(input, append([]expr.Option{
+		expr.Env(&StreamExtractorResult{}),
+		expr.AllowUndefinedVariables(),
+		expr.Function("__Resolution__", func(val ...any) (any, error) {
+			return Resolution(val[0].(string)), nil
+		}, new(func(string) Resolution)),
+		expr.Function("__Quality__", func(val ...any) (any, error) {
+			return Quality(val[0].(string)), nil
+		}, new(func(string) Quality)),
+		expr.Function("__Size__", func(val ...any) (any, error) {
+			return Size(val[0].(string)), nil
+		}, new(func(string) Size)),
+		expr.Patch(ValuePatcher{}),
+	}, opts...)...)
+}
+
 type StreamFilterEnv struct {
 	*StreamExtractorResult
 }
@@ -111,22 +129,7 @@ func (sfb StreamFilterBlob) Parse() (*StreamFilter, error) {
 		return sf, nil
 	}
 
-	program, err := expr.Compile(
-		string(sfb),
-		expr.Env(&StreamExtractorResult{}),
-		expr.AsBool(),
-		expr.AllowUndefinedVariables(),
-		expr.Function("__Resolution__", func(val ...any) (any, error) {
-			return Resolution(val[0].(string)), nil
-		}, new(func(string) Resolution)),
-		expr.Function("__Quality__", func(val ...any) (any, error) {
-			return Quality(val[0].(string)), nil
-		}, new(func(string) Quality)),
-		expr.Function("__Size__", func(val ...any) (any, error) {
-			return Size(val[0].(string)), nil
-		}, new(func(string) Size)),
-		expr.Patch(ValuePatcher{}),
-	)
+	program, err := compileStreamExpr(string(sfb), expr.AsBool())
 	if err != nil {
 		return sf, err
 	}
@@ -136,14 +139,91 @@ func (sfb StreamFilterBlob) Parse() (*StreamFilter, error) {
 }
 
 func (sf *StreamFilter) Match(r *StreamExtractorResult) bool {
-	if sf == nil || sf.program == nil || r == nil {
+	match, err := sf.Test(r)
+	if err != nil {
 		return true
 	}
+	return match
+}
+
+// Test is like Match, but also returns the runtime error.
+func (sf *StreamFilter) Test(r *StreamExtractorResult) (bool, error) {
+	if sf == nil || sf.program == nil || r == nil {
+		return true, nil
+	}
 
 	output, err := expr.Run(sf.program, r)
 	if err != nil {
-		return true
+		return true, err
+	}
+
+	return output.(bool), nil
+}
+
+func collectFilterClauses(node ast.Node, clauses []ast.Node) []ast.Node {
+	if bin, ok := node.(*ast.BinaryNode); ok && (bin.Operator == "&&" || bin.Operator == "and") {
+		clauses = collectFilterClauses(bin.Left, clauses)
+		return collectFilterClauses(bin.Right, clauses)
+	}
+	return append(clauses, node)
+}
+
+// Explain returns the first top-level `&&` clause of the filter that does
+// not match `r`.
+func (sf *StreamFilter) Explain(r *StreamExtractorResult) string {
+	tree, err := parser.Parse(string(sf.Blob))
+	if err != nil {
+		return string(sf.Blob)
+	}
+	clauses := collectFilterClauses(tree.Node, nil)
+	if len(clauses) == 1 {
+		return strings.TrimSpace(string(sf.Blob))
+	}
+	for _, clause := range clauses {
+		clauseExpr := clause.String()
+		program, err := compileStreamExpr(clauseExpr, expr.AsBool())
+		if err != nil {
+			continue
+		}
+		if output, err := expr.Run(program, r); err == nil && !output.(bool) {
+			return clauseExpr
+		}
+	}
+	return strings.TrimSpace(string(sf.Blob))
+}
+
+// FilterStreams drops the streams not matching the filter expression. Streams
+// without extractor result are always kept.
+func FilterStreams[T StreamSortable](items []T, filterExpr string, trace *StreamTrace[T]) []T {
+	if filterExpr == "" {
+		return items
+	}
+
+	filter, err := StreamFilterBlob(filterExpr).Parse()
+	if err != nil {
+		log.Warn("failed to parse filter expression", "error", err)
+		trace.AddError(StreamPreviewStageFilter, err)
+		return items
 	}
 
-	return output.(bool)
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if !item.IsSortable() {
+			result = append(result, item)
+			continue
+		}
+		r := item.GetExtractorResult()
+		match, err := filter.Test(r)
+		if err != nil {
+			trace.addItemError(r, StreamPreviewStageFilter, err)
+		}
+		if !match {
+			if trace != nil {
+				trace.addFilteredOut(item, filter.Explain(r))
+			}
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
 }
@@ -0,0 +1,118 @@
+package stremio_transformer
+
+import (
+	"errors"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/kv"
+)
+
+type StreamPreset struct {
+	Id        string
+	Value     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type StreamPresetStore struct {
+	kv       kv.KVStore[string]
+	validate func(value string) error
+}
+
+func newStreamPresetStore(kvType string, validate func(value string) error) *StreamPresetStore {
+	return &StreamPresetStore{
+		kv: kv.NewKVStore[string](&kv.KVStoreConfig{
+			Type: kvType,
+			GetKey: func(key string) string {
+				return key
+			},
+		}),
+		validate: validate,
+	}
+}
+
+// StreamSortPresets holds named sort configs, referenced by id from addon
+// userdata.
+var StreamSortPresets = newStreamPresetStore("st:transformer:sort", func(value string) error {
+	_, err := ParseSortConfig(value)
+	return err
+})
+
+// StreamFilterPresets holds named filter expressions, referenced by id from
+// addon userdata.
+var StreamFilterPresets = newStreamPresetStore("st:transformer:filter", func(value string) error {
+	_, err := StreamFilterBlob(value).Parse()
+	return err
+})
+
+func (s *StreamPresetStore) Get(id string) (string, error) {
+	value := ""
+	if err := s.kv.GetValue(id, &value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+func (s *StreamPresetStore) List() ([]StreamPreset, error) {
+	items, err := s.kv.List()
+	if err != nil {
+		return nil, err
+	}
+	presets := make([]StreamPreset, len(items))
+	for i := range items {
+		item := &items[i]
+		presets[i] = StreamPreset{
+			Id:        item.Key,
+			Value:     item.Value,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		}
+	}
+	return presets, nil
+}
+
+func (s *StreamPresetStore) ListIds() ([]string, error) {
+	presets, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(presets))
+	for i := range presets {
+		ids[i] = presets[i].Id
+	}
+	return ids, nil
+}
+
+func (s *StreamPresetStore) Set(id, value string) error {
+	if id == "" {
+		return errors.New("missing id")
+	}
+	if value == "" {
+		return errors.New("missing value")
+	}
+	if err := s.validate(value); err != nil {
+		return err
+	}
+	return s.kv.Set(id, value)
+}
+
+func (s *StreamPresetStore) Del(id string) error {
+	return s.kv.Del(id)
+}
+
+// Resolve returns the value of preset `id`, falling back to `value` when
+// `id` is empty or the preset is missing.
+func (s *StreamPresetStore) Resolve(id, value string) string {
+	if id == "" {
+		return value
+	}
+	preset, err := s.Get(id)
+	if err != nil {
+		log.Warn("failed to fetch stream preset", "id", id, "error", err)
+		return value
+	}
+	if preset == "" {
+		return value
+	}
+	return preset
+}
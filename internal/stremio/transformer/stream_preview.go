@@ -0,0 +1,134 @@
+package stremio_transformer
+
+type StreamPreviewStage string
+
+const (
+	StreamPreviewStageFetch     StreamPreviewStage = "fetch"
+	StreamPreviewStageExtractor StreamPreviewStage = "extractor"
+	StreamPreviewStageFilter    StreamPreviewStage = "filter"
+	StreamPreviewStageSort      StreamPreviewStage = "sort"
+	StreamPreviewStageTemplate  StreamPreviewStage = "template"
+)
+
+type StreamPreviewError struct {
+	Stage   StreamPreviewStage `json:"stage"`
+	Message string             `json:"message"`
+}
+
+type StreamPreviewItem struct {
+	Source string `json:"source,omitempty"`
+	Raw    struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"raw"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Fields        *StreamExtractorResult `json:"fields"`
+	IsFilteredOut bool                   `json:"is_filtered_out"`
+	FilterReason  string                 `json:"filter_reason,omitempty"`
+	Rank          int                    `json:"rank,omitempty"`
+	SortKeys      []float64              `json:"sort_keys,omitempty"`
+	Errors        []StreamPreviewError   `json:"errors,omitempty"`
+}
+
+func (item *StreamPreviewItem) AddError(stage StreamPreviewStage, err error) {
+	item.Errors = append(item.Errors, StreamPreviewError{Stage: stage, Message: err.Error()})
+}
+
+type StreamPreview struct {
+	Errors  []StreamPreviewError `json:"errors,omitempty"`
+	Streams []*StreamPreviewItem `json:"streams"`
+}
+
+func (p *StreamPreview) AddError(stage StreamPreviewStage, err error) {
+	p.Errors = append(p.Errors, StreamPreviewError{Stage: stage, Message: err.Error()})
+}
+
+type StreamPreviewable interface {
+	StreamSortable
+	// ToPreviewItem returns the item with the source, the raw and the
+	// rendered stream, and the extractor and template errors.
+	ToPreviewItem() *StreamPreviewItem
+}
+
+// StreamTrace records what the pipeline stages did to each stream, for the
+// configure preview. The stages accept a nil trace, so the stream handlers
+// and the preview run the same code.
+type StreamTrace[T StreamSortable] struct {
+	errors        []StreamPreviewError
+	filteredOut   []T
+	filterReasons []string
+	itemErrors    map[*StreamExtractorResult][]StreamPreviewError
+	sortKeys      map[*StreamExtractorResult][]float64
+}
+
+func NewStreamTrace[T StreamSortable]() *StreamTrace[T] {
+	return &StreamTrace[T]{
+		itemErrors: map[*StreamExtractorResult][]StreamPreviewError{},
+		sortKeys:   map[*StreamExtractorResult][]float64{},
+	}
+}
+
+func (t *StreamTrace[T]) AddError(stage StreamPreviewStage, err error) {
+	if t == nil {
+		return
+	}
+	t.errors = append(t.errors, StreamPreviewError{Stage: stage, Message: err.Error()})
+}
+
+func (t *StreamTrace[T]) addItemError(r *StreamExtractorResult, stage StreamPreviewStage, err error) {
+	if t == nil {
+		return
+	}
+	t.itemErrors[r] = append(t.itemErrors[r], StreamPreviewError{Stage: stage, Message: err.Error()})
+}
+
+func (t *StreamTrace[T]) addFilteredOut(item T, reason string) {
+	if t == nil {
+		return
+	}
+	t.filteredOut = append(t.filteredOut, item)
+	t.filterReasons = append(t.filterReasons, reason)
+}
+
+func (t *StreamTrace[T]) setSortKeys(r *StreamExtractorResult, keys []float64) {
+	if t == nil {
+		return
+	}
+	t.sortKeys[r] = keys
+}
+
+func toPreviewItem[T StreamPreviewable](t *StreamTrace[T], item T) *StreamPreviewItem {
+	pItem := item.ToPreviewItem()
+	if r := item.GetExtractorResult(); r != nil {
+		pItem.Errors = append(pItem.Errors, t.itemErrors[r]...)
+		pItem.SortKeys = t.sortKeys[r]
+	} else if len(pItem.Errors) == 0 {
+		pItem.Errors = append(pItem.Errors, StreamPreviewError{
+			Stage:   StreamPreviewStageExtractor,
+			Message: "nothing extracted",
+		})
+	}
+	return pItem
+}
+
+// PreviewStreams lists `streams`, the output of the pipeline in order,
+// followed by the ones filtered out on the way.
+func PreviewStreams[T StreamPreviewable](streams []T, trace *StreamTrace[T]) *StreamPreview {
+	preview := &StreamPreview{
+		Errors:  trace.errors,
+		Streams: make([]*StreamPreviewItem, 0, len(streams)+len(trace.filteredOut)),
+	}
+	for i, stream := range streams {
+		item := toPreviewItem(trace, stream)
+		item.Rank = i + 1
+		preview.Streams = append(preview.Streams, item)
+	}
+	for i, stream := range trace.filteredOut {
+		item := toPreviewItem(trace, stream)
+		item.IsFilteredOut = true
+		item.FilterReason = trace.filterReasons[i]
+		preview.Streams = append(preview.Streams, item)
+	}
+	return preview
+}
@@ -0,0 +1,107 @@
+package stremio_transformer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MunifTanjim/go-ptt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPreviewable struct {
+	testRankable
+	err error
+}
+
+func (s testPreviewable) ToPreviewItem() *StreamPreviewItem {
+	item := &StreamPreviewItem{Source: s.name, Name: s.name, Fields: s.r}
+	if s.err != nil {
+		item.AddError(StreamPreviewStageExtractor, s.err)
+	}
+	return item
+}
+
+func newTestPreviewable(name string, r *StreamExtractorResult) testPreviewable {
+	return testPreviewable{testRankable: testRankable{testSortable: testSortable{name: name, r: r}}}
+}
+
+func TestPreviewStreams(t *testing.T) {
+	newItems := func() []testPreviewable {
+		return []testPreviewable{
+			newTestPreviewable("a", &StreamExtractorResult{Result: &ptt.Result{Resolution: "720p"}, Seeders: 10}),
+			newTestPreviewable("b", &StreamExtractorResult{Result: &ptt.Result{Resolution: "2160p"}, Seeders: 1}),
+			newTestPreviewable("c", &StreamExtractorResult{Result: &ptt.Result{Resolution: "1080p"}, Seeders: 0}),
+			{testRankable: testRankable{testSortable: testSortable{name: "d"}}, err: errors.New("bad regex")},
+			newTestPreviewable("e", nil),
+		}
+	}
+
+	t.Run("filter and sort", func(t *testing.T) {
+		trace := NewStreamTrace[testPreviewable]()
+		items := FilterStreams(newItems(), `Resolution >= "1080p" && Seeders > 0`, trace)
+		SortStreams(items, "-resolution, -(Seeders / Missing)", trace)
+		preview := PreviewStreams(items, trace)
+
+		assert.Empty(t, preview.Errors)
+		require.Len(t, preview.Streams, 5)
+
+		b := preview.Streams[0]
+		assert.Equal(t, "b", b.Source)
+		assert.Equal(t, 1, b.Rank)
+		assert.Equal(t, float64(2160), b.SortKeys[0])
+		require.Len(t, b.Errors, 1)
+		assert.Equal(t, StreamPreviewStageSort, b.Errors[0].Stage)
+
+		e, d := preview.Streams[1], preview.Streams[2]
+		assert.Equal(t, "e", e.Source)
+		assert.Equal(t, 2, e.Rank)
+		assert.Equal(t, []StreamPreviewError{{Stage: StreamPreviewStageExtractor, Message: "nothing extracted"}}, e.Errors)
+		assert.Equal(t, "d", d.Source)
+		assert.Equal(t, 3, d.Rank)
+		assert.Equal(t, []StreamPreviewError{{Stage: StreamPreviewStageExtractor, Message: "bad regex"}}, d.Errors)
+
+		a, c := preview.Streams[3], preview.Streams[4]
+		assert.True(t, a.IsFilteredOut)
+		assert.Equal(t, `Resolution >= "1080p"`, a.FilterReason)
+		assert.True(t, c.IsFilteredOut)
+		assert.Equal(t, "Seeders > 0", c.FilterReason)
+		assert.Zero(t, a.Rank)
+	})
+
+	t.Run("quality profile", func(t *testing.T) {
+		trace := NewStreamTrace[testPreviewable]()
+		items := RankStreams(newItems(), &StreamQualityProfile{Resolutions: []string{"1080p", "720p"}}, trace)
+		preview := PreviewStreams(items, trace)
+
+		require.Len(t, preview.Streams, 5)
+		assert.Equal(t, "c", preview.Streams[0].Source)
+		assert.Equal(t, "a", preview.Streams[1].Source)
+		b := preview.Streams[4]
+		assert.Equal(t, "b", b.Source)
+		assert.True(t, b.IsFilteredOut)
+		assert.Equal(t, "quality profile", b.FilterReason)
+	})
+
+	t.Run("config errors", func(t *testing.T) {
+		trace := NewStreamTrace[testPreviewable]()
+		items := FilterStreams(newItems(), `Resolution >=`, trace)
+		SortStreams(items, "-resolution, Seeders +", trace)
+		preview := PreviewStreams(items, trace)
+
+		require.Len(t, preview.Errors, 2)
+		assert.Equal(t, StreamPreviewStageFilter, preview.Errors[0].Stage)
+		assert.Equal(t, StreamPreviewStageSort, preview.Errors[1].Stage)
+		assert.Len(t, preview.Streams, 5)
+	})
+}
+
+func TestStreamTraceNil(t *testing.T) {
+	items := []testSortable{
+		{"a", &StreamExtractorResult{Result: &ptt.Result{Resolution: "720p"}}},
+		{"b", &StreamExtractorResult{Result: &ptt.Result{Resolution: "2160p"}}},
+	}
+	items = FilterStreams(items, `Resolution == "2160p"`, nil)
+	SortStreams(items, "", nil)
+	assert.Equal(t, []string{"b"}, sortedNames(items))
+}
@@ -0,0 +1,231 @@
+package stremio_transformer
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/kv"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+// StreamQualityProfile ranks streams on the server, similar to Sonarr quality
+// profiles. Streams not matching the profile are dropped.
+type StreamQualityProfile struct {
+	// allowed resolutions, in order of preference; empty allows all
+	Resolutions []string `json:"resolutions,omitempty"`
+	// minimum size by resolution, e.g. `"2160p": "8GB"`
+	MinSize map[string]string `json:"min_size,omitempty"`
+	// release groups; when allow list is present, other groups are dropped
+	AllowGroups []string `json:"allow_groups,omitempty"`
+	DenyGroups  []string `json:"deny_groups,omitempty"`
+	// seeders are halved for every `SeedersHalfLife` days since last seen
+	SeedersHalfLife int `json:"seeders_half_life,omitempty"`
+}
+
+func (p *StreamQualityProfile) Validate() error {
+	for resolution, size := range p.MinSize {
+		if util.ToBytes(size) < 0 {
+			return errors.New("invalid min size for " + resolution + ": " + size)
+		}
+	}
+	if p.SeedersHalfLife < 0 {
+		return errors.New("invalid seeders half life")
+	}
+	return nil
+}
+
+func containsFold(items []string, value string) bool {
+	return slices.ContainsFunc(items, func(item string) bool {
+		return strings.EqualFold(item, value)
+	})
+}
+
+func (p *StreamQualityProfile) getResolutionRank(resolution string) int {
+	if len(p.Resolutions) == 0 {
+		return 0
+	}
+	return slices.IndexFunc(p.Resolutions, func(item string) bool {
+		return strings.EqualFold(item, resolution)
+	})
+}
+
+func getStreamSize(r *StreamExtractorResult) int64 {
+	if r.File.Size != "" {
+		return util.ToBytes(r.File.Size)
+	}
+	if r.Result != nil && r.Size != "" {
+		return util.ToBytes(r.Size)
+	}
+	return -1
+}
+
+func (p *StreamQualityProfile) Accepts(r *StreamExtractorResult) bool {
+	resolution, group := "", ""
+	if r.Result != nil {
+		resolution, group = r.Resolution, r.Group
+	}
+	if p.getResolutionRank(resolution) == -1 {
+		return false
+	}
+	if group != "" && containsFold(p.DenyGroups, group) {
+		return false
+	}
+	if len(p.AllowGroups) > 0 && !containsFold(p.AllowGroups, group) {
+		return false
+	}
+	for res, minSize := range p.MinSize {
+		if strings.EqualFold(res, resolution) {
+			if size := getStreamSize(r); size != -1 && size < util.ToBytes(minSize) {
+				return false
+			}
+			break
+		}
+	}
+	return true
+}
+
+// GetSeedersScore returns the seeders, decayed by the time since they were
+// last seen. Zero `seenAt` is treated as fresh.
+func (p *StreamQualityProfile) GetSeedersScore(seeders int, seenAt time.Time) float64 {
+	if p.SeedersHalfLife == 0 || seenAt.IsZero() {
+		return float64(seeders)
+	}
+	days := time.Since(seenAt).Hours() / 24
+	return float64(seeders) * math.Pow(0.5, days/float64(p.SeedersHalfLife))
+}
+
+type StreamQualityProfileRankable interface {
+	StreamSortable
+	GetSeenAt() time.Time
+}
+
+// RankStreams drops the streams not accepted by the profile, and sorts the
+// rest by preferred resolution and recency-weighted seeders.
+func RankStreams[T StreamQualityProfileRankable](items []T, p *StreamQualityProfile, trace *StreamTrace[T]) []T {
+	type rankedItem struct {
+		item           T
+		resolutionRank int
+		seedersScore   float64
+	}
+
+	ranked := make([]rankedItem, 0, len(items))
+	for _, item := range items {
+		if !item.IsSortable() {
+			ranked = append(ranked, rankedItem{item: item, resolutionRank: math.MaxInt})
+			continue
+		}
+		r := item.GetExtractorResult()
+		if !p.Accepts(r) {
+			trace.addFilteredOut(item, "quality profile")
+			continue
+		}
+		resolution := ""
+		if r.Result != nil {
+			resolution = r.Resolution
+		}
+		ranked = append(ranked, rankedItem{
+			item:           item,
+			resolutionRank: p.getResolutionRank(resolution),
+			seedersScore:   p.GetSeedersScore(r.Seeders, item.GetSeenAt()),
+		})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b rankedItem) int {
+		if a.resolutionRank != b.resolutionRank {
+			return a.resolutionRank - b.resolutionRank
+		}
+		if a.seedersScore > b.seedersScore {
+			return -1
+		}
+		if a.seedersScore < b.seedersScore {
+			return 1
+		}
+		return 0
+	})
+
+	result := make([]T, len(ranked))
+	for i := range ranked {
+		result[i] = ranked[i].item
+	}
+	return result
+}
+
+type StreamQualityProfileItem struct {
+	Id        string
+	Value     StreamQualityProfile
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type StreamQualityProfileStore struct {
+	kv kv.KVStore[*StreamQualityProfile]
+}
+
+// StreamQualityProfiles holds the per-instance quality profiles, referenced
+// by id from addon userdata.
+var StreamQualityProfiles = &StreamQualityProfileStore{
+	kv: kv.NewKVStore[*StreamQualityProfile](&kv.KVStoreConfig{
+		Type: "st:transformer:quality_profile",
+		GetKey: func(key string) string {
+			return key
+		},
+	}),
+}
+
+func (s *StreamQualityProfileStore) Get(id string) (*StreamQualityProfile, error) {
+	var profile *StreamQualityProfile
+	if err := s.kv.GetValue(id, &profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (s *StreamQualityProfileStore) List() ([]StreamQualityProfileItem, error) {
+	items, err := s.kv.List()
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]StreamQualityProfileItem, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		if item.Value == nil {
+			continue
+		}
+		profiles = append(profiles, StreamQualityProfileItem{
+			Id:        item.Key,
+			Value:     *item.Value,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return profiles, nil
+}
+
+func (s *StreamQualityProfileStore) ListIds() ([]string, error) {
+	profiles, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(profiles))
+	for i := range profiles {
+		ids[i] = profiles[i].Id
+	}
+	return ids, nil
+}
+
+func (s *StreamQualityProfileStore) Set(id string, profile *StreamQualityProfile) error {
+	if id == "" {
+		return errors.New("missing id")
+	}
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	return s.kv.Set(id, profile)
+}
+
+func (s *StreamQualityProfileStore) Del(id string) error {
+	return s.kv.Del(id)
+}
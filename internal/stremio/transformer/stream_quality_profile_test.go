@@ -0,0 +1,69 @@
+package stremio_transformer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/go-ptt"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRankable struct {
+	testSortable
+	seenAt time.Time
+}
+
+func (s testRankable) GetSeenAt() time.Time {
+	return s.seenAt
+}
+
+func newTestRankable(name, resolution, group, size string, seeders int, seenAt time.Time) testRankable {
+	return testRankable{
+		testSortable: testSortable{
+			name: name,
+			r: &StreamExtractorResult{
+				Result:  &ptt.Result{Resolution: resolution, Group: group, Size: size},
+				Seeders: seeders,
+			},
+		},
+		seenAt: seenAt,
+	}
+}
+
+func TestRankStreams(t *testing.T) {
+	now := time.Now()
+	items := []testRankable{
+		newTestRankable("1080p-old", "1080p", "NTb", "4GB", 100, now.Add(-60*24*time.Hour)),
+		newTestRankable("1080p-new", "1080p", "FLUX", "4GB", 40, now),
+		newTestRankable("2160p-small", "2160p", "FLUX", "2GB", 500, now),
+		newTestRankable("2160p", "2160p", "FLUX", "20GB", 10, now),
+		newTestRankable("720p", "720p", "FLUX", "1GB", 1000, now),
+		newTestRankable("1080p-denied", "1080p", "YIFY", "2GB", 1000, now),
+		{testSortable: testSortable{name: "unsortable"}},
+	}
+
+	ranked := RankStreams(items, &StreamQualityProfile{
+		Resolutions:     []string{"2160p", "1080p"},
+		MinSize:         map[string]string{"2160p": "8GB"},
+		DenyGroups:      []string{"yify"},
+		SeedersHalfLife: 15,
+	}, nil)
+
+	names := make([]string, len(ranked))
+	for i := range ranked {
+		names[i] = ranked[i].name
+	}
+	assert.Equal(t, []string{"2160p", "1080p-new", "1080p-old", "unsortable"}, names)
+}
+
+func TestStreamQualityProfileAllowGroups(t *testing.T) {
+	profile := &StreamQualityProfile{AllowGroups: []string{"FLUX"}}
+	assert.True(t, profile.Accepts(newTestRankable("", "1080p", "flux", "", 0, time.Time{}).r))
+	assert.False(t, profile.Accepts(newTestRankable("", "1080p", "NTb", "", 0, time.Time{}).r))
+	assert.False(t, profile.Accepts(newTestRankable("", "1080p", "", "", 0, time.Time{}).r))
+}
+
+func TestStreamQualityProfileValidate(t *testing.T) {
+	assert.NoError(t, (&StreamQualityProfile{MinSize: map[string]string{"1080p": "2GB"}}).Validate())
+	assert.Error(t, (&StreamQualityProfile{MinSize: map[string]string{"1080p": "big"}}).Validate())
+}
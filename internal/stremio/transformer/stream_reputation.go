@@ -0,0 +1,57 @@
+package stremio_transformer
+
+import (
+	"github.com/MunifTanjim/stremthru/internal/release_group_reputation"
+)
+
+// SetStreamReputation sets the reputation score on the extractor results. It
+// is the average of the release group and the uploader scores, for the ones
+// known for the stream.
+func SetStreamReputation[T StreamSortable](items []T) {
+	groups, uploaders := []string{}, []string{}
+	for i := range items {
+		r := items[i].GetExtractorResult()
+		if r == nil {
+			continue
+		}
+		if r.Result != nil && r.Group != "" {
+			groups = append(groups, r.Group)
+		}
+		if r.Indexer != "" {
+			uploaders = append(uploaders, r.Indexer)
+		}
+	}
+
+	scoreByGroup, err := release_group_reputation.GetScores(release_group_reputation.KindGroup, groups)
+	if err != nil {
+		log.Error("failed to get release group reputation", "error", err)
+	}
+	scoreByUploader, err := release_group_reputation.GetScores(release_group_reputation.KindUploader, uploaders)
+	if err != nil {
+		log.Error("failed to get uploader reputation", "error", err)
+	}
+
+	for i := range items {
+		r := items[i].GetExtractorResult()
+		if r == nil {
+			continue
+		}
+		total, count := 0, 0
+		if r.Result != nil && r.Group != "" {
+			if score, ok := scoreByGroup[release_group_reputation.NormalizeName(r.Group)]; ok {
+				total += score
+				count++
+			}
+		}
+		if r.Indexer != "" {
+			if score, ok := scoreByUploader[release_group_reputation.NormalizeName(r.Indexer)]; ok {
+				total += score
+				count++
+			}
+		}
+		r.Reputation = release_group_reputation.DefaultScore
+		if count > 0 {
+			r.Reputation = total / count
+		}
+	}
+}
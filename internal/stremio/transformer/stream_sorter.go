@@ -1,11 +1,15 @@
 package stremio_transformer
 
 import (
+	"errors"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 )
 
 type StreamSortableField string
@@ -15,13 +19,18 @@ const (
 	StreamSortableFieldQuality    StreamSortableField = "quality"
 	StreamSortableFieldSize       StreamSortableField = "size"
 	StreamSortableFieldHDR        StreamSortableField = "hdr"
+	StreamSortableFieldCodec      StreamSortableField = "codec"
+	StreamSortableFieldLanguage   StreamSortableField = "language"
+	StreamSortableFieldSeeders    StreamSortableField = "seeders"
+	StreamSortableFieldBitDepth   StreamSortableField = "bitdepth"
+	StreamSortableFieldStoreCache StreamSortableField = "store_is_cached"
+	StreamSortableFieldReputation StreamSortableField = "reputation"
+	// score computed from an expression against StreamExtractorResult
+	StreamSortableFieldExpr StreamSortableField = "expr"
 )
 
 type StreamSortable interface {
-	GetQuality() string
-	GetResolution() string
-	GetSize() string
-	GetHDR() string
+	GetExtractorResult() *StreamExtractorResult
 	IsSortable() bool
 }
 
@@ -109,20 +118,93 @@ func getSizeRank(input string) int64 {
 	return util.ToBytes(input)
 }
 
-func getHDRRank(input string) int64 {
-	return int64(len(input))
+func getHDRRank(input []string) int64 {
+	return int64(len(strings.Join(input, "|")))
 }
 
-func getFieldRank(str StreamSortable, field StreamSortableField) int64 {
-	switch field {
+func getCodecRank(input string) int64 {
+	codec := strings.ToLower(input)
+	switch {
+	case strings.Contains(codec, "av1"):
+		return 50
+	case strings.Contains(codec, "hevc"), strings.Contains(codec, "265"):
+		return 40
+	case strings.Contains(codec, "avc"), strings.Contains(codec, "264"):
+		return 30
+	case strings.Contains(codec, "xvid"), strings.Contains(codec, "divx"):
+		return 10
+	case codec != "":
+		return 1
+	}
+	return 0
+}
+
+func getBitDepthRank(input string) int64 {
+	depth := strings.TrimSuffix(strings.ToLower(input), "bit")
+	if v, err := strconv.Atoi(strings.TrimSpace(depth)); err == nil {
+		return int64(v)
+	}
+	return 0
+}
+
+// with preferred languages, the first matching preference ranks highest.
+// otherwise streams with more languages rank higher.
+func getLanguageRank(input []string, preferred []string) int64 {
+	if len(preferred) == 0 {
+		return int64(len(input))
+	}
+	for i, lang := range preferred {
+		if slices.Contains(input, lang) {
+			return int64(len(preferred) - i)
+		}
+	}
+	return 0
+}
+
+func toSortRank(value any) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+	}
+	return 0
+}
+
+func getFieldRank(r *StreamExtractorResult, config *StreamSorterConfig) (float64, error) {
+	switch config.Field {
 	case StreamSortableFieldResolution:
-		return getResolutionRank(str.GetResolution())
+		return float64(getResolutionRank(r.Resolution)), nil
 	case StreamSortableFieldQuality:
-		return getQualityRank(str.GetQuality())
+		return float64(getQualityRank(r.Quality)), nil
 	case StreamSortableFieldSize:
-		return getSizeRank(str.GetSize())
+		return float64(getSizeRank(r.Size)), nil
 	case StreamSortableFieldHDR:
-		return getHDRRank(str.GetHDR())
+		return float64(getHDRRank(r.HDR)), nil
+	case StreamSortableFieldCodec:
+		return float64(getCodecRank(r.Codec)), nil
+	case StreamSortableFieldLanguage:
+		return float64(getLanguageRank(r.Languages, config.Languages)), nil
+	case StreamSortableFieldSeeders:
+		return float64(r.Seeders), nil
+	case StreamSortableFieldBitDepth:
+		return float64(getBitDepthRank(r.BitDepth)), nil
+	case StreamSortableFieldStoreCache:
+		return toSortRank(r.Store.IsCached), nil
+	case StreamSortableFieldReputation:
+		return float64(r.Reputation), nil
+	case StreamSortableFieldExpr:
+		output, err := expr.Run(config.program, r)
+		if err != nil {
+			return 0, err
+		}
+		return toSortRank(output), nil
 	default:
 		panic("Unsupported field for sorting")
 	}
@@ -131,24 +213,86 @@ func getFieldRank(str StreamSortable, field StreamSortableField) int64 {
 type StreamSorterConfig struct {
 	Field StreamSortableField
 	Desc  bool
+	// preferred languages, for StreamSortableFieldLanguage
+	Languages []string
+	program   *vm.Program
 }
 
-func parseSortConfig(config string) []StreamSorterConfig {
+// splitSortConfig splits on commas that are not nested inside brackets or
+// string literals, so expressions can contain commas.
+func splitSortConfig(config string) []string {
+	parts := []string{}
+	depth := 0
+	var quote rune
+	start := 0
+	for i, c := range config {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, config[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, config[start:])
+}
+
+func ParseSortConfig(config string) ([]StreamSorterConfig, error) {
 	sortConfigs := []StreamSorterConfig{}
-	for part := range strings.SplitSeq(config, ",") {
+	errs := []error{}
+	for _, part := range splitSortConfig(config) {
 		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
 		desc := strings.HasPrefix(part, "-")
-		field := StreamSortableField(strings.TrimPrefix(part, "-"))
+		part = strings.TrimSpace(strings.TrimPrefix(part, "-"))
+
+		name, arg, hasArg := strings.Cut(part, ":")
+		field := StreamSortableField(name)
 		switch field {
-		case StreamSortableFieldResolution, StreamSortableFieldQuality, StreamSortableFieldSize, StreamSortableFieldHDR:
-			sortConfigs = append(sortConfigs, StreamSorterConfig{Field: field, Desc: desc})
+		case StreamSortableFieldResolution, StreamSortableFieldQuality, StreamSortableFieldSize, StreamSortableFieldHDR,
+			StreamSortableFieldCodec, StreamSortableFieldSeeders, StreamSortableFieldBitDepth, StreamSortableFieldStoreCache,
+			StreamSortableFieldReputation:
+			if !hasArg {
+				sortConfigs = append(sortConfigs, StreamSorterConfig{Field: field, Desc: desc})
+				continue
+			}
+		case StreamSortableFieldLanguage:
+			sortConfig := StreamSorterConfig{Field: field, Desc: desc}
+			if hasArg {
+				for lang := range strings.SplitSeq(arg, "|") {
+					if lang = strings.ToLower(strings.TrimSpace(lang)); lang != "" {
+						sortConfig.Languages = append(sortConfig.Languages, lang)
+					}
+				}
+			}
+			sortConfigs = append(sortConfigs, sortConfig)
+			continue
 		}
+
+		program, err := compileStreamExpr(part)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sortConfigs = append(sortConfigs, StreamSorterConfig{Field: StreamSortableFieldExpr, Desc: desc, program: program})
 	}
-	return sortConfigs
+	return sortConfigs, errors.Join(errs...)
 }
 
 type streamSorter[T StreamSortable] struct {
 	items  []T
+	ranks  [][]float64
+	errs   []error
 	config []StreamSorterConfig
 }
 
@@ -157,19 +301,20 @@ func (ss streamSorter[StreamSortable]) Len() int {
 }
 func (ss streamSorter[StreamSortable]) Swap(i, j int) {
 	ss.items[i], ss.items[j] = ss.items[j], ss.items[i]
+	ss.ranks[i], ss.ranks[j] = ss.ranks[j], ss.ranks[i]
+	ss.errs[i], ss.errs[j] = ss.errs[j], ss.errs[i]
 }
 
 func (ss streamSorter[StreamSortable]) Less(a, b int) bool {
-	aData, bData := ss.items[a], ss.items[b]
-	if !bData.IsSortable() {
+	aRanks, bRanks := ss.ranks[a], ss.ranks[b]
+	if bRanks == nil {
 		return true
-	} else if !aData.IsSortable() {
+	} else if aRanks == nil {
 		return false
 	}
 
-	for _, config := range ss.config {
-		va := getFieldRank(aData, config.Field)
-		vb := getFieldRank(bData, config.Field)
+	for i, config := range ss.config {
+		va, vb := aRanks[i], bRanks[i]
 
 		if va == vb {
 			continue
@@ -186,15 +331,57 @@ func (ss streamSorter[StreamSortable]) Less(a, b int) bool {
 
 const StreamDefaultSortConfig = "-resolution,-quality,-size"
 
-func SortStreams[T StreamSortable](items []T, config string) {
+func SortStreams[T StreamSortable](items []T, config string, trace *StreamTrace[T]) {
 	if config == "" {
 		config = StreamDefaultSortConfig
 	}
 
-	sortConfigs := parseSortConfig(config)
-	if len(sortConfigs) == 0 {
+	sortConfigs, err := ParseSortConfig(config)
+	if err != nil {
+		log.Warn("failed to parse sort config", "error", err)
+		trace.AddError(StreamPreviewStageSort, err)
+	}
+	ranks, errs := sortStreams(items, sortConfigs)
+	if trace == nil {
 		return
 	}
-	sorter := streamSorter[T]{items: items, config: sortConfigs}
+	for i := range items {
+		if r := items[i].GetExtractorResult(); r != nil {
+			trace.setSortKeys(r, ranks[i])
+			if errs[i] != nil {
+				trace.addItemError(r, StreamPreviewStageSort, errs[i])
+			}
+		}
+	}
+}
+
+// sortStreams sorts `items` in place, and returns the sort keys and the
+// errors (from expressions) for each item, in sorted order.
+func sortStreams[T StreamSortable](items []T, sortConfigs []StreamSorterConfig) ([][]float64, []error) {
+	ranks := make([][]float64, len(items))
+	errs := make([]error, len(items))
+	if len(sortConfigs) == 0 {
+		return ranks, errs
+	}
+
+	for i := range items {
+		if !items[i].IsSortable() {
+			continue
+		}
+		r := items[i].GetExtractorResult()
+		ranks[i] = make([]float64, len(sortConfigs))
+		itemErrs := []error{}
+		for j := range sortConfigs {
+			rank, err := getFieldRank(r, &sortConfigs[j])
+			if err != nil {
+				itemErrs = append(itemErrs, err)
+			}
+			ranks[i][j] = rank
+		}
+		errs[i] = errors.Join(itemErrs...)
+	}
+
+	sorter := streamSorter[T]{items: items, ranks: ranks, errs: errs, config: sortConfigs}
 	sort.Stable(sorter)
+	return ranks, errs
 }
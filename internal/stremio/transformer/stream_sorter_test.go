@@ -0,0 +1,79 @@
+package stremio_transformer
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/go-ptt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSortable struct {
+	name string
+	r    *StreamExtractorResult
+}
+
+func (s testSortable) IsSortable() bool {
+	return s.r != nil
+}
+
+func (s testSortable) GetExtractorResult() *StreamExtractorResult {
+	return s.r
+}
+
+func sortedNames(items []testSortable) []string {
+	names := make([]string, len(items))
+	for i := range items {
+		names[i] = items[i].name
+	}
+	return names
+}
+
+func TestSplitSortConfig(t *testing.T) {
+	assert.Equal(t, []string{"-resolution", " max(Seeders, 10)", ` Group in ["a,b", "c"]`}, splitSortConfig(`-resolution, max(Seeders, 10), Group in ["a,b", "c"]`))
+}
+
+func TestParseSortConfig(t *testing.T) {
+	configs, err := ParseSortConfig("-resolution, language:EN|ja, seeders, -(Resolution == \"2160p\" ? 100 : 0) + Seeders / 10")
+	require.NoError(t, err)
+	require.Len(t, configs, 4)
+	assert.Equal(t, StreamSorterConfig{Field: StreamSortableFieldResolution, Desc: true}, configs[0])
+	assert.Equal(t, []string{"en", "ja"}, configs[1].Languages)
+	assert.Equal(t, StreamSortableFieldSeeders, configs[2].Field)
+	assert.Equal(t, StreamSortableFieldExpr, configs[3].Field)
+	assert.True(t, configs[3].Desc)
+
+	_, err = ParseSortConfig("-resolution, Seeders +")
+	assert.Error(t, err)
+}
+
+func TestSortStreams(t *testing.T) {
+	newItems := func() []testSortable {
+		return []testSortable{
+			{"a", &StreamExtractorResult{Result: &ptt.Result{Resolution: "1080p", Codec: "avc", Languages: []string{"en"}}, Seeders: 100}},
+			{"unsortable", nil},
+			{"b", &StreamExtractorResult{Result: &ptt.Result{Resolution: "2160p", Codec: "hevc", BitDepth: "10bit", Languages: []string{"ja"}}, Seeders: 5}},
+			{"c", &StreamExtractorResult{Result: &ptt.Result{Resolution: "1080p", Codec: "hevc", Languages: []string{"en", "ja"}}, Seeders: 50, Store: StreamExtractorResultStore{IsCached: true}}},
+		}
+	}
+
+	for _, tc := range []struct {
+		config   string
+		expected []string
+	}{
+		{"", []string{"b", "a", "c", "unsortable"}},
+		{"-seeders", []string{"a", "c", "b", "unsortable"}},
+		{"-resolution,-codec", []string{"b", "c", "a", "unsortable"}},
+		{"-store_is_cached,-bitdepth", []string{"c", "b", "a", "unsortable"}},
+		{"-language:ja|en", []string{"b", "c", "a", "unsortable"}},
+		{"-language", []string{"c", "a", "b", "unsortable"}},
+		{`-(Resolution == "2160p" ? 100 : 0) + Seeders / 10`, []string{"b", "a", "c", "unsortable"}},
+		{`-(Resolution >= "1080p" ? 1 : 0), -Seeders`, []string{"a", "c", "b", "unsortable"}},
+	} {
+		t.Run(tc.config, func(t *testing.T) {
+			items := newItems()
+			SortStreams(items, tc.config, nil)
+			assert.Equal(t, tc.expected, sortedNames(items))
+		})
+	}
+}
@@ -12,7 +12,7 @@ var StreamTemplateDefault = StreamTemplateBlob{
 {{if ne .Quality ""}}💿 {{.Quality}} {{end}}{{if ne .Codec ""}}🎞️ {{.Codec}}{{end}}
 {{if ne (len .HDR) 0}}📺 {{str_join .HDR " "}} {{end -}}
 {{- if or (gt (len .Audio) 0) (gt (len .Channels) 0)}}🎧 {{if gt (len .Audio) 0}}{{str_join .Audio  ", "}}{{if gt (len .Channels) 0}} | {{end}}{{end}}{{if gt (len .Channels) 0}}{{str_join .Channels ", "}}{{end}}{{end}}
-{{if ne .Size ""}}{{if and (ne .File.Size "") (ne .File.Size .Size)}}💾 {{.File.Size}} {{end}}📦 {{.Size}}{{end}}{{if gt .Seeders 0}} 👤 {{.Seeders}}{{end}}{{if ne .Group ""}} ⚙️ {{.Group}}{{end}}{{if ne .Site ""}} 🔗 {{.Site}}{{end}}{{if ne .Indexer ""}} 🔍 {{.Indexer}}{{end}}{{if ne (len .Languages) 0}}
+{{if ne .Size ""}}{{if and (ne .File.Size "") (ne .File.Size .Size)}}💾 {{.File.Size}} {{end}}📦 {{.Size}}{{end}}{{if gt .Seeders 0}} 👤 {{.Seeders}}{{end}}{{if ne .Group ""}} ⚙️ {{.Group}}{{end}}{{if ne .Site ""}} 🔗 {{.Site}}{{end}}{{if ne .Indexer ""}} 🔍 {{.Indexer}}{{end}}{{if gt (len .Sources) 1}} 🧩 {{str_join .Sources ", "}}{{end}}{{if ne (len .Languages) 0}}
 🌐 {{lang_join .Languages " " "emoji"}}
 {{- end}}{{if ne .File.Name ""}}
 📄 {{.File.Name}}{{else if ne .TTitle ""}}
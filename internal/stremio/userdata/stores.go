@@ -103,6 +103,19 @@ func (ud *UserDataStores) Prepare(ctx *context.StoreContext) (err error, errFiel
 		ud.stores = stores
 		ud.isStremThruStore = true
 	} else if storeCount == 1 && ud.Stores[0].Code.IsP2P() {
+		if token := ud.Stores[0].Token; token != "" && !IsPublicInstance {
+			auth, err := core.ParseBasicAuth(token)
+			if err != nil {
+				return err, "token"
+			}
+			password := config.ProxyAuthPassword.GetPassword(auth.Username)
+			if password == "" || password != auth.Password {
+				return errors.New("invalid token"), "token"
+			}
+			ctx.IsProxyAuthorized = true
+			ctx.ProxyAuthUser = auth.Username
+			ctx.ProxyAuthPassword = auth.Password
+		}
 		ud.stores = nil
 		ud.isP2P = true
 		return nil, ""
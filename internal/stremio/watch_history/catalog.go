@@ -0,0 +1,143 @@
+package stremio_watch_history
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/imdb_title"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+func formatEpisode(season, episode int) string {
+	return fmt.Sprintf("S%02dE%02d", season, episode)
+}
+
+// the history rows of a title are grouped, so more rows than `limit` can be
+// needed for `limit` titles.
+const catalogMaxPages = 10
+
+// ListCatalogItems returns the history as "Continue Watching" catalog items,
+// at most `limit` titles, most recently played first. Items without imdb id
+// use their meta id, with `otherType`.
+func ListCatalogItems(addon, key string, limit int, otherType stremio.ContentType, log *logger.Logger) ([]stremio.MetaPreview, error) {
+	seen := map[string]struct{}{}
+	filtered := []WatchHistory{}
+	imdbIds := []string{}
+	hashes := []string{}
+	for page := 0; page < catalogMaxPages && len(filtered) < limit; page++ {
+		items, err := List(addon, key, limit, page*limit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			item := &items[i]
+			id := item.MId
+			if strings.HasPrefix(item.SId, "tt") {
+				id, _, _ = strings.Cut(item.SId, ":")
+			}
+			if id == "" {
+				continue
+			}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			filtered = append(filtered, *item)
+			if strings.HasPrefix(id, "tt") {
+				imdbIds = append(imdbIds, id)
+			}
+			if _, _, _, ok := parseSeriesStremId(item.SId); ok && item.Hash != "" {
+				hashes = append(hashes, item.Hash)
+			}
+			if len(filtered) == limit {
+				break
+			}
+		}
+		if len(items) < limit {
+			break
+		}
+	}
+
+	titleById := map[string]imdb_title.IMDBTitle{}
+	if len(imdbIds) > 0 {
+		titles, err := imdb_title.ListByIds(imdbIds)
+		if err != nil {
+			log.Error("failed to list imdb titles", "error", err)
+		}
+		for _, title := range titles {
+			titleById[title.TId] = title
+		}
+	}
+
+	filesByHash := map[string]torrent_stream.Files{}
+	if len(hashes) > 0 {
+		files, err := torrent_stream.GetFilesByHashes(hashes)
+		if err != nil {
+			log.Error("failed to get files by hashes", "error", err)
+		}
+		for hash, f := range files {
+			filesByHash[hash] = f
+		}
+	}
+
+	metas := make([]stremio.MetaPreview, 0, len(filtered))
+	for i := range filtered {
+		item := &filtered[i]
+
+		lastPlayed := "Last Played: " + item.UAt.Format(time.DateOnly)
+
+		if !strings.HasPrefix(item.SId, "tt") {
+			metas = append(metas, stremio.MetaPreview{
+				Id:          item.MId,
+				Type:        otherType,
+				Name:        item.File,
+				Description: lastPlayed + "\n" + item.File,
+				PosterShape: stremio.MetaPosterShapePoster,
+			})
+			continue
+		}
+
+		imdbId, _, isSeries := strings.Cut(item.SId, ":")
+		sType := stremio.ContentTypeMovie
+		if isSeries {
+			sType = stremio.ContentTypeSeries
+		}
+		meta := stremio.MetaPreview{
+			Id:          imdbId,
+			Type:        sType,
+			Name:        item.File,
+			Poster:      stremio_shared.GetCinemetaPosterURL(imdbId),
+			Background:  stremio_shared.GetCinemetaBackgroundURL(imdbId),
+			PosterShape: stremio.MetaPosterShapePoster,
+		}
+		if title, ok := titleById[imdbId]; ok {
+			meta.Name = title.Title
+			if title.Year > 0 {
+				meta.ReleaseInfo = strconv.Itoa(title.Year)
+			}
+		}
+
+		description := []string{lastPlayed}
+		if _, season, episode, ok := parseSeriesStremId(item.SId); ok {
+			description[0] += " · " + formatEpisode(season, episode)
+			if next := findNextEpisode(item.SId, filesByHash[item.Hash]); next != nil {
+				upNext := "Up Next: " + formatEpisode(next.Season, next.Episode)
+				if next.File != nil {
+					upNext += " · " + next.File.Name
+				}
+				description = append(description, upNext)
+			}
+		}
+		description = append(description, item.File)
+		meta.Description = strings.Join(description, "\n")
+
+		metas = append(metas, meta)
+	}
+
+	return metas, nil
+}
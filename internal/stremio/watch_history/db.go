@@ -0,0 +1,167 @@
+package stremio_watch_history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/db"
+)
+
+const TableName = "stremio_watch_history"
+
+type WatchHistory struct {
+	Addon string
+	Key   string
+	Hash  string
+	File  string
+	SId   string
+	MId   string
+	CAt   db.Timestamp
+	UAt   db.Timestamp
+}
+
+var Column = struct {
+	Addon string
+	Key   string
+	Hash  string
+	File  string
+	SId   string
+	MId   string
+	CAt   string
+	UAt   string
+}{
+	Addon: "addon",
+	Key:   "key",
+	Hash:  "hash",
+	File:  "file",
+	SId:   "sid",
+	MId:   "mid",
+	CAt:   "cat",
+	UAt:   "uat",
+}
+
+var columns = []string{
+	Column.Addon,
+	Column.Key,
+	Column.Hash,
+	Column.File,
+	Column.SId,
+	Column.MId,
+	Column.CAt,
+	Column.UAt,
+}
+
+// GetKey returns the history key for a userdata. Saved userdata (`k.<id>`)
+// uses its id, otherwise the value is hashed so that secrets do not end up
+// in the database.
+func GetKey(userData string) string {
+	if id, ok := strings.CutPrefix(userData, "k."); ok {
+		return id
+	}
+	hash := sha256.Sum256([]byte(userData))
+	return "h." + hex.EncodeToString(hash[:])
+}
+
+var query_record = fmt.Sprintf(
+	`INSERT INTO %s AS swh (%s) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (%s) DO UPDATE SET %s = CASE WHEN EXCLUDED.%s != '' THEN EXCLUDED.%s ELSE swh.%s END, %s = CASE WHEN EXCLUDED.%s != '' THEN EXCLUDED.%s ELSE swh.%s END, %s = %s`,
+	TableName,
+	db.JoinColumnNames(Column.Addon, Column.Key, Column.Hash, Column.File, Column.SId, Column.MId),
+	db.JoinColumnNames(Column.Addon, Column.Key, Column.Hash, Column.File),
+	Column.SId, Column.SId, Column.SId, Column.SId,
+	Column.MId, Column.MId, Column.MId, Column.MId,
+	Column.UAt,
+	db.CurrentTimestamp,
+)
+
+func Record(item *WatchHistory) error {
+	if item.Addon == "" || item.Key == "" || item.Hash == "" {
+		return nil
+	}
+	_, err := db.Exec(query_record, item.Addon, item.Key, strings.ToLower(item.Hash), item.File, item.SId, item.MId)
+	return err
+}
+
+var query_list = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s = ? ORDER BY %s DESC LIMIT ? OFFSET ?`,
+	db.JoinColumnNames(columns...),
+	TableName,
+	Column.Addon,
+	db.JoinColumnNames(Column.Key),
+	Column.UAt,
+)
+
+// List returns the most recently played items first.
+func List(addon, key string, limit, offset int) ([]WatchHistory, error) {
+	rows, err := db.Query(query_list, addon, key, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []WatchHistory{}
+	for rows.Next() {
+		item := WatchHistory{}
+		if err := rows.Scan(&item.Addon, &item.Key, &item.Hash, &item.File, &item.SId, &item.MId, &item.CAt, &item.UAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+var query_delete = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s = ?`,
+	TableName,
+	Column.Addon,
+	db.JoinColumnNames(Column.Key),
+)
+
+func Delete(addon, key string) error {
+	_, err := db.Exec(query_delete, addon, key)
+	return err
+}
+
+type ExportItem struct {
+	Hash          string    `json:"hash"`
+	File          string    `json:"file,omitempty"`
+	StremId       string    `json:"sid,omitempty"`
+	MetaId        string    `json:"mid,omitempty"`
+	FirstPlayedAt time.Time `json:"first_played_at"`
+	LastPlayedAt  time.Time `json:"last_played_at"`
+}
+
+type ExportData struct {
+	Addon string       `json:"addon"`
+	Items []ExportItem `json:"items"`
+}
+
+const max_export_items = 5000
+
+func Export(addon, key string) (*ExportData, error) {
+	items, err := List(addon, key, max_export_items, 0)
+	if err != nil {
+		return nil, err
+	}
+	data := &ExportData{
+		Addon: addon,
+		Items: make([]ExportItem, len(items)),
+	}
+	for i := range items {
+		item := &items[i]
+		data.Items[i] = ExportItem{
+			Hash:          item.Hash,
+			File:          item.File,
+			StremId:       item.SId,
+			MetaId:        item.MId,
+			FirstPlayedAt: item.CAt.Time,
+			LastPlayedAt:  item.UAt.Time,
+		}
+	}
+	return data, nil
+}
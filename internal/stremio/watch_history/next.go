@@ -0,0 +1,89 @@
+package stremio_watch_history
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
+)
+
+type NextEpisode struct {
+	StremId string
+	Season  int
+	Episode int
+	File    *torrent_stream.File
+}
+
+func parseSeriesStremId(sid string) (id string, season, episode int, ok bool) {
+	id, specs, isSeries := strings.Cut(sid, ":")
+	if !isSeries || !strings.HasPrefix(id, "tt") {
+		return "", 0, 0, false
+	}
+	strS, strEp, _ := strings.Cut(specs, ":")
+	season, errS := strconv.Atoi(strS)
+	episode, errEp := strconv.Atoi(strEp)
+	if errS != nil || errEp != nil {
+		return "", 0, 0, false
+	}
+	return id, season, episode, true
+}
+
+// GetNextEpisode suggests the episode after `sid`, preferring a file tagged
+// with it in the same torrent. When the torrent does not have it, the next
+// episode of the same season is suggested without a file.
+func GetNextEpisode(hash string, sid string) (*NextEpisode, error) {
+	if _, _, _, ok := parseSeriesStremId(sid); !ok {
+		return nil, nil
+	}
+
+	if hash == "" {
+		return findNextEpisode(sid, nil), nil
+	}
+
+	filesByHash, err := torrent_stream.GetFilesByHashes([]string{hash})
+	if err != nil {
+		return findNextEpisode(sid, nil), err
+	}
+	return findNextEpisode(sid, filesByHash[hash]), nil
+}
+
+// findNextEpisode is GetNextEpisode with the files of the torrent.
+func findNextEpisode(sid string, files []torrent_stream.File) *NextEpisode {
+	id, season, episode, ok := parseSeriesStremId(sid)
+	if !ok {
+		return nil
+	}
+
+	next := &NextEpisode{
+		StremId: id + ":" + strconv.Itoa(season) + ":" + strconv.Itoa(episode+1),
+		Season:  season,
+		Episode: episode + 1,
+	}
+
+	var nextSeasonFile *torrent_stream.File
+	for i := range files {
+		f := &files[i]
+		fId, fSeason, fEpisode, ok := parseSeriesStremId(f.SId)
+		if !ok || fId != id {
+			continue
+		}
+		if fSeason == season && fEpisode == episode+1 {
+			f.Normalize()
+			next.File = f
+			return next
+		}
+		if fSeason == season+1 && fEpisode == 1 {
+			nextSeasonFile = f
+		}
+	}
+
+	if nextSeasonFile != nil {
+		nextSeasonFile.Normalize()
+		next.StremId = nextSeasonFile.SId
+		next.Season = season + 1
+		next.Episode = 1
+		next.File = nextSeasonFile
+	}
+
+	return next
+}
@@ -0,0 +1,62 @@
+package stremio_watch_history
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeriesStremId(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		sid     string
+		id      string
+		season  int
+		episode int
+		ok      bool
+	}{
+		{"series", "tt0944947:1:2", "tt0944947", 1, 2, true},
+		{"movie", "tt0111161", "", 0, 0, false},
+		{"anime", "kitsu:1:2", "", 0, 0, false},
+		{"malformed", "tt0944947:1:x", "", 0, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			id, season, episode, ok := parseSeriesStremId(tc.sid)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.id, id)
+			assert.Equal(t, tc.season, season)
+			assert.Equal(t, tc.episode, episode)
+		})
+	}
+}
+
+func TestFindNextEpisode(t *testing.T) {
+	files := []torrent_stream.File{
+		{Path: "/Show/S01E02.mkv", SId: "tt0944947:1:2"},
+		{Path: "/Show/S01E03.mkv", SId: "tt0944947:1:3"},
+		{Path: "/Show/S02E01.mkv", SId: "tt0944947:2:1"},
+	}
+
+	next := findNextEpisode("tt0944947:1:2", files)
+	assert.Equal(t, "tt0944947:1:3", next.StremId)
+	assert.Equal(t, "S01E03.mkv", next.File.Name)
+
+	next = findNextEpisode("tt0944947:1:3", files)
+	assert.Equal(t, "tt0944947:2:1", next.StremId)
+	assert.Equal(t, 2, next.Season)
+	assert.Equal(t, 1, next.Episode)
+
+	next = findNextEpisode("tt0944947:2:1", files)
+	assert.Equal(t, "tt0944947:2:2", next.StremId)
+	assert.Nil(t, next.File)
+
+	assert.Nil(t, findNextEpisode("tt0111161", files))
+}
+
+func TestGetKey(t *testing.T) {
+	assert.Equal(t, "abc", GetKey("k.abc"))
+	key := GetKey("eyJzdG9yZV9uYW1lIjoiIn0")
+	assert.Len(t, key, 2+64)
+	assert.Equal(t, key, GetKey("eyJzdG9yZV9uYW1lIjoiIn0"))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/buddy"
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/release_group_reputation"
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	store_video "github.com/MunifTanjim/stremthru/internal/store/video"
@@ -124,6 +125,7 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 			} else if magnet.Status == store.MagnetStatusFailed || magnet.Status == store.MagnetStatusInvalid || magnet.Status == store.MagnetStatusUnknown {
 				strem.error_level = logger.LevelWarn
 				strem.error_video = "download_failed"
+				recordMagnetStatusReputation(magnetHash, magnet.Status)
 			}
 			return strem, err
 		}
@@ -221,5 +223,17 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Debug("redirecting to stream link")
+	if IsMethod(r, http.MethodGet) {
+		go release_group_reputation.RecordByHash(magnetHash, release_group_reputation.SignalSuccess)
+	}
 	http.Redirect(w, r, strem.link, http.StatusFound)
 }
+
+func recordMagnetStatusReputation(hash string, status store.MagnetStatus) {
+	switch status {
+	case store.MagnetStatusFailed:
+		go release_group_reputation.RecordByHash(hash, release_group_reputation.SignalFailed)
+	case store.MagnetStatusInvalid:
+		go release_group_reputation.RecordByHash(hash, release_group_reputation.SignalInvalid)
+	}
+}
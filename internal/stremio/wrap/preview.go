@@ -0,0 +1,94 @@
+package stremio_wrap
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+func (ws WrappedStream) ToPreviewItem() *stremio_transformer.StreamPreviewItem {
+	item := &stremio_transformer.StreamPreviewItem{
+		Source:      ws.source,
+		Name:        ws.Name,
+		Description: ws.Description,
+		Fields:      ws.r,
+	}
+	if item.Description == "" {
+		item.Description = ws.Title
+	}
+	raw := ws.raw
+	if raw == nil {
+		raw = ws.Stream
+	}
+	item.Raw.Name = raw.Name
+	item.Raw.Description = raw.Description
+	if item.Raw.Description == "" {
+		item.Raw.Description = raw.Title
+	}
+	if ws.r != nil && len(ws.r.Sources) > 0 {
+		item.Source = strings.Join(ws.r.Sources, ", ")
+	}
+	if ws.templateErr != nil {
+		item.AddError(stremio_transformer.StreamPreviewStageTemplate, ws.templateErr)
+	}
+	return item
+}
+
+// handleConfigurePreview runs the stream pipeline for a sample id with the
+// (draft) userdata from the configure page.
+func handleConfigurePreview(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) && !IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	id := strings.TrimSuffix(r.URL.Query().Get("id"), ".json")
+	if !strings.HasPrefix(id, "tt") {
+		shared.ErrorBadRequest(r, "invalid id, expected imdb id").Send(w, r)
+		return
+	}
+	rType := r.URL.Query().Get("type")
+	if rType == "" {
+		rType = string(stremio.ContentTypeMovie)
+		if strings.Contains(id, ":") {
+			rType = string(stremio.ContentTypeSeries)
+		}
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if len(ud.Upstreams) == 0 {
+		shared.ErrorBadRequest(r, "missing upstreams").Send(w, r)
+		return
+	}
+
+	ctx, err := ud.GetRequestContext(r)
+	if err != nil {
+		shared.ErrorBadRequest(r, err.Error()).Send(w, r)
+		return
+	}
+
+	trace := stremio_transformer.NewStreamTrace[WrappedStream]()
+
+	template, err := ud.template.Parse()
+	if err != nil {
+		trace.AddError(stremio_transformer.StreamPreviewStageTemplate, err)
+	}
+
+	streams, err := ud.fetchStreams(ctx, r, rType, id+".json", template, trace)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	streams = ud.processStreams(streams, template, trace)
+
+	SendResponse(w, r, 200, stremio_transformer.PreviewStreams(streams, trace))
+}
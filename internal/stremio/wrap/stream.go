@@ -27,11 +27,11 @@ import (
 
 var lazyPullTorz = config.Stremio.Torz.LazyPull
 
-func (ud UserData) fetchStream(ctx *context.StoreContext, r *http.Request, rType, id string) (*stremio.StreamHandlerResponse, error) {
+// fetchStreams fetches the streams from the upstreams (and torz), through the
+// extractor and the template.
+func (ud UserData) fetchStreams(ctx *context.StoreContext, r *http.Request, rType, id string, template *stremio_transformer.StreamTemplate, trace *stremio_transformer.StreamTrace[WrappedStream]) ([]WrappedStream, error) {
 	log := ctx.Log
 
-	eud := ud.GetEncoded()
-
 	stremId := strings.TrimSuffix(id, ".json")
 
 	upstreams, err := ud.getUpstreams(ctx, stremio.ResourceNameStream, rType, id)
@@ -48,11 +48,6 @@ func (ud UserData) fetchStream(ctx *context.StoreContext, r *http.Request, rType
 	chunks := make([][]WrappedStream, chunksCount)
 	errs := make([]error, chunksCount)
 
-	template, err := ud.template.Parse()
-	if err != nil {
-		return nil, err
-	}
-
 	isImdbStremId := strings.HasPrefix(stremId, "tt")
 	torrentInfoCategory := torrent_info.GetCategoryFromStremId(stremId, rType)
 
@@ -108,14 +103,21 @@ func (ud UserData) fetchStream(ctx *context.StoreContext, r *http.Request, rType
 				if tmpl == nil || tmpl.IsEmpty() || tmpl.IsRaw() {
 					tmpl = stremio_transformer.StreamTemplateDefault
 				}
+				if wstream.R.Addon.Name != "" {
+					wstream.R.Sources = []string{wstream.R.Addon.Name}
+				}
+				raw := *stream
 				s, err := tmpl.Execute(stream, wstream.R)
 				if err != nil {
 					errs[0] = err
 					return
 				}
 				wstreams[i] = WrappedStream{
-					Stream: s,
-					r:      wstream.R,
+					Stream:   s,
+					raw:      &raw,
+					source:   wstream.R.Addon.Name,
+					r:        wstream.R,
+					template: tmpl,
 				}
 			}
 			chunks[0] = wstreams
@@ -144,6 +146,7 @@ func (ud UserData) fetchStream(ctx *context.StoreContext, r *http.Request, rType
 					transformer := StreamTransformer{
 						Extractor: extractor,
 						Template:  template,
+						Source:    addonHostname,
 					}
 					for i := range streams {
 						stream := streams[i]
@@ -178,6 +181,7 @@ func (ud UserData) fetchStream(ctx *context.StoreContext, r *http.Request, rType
 	if ud.IncludeTorz {
 		if errs[0] != nil {
 			log.Error("failed to fetch torz streams", "error", errs[0])
+			trace.AddError(stremio_transformer.StreamPreviewStageFetch, errors.New("torz: "+errs[0].Error()))
 		} else {
 			allStreams = append(allStreams, chunks[0]...)
 		}
@@ -187,32 +191,56 @@ func (ud UserData) fetchStream(ctx *context.StoreContext, r *http.Request, rType
 		hostname := upstreams[i].baseUrl.Hostname()
 		if errs[idx] != nil {
 			log.Error("failed to fetch streams", "error", errs[idx], "hostname", hostname)
+			trace.AddError(stremio_transformer.StreamPreviewStageFetch, errors.New(hostname+": "+errs[idx].Error()))
 		} else {
 			allStreams = append(allStreams, chunks[idx]...)
 		}
 	}
 
+	return allStreams, nil
+}
+
+// processStreams runs the merge → reputation → filter → sort stages over the
+// fetched streams.
+func (ud UserData) processStreams(allStreams []WrappedStream, template *stremio_transformer.StreamTemplate, trace *stremio_transformer.StreamTrace[WrappedStream]) []WrappedStream {
 	if ud.IncludeTorz {
-		allStreams = dedupeStreams(allStreams)
+		allStreams = mergeStreams(allStreams, ud.MergeURLRule)
 	}
 
-	if ud.Filter != "" {
-		filter, err := stremio_transformer.StreamFilterBlob(ud.Filter).Parse()
-		if err == nil {
-			allStreams = filterStreams(allStreams, filter)
-		} else {
-			log.Warn("failed to parse filter expression", "error", err)
-		}
-	}
+	stremio_transformer.SetStreamReputation(allStreams)
+
+	allStreams = stremio_transformer.FilterStreams(allStreams, stremio_transformer.StreamFilterPresets.Resolve(ud.FilterPresetId, ud.Filter), trace)
 
 	if template != nil {
-		stremio_transformer.SortStreams(allStreams, ud.Sort)
+		stremio_transformer.SortStreams(allStreams, stremio_transformer.StreamSortPresets.Resolve(ud.SortPresetId, ud.Sort), trace)
 	}
 
 	if !ud.IncludeTorz {
-		allStreams = dedupeStreams(allStreams)
+		allStreams = mergeStreams(allStreams, ud.MergeURLRule)
+	}
+
+	return allStreams
+}
+
+func (ud UserData) fetchStream(ctx *context.StoreContext, r *http.Request, rType, id string) (*stremio.StreamHandlerResponse, error) {
+	log := ctx.Log
+
+	eud := ud.GetEncoded()
+
+	stremId := strings.TrimSuffix(id, ".json")
+
+	template, err := ud.template.Parse()
+	if err != nil {
+		return nil, err
 	}
 
+	allStreams, err := ud.fetchStreams(ctx, r, rType, id, template, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	allStreams = ud.processStreams(allStreams, template, nil)
+
 	totalStreams := len(allStreams)
 	log.Debug("found streams", "total_count", totalStreams, "deduped_count", len(allStreams))
 
@@ -336,14 +364,3 @@ func (ud UserData) fetchStream(ctx *context.StoreContext, r *http.Request, rType
 		Streams: streams,
 	}, nil
 }
-
-func filterStreams(streams []WrappedStream, filter *stremio_transformer.StreamFilter) []WrappedStream {
-	result := make([]WrappedStream, 0, len(streams))
-	for i := range streams {
-		stream := &streams[i]
-		if stream.r == nil || filter.Match(stream.r) {
-			result = append(result, *stream)
-		}
-	}
-	return result
-}
@@ -1,14 +1,94 @@
 package stremio_wrap
 
 import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_addon "github.com/MunifTanjim/stremthru/internal/stremio/addon"
 	"github.com/MunifTanjim/stremthru/stremio"
 )
 
-func (ud UserData) fetchSubtitles(ctx *context.StoreContext, rType, id, extra string) (*stremio.SubtitlesHandlerResponse, error) {
+const subtitleLangWildcard = "*"
+
+func parseSubtitleLangs(value string) []string {
+	langs := []string{}
+	for lang := range strings.SplitSeq(value, ",") {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if lang != "" && !slices.Contains(langs, lang) {
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
+
+// processSubtitles removes duplicate urls, keeps only the preferred languages
+// (in the preferred order) and caps the number of subtitles per language. With
+// no preferred languages, or with the `*` wildcard, other languages are kept
+// in upstream order.
+func processSubtitles(subtitles []stremio.Subtitle, langs []string, maxPerLang int) []stremio.Subtitle {
+	wildcardIdx := slices.Index(langs, subtitleLangWildcard)
+	if len(langs) == 0 {
+		wildcardIdx = 0
+	}
+
+	type rankedSubtitle struct {
+		rank int
+		sub  stremio.Subtitle
+	}
+
+	seenUrl := map[string]struct{}{}
+	countByLang := map[string]int{}
+	ranked := []rankedSubtitle{}
+	for _, sub := range subtitles {
+		if sub.Url == "" {
+			continue
+		}
+		if _, seen := seenUrl[sub.Url]; seen {
+			continue
+		}
+		lang := strings.ToLower(sub.Lang)
+
+		rank := slices.Index(langs, lang)
+		if rank == -1 {
+			if wildcardIdx == -1 {
+				continue
+			}
+			rank = wildcardIdx
+		}
+
+		if maxPerLang > 0 && countByLang[lang] >= maxPerLang {
+			continue
+		}
+		countByLang[lang]++
+
+		seenUrl[sub.Url] = struct{}{}
+		ranked = append(ranked, rankedSubtitle{rank: rank, sub: sub})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b rankedSubtitle) int {
+		return a.rank - b.rank
+	})
+
+	result := make([]stremio.Subtitle, len(ranked))
+	for i := range ranked {
+		result[i] = ranked[i].sub
+	}
+	return result
+}
+
+func (ud UserData) fetchSubtitles(ctx *context.StoreContext, r *http.Request, rType, id, extra string) (*stremio.SubtitlesHandlerResponse, error) {
 	log := ctx.Log
 
 	upstreams, err := ud.getUpstreams(ctx, stremio.ResourceNameSubtitles, rType, id)
@@ -47,7 +127,143 @@ func (ud UserData) fetchSubtitles(ctx *context.StoreContext, rType, id, extra st
 		subtitles = append(subtitles, chunks[i]...)
 	}
 
+	subtitles = processSubtitles(subtitles, parseSubtitleLangs(ud.SubLangs), ud.SubMaxPerLang)
+
+	if ctx.IsProxyAuthorized && (ud.SubVTT || ud.SubProxy) {
+		for i := range subtitles {
+			sub := &subtitles[i]
+			if ud.SubVTT && isSRTSubtitleURL(sub.Url) {
+				sub.Url = getSubtitleVTTURL(r, ud.GetEncoded(), sub.Url)
+			} else if !ud.SubProxy {
+				continue
+			} else if url, err := shared.CreateProxyLink(r, sub.Url, nil, config.TUNNEL_TYPE_AUTO, 12*time.Hour, ctx.ProxyAuthUser, ctx.ProxyAuthPassword, true, ""); err == nil {
+				sub.Url = url
+			} else {
+				log.Warn("failed to create subtitle proxy link", "error", err)
+			}
+		}
+	}
+
 	return &stremio.SubtitlesHandlerResponse{
 		Subtitles: subtitles,
 	}, nil
 }
+
+// isSRTSubtitleURL reports whether the link points to a SubRip file. Links
+// without an extension are served as SubRip by most of the subtitle addons.
+func isSRTSubtitleURL(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(path.Ext(u.Path)) {
+	case ".srt", "":
+		return true
+	default:
+		return false
+	}
+}
+
+func getSubtitleVTTURL(r *http.Request, eud, link string) string {
+	return ExtractRequestBaseURL(r).JoinPath("/stremio/wrap/" + eud + "/_/subtitle/" + base64.RawURLEncoding.EncodeToString([]byte(link)) + "/subtitle.vtt").String()
+}
+
+var errUnsupportedSubtitleFormat = errors.New("unsupported subtitle format")
+
+// convertSRTToVTT converts SubRip content to WebVTT. Content that is already
+// WebVTT is returned as is, anything else is rejected.
+func convertSRTToVTT(srt string) (string, error) {
+	srt = strings.TrimPrefix(srt, "\ufeff")
+	srt = strings.ReplaceAll(srt, "\r\n", "\n")
+	srt = strings.ReplaceAll(srt, "\r", "\n")
+	if strings.HasPrefix(srt, "WEBVTT") {
+		return srt, nil
+	}
+	if !srtTimingPattern.MatchString(srt) {
+		return "", errUnsupportedSubtitleFormat
+	}
+
+	var vtt strings.Builder
+	vtt.WriteString("WEBVTT\n\n")
+	for line := range strings.Lines(srt) {
+		if strings.Contains(line, "-->") {
+			line = strings.ReplaceAll(line, ",", ".")
+		}
+		vtt.WriteString(line)
+	}
+	return vtt.String(), nil
+}
+
+var srtTimingPattern = regexp.MustCompile(`(?m)^\d{2}:\d{2}:\d{2},\d{3} --> \d{2}:\d{2}:\d{2},\d{3}`)
+
+const max_subtitle_size = 10 * 1024 * 1024
+
+func handleSubtitleVTT(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) && !IsMethod(r, http.MethodHead) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	ctx, err := ud.GetRequestContext(r)
+	if err != nil {
+		shared.ErrorBadRequest(r, "failed to get request context: "+err.Error()).Send(w, r)
+		return
+	}
+
+	if !ud.SubVTT || !ctx.IsProxyAuthorized {
+		shared.ErrorForbidden(r).Send(w, r)
+		return
+	}
+
+	link, err := base64.RawURLEncoding.DecodeString(r.PathValue("encodedLink"))
+	if err != nil {
+		shared.ErrorBadRequest(r, "invalid subtitle link").Send(w, r)
+		return
+	}
+
+	res, err := config.DefaultHTTPClient.Get(string(link))
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		shared.ErrorBadGateway(r, "failed to fetch subtitle: "+res.Status).Send(w, r)
+		return
+	}
+
+	if res.ContentLength > max_subtitle_size {
+		shared.ErrorBadGateway(r, "subtitle is too large").Send(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, max_subtitle_size+1))
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if len(body) > max_subtitle_size {
+		shared.ErrorBadGateway(r, "subtitle is too large").Send(w, r)
+		return
+	}
+
+	vtt, err := convertSRTToVTT(string(body))
+	if err != nil {
+		shared.ErrorBadGateway(r, err.Error()).Send(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vtt; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	if !IsMethod(r, http.MethodHead) {
+		w.Write([]byte(vtt))
+	}
+}
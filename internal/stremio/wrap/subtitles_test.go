@@ -0,0 +1,72 @@
+package stremio_wrap
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/stremio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessSubtitles(t *testing.T) {
+	subtitles := []stremio.Subtitle{
+		{Id: "1", Url: "https://a/1.srt", Lang: "fre"},
+		{Id: "2", Url: "https://a/2.srt", Lang: "eng"},
+		{Id: "3", Url: "https://b/2.srt", Lang: "eng"},
+		{Id: "4", Url: "https://a/2.srt", Lang: "eng"},
+		{Id: "2", Url: "https://c/2.srt", Lang: "ENG"},
+		{Id: "5", Url: "https://a/5.srt", Lang: "spa"},
+		{Id: "6", Url: "https://a/6.srt", Lang: "eng"},
+		{Id: "7", Url: "https://a/5.srt", Lang: "spa"},
+	}
+
+	getIds := func(subtitles []stremio.Subtitle) []string {
+		ids := []string{}
+		for _, sub := range subtitles {
+			ids = append(ids, sub.Id)
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		name       string
+		langs      string
+		maxPerLang int
+		ids        []string
+	}{
+		{"dedupe", "", 0, []string{"1", "2", "3", "2", "5", "6"}},
+		{"preferred", "spa,eng", 0, []string{"5", "2", "3", "2", "6"}},
+		{"wildcard", "eng,*", 0, []string{"2", "3", "2", "6", "1", "5"}},
+		{"max per lang", "eng, fre", 2, []string{"2", "3", "1"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result := processSubtitles(subtitles, parseSubtitleLangs(tc.langs), tc.maxPerLang)
+			assert.Equal(t, tc.ids, getIds(result))
+		})
+	}
+}
+
+func TestConvertSRTToVTT(t *testing.T) {
+	srt := "\ufeff1\r\n00:00:01,000 --> 00:00:02,500\r\nHello, World\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nBye\r\n"
+	vtt, err := convertSRTToVTT(srt)
+	assert.NoError(t, err)
+	assert.Equal(t, "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.500\nHello, World\n\n2\n00:00:03.000 --> 00:00:04.000\nBye\n", vtt)
+
+	vtt, err = convertSRTToVTT("WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n", vtt)
+
+	_, err = convertSRTToVTT("[Script Info]\nTitle: Hi\n\n[Events]\nDialogue: 0,0:00:01.00,0:00:02.00,Default,,0,0,0,,Hi\n")
+	assert.ErrorIs(t, err, errUnsupportedSubtitleFormat)
+}
+
+func TestIsSRTSubtitleURL(t *testing.T) {
+	for link, expected := range map[string]bool{
+		"https://a/1.srt":            true,
+		"https://a/1.SRT?token=x":    true,
+		"https://a/download/file/12": true,
+		"https://a/1.vtt":            false,
+		"https://a/1.ass":            false,
+	} {
+		assert.Equal(t, expected, isSRTSubtitleURL(link), link)
+	}
+}
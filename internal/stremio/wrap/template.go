@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"net/http"
 	"regexp"
+	"strconv"
 
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/stremio/configure"
@@ -15,6 +16,11 @@ import (
 )
 
 func getTemplateData(ud *UserData, w http.ResponseWriter, r *http.Request) *TemplateData {
+	subMaxPerLang := ""
+	if ud.SubMaxPerLang > 0 {
+		subMaxPerLang = strconv.Itoa(ud.SubMaxPerLang)
+	}
+
 	td := &TemplateData{
 		Base: Base{
 			Title:       "StremThru Wrap",
@@ -46,7 +52,48 @@ func getTemplateData(ud *UserData, w http.ResponseWriter, r *http.Request) *Temp
 			Type:        "text",
 			Default:     ud.Sort,
 			Title:       "Stream Sort",
-			Description: "Comma separated fields: <code>resolution</code>, <code>quality</code>, <code>size</code>, <code>hdr</code>. Prefix with <code>-</code> for reverse sort. Default: <code>" + stremio_transformer.StreamDefaultSortConfig + "</code>",
+			Description: stremio_shared.StreamSortConfigDescription,
+		},
+		SortPresetConfig: stremio_shared.GetStreamSortPresetConfig(ud.SortPresetId),
+
+		MergeURLRuleConfig: configure.Config{
+			Key:     "merge_url",
+			Type:    configure.ConfigTypeSelect,
+			Default: ud.MergeURLRule,
+			Title:   "Duplicate Stream URL",
+			Options: []configure.ConfigOption{
+				{Value: streamMergeURLRuleFirst, Label: "First Stream"},
+				{Value: streamMergeURLRuleCached, Label: "Cached Stream"},
+				{Value: streamMergeURLRuleSeeders, Label: "Most Seeders"},
+			},
+			Description: "Streams for the same file from multiple addons are merged. Picks the stream whose URL is kept.",
+		},
+
+		SubLangsConfig: configure.Config{
+			Key:         "sub_langs",
+			Type:        "text",
+			Default:     ud.SubLangs,
+			Title:       "Subtitle Languages",
+			Description: "Comma separated language codes, in order of preference, e.g. <code>eng,spa</code>. Add <code>*</code> to keep other languages too.",
+		},
+		SubMaxPerLangConfig: configure.Config{
+			Key:         "sub_max",
+			Type:        configure.ConfigTypeNumber,
+			Default:     subMaxPerLang,
+			Title:       "Max Subtitles per Language",
+			Description: "Leave empty for no limit.",
+		},
+		SubProxyConfig: configure.Config{
+			Key:     "sub_proxy",
+			Type:    configure.ConfigTypeCheckbox,
+			Default: configure.ToCheckboxDefault(ud.SubProxy),
+			Title:   "Proxy Subtitles",
+		},
+		SubVTTConfig: configure.Config{
+			Key:     "sub_vtt",
+			Type:    configure.ConfigTypeCheckbox,
+			Default: configure.ToCheckboxDefault(ud.SubVTT),
+			Title:   "Convert Subtitles to WebVTT",
 		},
 
 		FilterConfig: configure.Config{
@@ -56,6 +103,7 @@ func getTemplateData(ud *UserData, w http.ResponseWriter, r *http.Request) *Temp
 			Title:       "🧪 Stream Filter",
 			Description: `Filter expression, check <a href="https://github.com/MunifTanjim/stremthru/wiki/Stream-Filter" target="_blank">documentation</a>.`,
 		},
+		FilterPresetConfig: stremio_shared.GetStreamFilterPresetConfig(ud.FilterPresetId),
 
 		RPDBAPIKey: configure.Config{
 			Key:          "rpdb_akey",
@@ -257,15 +305,22 @@ type TemplateData struct {
 	IsAuthed     bool
 	AuthError    string
 
-	ExtractorIds     []string
-	TemplateIds      []string
-	TemplateId       string
-	Template         stremio_transformer.StreamTemplateBlob
-	TemplateError    stremio_transformer.StreamTemplateBlob
-	SortConfig       configure.Config
-	FilterConfig     configure.Config
-	RPDBAPIKey       configure.Config
-	TopPostersAPIKey configure.Config
+	ExtractorIds        []string
+	TemplateIds         []string
+	TemplateId          string
+	Template            stremio_transformer.StreamTemplateBlob
+	TemplateError       stremio_transformer.StreamTemplateBlob
+	SortConfig          configure.Config
+	SortPresetConfig    configure.Config
+	MergeURLRuleConfig  configure.Config
+	SubLangsConfig      configure.Config
+	SubMaxPerLangConfig configure.Config
+	SubProxyConfig      configure.Config
+	SubVTTConfig        configure.Config
+	FilterConfig        configure.Config
+	FilterPresetConfig  configure.Config
+	RPDBAPIKey          configure.Config
+	TopPostersAPIKey    configure.Config
 
 	stremio_userdata.TemplateDataUserData
 }
@@ -13,11 +13,18 @@ import (
 type StreamTransformer struct {
 	Extractor stremio_transformer.StreamExtractor
 	Template  *stremio_transformer.StreamTemplate
+	// used as source when the extractor does not find the addon name
+	Source string
 }
 
 type WrappedStream struct {
 	*stremio.Stream
+	// stream as received, before the template
+	raw            *stremio.Stream
+	source         string
 	r              *stremio_transformer.StreamExtractorResult
+	template       *stremio_transformer.StreamTemplate
+	templateErr    error
 	noContentProxy bool
 }
 
@@ -25,24 +32,13 @@ func (ws WrappedStream) IsSortable() bool {
 	return ws.r != nil
 }
 
-func (ws WrappedStream) GetQuality() string {
-	return ws.r.Quality
-}
-
-func (ws WrappedStream) GetResolution() string {
-	return ws.r.Resolution
-}
-
-func (ws WrappedStream) GetSize() string {
-	return ws.r.Size
-}
-
-func (ws WrappedStream) GetHDR() string {
-	return strings.Join(ws.r.HDR, "|")
+func (ws WrappedStream) GetExtractorResult() *stremio_transformer.StreamExtractorResult {
+	return ws.r
 }
 
 func (st StreamTransformer) Do(stream *stremio.Stream, sType string, tryReconfigure bool) (*WrappedStream, error) {
-	s := &WrappedStream{Stream: stream}
+	raw := *stream
+	s := &WrappedStream{Stream: stream, raw: &raw, source: st.Source}
 
 	data := st.Extractor.Parse(stream, sType)
 	if data == nil {
@@ -70,10 +66,18 @@ func (st StreamTransformer) Do(stream *stremio.Stream, sType string, tryReconfig
 
 	s.r = data
 
+	if data.Addon.Name != "" {
+		data.Sources = []string{data.Addon.Name}
+	} else if st.Source != "" {
+		data.Sources = []string{st.Source}
+	}
+
 	if st.Template != nil && !st.Template.IsEmpty() {
+		s.template = st.Template
 		var err error
 		s.Stream, err = st.Template.Execute(s.Stream, data)
 		if err != nil {
+			s.templateErr = err
 			return s, err
 		}
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_addon "github.com/MunifTanjim/stremthru/internal/stremio/addon"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
 	stremio_userdata "github.com/MunifTanjim/stremthru/internal/stremio/userdata"
 	"github.com/MunifTanjim/stremthru/store"
@@ -85,6 +86,16 @@ type UserData struct {
 	Sort   string `json:"sort,omitempty"`
 	Filter string `json:"filter,omitempty"`
 
+	SortPresetId   string `json:"sort_preset,omitempty"`
+	FilterPresetId string `json:"filter_preset,omitempty"`
+
+	MergeURLRule string `json:"merge_url,omitempty"`
+
+	SubLangs      string `json:"sub_langs,omitempty"`
+	SubMaxPerLang int    `json:"sub_max,omitempty"`
+	SubProxy      bool   `json:"sub_proxy,omitempty"`
+	SubVTT        bool   `json:"sub_vtt,omitempty"`
+
 	RPDBAPIKey       string `json:"rpdb_akey,omitempty"`
 	TopPostersAPIKey string `json:"top_posters_akey,omitempty"`
 
@@ -141,18 +152,6 @@ func (ud *UserData) Ptr() *UserData {
 	return ud
 }
 
-func verifyTopPostersAPIKey(apiKey string) error {
-	resp, err := config.DefaultHTTPClient.Get("https://api.top-streaming.stream/auth/verify/" + apiKey)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("Invalid API key")
-	}
-	return nil
-}
-
 type userDataError struct {
 	upstreamUrl      []string
 	store            []string
@@ -219,7 +218,7 @@ func (ud *UserData) GetRequestContext(r *http.Request) (*context.StoreContext, e
 	}
 
 	if ud.TopPostersAPIKey != "" && udErr.top_posters_akey == "" {
-		if err := verifyTopPostersAPIKey(ud.TopPostersAPIKey); err != nil {
+		if err := stremio_shared.VerifyTopPostersAPIKey(ud.TopPostersAPIKey); err != nil {
 			udErr.top_posters_akey = "Failed to Verify: " + err.Error()
 			return ctx, udErr
 		}
@@ -521,6 +520,17 @@ func getUserData(r *http.Request) (*UserData, error) {
 		data.IncludeTorz = r.Form.Get("torz") == "on"
 		data.Sort = r.Form.Get("sort")
 		data.Filter = r.Form.Get("filter")
+		data.SortPresetId = r.Form.Get("sort_preset")
+		data.FilterPresetId = r.Form.Get("filter_preset")
+		data.MergeURLRule = r.Form.Get("merge_url")
+		data.SubLangs = strings.Join(parseSubtitleLangs(r.Form.Get("sub_langs")), ",")
+		if v := r.Form.Get("sub_max"); v != "" {
+			if subMax, err := strconv.Atoi(v); err == nil && subMax > 0 {
+				data.SubMaxPerLang = subMax
+			}
+		}
+		data.SubProxy = r.Form.Get("sub_proxy") == "on"
+		data.SubVTT = r.Form.Get("sub_vtt") == "on"
 		data.RPDBAPIKey = r.Form.Get("rpdb_akey")
 		data.TopPostersAPIKey = r.Form.Get("top_posters_akey")
 
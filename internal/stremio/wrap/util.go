@@ -1,8 +1,13 @@
 package stremio_wrap
 
 import (
+	"path"
+	"slices"
+	"strings"
+
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
 )
 
 var IsMethod = shared.IsMethod
@@ -12,20 +17,151 @@ var ExtractRequestBaseURL = shared.ExtractRequestBaseURL
 var SendResponse = stremio_shared.SendResponse
 var SendHTML = stremio_shared.SendHTML
 
-func dedupeStreams(allStreams []WrappedStream) []WrappedStream {
-	hashSeen := map[string]struct{}{}
+const (
+	streamMergeURLRuleFirst   = ""
+	streamMergeURLRuleCached  = "cached"
+	streamMergeURLRuleSeeders = "seeders"
+)
+
+func isSameStreamFile(a, b *stremio_transformer.StreamExtractorResult) bool {
+	if a.File.Name != "" && b.File.Name != "" {
+		return strings.EqualFold(path.Base(a.File.Name), path.Base(b.File.Name))
+	}
+	return a.File.Idx == b.File.Idx
+}
+
+func getStreamParseScore(r *stremio_transformer.StreamExtractorResult) int {
+	if r.Result == nil {
+		return 0
+	}
+	score := 0
+	for _, v := range []string{r.Title, r.Resolution, r.Quality, r.Codec, r.BitDepth, r.Group, r.Year, r.Edition, r.Container} {
+		if v != "" {
+			score++
+		}
+	}
+	for _, v := range [][]string{r.HDR, r.Audio, r.Channels, r.Languages} {
+		if len(v) > 0 {
+			score++
+		}
+	}
+	return score
+}
+
+func appendUnique(items []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(items, v) {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+func pickMergedStreamIdx(group []WrappedStream, rule string) int {
+	winnerIdx := 0
+	switch rule {
+	case streamMergeURLRuleCached:
+		for i := range group {
+			if group[i].URL != "" && group[i].r.Store.IsCached {
+				return i
+			}
+		}
+	case streamMergeURLRuleSeeders:
+		for i := range group {
+			if group[i].r.Seeders > group[winnerIdx].r.Seeders {
+				winnerIdx = i
+			}
+		}
+	}
+	return winnerIdx
+}
+
+func mergeStreamGroup(group []WrappedStream, rule string) WrappedStream {
+	winner := group[pickMergedStreamIdx(group, rule)]
+	r := *winner.r
 
-	streams := []WrappedStream{}
+	best := winner.r
+	bestScore := getStreamParseScore(best)
+	seeders := r.Seeders
+	sources := []string{}
+	languages := []string{}
+	for i := range group {
+		gr := group[i].r
+		if score := getStreamParseScore(gr); score > bestScore {
+			best, bestScore = gr, score
+		}
+		seeders = max(seeders, gr.Seeders)
+		sources = appendUnique(sources, gr.Sources...)
+		if gr.Result != nil {
+			languages = appendUnique(languages, gr.Languages...)
+		}
+		if r.File.Name == "" {
+			r.File.Name = gr.File.Name
+		}
+		if r.File.Size == "" {
+			r.File.Size = gr.File.Size
+		}
+	}
+
+	if best.Result != nil {
+		result := *best.Result
+		result.Languages = languages
+		r.Result = &result
+	}
+	if best.TTitle != "" {
+		r.TTitle = best.TTitle
+	}
+	r.Seeders = seeders
+	r.Sources = sources
+
+	winner.r = &r
+	if winner.template != nil && !winner.template.IsEmpty() {
+		stream := *winner.Stream
+		if s, err := winner.template.Execute(&stream, winner.r); err == nil {
+			winner.Stream = s
+		} else {
+			log.Warn("failed to execute template for merged stream", "error", err)
+			winner.templateErr = err
+		}
+	}
+	return winner
+}
+
+// mergeStreams collapses streams for the same file of the same torrent into
+// one, combining the metadata reported by each upstream. The stream whose
+// URL is kept is picked using `rule`.
+func mergeStreams(allStreams []WrappedStream, rule string) []WrappedStream {
+	groups := [][]WrappedStream{}
+	groupIndicesByHash := map[string][]int{}
 	for i := range allStreams {
 		s := allStreams[i]
-		if s.r != nil && s.r.Hash != "" {
-			if _, seen := hashSeen[s.r.Hash]; seen {
-				continue
-			} else {
-				hashSeen[s.r.Hash] = struct{}{}
+		if s.r == nil || s.r.Hash == "" {
+			groups = append(groups, []WrappedStream{s})
+			continue
+		}
+		hash := strings.ToLower(s.r.Hash)
+		groupIdx := -1
+		for _, idx := range groupIndicesByHash[hash] {
+			if isSameStreamFile(groups[idx][0].r, s.r) {
+				groupIdx = idx
+				break
 			}
 		}
-		streams = append(streams, s)
+		if groupIdx == -1 {
+			groupIndicesByHash[hash] = append(groupIndicesByHash[hash], len(groups))
+			groups = append(groups, []WrappedStream{s})
+		} else {
+			groups[groupIdx] = append(groups[groupIdx], s)
+		}
+	}
+
+	streams := make([]WrappedStream, len(groups))
+	for i, group := range groups {
+		if len(group) == 1 {
+			streams[i] = group[0]
+		} else {
+			streams[i] = mergeStreamGroup(group, rule)
+		}
 	}
 	return streams
 }
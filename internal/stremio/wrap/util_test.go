@@ -0,0 +1,81 @@
+package stremio_wrap
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/go-ptt"
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+	"github.com/MunifTanjim/stremthru/stremio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStream(source, hash string, fileIdx int, fileName string, seeders int, result *ptt.Result) WrappedStream {
+	return WrappedStream{
+		Stream: &stremio.Stream{Name: source, InfoHash: hash, FileIndex: fileIdx},
+		r: &stremio_transformer.StreamExtractorResult{
+			Result:  result,
+			Hash:    hash,
+			File:    stremio_transformer.StreamExtractorResultFile{Idx: fileIdx, Name: fileName},
+			Seeders: seeders,
+			Sources: []string{source},
+		},
+	}
+}
+
+func TestMergeStreams(t *testing.T) {
+	t.Run("merges metadata", func(t *testing.T) {
+		streams := mergeStreams([]WrappedStream{
+			newTestStream("Torrentio", "abc", 1, "", 10, &ptt.Result{Title: "Show", Languages: []string{"en"}}),
+			newTestStream("Comet", "ABC", 1, "", 25, &ptt.Result{Title: "Show", Resolution: "1080p", Codec: "hevc", Languages: []string{"en", "fr"}}),
+			newTestStream("Direct", "", 0, "", 0, nil),
+			newTestStream("MediaFusion", "abc", 1, "", 5, &ptt.Result{Languages: []string{"de"}}),
+		}, streamMergeURLRuleFirst)
+		require.Len(t, streams, 2)
+
+		s := streams[0]
+		assert.Equal(t, "Torrentio", s.Name)
+		assert.Equal(t, 25, s.r.Seeders)
+		assert.Equal(t, []string{"Torrentio", "Comet", "MediaFusion"}, s.r.Sources)
+		assert.Equal(t, []string{"en", "fr", "de"}, s.r.Languages)
+		assert.Equal(t, "1080p", s.r.Resolution)
+		assert.Equal(t, "Direct", streams[1].Name)
+	})
+
+	t.Run("keeps different files of same torrent", func(t *testing.T) {
+		streams := mergeStreams([]WrappedStream{
+			newTestStream("Torrentio", "abc", 1, "Show.S01E01.mkv", 0, &ptt.Result{}),
+			newTestStream("Comet", "abc", 2, "Show.S01E02.mkv", 0, &ptt.Result{}),
+			newTestStream("MediaFusion", "abc", 7, "Pack/Show.S01E02.mkv", 0, &ptt.Result{}),
+			newTestStream("Peerflix", "abc", 1, "", 0, &ptt.Result{}),
+		}, streamMergeURLRuleFirst)
+		require.Len(t, streams, 2)
+		assert.Equal(t, []string{"Torrentio", "Peerflix"}, streams[0].r.Sources)
+		assert.Equal(t, []string{"Comet", "MediaFusion"}, streams[1].r.Sources)
+	})
+
+	t.Run("url rule", func(t *testing.T) {
+		input := func() []WrappedStream {
+			cached := newTestStream("Comet", "abc", 0, "", 5, &ptt.Result{})
+			cached.URL = "https://example.com/video.mkv"
+			cached.r.Store.IsCached = true
+			return []WrappedStream{
+				newTestStream("Torrentio", "abc", 0, "", 10, &ptt.Result{}),
+				cached,
+				newTestStream("MediaFusion", "abc", 0, "", 50, &ptt.Result{}),
+			}
+		}
+		assert.Equal(t, "Torrentio", mergeStreams(input(), streamMergeURLRuleFirst)[0].Name)
+		assert.Equal(t, "Comet", mergeStreams(input(), streamMergeURLRuleCached)[0].Name)
+		assert.Equal(t, "MediaFusion", mergeStreams(input(), streamMergeURLRuleSeeders)[0].Name)
+	})
+
+	t.Run("re-executes template", func(t *testing.T) {
+		a := newTestStream("Torrentio", "abc", 0, "", 10, &ptt.Result{})
+		a.template = stremio_transformer.StreamTemplateDefault
+		b := newTestStream("Comet", "abc", 0, "", 5, &ptt.Result{})
+		streams := mergeStreams([]WrappedStream{a, b}, streamMergeURLRuleFirst)
+		require.Len(t, streams, 1)
+		assert.Contains(t, streams[0].Description, "🧩 Torrentio, Comet")
+	})
+}
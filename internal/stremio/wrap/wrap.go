@@ -110,7 +110,7 @@ func handleResource(w http.ResponseWriter, r *http.Request) {
 		return
 
 	case stremio.ResourceNameSubtitles:
-		res, err := ud.fetchSubtitles(ctx, contentType, id, extra)
+		res, err := ud.fetchSubtitles(ctx, r, contentType, id, extra)
 		if err != nil {
 			SendError(w, r, err)
 			return
@@ -152,6 +152,8 @@ func AddStremioWrapEndpoints(mux *http.ServeMux) {
 
 	router.HandleFunc("/configure", handleConfigure)
 	router.HandleFunc("/{userData}/configure", handleConfigure)
+	router.HandleFunc("/configure/preview", handleConfigurePreview)
+	router.HandleFunc("/{userData}/configure/preview", handleConfigurePreview)
 
 	router.HandleFunc("/{userData}/{resource}/{contentType}/{id}", withCors(handleResource))
 	router.HandleFunc("/{userData}/{resource}/{contentType}/{id}/{extra}", withCors(handleResource))
@@ -159,5 +161,7 @@ func AddStremioWrapEndpoints(mux *http.ServeMux) {
 	router.HandleFunc("/{userData}/_/strem/{magnetHash}/{fileIdx}/{$}", withCors(handleStrem))
 	router.HandleFunc("/{userData}/_/strem/{magnetHash}/{fileIdx}/{fileName}", withCors(handleStrem))
 
+	router.HandleFunc("/{userData}/_/subtitle/{encodedLink}/{fileName}", withCors(handleSubtitleVTT))
+
 	mux.Handle("/stremio/wrap/", http.StripPrefix("/stremio/wrap", commonMiddleware(router)))
 }
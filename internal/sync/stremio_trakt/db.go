@@ -38,15 +38,48 @@ func (d SyncDirection) ShouldSyncToStremio() bool {
 }
 
 func (d SyncDirection) IsDisabled() bool {
-	return d == SyncDirectionNone
+	return d == SyncDirectionNone || d == ""
 }
 
 type SyncConfigWatched struct {
 	Direction SyncDirection `json:"dir"`
 }
 
+type SyncConfigWatchlist struct {
+	Direction SyncDirection `json:"dir"`
+}
+
+type SyncConfigCollection struct {
+	Direction SyncDirection `json:"dir"`
+}
+
+type SyncConfigRatings struct {
+	Direction SyncDirection `json:"dir"`
+}
+
 type SyncConfig struct {
-	Watched SyncConfigWatched `json:"watched"`
+	Watched    SyncConfigWatched    `json:"watched"`
+	Watchlist  SyncConfigWatchlist  `json:"watchlist"`
+	Collection SyncConfigCollection `json:"collection"`
+	Ratings    SyncConfigRatings    `json:"ratings"`
+}
+
+// IsValid allows empty direction for watchlist, collection and ratings,
+// which were added after watched.
+func (sc SyncConfig) IsValid() bool {
+	if !sc.Watched.Direction.IsValid() {
+		return false
+	}
+	if sc.Watchlist.Direction != "" && !sc.Watchlist.Direction.IsValid() {
+		return false
+	}
+	if sc.Collection.Direction != "" && !sc.Collection.Direction.IsValid() {
+		return false
+	}
+	if sc.Ratings.Direction != "" && !sc.Ratings.Direction.IsValid() {
+		return false
+	}
+	return true
 }
 
 func (sc SyncConfig) Value() (driver.Value, error) {
@@ -61,8 +94,23 @@ type SyncStateWatched struct {
 	LastSyncedAt *time.Time `json:"last_synced_at"`
 }
 
+type SyncStateWatchlist struct {
+	LastSyncedAt *time.Time `json:"last_synced_at"`
+}
+
+type SyncStateCollection struct {
+	LastSyncedAt *time.Time `json:"last_synced_at"`
+}
+
+type SyncStateRatings struct {
+	LastSyncedAt *time.Time `json:"last_synced_at"`
+}
+
 type SyncState struct {
-	Watched SyncStateWatched `json:"watched"`
+	Watched    SyncStateWatched    `json:"watched"`
+	Watchlist  SyncStateWatchlist  `json:"watchlist"`
+	Collection SyncStateCollection `json:"collection"`
+	Ratings    SyncStateRatings    `json:"ratings"`
 }
 
 func (ss SyncState) Value() (driver.Value, error) {
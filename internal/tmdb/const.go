@@ -25,6 +25,12 @@ const (
 	PosterSizeOriginal PosterSize = "original"
 )
 
+type StillSize string
+
+const (
+	StillSizeW300 StillSize = "w300"
+)
+
 var movieGenreMap = map[int]string{
 	12:    "Adventure",
 	14:    "Fantasy",
@@ -68,15 +74,16 @@ var tvGenreMap = map[int]string{
 
 type Genre string
 
-var Genres, MovieGenres, TVGenres, genreIdByName, genreNameById = func() ([]string, []string, []string, map[string]int, map[int]string) {
+var Genres, MovieGenres, TVGenres, movieGenreIdByName, tvGenreIdByName, genreNameById = func() ([]string, []string, []string, map[string]int, map[string]int, map[int]string) {
 	genres := make([]string, 0, len(movieGenreMap)+len(tvGenreMap))
-	idByName := map[string]int{}
+	movieIdByName := make(map[string]int, len(movieGenreMap))
+	tvIdByName := make(map[string]int, len(tvGenreMap))
 	nameById := map[int]string{}
 
 	movieGenres := make([]string, 0, len(movieGenreMap))
 	for id, genre := range movieGenreMap {
 		genres = append(genres, genre)
-		idByName[genre] = id
+		movieIdByName[genre] = id
 		nameById[id] = genre
 		movieGenres = append(movieGenres, genre)
 	}
@@ -87,7 +94,7 @@ var Genres, MovieGenres, TVGenres, genreIdByName, genreNameById = func() ([]stri
 		if _, seen := nameById[id]; !seen {
 			genres = append(genres, genre)
 		}
-		idByName[genre] = id
+		tvIdByName[genre] = id
 		nameById[id] = genre
 		tvGenres = append(tvGenres, genre)
 	}
@@ -95,13 +102,15 @@ var Genres, MovieGenres, TVGenres, genreIdByName, genreNameById = func() ([]stri
 
 	slices.Sort(genres)
 
-	return genres, movieGenres, tvGenres, idByName, nameById
+	return genres, movieGenres, tvGenres, movieIdByName, tvIdByName, nameById
 }()
 
-func (genre Genre) Id() int {
-	id, ok := genreIdByName[string(genre)]
-	if ok {
-		return id
-	}
-	return 0
+// MovieId returns the movie genre id, or 0 if it is not a movie genre.
+func (genre Genre) MovieId() int {
+	return movieGenreIdByName[string(genre)]
+}
+
+// TVId returns the tv genre id, or 0 if it is not a tv genre.
+func (genre Genre) TVId() int {
+	return tvGenreIdByName[string(genre)]
 }
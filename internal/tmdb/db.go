@@ -341,6 +341,75 @@ func GetListItems(listId string) ([]TMDBItem, error) {
 	return items, nil
 }
 
+var query_get_items_by_id = fmt.Sprintf(
+	`SELECT %s, %s(ig.%s) AS genres FROM %s i LEFT JOIN %s ig ON i.%s = ig.%s AND i.%s = ig.%s WHERE i.%s = ? AND i.%s IN `,
+	db.JoinPrefixedColumnNames("i.", ItemColumns...),
+	db.FnJSONGroupArray,
+	ItemGenreColumn.GenreId,
+	ItemTableName,
+	ItemGenreTableName,
+	ItemColumn.Id,
+	ItemGenreColumn.ItemId,
+	ItemColumn.Type,
+	ItemGenreColumn.ItemType,
+	ItemColumn.Type,
+	ItemColumn.Id,
+)
+var query_get_items_by_id_group_by = fmt.Sprintf(
+	` GROUP BY i.%s, i.%s`,
+	ItemColumn.Id,
+	ItemColumn.Type,
+)
+
+func GetItemsById(itemType MediaType, ids ...int) (map[int]*TMDBItem, error) {
+	count := len(ids)
+	if count == 0 {
+		return nil, nil
+	}
+
+	query := query_get_items_by_id + "(" + util.RepeatJoin("?", count, ",") + ")" + query_get_items_by_id_group_by
+	args := make([]any, 1+count)
+	args[0] = itemType
+	for i := range ids {
+		args[1+i] = ids[i]
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	itemById := make(map[int]*TMDBItem, count)
+	for rows.Next() {
+		item := &TMDBItem{}
+		if err := rows.Scan(
+			&item.Id,
+			&item.Type,
+			&item.IsPartial,
+			&item.Title,
+			&item.OriginalTitle,
+			&item.Overview,
+			&item.ReleaseDate,
+			&item.IsAdult,
+			&item.Backdrop,
+			&item.Poster,
+			&item.Popularity,
+			&item.VoteAverage,
+			&item.VoteCount,
+			&item.UpdatedAt,
+			&item.Genres,
+		); err != nil {
+			return nil, err
+		}
+		itemById[item.Id] = item
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return itemById, nil
+}
+
 var query_upsert_list = fmt.Sprintf(
 	`INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s`,
 	ListTableName,
@@ -0,0 +1,154 @@
+package tmdb
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type DetailsGenre struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type GetMovieDetailsData struct {
+	ResponseError
+	Id            int            `json:"id"`
+	IMDBId        string         `json:"imdb_id"`
+	Title         string         `json:"title"`
+	OriginalTitle string         `json:"original_title"`
+	Overview      string         `json:"overview"`
+	ReleaseDate   string         `json:"release_date"` // YYYY-MM-DD
+	Runtime       int            `json:"runtime"`
+	Adult         bool           `json:"adult"`
+	BackdropPath  string         `json:"backdrop_path"`
+	PosterPath    string         `json:"poster_path"`
+	Genres        []DetailsGenre `json:"genres"`
+	Status        string         `json:"status"`
+}
+
+type GetMovieDetailsParams struct {
+	Ctx
+	MovieId string
+}
+
+func (c APIClient) GetMovieDetails(params *GetMovieDetailsParams) (APIResponse[GetMovieDetailsData], error) {
+	var response GetMovieDetailsData
+	res, err := c.Request("GET", "/3/movie/"+params.MovieId, &params.Ctx, &response)
+	return newAPIResponse(res, response), err
+}
+
+type TVSeason struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	Overview     string `json:"overview"`
+	AirDate      string `json:"air_date"` // YYYY-MM-DD
+	EpisodeCount int    `json:"episode_count"`
+	PosterPath   string `json:"poster_path"`
+	SeasonNumber int    `json:"season_number"`
+}
+
+type GetTVDetailsData struct {
+	ResponseError
+	Id             int            `json:"id"`
+	Name           string         `json:"name"`
+	OriginalName   string         `json:"original_name"`
+	Overview       string         `json:"overview"`
+	FirstAirDate   string         `json:"first_air_date"` // YYYY-MM-DD
+	EpisodeRunTime []int          `json:"episode_run_time"`
+	Adult          bool           `json:"adult"`
+	BackdropPath   string         `json:"backdrop_path"`
+	PosterPath     string         `json:"poster_path"`
+	Genres         []DetailsGenre `json:"genres"`
+	Seasons        []TVSeason     `json:"seasons"`
+	Status         string         `json:"status"` // Returning Series / Ended / Canceled
+
+	SeasonDetails map[int]GetTVSeasonDetailsData `json:"-"`
+}
+
+func (d *GetTVDetailsData) UnmarshalJSON(data []byte) error {
+	type tvDetailsData GetTVDetailsData
+	if err := json.Unmarshal(data, (*tvDetailsData)(d)); err != nil {
+		return err
+	}
+
+	var appended map[string]json.RawMessage
+	if err := json.Unmarshal(data, &appended); err != nil {
+		return err
+	}
+	for key, value := range appended {
+		if !strings.HasPrefix(key, "season/") {
+			continue
+		}
+		var season GetTVSeasonDetailsData
+		if err := json.Unmarshal(value, &season); err != nil {
+			return err
+		}
+		if d.SeasonDetails == nil {
+			d.SeasonDetails = map[int]GetTVSeasonDetailsData{}
+		}
+		d.SeasonDetails[season.SeasonNumber] = season
+	}
+	return nil
+}
+
+// MaxAppendToResponse is the max number of sub-requests
+// TMDB processes for `append_to_response`.
+const MaxAppendToResponse = 20
+
+type GetTVDetailsParams struct {
+	Ctx
+	SeriesId string
+	// seasons to include in `SeasonDetails`, at most `MaxAppendToResponse`
+	AppendSeasons []int
+}
+
+func (c APIClient) GetTVDetails(params *GetTVDetailsParams) (APIResponse[GetTVDetailsData], error) {
+	if len(params.AppendSeasons) > 0 {
+		appends := make([]string, len(params.AppendSeasons))
+		for i, seasonNumber := range params.AppendSeasons {
+			appends[i] = "season/" + strconv.Itoa(seasonNumber)
+		}
+		query := url.Values{}
+		query.Set("append_to_response", strings.Join(appends, ","))
+		params.Query = &query
+	}
+
+	var response GetTVDetailsData
+	res, err := c.Request("GET", "/3/tv/"+params.SeriesId, &params.Ctx, &response)
+	return newAPIResponse(res, response), err
+}
+
+type TVEpisode struct {
+	Id            int    `json:"id"`
+	Name          string `json:"name"`
+	Overview      string `json:"overview"`
+	AirDate       string `json:"air_date"` // YYYY-MM-DD
+	EpisodeNumber int    `json:"episode_number"`
+	SeasonNumber  int    `json:"season_number"`
+	Runtime       int    `json:"runtime"`
+	StillPath     string `json:"still_path"`
+}
+
+type GetTVSeasonDetailsData struct {
+	ResponseError
+	Id           int         `json:"id"`
+	Name         string      `json:"name"`
+	Overview     string      `json:"overview"`
+	AirDate      string      `json:"air_date"`
+	SeasonNumber int         `json:"season_number"`
+	Episodes     []TVEpisode `json:"episodes"`
+}
+
+type GetTVSeasonDetailsParams struct {
+	Ctx
+	SeriesId     string
+	SeasonNumber int
+}
+
+func (c APIClient) GetTVSeasonDetails(params *GetTVSeasonDetailsParams) (APIResponse[GetTVSeasonDetailsData], error) {
+	var response GetTVSeasonDetailsData
+	res, err := c.Request("GET", "/3/tv/"+params.SeriesId+"/season/"+strconv.Itoa(params.SeasonNumber), &params.Ctx, &response)
+	return newAPIResponse(res, response), err
+}
@@ -0,0 +1,32 @@
+package tmdb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTVDetailsDataUnmarshalJSON(t *testing.T) {
+	var data GetTVDetailsData
+	err := json.Unmarshal([]byte(`{
+		"id": 1399,
+		"name": "Game of Thrones",
+		"seasons": [{"season_number": 1, "episode_count": 10}, {"season_number": 2, "episode_count": 10}],
+		"season/1": {"season_number": 1, "episodes": [{"id": 63056, "episode_number": 1, "season_number": 1}]},
+		"season/2": {"season_number": 2, "episodes": []}
+	}`), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1399, data.Id)
+	assert.Equal(t, "Game of Thrones", data.Name)
+	assert.Len(t, data.Seasons, 2)
+	assert.Len(t, data.SeasonDetails, 2)
+	assert.Equal(t, 63056, data.SeasonDetails[1].Episodes[0].Id)
+	assert.Empty(t, data.SeasonDetails[2].Episodes)
+
+	data = GetTVDetailsData{}
+	err = json.Unmarshal([]byte(`{"success": false, "status_code": 34, "status_message": "not found"}`), &data)
+	assert.NoError(t, err)
+	assert.Error(t, data.GetError())
+	assert.Nil(t, data.SeasonDetails)
+}
@@ -9,10 +9,16 @@ type FetchDiscoverMovieData = fetchListData[ListItemMovie]
 
 type DiscoverMovieParams struct {
 	Ctx
-	IncludeAdult  bool
-	Page          int
-	SortBy        string
-	WithCompanies string // can be a comma (AND) or pipe (OR) separated query
+	IncludeAdult          bool
+	Page                  int
+	PrimaryReleaseDateGte string
+	PrimaryReleaseDateLte string
+	SortBy                string
+	VoteAverageGte        float64
+	VoteAverageLte        float64
+	VoteCountGte          int
+	WithCompanies         string // can be a comma (AND) or pipe (OR) separated query
+	WithGenres            string // can be a comma (AND) or pipe (OR) separated query
 }
 
 func (c APIClient) DiscoverMovie(params *DiscoverMovieParams) (APIResponse[FetchDiscoverMovieData], error) {
@@ -29,6 +35,24 @@ func (c APIClient) DiscoverMovie(params *DiscoverMovieParams) (APIResponse[Fetch
 	if params.WithCompanies != "" {
 		query.Set("with_companies", params.WithCompanies)
 	}
+	if params.PrimaryReleaseDateGte != "" {
+		query.Set("primary_release_date.gte", params.PrimaryReleaseDateGte)
+	}
+	if params.PrimaryReleaseDateLte != "" {
+		query.Set("primary_release_date.lte", params.PrimaryReleaseDateLte)
+	}
+	if params.VoteAverageGte > 0 {
+		query.Set("vote_average.gte", strconv.FormatFloat(params.VoteAverageGte, 'f', -1, 64))
+	}
+	if params.VoteAverageLte > 0 {
+		query.Set("vote_average.lte", strconv.FormatFloat(params.VoteAverageLte, 'f', -1, 64))
+	}
+	if params.VoteCountGte > 0 {
+		query.Set("vote_count.gte", strconv.Itoa(params.VoteCountGte))
+	}
+	if params.WithGenres != "" {
+		query.Set("with_genres", params.WithGenres)
+	}
 	params.Query = &query
 
 	response := FetchDiscoverMovieData{}
@@ -40,11 +64,17 @@ type FetchDiscoverTVData = fetchListData[ListItemShow]
 
 type DiscoverTVParams struct {
 	Ctx
-	IncludeAdult  bool
-	Page          int
-	SortBy        string
-	WithCompanies string // can be a comma (AND) or pipe (OR) separated query
-	WithNetworks  int
+	FirstAirDateGte string
+	FirstAirDateLte string
+	IncludeAdult    bool
+	Page            int
+	SortBy          string
+	VoteAverageGte  float64
+	VoteAverageLte  float64
+	VoteCountGte    int
+	WithCompanies   string // can be a comma (AND) or pipe (OR) separated query
+	WithGenres      string // can be a comma (AND) or pipe (OR) separated query
+	WithNetworks    int
 }
 
 func (c APIClient) DiscoverTV(params *DiscoverTVParams) (APIResponse[FetchDiscoverTVData], error) {
@@ -64,6 +94,24 @@ func (c APIClient) DiscoverTV(params *DiscoverTVParams) (APIResponse[FetchDiscov
 	if params.WithNetworks != 0 {
 		query.Set("with_networks", strconv.Itoa(params.WithNetworks))
 	}
+	if params.FirstAirDateGte != "" {
+		query.Set("first_air_date.gte", params.FirstAirDateGte)
+	}
+	if params.FirstAirDateLte != "" {
+		query.Set("first_air_date.lte", params.FirstAirDateLte)
+	}
+	if params.VoteAverageGte > 0 {
+		query.Set("vote_average.gte", strconv.FormatFloat(params.VoteAverageGte, 'f', -1, 64))
+	}
+	if params.VoteAverageLte > 0 {
+		query.Set("vote_average.lte", strconv.FormatFloat(params.VoteAverageLte, 'f', -1, 64))
+	}
+	if params.VoteCountGte > 0 {
+		query.Set("vote_count.gte", strconv.Itoa(params.VoteCountGte))
+	}
+	if params.WithGenres != "" {
+		query.Set("with_genres", params.WithGenres)
+	}
 	params.Query = &query
 
 	response := FetchDiscoverTVData{}
@@ -1,9 +1,11 @@
 package tmdb
 
 import (
+	"sync"
 	"time"
 
 	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/oauth"
 	"golang.org/x/oauth2"
 )
@@ -45,3 +47,23 @@ func GetAPIClient(tokenId string) *APIClient {
 
 	return client
 }
+
+var getSystemAPIClient = sync.OnceValue(func() *APIClient {
+	conf := APIClientConfig{}
+	conf.OAuth = APIClientConfigOAuth{
+		Config: oauth.TMDBOAuthConfig.Config,
+		GetTokenSource: func(oauthConfig oauth2.Config) oauth2.TokenSource {
+			return oauth2.StaticTokenSource(&oauth2.Token{
+				AccessToken: config.Integration.TMDB.AccessToken,
+				TokenType:   "Bearer",
+			})
+		},
+	}
+	return NewAPIClient(&conf)
+})
+
+// GetSystemAPIClient returns the client authorized with the configured
+// access token, for the requests not tied to any user.
+func GetSystemAPIClient() *APIClient {
+	return getSystemAPIClient()
+}
@@ -80,6 +80,7 @@ const (
 	TorrentInfoSourceAnimeTosho  TorrentInfoSource = "ato"
 	TorrentInfoSourceDHT         TorrentInfoSource = "dht"
 	TorrentInfoSourceDMM         TorrentInfoSource = "dmm"
+	TorrentInfoSourceImport      TorrentInfoSource = "imp"
 	TorrentInfoSourceIndexer     TorrentInfoSource = "ixr"
 	TorrentInfoSourceMediaFusion TorrentInfoSource = "mfn"
 	TorrentInfoSourceTorrentio   TorrentInfoSource = "tio"
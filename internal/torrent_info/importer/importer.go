@@ -0,0 +1,249 @@
+package torrent_info_importer
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	ti "github.com/MunifTanjim/stremthru/internal/torrent_info"
+	ts "github.com/MunifTanjim/stremthru/internal/torrent_stream"
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+var log = logger.Scoped("torrent_info/importer")
+
+const batchSize = 1000
+
+type Result struct {
+	Files    int      `json:"files"`
+	Torrents int      `json:"torrents"`
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+type Importer struct {
+	Result Result
+
+	items  []ti.TorrentInfoInsertData
+	upsert func(items []ti.TorrentInfoInsertData) error
+}
+
+func New() *Importer {
+	return &Importer{
+		items: make([]ti.TorrentInfoInsertData, 0, batchSize),
+		upsert: func(items []ti.TorrentInfoInsertData) error {
+			return ti.Upsert(items, ti.TorrentInfoCategoryUnknown, false)
+		},
+	}
+}
+
+func (imp *Importer) fail(name string, err error) {
+	imp.Result.Failed++
+	if len(imp.Result.Errors) < 100 {
+		imp.Result.Errors = append(imp.Result.Errors, name+": "+err.Error())
+	}
+	log.Warn("failed to import", "name", name, "error", err)
+}
+
+func (imp *Importer) add(item ti.TorrentInfoInsertData) error {
+	imp.items = append(imp.items, item)
+	imp.Result.Torrents++
+	if len(imp.items) >= batchSize {
+		return imp.Flush()
+	}
+	return nil
+}
+
+func (imp *Importer) Flush() error {
+	if len(imp.items) == 0 {
+		return nil
+	}
+	err := imp.upsert(imp.items)
+	imp.items = imp.items[:0]
+	return err
+}
+
+func ParseTorrentFile(r io.Reader) (*ti.TorrentInfoInsertData, error) {
+	mi, err := metainfo.Load(r)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := mi.UnmarshalInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(info.Pieces) == 0 && info.HasV2() {
+		return nil, errors.New("unsupported torrent file: only v1 torrents are supported")
+	}
+
+	item := &ti.TorrentInfoInsertData{
+		Hash:         mi.HashInfoBytes().HexString(),
+		TorrentTitle: info.BestName(),
+		Size:         info.TotalLength(),
+		Source:       ti.TorrentInfoSourceImport,
+		Private:      info.Private != nil && *info.Private,
+		Files:        ts.UpvertedFilesFromTorrentInfo(&info),
+	}
+	return item, nil
+}
+
+func (imp *Importer) ImportTorrentFile(name string, r io.Reader) error {
+	imp.Result.Files++
+	item, err := ParseTorrentFile(r)
+	if err != nil {
+		imp.fail(name, err)
+		return nil
+	}
+	return imp.add(*item)
+}
+
+type DumpItem struct {
+	ti.TorrentItem
+	IsSizeApprox bool `json:"_size_approx"`
+}
+
+// ImportDump accepts newline delimited items, or a json array of items,
+// in the shape produced by `torrent_info.DumpTorrents`.
+func (imp *Importer) ImportDump(name string, r io.Reader) error {
+	imp.Result.Files++
+
+	br := bufio.NewReader(r)
+	decoder := json.NewDecoder(br)
+
+	isArray := false
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			imp.fail(name, err)
+			return nil
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			br.ReadByte()
+			continue
+		}
+		isArray = b[0] == '['
+		break
+	}
+
+	if isArray {
+		if _, err := decoder.Token(); err != nil {
+			imp.fail(name, err)
+			return nil
+		}
+	}
+
+	line := 0
+	for {
+		if isArray && !decoder.More() {
+			break
+		}
+		line++
+		var item DumpItem
+		if err := decoder.Decode(&item); err != nil {
+			if err == io.EOF {
+				break
+			}
+			imp.fail(fmt.Sprintf("%s#%d", name, line), err)
+			return nil
+		}
+		item.Hash = strings.ToLower(item.Hash)
+		if item.IsSizeApprox {
+			item.Size = -1
+		}
+		if item.Source == ti.TorrentInfoSourceUnknown {
+			item.Source = ti.TorrentInfoSourceImport
+		}
+		if err := imp.add(item.TorrentItem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *Importer) ImportTar(name string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			imp.fail(name, err)
+			return nil
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := imp.importEntry(name+":"+hdr.Name, tr, false); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportFile picks the importer based on the extension of `name`.
+// Files with unknown extension are ignored.
+func (imp *Importer) ImportFile(name string, r io.Reader) error {
+	return imp.importEntry(name, r, true)
+}
+
+func (imp *Importer) importEntry(name string, r io.Reader, allowArchive bool) error {
+	lname := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lname, ".torrent"):
+		return imp.ImportTorrentFile(name, r)
+	case strings.HasSuffix(lname, ".jsonl"), strings.HasSuffix(lname, ".ndjson"), strings.HasSuffix(lname, ".json"):
+		return imp.ImportDump(name, r)
+	case strings.HasSuffix(lname, ".jsonl.gz"), strings.HasSuffix(lname, ".ndjson.gz"), strings.HasSuffix(lname, ".json.gz"):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			imp.fail(name, err)
+			return nil
+		}
+		defer gr.Close()
+		return imp.ImportDump(name, gr)
+	case allowArchive && strings.HasSuffix(lname, ".tar"):
+		return imp.ImportTar(name, r)
+	case allowArchive && (strings.HasSuffix(lname, ".tar.gz") || strings.HasSuffix(lname, ".tgz")):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			imp.fail(name, err)
+			return nil
+		}
+		defer gr.Close()
+		return imp.ImportTar(name, gr)
+	}
+	return nil
+}
+
+// ImportPath imports a single file, or every supported file inside a
+// directory (recursively).
+func (imp *Importer) ImportPath(root string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			imp.fail(path, err)
+			return nil
+		}
+		defer file.Close()
+		return imp.ImportFile(path, file)
+	})
+}
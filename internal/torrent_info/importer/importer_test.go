@@ -0,0 +1,135 @@
+package torrent_info_importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	ti "github.com/MunifTanjim/stremthru/internal/torrent_info"
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestImporter() (*Importer, *[]ti.TorrentInfoInsertData) {
+	imported := []ti.TorrentInfoInsertData{}
+	imp := New()
+	imp.upsert = func(items []ti.TorrentInfoInsertData) error {
+		imported = append(imported, items...)
+		return nil
+	}
+	return imp, &imported
+}
+
+func makeTorrentFile(t *testing.T, info metainfo.Info) []byte {
+	t.Helper()
+	info.PieceLength = 16384
+	info.Pieces = make([]byte, 20)
+	infoBytes, err := bencode.Marshal(info)
+	require.NoError(t, err)
+	mi := metainfo.MetaInfo{InfoBytes: infoBytes}
+	var buf bytes.Buffer
+	require.NoError(t, mi.Write(&buf))
+	return buf.Bytes()
+}
+
+func TestParseTorrentFile(t *testing.T) {
+	t.Run("single file", func(t *testing.T) {
+		data := makeTorrentFile(t, metainfo.Info{
+			Name:   "Movie.2020.1080p.mkv",
+			Length: 1024,
+		})
+		item, err := ParseTorrentFile(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Len(t, item.Hash, 40)
+		assert.Equal(t, "Movie.2020.1080p.mkv", item.TorrentTitle)
+		assert.Equal(t, int64(1024), item.Size)
+		assert.Equal(t, ti.TorrentInfoSourceImport, item.Source)
+		require.Len(t, item.Files, 1)
+		assert.Equal(t, "/Movie.2020.1080p.mkv", item.Files[0].Path)
+		assert.Equal(t, int64(1024), item.Files[0].Size)
+	})
+
+	t.Run("multi file", func(t *testing.T) {
+		private := true
+		data := makeTorrentFile(t, metainfo.Info{
+			Name: "Show.S01",
+			Files: []metainfo.FileInfo{
+				{Path: []string{"Show.S01E01.mkv"}, Length: 100},
+				{Path: []string{"Extras", "Sample.mkv"}, Length: 10},
+			},
+			Private: &private,
+		})
+		item, err := ParseTorrentFile(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, "Show.S01", item.TorrentTitle)
+		assert.Equal(t, int64(110), item.Size)
+		assert.True(t, item.Private)
+		require.Len(t, item.Files, 2)
+		assert.Equal(t, "/Show.S01E01.mkv", item.Files[0].Path)
+		assert.Equal(t, "/Extras/Sample.mkv", item.Files[1].Path)
+		assert.Equal(t, 1, item.Files[1].Idx)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseTorrentFile(strings.NewReader("not a torrent"))
+		assert.Error(t, err)
+	})
+}
+
+func TestImportDump(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+	}{
+		{"jsonl", `{"hash":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","name":"One","size":10,"_size_approx":false}
+{"hash":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb","name":"Two","size":20,"_size_approx":true}
+`},
+		{"json", `[
+  {"hash":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","name":"One","size":10,"_size_approx":false},
+  {"hash":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb","name":"Two","size":20,"_size_approx":true}
+]`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			imp, imported := newTestImporter()
+			require.NoError(t, imp.ImportFile("dump."+tc.name, strings.NewReader(tc.input)))
+			require.NoError(t, imp.Flush())
+			assert.Equal(t, Result{Files: 1, Torrents: 2}, imp.Result)
+			require.Len(t, *imported, 2)
+			assert.Equal(t, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", (*imported)[0].Hash)
+			assert.Equal(t, int64(10), (*imported)[0].Size)
+			assert.Equal(t, ti.TorrentInfoSourceImport, (*imported)[0].Source)
+			assert.Equal(t, "Two", (*imported)[1].TorrentTitle)
+			assert.Equal(t, int64(-1), (*imported)[1].Size)
+		})
+	}
+}
+
+func TestImportTar(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range map[string][]byte{
+		"a.torrent":  makeTorrentFile(t, metainfo.Info{Name: "A", Length: 1}),
+		"b.torrent":  []byte("broken"),
+		"readme.txt": []byte("ignored"),
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	imp, imported := newTestImporter()
+	require.NoError(t, imp.ImportFile("torrents.tar.gz", &buf))
+	require.NoError(t, imp.Flush())
+	assert.Equal(t, 2, imp.Result.Files)
+	assert.Equal(t, 1, imp.Result.Torrents)
+	assert.Equal(t, 1, imp.Result.Failed)
+	require.Len(t, *imported, 1)
+	assert.Equal(t, "A", (*imported)[0].TorrentTitle)
+}
@@ -6,8 +6,8 @@ import (
 	"github.com/anacrolix/torrent/metainfo"
 )
 
-func FilesFromTorrentInfo(info *metainfo.Info) (files Files) {
-	for i, f := range info.Files {
+func toFiles(info *metainfo.Info, infoFiles []metainfo.FileInfo) (files Files) {
+	for i, f := range infoFiles {
 		path := "/" + f.DisplayPath(info)
 		files = append(files, File{
 			Path:   path,
@@ -19,3 +19,13 @@ func FilesFromTorrentInfo(info *metainfo.Info) (files Files) {
 	}
 	return files
 }
+
+func FilesFromTorrentInfo(info *metainfo.Info) (files Files) {
+	return toFiles(info, info.Files)
+}
+
+// UpvertedFilesFromTorrentInfo also lists the lone file of a single-file
+// torrent, which FilesFromTorrentInfo leaves out.
+func UpvertedFilesFromTorrentInfo(info *metainfo.Info) (files Files) {
+	return toFiles(info, info.UpvertedFiles())
+}
@@ -0,0 +1,31 @@
+package torrent_stream
+
+import (
+	"testing"
+
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesFromTorrentInfo(t *testing.T) {
+	single := &metainfo.Info{Name: "Movie.2024.mkv", Length: 100}
+	multi := &metainfo.Info{
+		Name: "Show.S01",
+		Files: []metainfo.FileInfo{
+			{Path: []string{"Show.S01E01.mkv"}, Length: 10},
+			{Path: []string{"Extras", "Sample.mkv"}, Length: 5},
+		},
+	}
+
+	assert.Empty(t, FilesFromTorrentInfo(single))
+	assert.Equal(t, Files{
+		{Path: "/Movie.2024.mkv", Idx: 0, Size: 100, Name: "Movie.2024.mkv", Source: "tor"},
+	}, UpvertedFilesFromTorrentInfo(single))
+
+	expected := Files{
+		{Path: "/Show.S01E01.mkv", Idx: 0, Size: 10, Name: "Show.S01E01.mkv", Source: "tor"},
+		{Path: "/Extras/Sample.mkv", Idx: 1, Size: 5, Name: "Sample.mkv", Source: "tor"},
+	}
+	assert.Equal(t, expected, FilesFromTorrentInfo(multi))
+	assert.Equal(t, expected, UpvertedFilesFromTorrentInfo(multi))
+}
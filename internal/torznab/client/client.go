@@ -86,7 +86,7 @@ func (r Response[T]) GetError(res *http.Response) error {
 func (r *Response[T]) Unmarshal(res *http.Response, body []byte, v any) error {
 	contentType := res.Header.Get("Content-Type")
 	switch {
-	case strings.Contains(contentType, "application/xml") || strings.Contains(contentType, "application/rss+xml"):
+	case strings.Contains(contentType, "application/xml") || strings.Contains(contentType, "application/rss+xml") || strings.Contains(contentType, "text/xml"):
 		var root struct {
 			XMLName xml.Name
 		}
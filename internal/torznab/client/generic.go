@@ -0,0 +1,110 @@
+package torznab_client
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+)
+
+type GenericIndexerConfig struct {
+	ClientConfig
+	Name string
+}
+
+// GenericIndexer talks to any Torznab feed that is not behind a known
+// aggregator like Jackett or Prowlarr.
+type GenericIndexer struct {
+	*Client
+	id   string
+	name string
+
+	categories *cache.CachedValue[map[int]genericCategory]
+}
+
+func NewGenericIndexer(conf *GenericIndexerConfig) *GenericIndexer {
+	gi := &GenericIndexer{
+		Client: NewClient(&conf.ClientConfig),
+		id:     TorznabURL(conf.BaseURL).Encode(),
+		name:   conf.Name,
+	}
+	gi.categories = cache.NewCachedValue(cache.CachedValueConfig[map[int]genericCategory]{
+		Get: gi.getCategories,
+		TTL: 15 * time.Minute,
+	})
+	return gi
+}
+
+func (gi GenericIndexer) GetId() string {
+	return "torznab/" + gi.id
+}
+
+func (gi GenericIndexer) Search(query url.Values) ([]Torz, error) {
+	params := &Ctx{}
+	params.Query = &query
+	var resp Response[SearchResponse]
+	_, err := gi.Client.Request("GET", "/api", params, &resp)
+	if err != nil {
+		return nil, err
+	}
+	items := resp.Data.Channel.Items
+	result := make([]Torz, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		if item.IsEmpty() {
+			continue
+		}
+		torz := item.ToTorz()
+		torz.Indexer = gi.name
+		result = append(result, *torz)
+	}
+	return result, nil
+}
+
+type genericCategory struct {
+	category Category
+	parent   Category
+}
+
+// getCategories indexes the categories advertised by the feed's caps. Unlike
+// Jackett or Prowlarr, a generic feed has no fixed category list, custom ids
+// included, so the caps are the only source for the mapping.
+func (gi *GenericIndexer) getCategories() (map[int]genericCategory, error) {
+	caps, err := gi.GetCaps()
+	if err != nil {
+		return nil, err
+	}
+	categories := map[int]genericCategory{}
+	for i := range caps.Categories {
+		cat := &caps.Categories[i]
+		categories[cat.ID] = genericCategory{category: cat.Category, parent: cat.Category}
+		for _, subcat := range cat.Subcat {
+			categories[subcat.ID] = genericCategory{category: subcat, parent: cat.Category}
+		}
+	}
+	return categories, nil
+}
+
+func (gi GenericIndexer) GetCategoryById(id int) (*Category, error) {
+	categories, err := gi.categories.Get()
+	if err != nil {
+		return nil, err
+	}
+	if cat, ok := categories[id]; ok {
+		return &cat.category, nil
+	}
+	return nil, nil
+}
+
+// ParentCategory maps a category to the top-level category it is advertised
+// under, falling back to the category itself when the caps do not list it.
+func (gi GenericIndexer) ParentCategory(c Category) (Category, error) {
+	categories, err := gi.categories.Get()
+	if err != nil {
+		return c, err
+	}
+	if cat, ok := categories[c.ID]; ok {
+		return cat.parent, nil
+	}
+	return c, nil
+}
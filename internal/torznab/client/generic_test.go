@@ -0,0 +1,58 @@
+package torznab_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericIndexerCategory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<caps>
+  <server title="Feed" />
+  <searching>
+    <search available="yes" supportedParams="q" />
+  </searching>
+  <categories>
+    <category id="2000" name="Movies">
+      <subcat id="2040" name="Movies/HD" />
+      <subcat id="100001" name="Movies/Remux" />
+    </category>
+    <category id="5000" name="TV" />
+  </categories>
+</caps>`))
+	}))
+	defer server.Close()
+
+	gi := NewGenericIndexer(&GenericIndexerConfig{
+		ClientConfig: ClientConfig{BaseURL: server.URL, APIKey: "key"},
+		Name:         "Feed",
+	})
+
+	cat, err := gi.GetCategoryById(100001)
+	require.NoError(t, err)
+	require.NotNil(t, cat)
+	assert.Equal(t, "Movies/Remux", cat.Name)
+
+	cat, err = gi.GetCategoryById(3000)
+	require.NoError(t, err)
+	assert.Nil(t, cat)
+
+	for _, test := range []struct {
+		cat, parent Category
+	}{
+		{Category{ID: 100001}, Category{ID: 2000, Name: "Movies"}},
+		{Category{ID: 2040}, Category{ID: 2000, Name: "Movies"}},
+		{Category{ID: 5000}, Category{ID: 5000, Name: "TV"}},
+		{Category{ID: 3000, Name: "Audio"}, Category{ID: 3000, Name: "Audio"}},
+	} {
+		parent, err := gi.ParentCategory(test.cat)
+		require.NoError(t, err)
+		assert.Equal(t, test.parent, parent)
+	}
+}
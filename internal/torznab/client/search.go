@@ -0,0 +1,71 @@
+package torznab_client
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type ChannelItem struct {
+	Title        string        `xml:"title"`
+	GUID         string        `xml:"guid"`
+	Comments     string        `xml:"comments"`
+	PubDate      string        `xml:"pubDate"` // Mon, 02 Jan 2006 15:04:05 -0700
+	Size         int64         `xml:"size"`
+	Grabs        int           `xml:"grabs"`
+	Description  string        `xml:"description"`
+	Link         string        `xml:"link"`
+	Categories   []int         `xml:"category"`
+	Enclosure    ItemEnclosure `xml:"enclosure"`
+	TorznabAttrs TorznabAttrs  `xml:"http://torznab.com/schemas/2015/feed attr"`
+}
+
+func (o ChannelItem) IsEmpty() bool {
+	return o.Size == 0 && o.Grabs == 0 && o.Enclosure.Length == 0
+}
+
+func (o ChannelItem) ToTorz() *Torz {
+	t := &Torz{}
+	t.Hash = strings.ToLower(o.TorznabAttrs.Get(TorznabAttrNameInfoHash))
+	t.Title = o.Title
+	t.Size = o.Size
+	if t.Size == 0 {
+		t.Size = o.Enclosure.Length
+	}
+	t.Seeders = util.SafeParseInt(o.TorznabAttrs.Get(TorznabAttrNameSeeders), 0)
+	if peers := util.SafeParseInt(o.TorznabAttrs.Get(TorznabAttrNamePeers), 0); peers > t.Seeders {
+		t.Leechers = peers - t.Seeders
+	}
+	if magnetURL := o.TorznabAttrs.Get(TorznabAttrNameMagnetURL); strings.HasPrefix(magnetURL, "magnet:?") {
+		t.MagnetLink = magnetURL
+	}
+	if strings.HasPrefix(o.Enclosure.URL, "magnet:?") {
+		t.MagnetLink = o.Enclosure.URL
+	} else if strings.HasPrefix(o.Enclosure.URL, "http") {
+		t.SourceLink = o.Enclosure.URL
+	}
+	if t.Hash == "" && t.MagnetLink != "" {
+		if m, err := core.ParseMagnetLink(t.MagnetLink); err == nil {
+			t.Hash = m.Hash
+		}
+	}
+	return t
+}
+
+type Channel struct {
+	XMLName     xml.Name      `xml:"channel"`
+	Title       string        `xml:"title,omitempty"`
+	Description string        `xml:"description,omitempty"`
+	Link        string        `xml:"link,omitempty"`
+	Language    string        `xml:"language,omitempty"`
+	Category    string        `xml:"category,omitempty"`
+	Items       []ChannelItem `xml:"item"`
+}
+
+type SearchResponse struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr,omitempty"`
+	Channel Channel  `xml:"channel"`
+}
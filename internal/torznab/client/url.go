@@ -0,0 +1,66 @@
+package torznab_client
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+type torznabURL struct {
+	raw     string
+	BaseURL string
+}
+
+// TorznabURL handles a bare Torznab feed url, i.e. the url that serves
+// `{url}/api?t=caps`. A trailing `/api` is optional.
+func TorznabURL(str string) *torznabURL {
+	turl := torznabURL{raw: str}
+	return &turl
+}
+
+func (turl *torznabURL) Parse() error {
+	if turl.BaseURL != "" {
+		return nil
+	}
+	if !strings.HasPrefix(turl.raw, "http://") && !strings.HasPrefix(turl.raw, "https://") {
+		return errors.New("invalid torznab url")
+	}
+	u, err := url.Parse(strings.TrimSpace(turl.raw))
+	if err != nil {
+		return err
+	}
+	if u.Host == "" {
+		return errors.New("invalid torznab url")
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(strings.TrimRight(u.Path, "/"), "/api")
+	turl.BaseURL = u.String()
+	return nil
+}
+
+func (turl torznabURL) Encode() string {
+	if err := turl.Parse(); err != nil {
+		return ""
+	}
+	u, err := url.Parse(turl.BaseURL)
+	if err != nil {
+		return ""
+	}
+	id := u.Scheme + ":" + u.Host
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		id += "::" + strings.ReplaceAll(path, "/", ":")
+	}
+	return id
+}
+
+func (turl torznabURL) Host() string {
+	if err := turl.Parse(); err != nil {
+		return ""
+	}
+	u, err := url.Parse(turl.BaseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
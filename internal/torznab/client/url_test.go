@@ -0,0 +1,49 @@
+package torznab_client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTorznabURL(t *testing.T) {
+	for _, test := range []struct {
+		input           string
+		expectedBase    string
+		expectedEncoded string
+		shouldError     bool
+	}{
+		{
+			input:           "https://api.nzbgeek.info",
+			expectedBase:    "https://api.nzbgeek.info",
+			expectedEncoded: "https:api.nzbgeek.info",
+		},
+		{
+			input:           "https://api.nzbgeek.info/api/",
+			expectedBase:    "https://api.nzbgeek.info",
+			expectedEncoded: "https:api.nzbgeek.info",
+		},
+		{
+			input:           "http://localhost:5076/newznab/api?apikey=xxx",
+			expectedBase:    "http://localhost:5076/newznab",
+			expectedEncoded: "http:localhost:5076::newznab",
+		},
+		{
+			input:       "localhost:5076",
+			shouldError: true,
+		},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			u := TorznabURL(test.input)
+			err := u.Parse()
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBase, u.BaseURL)
+			assert.Equal(t, test.expectedEncoded, u.Encode())
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/ratelimit"
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
 	"github.com/MunifTanjim/stremthru/internal/torznab/jackett"
+	"github.com/MunifTanjim/stremthru/internal/torznab/prowlarr"
 	rrl "github.com/nccapo/rate-limiter"
 )
 
@@ -26,18 +28,33 @@ const TableName = "torznab_indexer"
 type IndexerType string
 
 const (
-	IndexerTypeJackett IndexerType = "jackett"
+	IndexerTypeJackett  IndexerType = "jackett"
+	IndexerTypeProwlarr IndexerType = "prowlarr"
+	IndexerTypeTorznab  IndexerType = "torznab"
 )
 
 func (it IndexerType) IsValid() bool {
 	switch it {
-	case IndexerTypeJackett:
+	case IndexerTypeJackett, IndexerTypeProwlarr, IndexerTypeTorznab:
 		return true
 	default:
 		return false
 	}
 }
 
+// DetectIndexerType guesses the indexer type from the shape of the url,
+// falling back to a generic torznab feed. A Prowlarr `/{id}/api` url is
+// indistinguishable from a generic feed, so it needs an explicit type.
+func DetectIndexerType(url string) IndexerType {
+	if err := jackett.TorznabURL(url).Parse(); err == nil {
+		return IndexerTypeJackett
+	}
+	if prowlarr.IsIndexerAPIURL(url) {
+		return IndexerTypeProwlarr
+	}
+	return IndexerTypeTorznab
+}
+
 func ParseCompositeId(compositeId string) (IndexerType, string, error) {
 	typeStr, id, ok := strings.Cut(compositeId, ":")
 	if !ok {
@@ -100,6 +117,38 @@ func NewTorznabIndexer(indexerType IndexerType, url, apiKey string) (*TorznabInd
 			return nil, fmt.Errorf("invalid torznab url: %w", err)
 		}
 
+		indexer := &TorznabIndexer{
+			Type: indexerType,
+			Id:   u.Encode(),
+			URL:  url,
+		}
+		err := indexer.SetAPIKey(apiKey)
+		if err != nil {
+			return nil, err
+		}
+		return indexer, nil
+	case IndexerTypeProwlarr:
+		u := prowlarr.TorznabURL(url)
+		if err := u.Parse(); err != nil {
+			return nil, fmt.Errorf("invalid torznab url: %w", err)
+		}
+
+		indexer := &TorznabIndexer{
+			Type: indexerType,
+			Id:   u.Encode(),
+			URL:  url,
+		}
+		err := indexer.SetAPIKey(apiKey)
+		if err != nil {
+			return nil, err
+		}
+		return indexer, nil
+	case IndexerTypeTorznab:
+		u := torznab_client.TorznabURL(url)
+		if err := u.Parse(); err != nil {
+			return nil, fmt.Errorf("invalid torznab url: %w", err)
+		}
+
 		indexer := &TorznabIndexer{
 			Type: indexerType,
 			Id:   u.Encode(),
@@ -160,6 +209,64 @@ func (i *TorznabIndexer) Validate() error {
 			i.Name = jackett.GetIndexerName(u.IndexerId)
 		}
 
+		return nil
+	case IndexerTypeProwlarr:
+		u := prowlarr.TorznabURL(i.URL)
+		if err := u.Parse(); err != nil {
+			return fmt.Errorf("invalid torznab url: %w", err)
+		}
+
+		apiKey, err := i.GetAPIKey()
+		if err != nil {
+			return fmt.Errorf("failed to decrypt api key: %w", err)
+		}
+
+		client := prowlarr.NewClient(&prowlarr.ClientConfig{
+			BaseURL: u.BaseURL,
+			APIKey:  apiKey,
+		})
+
+		torznabClient := client.GetTorznabClient(u.IndexerId)
+
+		_, err = torznabClient.GetCaps()
+		if err != nil {
+			return fmt.Errorf("failed to fetch capabilities: %w", err)
+		}
+
+		if i.Name == "" {
+			i.Name = prowlarr.GetIndexerName(u.IndexerId)
+		}
+
+		return nil
+	case IndexerTypeTorznab:
+		u := torznab_client.TorznabURL(i.URL)
+		if err := u.Parse(); err != nil {
+			return fmt.Errorf("invalid torznab url: %w", err)
+		}
+
+		apiKey, err := i.GetAPIKey()
+		if err != nil {
+			return fmt.Errorf("failed to decrypt api key: %w", err)
+		}
+
+		client := torznab_client.NewClient(&torznab_client.ClientConfig{
+			BaseURL: u.BaseURL,
+			APIKey:  apiKey,
+		})
+
+		caps, err := client.GetCaps()
+		if err != nil {
+			return fmt.Errorf("failed to fetch capabilities: %w", err)
+		}
+
+		if i.Name == "" {
+			if caps.Server.Title != "" {
+				i.Name = caps.Server.Title
+			} else {
+				i.Name = u.Host()
+			}
+		}
+
 		return nil
 	default:
 		return fmt.Errorf("unsupported indexer type: %s", i.Type)
@@ -197,25 +304,72 @@ var columns = []string{
 	Column.UAt,
 }
 
-var query_exists = fmt.Sprintf(
-	`SELECT 1 FROM %s`,
-	TableName,
-)
+// Table runs the indexer queries against a table sharing the torznab_indexer
+// schema, so other indexer kinds can reuse the storage.
+type Table struct {
+	queryExists  string
+	queryGetAll  string
+	queryGetById string
+	queryUpsert  string
+	queryDelete  string
+}
 
-func Exists() bool {
+func NewTable(name string) *Table {
+	return &Table{
+		queryExists: fmt.Sprintf(
+			`SELECT 1 FROM %s`,
+			name,
+		),
+		queryGetAll: fmt.Sprintf(
+			`SELECT %s FROM %s`,
+			strings.Join(columns, ", "),
+			name,
+		),
+		queryGetById: fmt.Sprintf(
+			`SELECT %s FROM %s WHERE %s = ? AND %s = ?`,
+			strings.Join(columns, ", "),
+			name,
+			Column.Type,
+			Column.Id,
+		),
+		queryUpsert: fmt.Sprintf(
+			`INSERT INTO %s (%s) VALUES (?,?,?,?,?,?) ON CONFLICT (%s, %s) DO UPDATE SET %s`,
+			name,
+			db.JoinColumnNames(
+				Column.Type,
+				Column.Id,
+				Column.Name,
+				Column.URL,
+				Column.APIKey,
+				Column.RateLimitConfigId,
+			),
+			Column.Type,
+			Column.Id,
+			strings.Join([]string{
+				fmt.Sprintf(`%s = EXCLUDED.%s`, Column.Name, Column.Name),
+				fmt.Sprintf(`%s = EXCLUDED.%s`, Column.URL, Column.URL),
+				fmt.Sprintf(`%s = EXCLUDED.%s`, Column.APIKey, Column.APIKey),
+				fmt.Sprintf(`%s = EXCLUDED.%s`, Column.RateLimitConfigId, Column.RateLimitConfigId),
+				fmt.Sprintf(`%s = %s`, Column.UAt, db.CurrentTimestamp),
+			}, ", "),
+		),
+		queryDelete: fmt.Sprintf(
+			`DELETE FROM %s WHERE %s = ? AND %s = ?`,
+			name,
+			Column.Type,
+			Column.Id,
+		),
+	}
+}
+
+func (t *Table) Exists() bool {
 	var one int
-	err := db.QueryRow(query_exists).Scan(&one)
+	err := db.QueryRow(t.queryExists).Scan(&one)
 	return err == nil
 }
 
-var query_get_all = fmt.Sprintf(
-	`SELECT %s FROM %s`,
-	strings.Join(columns, ", "),
-	TableName,
-)
-
-func GetAll() ([]TorznabIndexer, error) {
-	rows, err := db.Query(query_get_all)
+func (t *Table) GetAll() ([]TorznabIndexer, error) {
+	rows, err := db.Query(t.queryGetAll)
 	if err != nil {
 		return nil, err
 	}
@@ -232,16 +386,8 @@ func GetAll() ([]TorznabIndexer, error) {
 	return items, nil
 }
 
-var query_get_by_id = fmt.Sprintf(
-	`SELECT %s FROM %s WHERE %s = ? AND %s = ?`,
-	strings.Join(columns, ", "),
-	TableName,
-	Column.Type,
-	Column.Id,
-)
-
-func GetById(indexerType IndexerType, id string) (*TorznabIndexer, error) {
-	row := db.QueryRow(query_get_by_id, indexerType, id)
+func (t *Table) GetById(indexerType IndexerType, id string) (*TorznabIndexer, error) {
+	row := db.QueryRow(t.queryGetById, indexerType, id)
 
 	item := TorznabIndexer{}
 	if err := row.Scan(&item.Type, &item.Id, &item.Name, &item.URL, &item.APIKey, &item.RateLimitConfigId, &item.CAt, &item.UAt); err != nil {
@@ -253,38 +399,8 @@ func GetById(indexerType IndexerType, id string) (*TorznabIndexer, error) {
 	return &item, nil
 }
 
-func GetByCompositeId(compositeId string) (*TorznabIndexer, error) {
-	indexerType, id, err := ParseCompositeId(compositeId)
-	if err != nil {
-		return nil, err
-	}
-	return GetById(indexerType, id)
-}
-
-var query_upsert = fmt.Sprintf(
-	`INSERT INTO %s (%s) VALUES (?,?,?,?,?,?) ON CONFLICT (%s, %s) DO UPDATE SET %s`,
-	TableName,
-	db.JoinColumnNames(
-		Column.Type,
-		Column.Id,
-		Column.Name,
-		Column.URL,
-		Column.APIKey,
-		Column.RateLimitConfigId,
-	),
-	Column.Type,
-	Column.Id,
-	strings.Join([]string{
-		fmt.Sprintf(`%s = EXCLUDED.%s`, Column.Name, Column.Name),
-		fmt.Sprintf(`%s = EXCLUDED.%s`, Column.URL, Column.URL),
-		fmt.Sprintf(`%s = EXCLUDED.%s`, Column.APIKey, Column.APIKey),
-		fmt.Sprintf(`%s = EXCLUDED.%s`, Column.RateLimitConfigId, Column.RateLimitConfigId),
-		fmt.Sprintf(`%s = %s`, Column.UAt, db.CurrentTimestamp),
-	}, ", "),
-)
-
-func (i *TorznabIndexer) Upsert() error {
-	_, err := db.Exec(query_upsert,
+func (t *Table) Upsert(i *TorznabIndexer) error {
+	_, err := db.Exec(t.queryUpsert,
 		i.Type,
 		i.Id,
 		i.Name,
@@ -295,16 +411,39 @@ func (i *TorznabIndexer) Upsert() error {
 	return err
 }
 
-var query_delete = fmt.Sprintf(
-	`DELETE FROM %s WHERE %s = ? AND %s = ?`,
-	TableName,
-	Column.Type,
-	Column.Id,
-)
+func (t *Table) Delete(indexerType IndexerType, id string) error {
+	_, err := db.Exec(t.queryDelete, indexerType, id)
+	return err
+}
+
+var table = NewTable(TableName)
+
+func Exists() bool {
+	return table.Exists()
+}
+
+func GetAll() ([]TorznabIndexer, error) {
+	return table.GetAll()
+}
+
+func GetById(indexerType IndexerType, id string) (*TorznabIndexer, error) {
+	return table.GetById(indexerType, id)
+}
+
+func GetByCompositeId(compositeId string) (*TorznabIndexer, error) {
+	indexerType, id, err := ParseCompositeId(compositeId)
+	if err != nil {
+		return nil, err
+	}
+	return GetById(indexerType, id)
+}
+
+func (i *TorznabIndexer) Upsert() error {
+	return table.Upsert(i)
+}
 
 func Delete(indexerType IndexerType, id string) error {
-	_, err := db.Exec(query_delete, indexerType, id)
-	return err
+	return table.Delete(indexerType, id)
 }
 
 func DeleteByCompositeId(compositeId string) error {
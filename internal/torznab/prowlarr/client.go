@@ -0,0 +1,53 @@
+package prowlarr
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type ClientConfig struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	APIKey     string
+	UserAgent  string
+}
+
+type Client struct {
+	BaseURL    *url.URL
+	HTTPClient *http.Client
+
+	userAgent string
+	apiKey    string
+
+	torznabClientById cache.Cache[TorznabClient]
+}
+
+func NewClient(conf *ClientConfig) *Client {
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = config.GetHTTPClient(config.TUNNEL_TYPE_AUTO)
+	}
+
+	if conf.UserAgent == "" {
+		conf.UserAgent = "stremthru/" + config.Version
+	}
+
+	c := Client{
+		HTTPClient: conf.HTTPClient,
+		userAgent:  conf.UserAgent,
+		apiKey:     conf.APIKey,
+	}
+
+	c.BaseURL = util.MustParseURL(conf.BaseURL)
+
+	c.torznabClientById = cache.NewCache[TorznabClient](&cache.CacheConfig{
+		Lifetime: 30 * time.Minute,
+		Name:     "torznab:prowlarr:torznab-client",
+	})
+
+	return &c
+}
@@ -0,0 +1,180 @@
+package prowlarr
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
+)
+
+type torznabURL struct {
+	raw       string
+	BaseURL   string
+	IndexerId string
+}
+
+func TorznabURL(str string) *torznabURL {
+	purl := torznabURL{raw: str}
+	return &purl
+}
+
+func (turl *torznabURL) Parse() error {
+	if turl.BaseURL != "" && turl.IndexerId != "" {
+		return nil
+	}
+	if strings.HasPrefix(turl.raw, "http://") || strings.HasPrefix(turl.raw, "https://") {
+		return turl.FromDecoded()
+	}
+	return turl.FromEncoded()
+}
+
+func (purl *torznabURL) FromDecoded() error {
+	for _, pattern := range torznabUrlPatterns {
+		for _, match := range pattern.FindAllStringSubmatch(purl.raw, -1) {
+			for i, name := range pattern.SubexpNames() {
+				value := match[i]
+				switch name {
+				case "base_url":
+					purl.BaseURL = value
+				case "indexer_id":
+					purl.IndexerId = value
+				}
+			}
+		}
+		if purl.BaseURL != "" && purl.IndexerId != "" {
+			return nil
+		}
+	}
+	return errors.New("invalid torznab url")
+}
+
+func (purl *torznabURL) FromEncoded() error {
+	schemeHost, indexerId, ok := strings.Cut(purl.raw, "::")
+	if !ok {
+		return errors.New("invalid encoded torznab url")
+	}
+	scheme, host, ok := strings.Cut(schemeHost, ":")
+	if !ok {
+		return errors.New("invalid encoded torznab url")
+	}
+	purl.BaseURL = scheme + "://" + host
+	purl.IndexerId = indexerId
+	return nil
+}
+
+func (purl torznabURL) Encode() string {
+	if err := purl.Parse(); err != nil {
+		return ""
+	}
+	u, err := url.Parse(purl.BaseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme + ":" + u.Host + "::" + purl.IndexerId
+}
+
+func (purl torznabURL) Decode() string {
+	if strings.HasPrefix(purl.raw, "http://") || strings.HasPrefix(purl.raw, "https://") {
+		return purl.raw
+	}
+	if err := purl.Parse(); err != nil {
+		return ""
+	}
+	return strings.TrimRight(purl.BaseURL, "/") + "/" + purl.IndexerId + "/api"
+}
+
+var indexerAPIUrlPattern = regexp.MustCompile(`(?i)^(?<base_url>https?:\/\/.+?)\/api\/v1\/indexer\/(?<indexer_id>\d+)\/newznab\/?$`)
+
+var torznabUrlPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(?<base_url>https?:\/\/.+?)\/(?<indexer_id>\d+)\/api\/?$`),
+	indexerAPIUrlPattern,
+}
+
+// IsIndexerAPIURL reports whether the url uses the Prowlarr specific
+// `/api/v1/indexer/{id}/newznab` path. The shorter `/{id}/api` path is
+// shared with other torznab feeds, so it needs an explicit type.
+func IsIndexerAPIURL(str string) bool {
+	return indexerAPIUrlPattern.MatchString(str)
+}
+
+type TorznabClient struct {
+	*torznab_client.Client
+	id string
+}
+
+func (tc TorznabClient) GetId() string {
+	return "prowlarr/" + tc.id
+}
+
+type ItemProwlarrIndexer struct {
+	ID   string `xml:"id,attr"`
+	Name string `xml:",chardata"`
+}
+
+type ChannelItem struct {
+	torznab_client.ChannelItem
+	ProwlarrIndexer ItemProwlarrIndexer `xml:"prowlarrindexer"`
+	Type            string              `xml:"type"`
+}
+
+func (o ChannelItem) ToTorz() *torznab_client.Torz {
+	t := o.ChannelItem.ToTorz()
+	t.Indexer = o.ProwlarrIndexer.Name
+	t.Private = o.Type == "private" || o.Type == "semi-private"
+	return t
+}
+
+type Channel struct {
+	Items []ChannelItem `xml:"item"`
+}
+
+type SearchResponse struct {
+	Channel Channel `xml:"channel"`
+}
+
+func (tc TorznabClient) Search(query url.Values) ([]torznab_client.Torz, error) {
+	params := &torznab_client.Ctx{}
+	params.Query = &query
+	var resp torznab_client.Response[SearchResponse]
+	_, err := tc.Client.Request(http.MethodGet, "/api", params, &resp)
+	if err != nil {
+		return nil, err
+	}
+	items := resp.Data.Channel.Items
+	result := make([]torznab_client.Torz, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		if item.IsEmpty() {
+			continue
+		}
+		torz := item.ToTorz()
+		if torz.Indexer == "" {
+			torz.Indexer = GetIndexerName(tc.id)
+		}
+		result = append(result, *torz)
+	}
+	return result, nil
+}
+
+func (c *Client) GetTorznabClient(id string) *TorznabClient {
+	var client TorznabClient
+	if c.torznabClientById.Get(id, &client) {
+		return &client
+	}
+	tc := torznab_client.NewClient(&torznab_client.ClientConfig{
+		BaseURL:    c.BaseURL.JoinPath("/" + id).String(),
+		HTTPClient: c.HTTPClient,
+		APIKey:     c.apiKey,
+		UserAgent:  c.userAgent,
+	})
+	client = TorznabClient{Client: tc, id: id}
+	c.torznabClientById.Add(id, client)
+	return &client
+}
+
+func GetIndexerName(id string) string {
+	return "Prowlarr #" + id
+}
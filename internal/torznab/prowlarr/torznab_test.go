@@ -0,0 +1,111 @@
+package prowlarr
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTorznabURL(t *testing.T) {
+	for _, test := range []struct {
+		input           string
+		expectedBase    string
+		expectedIndexer string
+		expectedEncoded string
+		shouldError     bool
+	}{
+		{
+			input:           "http://localhost:9696/1/api",
+			expectedBase:    "http://localhost:9696",
+			expectedIndexer: "1",
+			expectedEncoded: "http:localhost:9696::1",
+		},
+		{
+			input:           "https://example.com/prowlarr/12/api/",
+			expectedBase:    "https://example.com/prowlarr",
+			expectedIndexer: "12",
+			expectedEncoded: "https:example.com::12",
+		},
+		{
+			input:           "http://localhost:9696/api/v1/indexer/3/newznab",
+			expectedBase:    "http://localhost:9696",
+			expectedIndexer: "3",
+			expectedEncoded: "http:localhost:9696::3",
+		},
+		{
+			input:           "http:localhost:9696::7",
+			expectedBase:    "http://localhost:9696",
+			expectedIndexer: "7",
+			expectedEncoded: "http:localhost:9696::7",
+		},
+		{
+			input:       "http://localhost:9117/api/v2.0/indexers/all/results/torznab",
+			shouldError: true,
+		},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			u := TorznabURL(test.input)
+			err := u.Parse()
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBase, u.BaseURL)
+			assert.Equal(t, test.expectedIndexer, u.IndexerId)
+			assert.Equal(t, test.expectedEncoded, u.Encode())
+		})
+	}
+}
+
+func TestChannelItemToTorz(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:torznab="http://torznab.com/schemas/2015/feed">
+  <channel>
+    <item>
+      <title>Movie.2024.1080p.WEB-DL</title>
+      <guid>https://tracker.example/details/1</guid>
+      <prowlarrindexer id="4" type="private">TrackerX</prowlarrindexer>
+      <type>private</type>
+      <size>1234</size>
+      <grabs>5</grabs>
+      <enclosure url="http://localhost:9696/4/download?link=abc" length="1234" type="application/x-bittorrent" />
+      <torznab:attr name="seeders" value="10" />
+      <torznab:attr name="peers" value="15" />
+      <torznab:attr name="infohash" value="0123456789ABCDEF0123456789ABCDEF01234567" />
+    </item>
+  </channel>
+</rss>`
+
+	var resp SearchResponse
+	require.NoError(t, xml.Unmarshal([]byte(body), &resp))
+	require.Len(t, resp.Channel.Items, 1)
+
+	torz := resp.Channel.Items[0].ToTorz()
+	assert.Equal(t, "TrackerX", torz.Indexer)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", torz.Hash)
+	assert.Equal(t, "Movie.2024.1080p.WEB-DL", torz.Title)
+	assert.Equal(t, int64(1234), torz.Size)
+	assert.Equal(t, 10, torz.Seeders)
+	assert.Equal(t, 5, torz.Leechers)
+	assert.True(t, torz.Private)
+	assert.Equal(t, "http://localhost:9696/4/download?link=abc", torz.SourceLink)
+}
+
+func TestIsIndexerAPIURL(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected bool
+	}{
+		{"http://localhost:9696/api/v1/indexer/3/newznab", true},
+		{"https://example.com/prowlarr/api/v1/indexer/3/newznab/", true},
+		{"http://localhost:9696/1/api", false},
+		{"https://feed.example/12/api", false},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsIndexerAPIURL(test.input))
+		})
+	}
+}
@@ -23,8 +23,13 @@ type jsonChannelItemEnclosure struct {
 	Attributes ChannelItemEnclosure `json:"@attributes"`
 }
 
+const (
+	TorznabNamespace = "http://torznab.com/schemas/2015/feed"
+	NewznabNamespace = "http://www.newznab.com/DTD/2010/feeds/attributes/"
+)
+
 type ChannelItemAttribute struct {
-	XMLName xml.Name `xml:"torznab:attr" json:"-"`
+	XMLName xml.Name `json:"-"`
 	Name    string   `xml:"name,attr" json:"name"`
 	Value   string   `xml:"value,attr" json:"value"`
 }
@@ -78,7 +83,8 @@ type Channel struct {
 type RSS struct {
 	XMLName          xml.Name `xml:"rss" json:"-"`
 	AtomNamespace    string   `xml:"xmlns:atom,attr" json:"-"`
-	TorznabNamespace string   `xml:"xmlns:torznab,attr" json:"-"`
+	TorznabNamespace string   `xml:"xmlns:torznab,attr,omitempty" json:"-"`
+	NewznabNamespace string   `xml:"xmlns:newznab,attr,omitempty" json:"-"`
 	Version          string   `xml:"version,attr,omitempty" json:"-"`
 	Channel          Channel  `xml:"channel" json:"channel"`
 }
@@ -96,11 +102,13 @@ type ResultItem struct {
 	Files       int
 	GUID        string
 	Link        string
+	NZBLink     string
 	PublishDate time.Time
 	Title       string
 
 	Audio      string
 	Codec      string
+	Grabs      int
 	IMDB       string
 	InfoHash   string
 	Language   string
@@ -118,6 +126,12 @@ func (ri ResultItem) toChannelItem() ChannelItem {
 		attrs = append(attrs, ChannelItemAttribute{Name: "audio", Value: ri.Audio})
 	}
 	attrs = append(attrs, ChannelItemAttribute{Name: "category", Value: strconv.Itoa(ri.Category.ID)})
+	if ri.Files > 0 && ri.NZBLink != "" {
+		attrs = append(attrs, ChannelItemAttribute{Name: "files", Value: strconv.Itoa(ri.Files)})
+	}
+	if ri.Grabs > 0 {
+		attrs = append(attrs, ChannelItemAttribute{Name: "grabs", Value: strconv.Itoa(ri.Grabs)})
+	}
 	if ri.IMDB != "" {
 		attrs = append(attrs, ChannelItemAttribute{Name: "imdb", Value: strings.TrimPrefix(ri.IMDB, "tt")})
 	}
@@ -161,6 +175,22 @@ func (ri ResultItem) toChannelItem() ChannelItem {
 		attrs = append(attrs, ChannelItemAttribute{Name: "year", Value: strconv.Itoa(ri.Year)})
 	}
 
+	attrName := xml.Name{Local: "torznab:attr"}
+	enclosureType := "application/x-bittorrent;x-scheme-handler/magnet"
+	if ri.NZBLink != "" {
+		attrName.Local = "newznab:attr"
+		enclosureType = "application/x-nzb"
+		if ri.GUID == "" {
+			ri.GUID = ri.NZBLink
+		}
+		if ri.Link == "" {
+			ri.Link = ri.NZBLink
+		}
+	}
+	for i := range attrs {
+		attrs[i].XMLName = attrName
+	}
+
 	return ChannelItem{
 		Attributes:  attrs,
 		Category:    ri.Category.Name,
@@ -173,7 +203,7 @@ func (ri ResultItem) toChannelItem() ChannelItem {
 		Enclosure: ChannelItemEnclosure{
 			URL:    ri.Link,
 			Length: ri.Size,
-			Type:   "application/x-bittorrent;x-scheme-handler/magnet",
+			Type:   enclosureType,
 		},
 	}
 }
@@ -192,10 +222,12 @@ func (ri ResultItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 type ResultFeed struct {
 	Info  Info
 	Items []ResultItem
+	// Newznab switches the feed namespace for NZB results.
+	Newznab bool
 }
 
 func (rf ResultFeed) toRSS() RSS {
-	return RSS{
+	rss := RSS{
 		Version: "2.0",
 		Channel: Channel{
 			Category:    rf.Info.Category,
@@ -205,9 +237,14 @@ func (rf ResultFeed) toRSS() RSS {
 			Link:        rf.Info.Link,
 			Title:       rf.Info.Title,
 		},
-		AtomNamespace:    "http://www.w3.org/2005/Atom",
-		TorznabNamespace: "http://torznab.com/schemas/2015/feed",
+		AtomNamespace: "http://www.w3.org/2005/Atom",
+	}
+	if rf.Newznab {
+		rss.NewznabNamespace = NewznabNamespace
+	} else {
+		rss.TorznabNamespace = TorznabNamespace
 	}
+	return rss
 }
 
 func (rf ResultFeed) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,202 @@
+package tracker_account
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/oauth"
+	"github.com/MunifTanjim/stremthru/internal/sync/stremio_tracker"
+)
+
+const TableName = "tracker_account"
+
+func IsSupportedProvider(provider oauth.Provider) bool {
+	switch provider {
+	case oauth.ProviderAniList, oauth.ProviderMAL, oauth.ProviderSimkl:
+		return true
+	}
+	return false
+}
+
+type TrackerAccount struct {
+	Id           string
+	Provider     oauth.Provider
+	OAuthTokenId string
+	CAt          db.Timestamp
+	UAt          db.Timestamp
+
+	otok *oauth.OAuthToken
+}
+
+func (a *TrackerAccount) OAuthToken() *oauth.OAuthToken {
+	if a.otok == nil {
+		otok, err := oauth.GetOAuthTokenById(a.OAuthTokenId)
+		if err != nil || otok == nil {
+			return nil
+		}
+		a.otok = otok
+	}
+	return a.otok
+}
+
+// IsValid treats token without expiry (e.g. Simkl) as valid.
+func (a *TrackerAccount) IsValid() bool {
+	otok := a.OAuthToken()
+	if otok == nil {
+		return false
+	}
+	return otok.ExpiresAt.IsZero() || !otok.IsExpired()
+}
+
+var Column = struct {
+	Id           string
+	Provider     string
+	OAuthTokenId string
+	CAt          string
+	UAt          string
+}{
+	Id:           "id",
+	Provider:     "provider",
+	OAuthTokenId: "oauth_token_id",
+	CAt:          "cat",
+	UAt:          "uat",
+}
+
+var columns = []string{
+	Column.Id,
+	Column.Provider,
+	Column.OAuthTokenId,
+	Column.CAt,
+	Column.UAt,
+}
+
+var query_get_all = fmt.Sprintf(
+	`SELECT %s FROM %s`,
+	strings.Join(columns, ", "),
+	TableName,
+)
+
+func GetAll() ([]TrackerAccount, error) {
+	rows, err := db.Query(query_get_all)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []TrackerAccount{}
+	for rows.Next() {
+		item := TrackerAccount{}
+		if err := rows.Scan(&item.Id, &item.Provider, &item.OAuthTokenId, &item.CAt, &item.UAt); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+var query_get_by_id = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ?`,
+	strings.Join(columns, ", "),
+	TableName,
+	Column.Id,
+)
+
+func GetById(id string) (*TrackerAccount, error) {
+	row := db.QueryRow(query_get_by_id, id)
+
+	item := TrackerAccount{}
+	if err := row.Scan(&item.Id, &item.Provider, &item.OAuthTokenId, &item.CAt, &item.UAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+var query_insert = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES (?,?,?)`,
+	TableName,
+	db.JoinColumnNames(
+		Column.Id,
+		Column.Provider,
+		Column.OAuthTokenId,
+	),
+)
+
+var query_set_oauth_token_id = fmt.Sprintf(
+	`UPDATE %s SET %s = ?, %s = %s WHERE %s = ?`,
+	TableName,
+	Column.OAuthTokenId,
+	Column.UAt, db.CurrentTimestamp,
+	Column.Id,
+)
+
+// Insert uses `<provider>:<user_id>` as the account id. For existing account,
+// the oauth token is replaced, e.g. after re-authorization.
+func Insert(oauthTokenId string) (*TrackerAccount, error) {
+	otok, err := oauth.GetOAuthTokenById(oauthTokenId)
+	if err != nil {
+		return nil, err
+	}
+	if otok == nil {
+		return nil, errors.New("oauth token not found")
+	}
+	if !IsSupportedProvider(otok.Provider) {
+		return nil, errors.New("oauth token is not for supported tracker")
+	}
+
+	id := string(otok.Provider) + ":" + otok.UserId
+
+	existing, err := GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.OAuthTokenId != oauthTokenId {
+			if _, err := db.Exec(query_set_oauth_token_id, oauthTokenId, id); err != nil {
+				return nil, err
+			}
+			existing.OAuthTokenId = oauthTokenId
+			existing.UAt = db.Timestamp{Time: time.Now()}
+		}
+		existing.otok = otok
+		return existing, nil
+	}
+
+	_, err = db.Exec(query_insert, id, otok.Provider, oauthTokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrackerAccount{
+		Id:           id,
+		Provider:     otok.Provider,
+		OAuthTokenId: oauthTokenId,
+		CAt:          db.Timestamp{Time: time.Now()},
+		UAt:          db.Timestamp{Time: time.Now()},
+		otok:         otok,
+	}, nil
+}
+
+var query_delete = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ?`,
+	TableName,
+	Column.Id,
+)
+
+func Delete(id string) error {
+	if _, err := db.Exec(query_delete, id); err != nil {
+		return err
+	}
+	if err := sync_stremio_tracker.UnlinkByTrackerAccount(id); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package tracker_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/oauth"
+	"github.com/MunifTanjim/stremthru/internal/request"
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"golang.org/x/oauth2"
+)
+
+type Ctx = request.Ctx
+
+type ResponseError struct {
+	Err     string `json:"error,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e *ResponseError) Error() string {
+	ret, _ := json.Marshal(e)
+	return string(ret)
+}
+
+func (r *ResponseError) GetError(res *http.Response) error {
+	if r == nil || r.Err == "" {
+		return nil
+	}
+	return r
+}
+
+func (r *ResponseError) Unmarshal(res *http.Response, body []byte, v any) error {
+	contentType := res.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "application/json"):
+		return core.UnmarshalJSON(res.StatusCode, body, v)
+	default:
+		return errors.New("unexpected content type: " + contentType)
+	}
+}
+
+// ProviderConfig describes an OAuth protected tracker API.
+type ProviderConfig struct {
+	Name              string
+	BaseURL           string
+	OAuth             *oauth.OAuthConfig
+	TokenSourceConfig oauth.TokenSourceConfig
+	// SetHeader adds the provider specific headers, e.g. the client id.
+	SetHeader func(header *http.Header, clientId string)
+}
+
+type APIClient struct {
+	BaseURL    *url.URL
+	httpClient *http.Client
+
+	reqQuery  func(query *url.Values, params request.Context)
+	reqHeader func(header *http.Header, params request.Context)
+}
+
+func (c APIClient) Request(method, path string, params request.Context, v request.ResponseContainer) (*http.Response, error) {
+	if params == nil {
+		params = &Ctx{}
+	}
+	req, err := params.NewRequest(c.BaseURL, method, path, c.reqHeader, c.reqQuery)
+	if err != nil {
+		error := core.NewAPIError("failed to create request")
+		error.Cause = err
+		return nil, error
+	}
+	res, err := c.httpClient.Do(req)
+	err = request.ProcessResponseBody(res, err, v)
+	if err != nil {
+		error := core.NewUpstreamError("")
+		if rerr, ok := err.(*core.Error); ok {
+			error.Msg = rerr.Msg
+			error.Code = rerr.Code
+			error.StatusCode = rerr.StatusCode
+			error.UpstreamCause = rerr
+		} else {
+			error.Cause = err
+		}
+		error.InjectReq(req)
+		return res, err
+	}
+	return res, nil
+}
+
+type Provider struct {
+	conf        *ProviderConfig
+	clientCache *cache.LRUCache[APIClient]
+}
+
+func NewProvider(conf *ProviderConfig) *Provider {
+	return &Provider{
+		conf: conf,
+		clientCache: cache.NewLRUCache[APIClient](&cache.CacheConfig{
+			Lifetime: 1 * time.Hour,
+			Name:     conf.Name + ":api-client",
+		}),
+	}
+}
+
+func (p *Provider) newAPIClient(tokenSource oauth2.TokenSource) *APIClient {
+	c := &APIClient{}
+
+	c.BaseURL = util.MustParseURL(p.conf.BaseURL)
+
+	if tokenSource == nil {
+		c.httpClient = config.DefaultHTTPClient
+	} else {
+		c.httpClient = oauth2.NewClient(
+			context.WithValue(context.Background(), oauth2.HTTPClient, config.DefaultHTTPClient),
+			tokenSource,
+		)
+	}
+
+	c.reqQuery = func(query *url.Values, params request.Context) {
+	}
+
+	c.reqHeader = func(header *http.Header, params request.Context) {
+		if p.conf.SetHeader != nil {
+			p.conf.SetHeader(header, p.conf.OAuth.ClientID)
+		}
+	}
+
+	return c
+}
+
+// GetAPIClient returns a client authenticated with the oauth token.
+func (p *Provider) GetAPIClient(tokenId string) *APIClient {
+	if tokenId == "" {
+		panic("tokenId cannot be empty")
+	}
+
+	var cachedClient APIClient
+	if p.clientCache.Get(tokenId, &cachedClient) {
+		return &cachedClient
+	}
+
+	var tokenSource oauth2.TokenSource
+	if otok, _ := oauth.GetOAuthTokenById(tokenId); otok != nil {
+		tokenSource = oauth.DatabaseTokenSource(&oauth.DatabaseTokenSourceConfig{
+			OAuth:             &p.conf.OAuth.Config,
+			TokenSourceConfig: p.conf.TokenSourceConfig,
+		}, otok.ToToken())
+	}
+
+	client := p.newAPIClient(tokenSource)
+
+	p.clientCache.Add(tokenId, *client)
+
+	return client
+}
@@ -0,0 +1,50 @@
+package trakt
+
+import (
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type ScrobbleAction string
+
+const (
+	ScrobbleActionStart ScrobbleAction = "start"
+	ScrobbleActionPause ScrobbleAction = "pause"
+	ScrobbleActionStop  ScrobbleAction = "stop"
+)
+
+type ScrobbleParamsItem struct {
+	Ids ListItemIds `json:"ids"`
+}
+
+type ScrobbleParamsEpisode struct {
+	Season int `json:"season"`
+	Number int `json:"number"`
+}
+
+type ScrobbleData struct {
+	ResponseError
+	Id       int64               `json:"id"`
+	Action   ScrobbleAction      `json:"action"` // start / pause / scrobble
+	Progress float64             `json:"progress"`
+	Movie    *ListItemMovie      `json:"movie,omitempty"`
+	Episode  *MinimalItemEpisode `json:"episode,omitempty"`
+	Show     *ListItemShow       `json:"show,omitempty"`
+}
+
+type ScrobbleParams struct {
+	Ctx
+	Action   ScrobbleAction         `json:"-"`
+	Movie    *ScrobbleParamsItem    `json:"movie,omitempty"`
+	Show     *ScrobbleParamsItem    `json:"show,omitempty"`
+	Episode  *ScrobbleParamsEpisode `json:"episode,omitempty"`
+	Progress float64                `json:"progress"` // 0 - 100
+}
+
+// Scrobble reports playback progress. Trakt adds the item to history when
+// `stop` is sent with progress >= 80.
+func (c APIClient) Scrobble(params *ScrobbleParams) (request.APIResponse[ScrobbleData], error) {
+	params.JSON = params
+	response := ScrobbleData{}
+	res, err := c.Request("POST", "/scrobble/"+string(params.Action), params, &response)
+	return request.NewAPIResponse(res, response), err
+}
@@ -0,0 +1,41 @@
+package trakt
+
+import (
+	"net/url"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type FetchMovieData struct {
+	ResponseError
+	ListItemMovie
+}
+
+type FetchMovieParams struct {
+	Ctx
+	Id string // trakt id, slug or imdb id
+}
+
+func (c APIClient) FetchMovie(params *FetchMovieParams) (request.APIResponse[ListItemMovie], error) {
+	params.Query = &url.Values{"extended": []string{"full"}}
+	response := FetchMovieData{}
+	res, err := c.Request("GET", "/movies/"+params.Id, params, &response)
+	return request.NewAPIResponse(res, response.ListItemMovie), err
+}
+
+type FetchShowData struct {
+	ResponseError
+	ListItemShow
+}
+
+type FetchShowParams struct {
+	Ctx
+	Id string // trakt id, slug or imdb id
+}
+
+func (c APIClient) FetchShow(params *FetchShowParams) (request.APIResponse[ListItemShow], error) {
+	params.Query = &url.Values{"extended": []string{"full"}}
+	response := FetchShowData{}
+	res, err := c.Request("GET", "/shows/"+params.Id, params, &response)
+	return request.NewAPIResponse(res, response.ListItemShow), err
+}
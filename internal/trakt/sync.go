@@ -161,3 +161,216 @@ func (c APIClient) RemoveFromHistory(params *RemoveFromHistoryParams) (request.A
 	res, err := c.Request("POST", "/sync/history/remove", params, &response)
 	return request.NewAPIResponse(res, response), err
 }
+
+type WatchlistItem struct {
+	Rank     int            `json:"rank"`
+	Id       int64          `json:"id"`
+	ListedAt time.Time      `json:"listed_at"`
+	Notes    string         `json:"notes,omitempty"`
+	Type     ItemType       `json:"type"` // "movie" or "show"
+	Movie    *ListItemMovie `json:"movie,omitempty"`
+	Show     *ListItemShow  `json:"show,omitempty"`
+}
+
+type GetWatchlistData = []WatchlistItem
+
+type GetWatchlistParams struct {
+	Ctx
+	Type HistoryItemType
+}
+
+func (c APIClient) GetWatchlist(params *GetWatchlistParams) (request.APIResponse[GetWatchlistData], error) {
+	path := "/sync/watchlist"
+	if params.Type != "" {
+		path += "/" + string(params.Type)
+	}
+
+	response := paginatedResponseData[WatchlistItem]{}
+	res, err := c.Request("GET", path, params, &response)
+	return request.NewAPIResponse(res, response.data), err
+}
+
+type SyncListParamsItem struct {
+	Ids ListItemIds `json:"ids"`
+}
+
+type SyncListResponseCount struct {
+	Movies   int `json:"movies"`
+	Shows    int `json:"shows"`
+	Seasons  int `json:"seasons"`
+	Episodes int `json:"episodes"`
+}
+
+type SyncListResponseNotFound struct {
+	Movies   []SyncHistoryResponseNotFoundItem `json:"movies"`
+	Shows    []SyncHistoryResponseNotFoundItem `json:"shows"`
+	Seasons  []SyncHistoryResponseNotFoundItem `json:"seasons"`
+	Episodes []SyncHistoryResponseNotFoundItem `json:"episodes"`
+}
+
+type AddToWatchlistData struct {
+	ResponseError
+	Added    SyncListResponseCount    `json:"added"`
+	Existing SyncListResponseCount    `json:"existing"`
+	NotFound SyncListResponseNotFound `json:"not_found"`
+}
+
+type AddToWatchlistParams struct {
+	Ctx
+	Movies []SyncListParamsItem `json:"movies,omitempty"`
+	Shows  []SyncListParamsItem `json:"shows,omitempty"`
+}
+
+func (c APIClient) AddToWatchlist(params *AddToWatchlistParams) (request.APIResponse[AddToWatchlistData], error) {
+	params.JSON = params
+	response := AddToWatchlistData{}
+	res, err := c.Request("POST", "/sync/watchlist", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type RemoveFromWatchlistData struct {
+	ResponseError
+	Deleted  SyncListResponseCount    `json:"deleted"`
+	NotFound SyncListResponseNotFound `json:"not_found"`
+}
+
+type RemoveFromWatchlistParams struct {
+	Ctx
+	Movies []SyncListParamsItem `json:"movies,omitempty"`
+	Shows  []SyncListParamsItem `json:"shows,omitempty"`
+}
+
+func (c APIClient) RemoveFromWatchlist(params *RemoveFromWatchlistParams) (request.APIResponse[RemoveFromWatchlistData], error) {
+	params.JSON = params
+	response := RemoveFromWatchlistData{}
+	res, err := c.Request("POST", "/sync/watchlist/remove", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type CollectionItem struct {
+	CollectedAt     *time.Time     `json:"collected_at,omitempty"`      // movie
+	LastCollectedAt *time.Time     `json:"last_collected_at,omitempty"` // show
+	Movie           *ListItemMovie `json:"movie,omitempty"`
+	Show            *ListItemShow  `json:"show,omitempty"`
+}
+
+func (item CollectionItem) GetCollectedAt() time.Time {
+	if item.CollectedAt != nil {
+		return *item.CollectedAt
+	}
+	if item.LastCollectedAt != nil {
+		return *item.LastCollectedAt
+	}
+	return time.Time{}
+}
+
+type GetCollectionData = []CollectionItem
+
+type GetCollectionParams struct {
+	Ctx
+	Type HistoryItemType // movies / shows
+}
+
+func (c APIClient) GetCollection(params *GetCollectionParams) (request.APIResponse[GetCollectionData], error) {
+	path := "/sync/collection/" + string(params.Type)
+
+	response := paginatedResponseData[CollectionItem]{}
+	res, err := c.Request("GET", path, params, &response)
+	return request.NewAPIResponse(res, response.data), err
+}
+
+type SyncCollectionParamsItem struct {
+	CollectedAt *time.Time  `json:"collected_at,omitempty"`
+	Ids         ListItemIds `json:"ids"`
+}
+
+type AddToCollectionData struct {
+	ResponseError
+	Added    SyncListResponseCount    `json:"added"`
+	Updated  SyncListResponseCount    `json:"updated"`
+	Existing SyncListResponseCount    `json:"existing"`
+	NotFound SyncListResponseNotFound `json:"not_found"`
+}
+
+type AddToCollectionParams struct {
+	Ctx
+	Movies []SyncCollectionParamsItem `json:"movies,omitempty"`
+	Shows  []SyncCollectionParamsItem `json:"shows,omitempty"`
+}
+
+func (c APIClient) AddToCollection(params *AddToCollectionParams) (request.APIResponse[AddToCollectionData], error) {
+	params.JSON = params
+	response := AddToCollectionData{}
+	res, err := c.Request("POST", "/sync/collection", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type RemoveFromCollectionData struct {
+	ResponseError
+	Deleted  SyncListResponseCount    `json:"deleted"`
+	NotFound SyncListResponseNotFound `json:"not_found"`
+}
+
+type RemoveFromCollectionParams struct {
+	Ctx
+	Movies []SyncCollectionParamsItem `json:"movies,omitempty"`
+	Shows  []SyncCollectionParamsItem `json:"shows,omitempty"`
+}
+
+func (c APIClient) RemoveFromCollection(params *RemoveFromCollectionParams) (request.APIResponse[RemoveFromCollectionData], error) {
+	params.JSON = params
+	response := RemoveFromCollectionData{}
+	res, err := c.Request("POST", "/sync/collection/remove", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type RatingItem struct {
+	RatedAt time.Time      `json:"rated_at"`
+	Rating  int            `json:"rating"` // 1 - 10
+	Type    ItemType       `json:"type"`   // "movie" or "show"
+	Movie   *ListItemMovie `json:"movie,omitempty"`
+	Show    *ListItemShow  `json:"show,omitempty"`
+}
+
+type GetRatingsData = []RatingItem
+
+type GetRatingsParams struct {
+	Ctx
+	Type HistoryItemType // movies / shows
+}
+
+func (c APIClient) GetRatings(params *GetRatingsParams) (request.APIResponse[GetRatingsData], error) {
+	path := "/sync/ratings"
+	if params.Type != "" {
+		path += "/" + string(params.Type)
+	}
+
+	response := paginatedResponseData[RatingItem]{}
+	res, err := c.Request("GET", path, params, &response)
+	return request.NewAPIResponse(res, response.data), err
+}
+
+type SyncRatingsParamsItem struct {
+	RatedAt *time.Time  `json:"rated_at,omitempty"`
+	Rating  int         `json:"rating"`
+	Ids     ListItemIds `json:"ids"`
+}
+
+type AddRatingsData struct {
+	ResponseError
+	Added    SyncListResponseCount    `json:"added"`
+	NotFound SyncListResponseNotFound `json:"not_found"`
+}
+
+type AddRatingsParams struct {
+	Ctx
+	Movies []SyncRatingsParamsItem `json:"movies,omitempty"`
+	Shows  []SyncRatingsParamsItem `json:"shows,omitempty"`
+}
+
+func (c APIClient) AddRatings(params *AddRatingsParams) (request.APIResponse[AddRatingsData], error) {
+	params.JSON = params
+	response := AddRatingsData{}
+	res, err := c.Request("POST", "/sync/ratings", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
@@ -1,6 +1,7 @@
 package trakt
 
 import (
+	"errors"
 	"time"
 
 	"github.com/MunifTanjim/stremthru/internal/cache"
@@ -45,3 +46,30 @@ func GetAPIClient(tokenId string) *APIClient {
 
 	return client
 }
+
+// GetOAuthToken returns the token for `tokenId`. An expired token that can not
+// be used anymore is cleared, and reported as invalid or revoked.
+func GetOAuthToken(tokenId string) (*oauth.OAuthToken, error) {
+	otok, err := oauth.GetOAuthTokenById(tokenId)
+	if err != nil {
+		return nil, errors.New("failed to retrieve token: " + err.Error())
+	}
+	if otok != nil && otok.Provider != oauth.ProviderTraktTv {
+		otok = nil
+	}
+	if otok != nil && otok.IsExpired() {
+		settings, err := GetAPIClient(otok.Id).RetrieveSettings(&RetrieveSettingsParams{})
+		if err != nil || settings.Data.User.Ids.Slug != otok.UserId {
+			otok.AccessToken = ""
+			otok.RefreshToken = ""
+			if err := oauth.SaveOAuthToken(otok); err != nil {
+				log.Error("failed to delete trakt token", "error", err, "id", otok.Id)
+			}
+			otok = nil
+		}
+	}
+	if otok == nil {
+		return nil, errors.New("Invalid or Revoked")
+	}
+	return otok, nil
+}
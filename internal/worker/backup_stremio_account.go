@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"github.com/MunifTanjim/stremthru/internal/config"
+	stremio_account "github.com/MunifTanjim/stremthru/internal/stremio/account"
+	stremio_backup "github.com/MunifTanjim/stremthru/internal/stremio/backup"
+)
+
+func InitBackupStremioAccountWorker(conf *WorkerConfig) *Worker {
+	conf.Executor = func(w *Worker) error {
+		log := w.Log
+
+		accounts, err := stremio_account.GetAll()
+		if err != nil {
+			return err
+		}
+
+		for i := range accounts {
+			account := &accounts[i]
+
+			token, err := account.GetValidToken()
+			if err != nil {
+				log.Warn("failed to get valid token", "error", err, "account_id", account.Id)
+				continue
+			}
+
+			if err := stremio_backup.Snapshot(account.Id, token, config.Stremio.Sidekick.BackupKeepCount); err != nil {
+				log.Error("failed to backup account", "error", err, "account_id", account.Id)
+				continue
+			}
+
+			log.Debug("backed up account", "account_id", account.Id)
+		}
+
+		return nil
+	}
+
+	worker := NewWorker(conf)
+
+	return worker
+}
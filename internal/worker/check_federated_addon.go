@@ -0,0 +1,15 @@
+package worker
+
+import (
+	stremio_federated_addon "github.com/MunifTanjim/stremthru/internal/stremio/federated_addon"
+)
+
+func InitCheckFederatedAddonWorker(conf *WorkerConfig) *Worker {
+	conf.Executor = func(w *Worker) error {
+		return stremio_federated_addon.CheckAll()
+	}
+
+	worker := NewWorker(conf)
+
+	return worker
+}
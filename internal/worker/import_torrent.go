@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"os"
+	"path"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	torrent_info_importer "github.com/MunifTanjim/stremthru/internal/torrent_info/importer"
+)
+
+var importTorrentDir = path.Join(config.DataDir, "import")
+var importTorrentDoneDir = path.Join(importTorrentDir, ".done")
+
+func getPendingTorrentImports() []os.DirEntry {
+	entries, err := os.ReadDir(importTorrentDir)
+	if err != nil {
+		return nil
+	}
+	pending := []os.DirEntry{}
+	for _, entry := range entries {
+		if entry.Name()[0] == '.' {
+			continue
+		}
+		pending = append(pending, entry)
+	}
+	return pending
+}
+
+func InitImportTorrentWorker(conf *WorkerConfig) *Worker {
+	conf.Executor = func(w *Worker) error {
+		log := w.Log
+
+		if err := os.MkdirAll(importTorrentDoneDir, 0755); err != nil {
+			return err
+		}
+
+		for _, entry := range getPendingTorrentImports() {
+			entryPath := path.Join(importTorrentDir, entry.Name())
+
+			imp := torrent_info_importer.New()
+			if err := imp.ImportPath(entryPath); err != nil {
+				return err
+			}
+			if err := imp.Flush(); err != nil {
+				return err
+			}
+
+			result := imp.Result
+			log.Info("imported", "name", entry.Name(), "files", result.Files, "torrents", result.Torrents, "failed", result.Failed)
+
+			if err := os.Rename(entryPath, path.Join(importTorrentDoneDir, entry.Name())); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	worker := NewWorker(conf)
+	return worker
+}
@@ -0,0 +1,845 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/anidb"
+	"github.com/MunifTanjim/stremthru/internal/anilist"
+	"github.com/MunifTanjim/stremthru/internal/anime"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/mal"
+	"github.com/MunifTanjim/stremthru/internal/oauth"
+	"github.com/MunifTanjim/stremthru/internal/simkl"
+	stremio_account "github.com/MunifTanjim/stremthru/internal/stremio/account"
+	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
+	"github.com/MunifTanjim/stremthru/internal/stremio/cinemeta"
+	"github.com/MunifTanjim/stremthru/internal/sync/stremio_tracker"
+	tracker_account "github.com/MunifTanjim/stremthru/internal/tracker/account"
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/stremio"
+	stremio_watched_bitfield "github.com/MunifTanjim/stremthru/stremio/watched_bitfield"
+)
+
+type trackerWatchedEpisode struct {
+	season  int
+	episode int
+}
+
+// getTrackerWatchedEpisodes lists the episodes marked in the Stremio watched
+// bitfield.
+func getTrackerWatchedEpisodes(watched string, videoIds []string) ([]trackerWatchedEpisode, error) {
+	wbf, err := stremio_watched_bitfield.NewWatchedBitFieldFromString(watched, videoIds)
+	if err != nil {
+		return nil, err
+	}
+	episodes := []trackerWatchedEpisode{}
+	for _, videoId := range videoIds {
+		if !wbf.GetVideo(videoId) {
+			continue
+		}
+		parts := strings.Split(videoId, ":")
+		if len(parts) < 3 {
+			continue
+		}
+		season, episode := util.SafeParseInt(parts[1], 0), util.SafeParseInt(parts[2], 0)
+		if season < 1 || episode < 1 {
+			continue
+		}
+		episodes = append(episodes, trackerWatchedEpisode{season: season, episode: episode})
+	}
+	return episodes, nil
+}
+
+// setTrackerWatchedEpisodes marks the episodes in the Stremio watched
+// bitfield. Returns false if all of them were already marked.
+func setTrackerWatchedEpisodes(wbf *stremio_watched_bitfield.WatchedBitField, imdbId string, episodes []trackerWatchedEpisode) bool {
+	changed := false
+	for _, ep := range episodes {
+		videoId := fmt.Sprintf("%s:%d:%d", imdbId, ep.season, ep.episode)
+		if !wbf.GetVideo(videoId) {
+			wbf.SetVideo(videoId, true)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// getAnimeWatchedEpisodes translates the progress of the AniDB entry into the
+// watched TVDB episodes.
+func getAnimeWatchedEpisodes(episodeMaps anidb.AniDBTVDBEpisodeMaps, anidbId string, progress int) []trackerWatchedEpisode {
+	episodes := []trackerWatchedEpisode{}
+	for ep := 1; ep <= progress; ep++ {
+		season, tvdbEpisodes := episodeMaps.GetTVDBEpisodes(anidbId, ep)
+		for _, tvdbEpisode := range tvdbEpisodes {
+			episodes = append(episodes, trackerWatchedEpisode{season: season, episode: tvdbEpisode})
+		}
+	}
+	return episodes
+}
+
+var syncStremioTrackerLinkNow func(link *sync_stremio_tracker.SyncStremioTrackerLink)
+
+// SyncStremioTrackerLinkNow syncs the link in the background, without waiting
+// for the next run of the worker. Returns false if the worker is disabled.
+func SyncStremioTrackerLinkNow(link *sync_stremio_tracker.SyncStremioTrackerLink) bool {
+	if syncStremioTrackerLinkNow == nil {
+		return false
+	}
+	go syncStremioTrackerLinkNow(link)
+	return true
+}
+
+func InitSyncStremioTrackerWorker(conf *WorkerConfig) *Worker {
+	type watchedSeries struct {
+		imdbId   string
+		episodes []trackerWatchedEpisode
+	}
+
+	// trackerWatchedItem is a movie or the episodes of a series watched on the
+	// tracker.
+	type trackerWatchedItem struct {
+		imdbId    string
+		sType     string
+		watchedAt time.Time
+		episodes  []trackerWatchedEpisode
+	}
+
+	type Ctx struct {
+		now        time.Time
+		log        *logger.Logger
+		link       *sync_stremio_tracker.SyncStremioTrackerLink
+		isFullSync bool
+		startAt    time.Time
+
+		stremioAccount *stremio_account.StremioAccount
+		stremioClient  *stremio_api.Client
+		stremioToken   string
+		stremioMovies  []stremio_api.LibraryItem
+		stremioSeries  []watchedSeries
+
+		trackerAccount *tracker_account.TrackerAccount
+	}
+
+	createLibraryItem := func(ctx *Ctx, meta stremio.Meta, state stremio_api.LibraryItemState) stremio_api.LibraryItem {
+		return stremio_api.LibraryItem{
+			Id:          meta.Id,
+			Type:        string(meta.Type),
+			Name:        meta.Name,
+			Poster:      meta.Poster,
+			PosterShape: meta.PosterShape,
+			Background:  meta.Background,
+			Logo:        meta.Logo,
+			Year:        meta.ReleaseInfo,
+			State:       state,
+			Removed:     false,
+			Temp:        false,
+			CTime:       stremio_api.JSONTime{Time: ctx.now},
+			MTime:       stremio_api.JSONTime{Time: ctx.now},
+		}
+	}
+
+	// animeProgress is the number of episodes watched, by anime id of the
+	// tracker.
+	type animeProgress map[int]int
+
+	type animeListEntry struct {
+		progress  int
+		total     int
+		completed bool
+		updatedAt time.Time
+	}
+
+	type animeTracker struct {
+		idColumn       string
+		getList        func() (map[int]animeListEntry, error)
+		getTotal       func(id int) (int, error)
+		updateProgress func(id, progress int, completed bool) error
+	}
+
+	getAnimeId := func(tracker *animeTracker, idMap *anime.AnimeIdMap) int {
+		switch tracker.idColumn {
+		case anime.IdMapColumn.MAL:
+			return util.SafeParseInt(idMap.MAL, 0)
+		case anime.IdMapColumn.AniList:
+			return util.SafeParseInt(idMap.AniList, 0)
+		}
+		return 0
+	}
+
+	getWatchedEpisodes := func(item stremio_api.LibraryItem) ([]trackerWatchedEpisode, error) {
+		meta, err := cinemeta.FetchMeta("series", item.Id)
+		if err != nil {
+			return nil, err
+		}
+		var videoIds []string
+		for _, video := range meta.Videos {
+			videoIds = append(videoIds, video.Id)
+		}
+		return getTrackerWatchedEpisodes(item.State.Watched, videoIds)
+	}
+
+	syncToSimkl := func(ctx *Ctx) error {
+		client := simkl.GetAPIClient(ctx.trackerAccount.OAuthTokenId)
+
+		var movies []simkl.AddToHistoryParamsMovie
+		for _, item := range ctx.stremioMovies {
+			movies = append(movies, simkl.AddToHistoryParamsMovie{
+				Ids:       simkl.ItemIds{IMDB: item.Id},
+				WatchedAt: &item.State.LastWatched,
+			})
+		}
+
+		var shows []simkl.AddToHistoryParamsShow
+		for _, series := range ctx.stremioSeries {
+			episodesBySeason := map[int][]simkl.AddToHistoryParamsEpisode{}
+			for _, ep := range series.episodes {
+				episodesBySeason[ep.season] = append(episodesBySeason[ep.season], simkl.AddToHistoryParamsEpisode{
+					Number: ep.episode,
+				})
+			}
+			seasons := make([]simkl.AddToHistoryParamsSeason, 0, len(episodesBySeason))
+			for season, episodes := range episodesBySeason {
+				seasons = append(seasons, simkl.AddToHistoryParamsSeason{
+					Number:   season,
+					Episodes: episodes,
+				})
+			}
+			shows = append(shows, simkl.AddToHistoryParamsShow{
+				Ids:     simkl.ItemIds{IMDB: series.imdbId},
+				Seasons: seasons,
+			})
+		}
+
+		if len(movies) == 0 && len(shows) == 0 {
+			return nil
+		}
+
+		res, err := client.AddToHistory(&simkl.AddToHistoryParams{
+			Movies: movies,
+			Shows:  shows,
+		})
+		if err != nil {
+			return err
+		}
+
+		ctx.log.Debug("synced watched from stremio to simkl", "movies", res.Data.Added.Movies, "shows", res.Data.Added.Shows, "episodes", res.Data.Added.Episodes)
+		return nil
+	}
+
+	getSimklWatched := func(ctx *Ctx) ([]trackerWatchedItem, error) {
+		client := simkl.GetAPIClient(ctx.trackerAccount.OAuthTokenId)
+
+		items := []trackerWatchedItem{}
+
+		moviesRes, err := client.GetAllItems(&simkl.GetAllItemsParams{Type: "movies", DateFrom: ctx.startAt})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range moviesRes.Data.Movies {
+			if item.Status != "completed" || item.Movie.Ids.IMDB == "" {
+				continue
+			}
+			watched := trackerWatchedItem{imdbId: item.Movie.Ids.IMDB, sType: "movie"}
+			if item.LastWatchedAt != nil {
+				watched.watchedAt = *item.LastWatchedAt
+			}
+			items = append(items, watched)
+		}
+
+		showsRes, err := client.GetAllItems(&simkl.GetAllItemsParams{Type: "shows", DateFrom: ctx.startAt})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range showsRes.Data.Shows {
+			if item.Show.Ids.IMDB == "" {
+				continue
+			}
+			watched := trackerWatchedItem{imdbId: item.Show.Ids.IMDB, sType: "series"}
+			for _, season := range item.Seasons {
+				for _, ep := range season.Episodes {
+					watched.episodes = append(watched.episodes, trackerWatchedEpisode{season: season.Number, episode: ep.Number})
+					if ep.WatchedAt != nil && ep.WatchedAt.After(watched.watchedAt) {
+						watched.watchedAt = *ep.WatchedAt
+					}
+				}
+			}
+			if len(watched.episodes) > 0 {
+				items = append(items, watched)
+			}
+		}
+
+		return items, nil
+	}
+
+	// getAnimeProgress translates the watched Stremio items (IMDB / TVDB
+	// numbering) into the watched episode count of each anime entry.
+	getAnimeProgress := func(ctx *Ctx, tracker *animeTracker) (animeProgress, error) {
+		progress := animeProgress{}
+		updateProgress := func(id, episode int) {
+			if id > 0 && episode > progress[id] {
+				progress[id] = episode
+			}
+		}
+
+		for _, item := range ctx.stremioMovies {
+			idMaps, err := anime.GetIdMapsByIMDBId(item.Id)
+			if err != nil {
+				return nil, err
+			}
+			for i := range idMaps {
+				idMap := &idMaps[i]
+				if idMap.IMDB == item.Id && idMap.Type == anime.AnimeIdMapTypeMovie {
+					updateProgress(getAnimeId(tracker, idMap), 1)
+				}
+			}
+		}
+
+		for _, series := range ctx.stremioSeries {
+			idMaps, err := anime.GetIdMapsByIMDBId(series.imdbId)
+			if err != nil {
+				return nil, err
+			}
+			tvdbId := ""
+			idMapByAniDBId := map[string]*anime.AnimeIdMap{}
+			for i := range idMaps {
+				idMap := &idMaps[i]
+				if tvdbId == "" && idMap.IMDB == series.imdbId && idMap.TVDB != "" {
+					tvdbId = idMap.TVDB
+				}
+				if idMap.AniDB != "" {
+					idMapByAniDBId[idMap.AniDB] = idMap
+				}
+			}
+			if tvdbId == "" {
+				continue
+			}
+			episodeMaps, err := anidb.GetTVDBEpisodeMapsByTVDBId(tvdbId)
+			if err != nil {
+				return nil, err
+			}
+			if len(episodeMaps) == 0 {
+				continue
+			}
+			for _, ep := range series.episodes {
+				anidbId, anidbEpisode := episodeMaps.GetAniDBEpisode(ep.season, ep.episode)
+				if anidbId == "" || anidbEpisode < 1 {
+					continue
+				}
+				if idMap, ok := idMapByAniDBId[anidbId]; ok {
+					updateProgress(getAnimeId(tracker, idMap), anidbEpisode)
+				}
+			}
+		}
+
+		return progress, nil
+	}
+
+	syncToAnimeTracker := func(ctx *Ctx, tracker *animeTracker, entryById map[int]animeListEntry) error {
+		progress, err := getAnimeProgress(ctx, tracker)
+		if err != nil {
+			return err
+		}
+		if len(progress) == 0 {
+			return nil
+		}
+
+		updated := 0
+		for id, watched := range progress {
+			entry, exists := entryById[id]
+			if exists && (entry.completed || entry.progress >= watched) {
+				continue
+			}
+			total := entry.total
+			if !exists {
+				total, err = tracker.getTotal(id)
+				if err != nil {
+					ctx.log.Warn("failed to fetch total episodes", "error", err, "id", id)
+				}
+			}
+			if total > 0 && watched > total {
+				watched = total
+			}
+			completed := total > 0 && watched == total
+			if err := tracker.updateProgress(id, watched, completed); err != nil {
+				return err
+			}
+			updated++
+		}
+
+		ctx.log.Debug("synced watched from stremio to tracker", "count", updated)
+		return nil
+	}
+
+	// getAnimeWatched translates the progress of the anime entries changed
+	// since the last sync into the watched IMDB movies and episodes.
+	getAnimeWatched := func(ctx *Ctx, tracker *animeTracker, entryById map[int]animeListEntry) ([]trackerWatchedItem, error) {
+		var ids []string
+		for id, entry := range entryById {
+			if entry.progress < 1 {
+				continue
+			}
+			if !ctx.isFullSync && !entry.updatedAt.After(ctx.startAt) {
+				continue
+			}
+			ids = append(ids, fmt.Sprint(id))
+		}
+		if len(ids) == 0 {
+			return nil, nil
+		}
+
+		idMaps, err := anime.GetIdMapsByIds(tracker.idColumn, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		itemByImdbId := map[string]*trackerWatchedItem{}
+		episodeMapsByTVDBId := map[string]anidb.AniDBTVDBEpisodeMaps{}
+		for i := range idMaps {
+			idMap := &idMaps[i]
+			if idMap.IMDB == "" {
+				continue
+			}
+			entry := entryById[getAnimeId(tracker, idMap)]
+
+			if idMap.Type == anime.AnimeIdMapTypeMovie {
+				itemByImdbId[idMap.IMDB] = &trackerWatchedItem{imdbId: idMap.IMDB, sType: "movie", watchedAt: entry.updatedAt}
+				continue
+			}
+
+			if idMap.TVDB == "" || idMap.AniDB == "" {
+				continue
+			}
+			episodeMaps, ok := episodeMapsByTVDBId[idMap.TVDB]
+			if !ok {
+				episodeMaps, err = anidb.GetTVDBEpisodeMapsByTVDBId(idMap.TVDB)
+				if err != nil {
+					return nil, err
+				}
+				episodeMapsByTVDBId[idMap.TVDB] = episodeMaps
+			}
+			episodes := getAnimeWatchedEpisodes(episodeMaps, idMap.AniDB, entry.progress)
+			if len(episodes) == 0 {
+				continue
+			}
+			item, ok := itemByImdbId[idMap.IMDB]
+			if !ok {
+				item = &trackerWatchedItem{imdbId: idMap.IMDB, sType: "series"}
+				itemByImdbId[idMap.IMDB] = item
+			}
+			item.episodes = append(item.episodes, episodes...)
+			if entry.updatedAt.After(item.watchedAt) {
+				item.watchedAt = entry.updatedAt
+			}
+		}
+
+		items := make([]trackerWatchedItem, 0, len(itemByImdbId))
+		for _, item := range itemByImdbId {
+			items = append(items, *item)
+		}
+		return items, nil
+	}
+
+	syncToStremio := func(ctx *Ctx, items []trackerWatchedItem) error {
+		if len(items) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(items))
+		for i := range items {
+			ids[i] = items[i].imdbId
+		}
+		res, err := ctx.stremioClient.GetAllLibraryItems(&stremio_api.GetAllLibraryItemsParams{
+			Ctx: stremio_api.Ctx{APIKey: ctx.stremioToken},
+			Ids: ids,
+		})
+		if err != nil {
+			return err
+		}
+		stremioItemById := map[string]stremio_api.LibraryItem{}
+		for _, item := range res.Data {
+			stremioItemById[item.Id] = item
+		}
+
+		var itemsToUpdate []stremio_api.LibraryItem
+		for _, item := range items {
+			libraryItem, exists := stremioItemById[item.imdbId]
+			if exists && libraryItem.Type != item.sType {
+				continue
+			}
+
+			switch item.sType {
+			case "movie":
+				if exists && libraryItem.State.TimesWatched > 0 {
+					continue
+				}
+				if exists {
+					libraryItem.MTime = stremio_api.JSONTime{Time: ctx.now}
+				} else {
+					meta, err := cinemeta.FetchMeta("movie", item.imdbId)
+					if err != nil {
+						ctx.log.Warn("failed to fetch meta", "error", err, "id", item.imdbId)
+						continue
+					}
+					libraryItem = createLibraryItem(ctx, meta, stremio_api.LibraryItemState{})
+				}
+				libraryItem.State.TimesWatched = 1
+
+			case "series":
+				meta, err := cinemeta.FetchMeta("series", item.imdbId)
+				if err != nil {
+					ctx.log.Warn("failed to fetch meta", "error", err, "id", item.imdbId)
+					continue
+				}
+				var videoIds []string
+				for _, video := range meta.Videos {
+					videoIds = append(videoIds, video.Id)
+				}
+
+				var wbf *stremio_watched_bitfield.WatchedBitField
+				if exists && libraryItem.State.Watched != "" {
+					if wbf, err = stremio_watched_bitfield.NewWatchedBitFieldFromString(libraryItem.State.Watched, videoIds); err != nil {
+						return err
+					}
+				} else {
+					wbf = stremio_watched_bitfield.NewWatchedBitField(stremio_watched_bitfield.NewBitField8(len(videoIds)), videoIds)
+				}
+
+				if !setTrackerWatchedEpisodes(wbf, item.imdbId, item.episodes) {
+					continue
+				}
+				watchedStr, err := wbf.String()
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					libraryItem.MTime = stremio_api.JSONTime{Time: ctx.now}
+				} else {
+					libraryItem = createLibraryItem(ctx, meta, stremio_api.LibraryItemState{})
+				}
+				libraryItem.State.Watched = watchedStr
+				if videoId := wbf.GetNextUnwatchedVideoId(); videoId != libraryItem.State.VideoId {
+					libraryItem.State.VideoId = videoId
+					libraryItem.State.TimeOffset = 0
+				}
+
+			default:
+				continue
+			}
+
+			if item.watchedAt.After(libraryItem.State.LastWatched) {
+				libraryItem.State.LastWatched = item.watchedAt
+			}
+			itemsToUpdate = append(itemsToUpdate, libraryItem)
+		}
+
+		if len(itemsToUpdate) == 0 {
+			return nil
+		}
+
+		_, err = ctx.stremioClient.UpdateLibraryItems(&stremio_api.UpdateLibraryItemsParams{
+			Ctx:     stremio_api.Ctx{APIKey: ctx.stremioToken},
+			Changes: itemsToUpdate,
+		})
+		if err != nil {
+			return err
+		}
+
+		ctx.log.Debug("synced watched from tracker to stremio", "count", len(itemsToUpdate))
+		return nil
+	}
+
+	getMALTracker := func(ctx *Ctx) *animeTracker {
+		client := mal.GetAPIClient(ctx.trackerAccount.OAuthTokenId)
+		return &animeTracker{
+			idColumn: anime.IdMapColumn.MAL,
+			getList: func() (map[int]animeListEntry, error) {
+				entryById := map[int]animeListEntry{}
+				offset := 0
+				for {
+					res, err := client.GetMyAnimeList(&mal.GetMyAnimeListParams{Offset: offset})
+					if err != nil {
+						return nil, err
+					}
+					for _, item := range res.Data.Data {
+						updatedAt, _ := time.Parse(time.RFC3339, item.ListStatus.UpdatedAt)
+						entryById[item.Node.Id] = animeListEntry{
+							progress:  item.ListStatus.NumEpisodesWatched,
+							total:     item.Node.NumEpisodes,
+							completed: item.ListStatus.Status == mal.AnimeListStatusCompleted,
+							updatedAt: updatedAt,
+						}
+					}
+					if res.Data.Paging.Next == "" || len(res.Data.Data) == 0 {
+						break
+					}
+					offset += len(res.Data.Data)
+				}
+				return entryById, nil
+			},
+			getTotal: func(id int) (int, error) {
+				res, err := client.GetAnime(&mal.GetAnimeParams{Id: id})
+				if err != nil {
+					return 0, err
+				}
+				return res.Data.NumEpisodes, nil
+			},
+			updateProgress: func(id, progress int, completed bool) error {
+				status := mal.AnimeListStatusWatching
+				if completed {
+					status = mal.AnimeListStatusCompleted
+				}
+				_, err := client.UpdateMyListStatus(&mal.UpdateMyListStatusParams{
+					Id:                 id,
+					Status:             status,
+					NumWatchedEpisodes: progress,
+				})
+				return err
+			},
+		}
+	}
+
+	getAniListTracker := func(ctx *Ctx) (*animeTracker, error) {
+		client := anilist.GetViewerClient(ctx.trackerAccount.OAuthTokenId)
+		if client == nil {
+			return nil, fmt.Errorf("anilist token not found")
+		}
+		return &animeTracker{
+			idColumn: anime.IdMapColumn.AniList,
+			getList: func() (map[int]animeListEntry, error) {
+				entries, err := client.FetchAnimeList()
+				if err != nil {
+					return nil, err
+				}
+				entryById := make(map[int]animeListEntry, len(entries))
+				for id, entry := range entries {
+					entryById[id] = animeListEntry{
+						progress:  entry.Progress,
+						total:     entry.Media.Episodes,
+						completed: entry.Status == anilist.MediaListStatusCompleted,
+						updatedAt: time.Unix(entry.UpdatedAt, 0),
+					}
+				}
+				return entryById, nil
+			},
+			getTotal: anilist.FetchMediaEpisodes,
+			updateProgress: func(id, progress int, completed bool) error {
+				status := anilist.MediaListStatusCurrent
+				if completed {
+					status = anilist.MediaListStatusCompleted
+				}
+				return client.SaveMediaListEntry(id, progress, status)
+			},
+		}, nil
+	}
+
+	prepareCtx := func(link *sync_stremio_tracker.SyncStremioTrackerLink, log *logger.Logger) (*Ctx, error) {
+		ctx := &Ctx{
+			log:  log,
+			link: link,
+		}
+
+		stremioAccount, err := stremio_account.GetById(link.StremioAccountId)
+		if err != nil || stremioAccount == nil {
+			return nil, fmt.Errorf("stremio account not found: %w", err)
+		}
+		ctx.stremioAccount = stremioAccount
+
+		trackerAccount, err := tracker_account.GetById(link.TrackerAccountId)
+		if err != nil || trackerAccount == nil {
+			return nil, fmt.Errorf("tracker account not found: %w", err)
+		}
+		ctx.trackerAccount = trackerAccount
+
+		stremioToken, err := stremioAccount.GetValidToken()
+		if err != nil {
+			return nil, err
+		}
+		ctx.stremioToken = stremioToken
+
+		ctx.stremioClient = stremio_api.NewClient(&stremio_api.ClientConfig{})
+
+		ctx.now = time.Now()
+
+		return ctx, nil
+	}
+
+	fetchStremioWatched := func(ctx *Ctx) error {
+		var stremioItemIds []string
+		if !ctx.isFullSync {
+			tsRes, err := ctx.stremioClient.GetAllLibraryItemTimestamps(&stremio_api.GetAllLibraryItemTimestampsParams{Ctx: stremio_api.Ctx{APIKey: ctx.stremioToken}})
+			if err != nil {
+				return err
+			}
+			for _, ts := range tsRes.Data {
+				if !strings.HasPrefix(ts.Id, "tt") {
+					continue
+				}
+				if ts.ModifiedAt.After(ctx.startAt) {
+					stremioItemIds = append(stremioItemIds, ts.Id)
+				}
+			}
+			if len(stremioItemIds) == 0 {
+				return nil
+			}
+		}
+
+		stremioLibItemsRes, err := ctx.stremioClient.GetAllLibraryItems(&stremio_api.GetAllLibraryItemsParams{
+			Ctx: stremio_api.Ctx{APIKey: ctx.stremioToken},
+			Ids: stremioItemIds,
+		})
+		if err != nil {
+			return err
+		}
+		for _, item := range stremioLibItemsRes.Data {
+			if !strings.HasPrefix(item.Id, "tt") {
+				continue
+			}
+			switch item.Type {
+			case "movie":
+				if item.State.TimesWatched > 0 {
+					ctx.stremioMovies = append(ctx.stremioMovies, item)
+				}
+			case "series":
+				if item.State.Watched == "" {
+					continue
+				}
+				episodes, err := getWatchedEpisodes(item)
+				if err != nil {
+					return fmt.Errorf("failed to read watched episodes of %s: %w", item.Id, err)
+				}
+				if len(episodes) > 0 {
+					ctx.stremioSeries = append(ctx.stremioSeries, watchedSeries{
+						imdbId:   item.Id,
+						episodes: episodes,
+					})
+				}
+			}
+		}
+
+		ctx.log.Debug("fetched stremio items", "movies", len(ctx.stremioMovies), "series", len(ctx.stremioSeries))
+		return nil
+	}
+
+	syncWatched := func(link *sync_stremio_tracker.SyncStremioTrackerLink, log *logger.Logger) error {
+		log = log.With(
+			"stremio_account_id", link.StremioAccountId,
+			"tracker_account_id", link.TrackerAccountId,
+		)
+
+		ctx, err := prepareCtx(link, log)
+		if err != nil {
+			return err
+		}
+
+		if !ctx.trackerAccount.IsValid() {
+			log.Warn("skipping, invalid tracker account")
+			return nil
+		}
+
+		if link.SyncState.Watched.LastSyncedAt != nil {
+			ctx.startAt = *link.SyncState.Watched.LastSyncedAt
+		}
+
+		ctx.isFullSync = ctx.startAt.IsZero()
+
+		log.Debug("starting watched sync", "is_full_sync", ctx.isFullSync, "start_at", ctx.startAt)
+
+		direction := link.SyncConfig.Watched.Direction
+
+		if direction.ShouldSyncToTracker() {
+			if err := fetchStremioWatched(ctx); err != nil {
+				return err
+			}
+		}
+
+		var trackerWatched []trackerWatchedItem
+		switch ctx.trackerAccount.Provider {
+		case oauth.ProviderSimkl:
+			if direction.ShouldSyncToTracker() {
+				err = syncToSimkl(ctx)
+			}
+			if err == nil && direction.ShouldSyncToStremio() {
+				trackerWatched, err = getSimklWatched(ctx)
+			}
+		case oauth.ProviderMAL, oauth.ProviderAniList:
+			var tracker *animeTracker
+			if ctx.trackerAccount.Provider == oauth.ProviderMAL {
+				tracker = getMALTracker(ctx)
+			} else {
+				tracker, err = getAniListTracker(ctx)
+			}
+			var entryById map[int]animeListEntry
+			if err == nil {
+				entryById, err = tracker.getList()
+			}
+			if err == nil && direction.ShouldSyncToTracker() {
+				err = syncToAnimeTracker(ctx, tracker, entryById)
+			}
+			if err == nil && direction.ShouldSyncToStremio() {
+				trackerWatched, err = getAnimeWatched(ctx, tracker, entryById)
+			}
+		default:
+			err = fmt.Errorf("unsupported tracker: %s", ctx.trackerAccount.Provider)
+		}
+		if err != nil {
+			log.Error("failed to sync watched between stremio and tracker", "error", err, "provider", ctx.trackerAccount.Provider)
+			return err
+		}
+
+		if direction.ShouldSyncToStremio() {
+			if err := syncToStremio(ctx, trackerWatched); err != nil {
+				log.Error("failed to sync watched from tracker to stremio", "error", err, "provider", ctx.trackerAccount.Provider)
+				return err
+			}
+		}
+
+		link.SyncState.Watched.LastSyncedAt = &ctx.now
+		sync_stremio_tracker.SetSyncState(link.StremioAccountId, link.TrackerAccountId, link.SyncState)
+		return nil
+	}
+
+	syncLink := func(link *sync_stremio_tracker.SyncStremioTrackerLink, log *logger.Logger) error {
+		if link.SyncConfig.Watched.Direction.IsDisabled() {
+			return nil
+		}
+		lock := lockSyncLink(conf.Name, link.StremioAccountId, link.TrackerAccountId)
+		if lock == nil {
+			log.Info("skipping, link is already being synced", "stremio_account_id", link.StremioAccountId, "tracker_account_id", link.TrackerAccountId)
+			return nil
+		}
+		defer lock.Release()
+		return syncWatched(link, log)
+	}
+
+	conf.Executor = func(w *Worker) error {
+		log := w.Log
+
+		links, err := sync_stremio_tracker.GetAll()
+		if err != nil {
+			return err
+		}
+
+		for _, link := range links {
+			if err := syncLink(&link, log); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	worker := NewWorker(conf)
+
+	if worker != nil {
+		syncStremioTrackerLinkNow = func(link *sync_stremio_tracker.SyncStremioTrackerLink) {
+			if err := syncLink(link, worker.Log); err != nil {
+				worker.Log.Error("failed to sync link", "error", err, "stremio_account_id", link.StremioAccountId, "tracker_account_id", link.TrackerAccountId)
+			}
+		}
+	}
+
+	return worker
+}
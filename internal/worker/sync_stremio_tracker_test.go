@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/internal/anidb"
+	stremio_watched_bitfield "github.com/MunifTanjim/stremthru/stremio/watched_bitfield"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackerWatchedEpisodes(t *testing.T) {
+	imdbId := "tt0000001"
+	videoIds := []string{
+		"tt0000001:0:1",
+		"tt0000001:1:1",
+		"tt0000001:1:2",
+		"tt0000001:2:1",
+	}
+
+	wbf := stremio_watched_bitfield.NewWatchedBitField(stremio_watched_bitfield.NewBitField8(len(videoIds)), videoIds)
+	changed := setTrackerWatchedEpisodes(wbf, imdbId, []trackerWatchedEpisode{
+		{season: 0, episode: 1},
+		{season: 1, episode: 2},
+		{season: 2, episode: 1},
+	})
+	assert.True(t, changed)
+	assert.False(t, setTrackerWatchedEpisodes(wbf, imdbId, []trackerWatchedEpisode{{season: 1, episode: 2}}))
+
+	watched, err := wbf.String()
+	assert.NoError(t, err)
+
+	episodes, err := getTrackerWatchedEpisodes(watched, videoIds)
+	assert.NoError(t, err)
+	assert.Equal(t, []trackerWatchedEpisode{
+		{season: 1, episode: 2},
+		{season: 2, episode: 1},
+	}, episodes)
+
+	_, err = getTrackerWatchedEpisodes("invalid", videoIds)
+	assert.Error(t, err)
+}
+
+func TestGetAnimeWatchedEpisodes(t *testing.T) {
+	episodeMaps := anidb.AniDBTVDBEpisodeMaps{
+		{AniDBId: "19", TVDBId: "83692", AniDBSeason: 1, TVDBSeason: 1, Start: 1, End: 2},
+		{AniDBId: "19", TVDBId: "83692", AniDBSeason: 1, TVDBSeason: 2, Start: 3, End: 4, Offset: -2},
+		{AniDBId: "200", TVDBId: "2000", AniDBSeason: 1, TVDBSeason: 3, Map: anidb.AniDBTVDBEpisodeMapMap{1: {1, 2}}},
+	}
+
+	assert.Equal(t, []trackerWatchedEpisode{
+		{season: 1, episode: 1},
+		{season: 1, episode: 2},
+		{season: 2, episode: 1},
+	}, getAnimeWatchedEpisodes(episodeMaps, "19", 3))
+
+	assert.Equal(t, []trackerWatchedEpisode{
+		{season: 3, episode: 1},
+		{season: 3, episode: 2},
+	}, getAnimeWatchedEpisodes(episodeMaps, "200", 1))
+
+	assert.Empty(t, getAnimeWatchedEpisodes(episodeMaps, "19", 0))
+	assert.Empty(t, getAnimeWatchedEpisodes(episodeMaps, "300", 2))
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,6 +20,100 @@ import (
 	stremio_watched_bitfield "github.com/MunifTanjim/stremthru/stremio/watched_bitfield"
 )
 
+type traktLibraryTarget string
+
+const (
+	traktLibraryTargetWatchlist  traktLibraryTarget = "watchlist"
+	traktLibraryTargetCollection traktLibraryTarget = "collection"
+)
+
+func isLibraryItemWatched(item *stremio_api.LibraryItem) bool {
+	return item.State.TimesWatched > 0 || item.State.FlaggedWatched > 0 || item.State.Watched != ""
+}
+
+// includes tells if the saved Stremio library item belongs to the target:
+// the watchlist gets the items not watched yet, the collection gets all of
+// them, since the library is what the user has in Stremio.
+func (target traktLibraryTarget) includes(item *stremio_api.LibraryItem) bool {
+	switch target {
+	case traktLibraryTargetWatchlist:
+		return !isLibraryItemWatched(item)
+	case traktLibraryTargetCollection:
+		return true
+	}
+	return false
+}
+
+type traktLibraryItem struct {
+	imdbId  string
+	sType   string // movie / series
+	addedAt time.Time
+}
+
+type traktLibraryChanges struct {
+	moviesToAdd    []string
+	showsToAdd     []string
+	moviesToRemove []string
+	showsToRemove  []string
+}
+
+// getTraktLibraryChanges lists the changes in Stremio library to apply to the
+// Trakt watchlist or collection. Removals are only propagated on incremental
+// sync, for items removed after they were added to Trakt.
+func getTraktLibraryChanges(target traktLibraryTarget, stremioItemById map[string]stremio_api.LibraryItem, traktItemById map[string]traktLibraryItem, startAt time.Time) traktLibraryChanges {
+	isFullSync := startAt.IsZero()
+	changes := traktLibraryChanges{}
+	for id, item := range stremioItemById {
+		if item.Temp || (!isFullSync && !item.MTime.After(startAt)) {
+			continue
+		}
+		traktItem, inTrakt := traktItemById[id]
+		if !item.Removed {
+			if inTrakt || !target.includes(&item) {
+				continue
+			}
+			if item.Type == "movie" {
+				changes.moviesToAdd = append(changes.moviesToAdd, id)
+			} else {
+				changes.showsToAdd = append(changes.showsToAdd, id)
+			}
+		} else if !isFullSync && inTrakt && item.MTime.After(traktItem.addedAt) {
+			if item.Type == "movie" {
+				changes.moviesToRemove = append(changes.moviesToRemove, id)
+			} else {
+				changes.showsToRemove = append(changes.showsToRemove, id)
+			}
+		}
+	}
+	slices.Sort(changes.moviesToAdd)
+	slices.Sort(changes.showsToAdd)
+	slices.Sort(changes.moviesToRemove)
+	slices.Sort(changes.showsToRemove)
+	return changes
+}
+
+// Stremio only has like and love, they are mapped to "Great" and "Totally
+// Ninja!" on Trakt.
+func likeStatusToTraktRating(status stremio_api.LikeStatus) int {
+	switch status {
+	case stremio_api.LikeStatusLoved:
+		return 10
+	case stremio_api.LikeStatusLiked:
+		return 8
+	}
+	return 0
+}
+
+func traktRatingToLikeStatus(rating int) stremio_api.LikeStatus {
+	switch {
+	case rating >= 9:
+		return stremio_api.LikeStatusLoved
+	case rating >= 7:
+		return stremio_api.LikeStatusLiked
+	}
+	return stremio_api.LikeStatusNone
+}
+
 func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 	type Ctx struct {
 		now        time.Time
@@ -508,12 +603,7 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 		return nil
 	}
 
-	syncWatched := func(link *sync_stremio_trakt.SyncStremioTraktLink, log *logger.Logger) error {
-		log = log.With(
-			"stremio_account_id", link.StremioAccountId,
-			"trakt_account_id", link.TraktAccountId,
-		)
-
+	prepareCtx := func(link *sync_stremio_trakt.SyncStremioTraktLink, log *logger.Logger) (*Ctx, error) {
 		ctx := &Ctx{
 			log:  log,
 			link: link,
@@ -521,19 +611,19 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 
 		stremioAccount, err := stremio_account.GetById(link.StremioAccountId)
 		if err != nil || stremioAccount == nil {
-			return fmt.Errorf("stremio account not found: %w", err)
+			return nil, fmt.Errorf("stremio account not found: %w", err)
 		}
 		ctx.stremioAccount = stremioAccount
 
 		traktAccount, err := trakt_account.GetById(link.TraktAccountId)
 		if err != nil || traktAccount == nil {
-			return fmt.Errorf("trakt account not found: %w", err)
+			return nil, fmt.Errorf("trakt account not found: %w", err)
 		}
 		ctx.traktAccount = traktAccount
 
 		stremioToken, err := stremioAccount.GetValidToken()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		ctx.stremioToken = stremioToken
 
@@ -543,6 +633,21 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 
 		ctx.now = time.Now()
 
+		return ctx, nil
+	}
+
+	syncWatched := func(link *sync_stremio_trakt.SyncStremioTraktLink, log *logger.Logger) error {
+		log = log.With(
+			"stremio_account_id", link.StremioAccountId,
+			"trakt_account_id", link.TraktAccountId,
+		)
+
+		ctx, err := prepareCtx(link, log)
+		if err != nil {
+			return err
+		}
+		stremioToken := ctx.stremioToken
+
 		var startAt time.Time
 		if link.SyncState.Watched.LastSyncedAt != nil {
 			startAt = *link.SyncState.Watched.LastSyncedAt
@@ -643,6 +748,354 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 		return nil
 	}
 
+	getTraktLibraryItems := func(ctx *Ctx, target traktLibraryTarget) ([]traktLibraryItem, error) {
+		items := []traktLibraryItem{}
+		switch target {
+		case traktLibraryTargetWatchlist:
+			res, err := ctx.traktClient.GetWatchlist(&trakt.GetWatchlistParams{})
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range res.Data {
+				switch item.Type {
+				case trakt.ItemTypeMovie:
+					if item.Movie != nil && item.Movie.Ids.IMDB != "" {
+						items = append(items, traktLibraryItem{item.Movie.Ids.IMDB, "movie", item.ListedAt})
+					}
+				case trakt.ItemTypeShow:
+					if item.Show != nil && item.Show.Ids.IMDB != "" {
+						items = append(items, traktLibraryItem{item.Show.Ids.IMDB, "series", item.ListedAt})
+					}
+				}
+			}
+		case traktLibraryTargetCollection:
+			for _, itemType := range []trakt.HistoryItemType{trakt.HistoryItemTypeMovies, trakt.HistoryItemTypeShows} {
+				res, err := ctx.traktClient.GetCollection(&trakt.GetCollectionParams{Type: itemType})
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range res.Data {
+					if item.Movie != nil && item.Movie.Ids.IMDB != "" {
+						items = append(items, traktLibraryItem{item.Movie.Ids.IMDB, "movie", item.GetCollectedAt()})
+					} else if item.Show != nil && item.Show.Ids.IMDB != "" {
+						items = append(items, traktLibraryItem{item.Show.Ids.IMDB, "series", item.GetCollectedAt()})
+					}
+				}
+			}
+		}
+		return items, nil
+	}
+
+	updateTraktLibrary := func(ctx *Ctx, target traktLibraryTarget, remove bool, movieIds, showIds []string) error {
+		switch target {
+		case traktLibraryTargetWatchlist:
+			var movies, shows []trakt.SyncListParamsItem
+			for _, id := range movieIds {
+				movies = append(movies, trakt.SyncListParamsItem{Ids: trakt.ListItemIds{IMDB: id}})
+			}
+			for _, id := range showIds {
+				shows = append(shows, trakt.SyncListParamsItem{Ids: trakt.ListItemIds{IMDB: id}})
+			}
+			if remove {
+				_, err := ctx.traktClient.RemoveFromWatchlist(&trakt.RemoveFromWatchlistParams{Movies: movies, Shows: shows})
+				return err
+			}
+			_, err := ctx.traktClient.AddToWatchlist(&trakt.AddToWatchlistParams{Movies: movies, Shows: shows})
+			return err
+		case traktLibraryTargetCollection:
+			var movies, shows []trakt.SyncCollectionParamsItem
+			for _, id := range movieIds {
+				movies = append(movies, trakt.SyncCollectionParamsItem{Ids: trakt.ListItemIds{IMDB: id}})
+			}
+			for _, id := range showIds {
+				shows = append(shows, trakt.SyncCollectionParamsItem{Ids: trakt.ListItemIds{IMDB: id}})
+			}
+			if remove {
+				_, err := ctx.traktClient.RemoveFromCollection(&trakt.RemoveFromCollectionParams{Movies: movies, Shows: shows})
+				return err
+			}
+			_, err := ctx.traktClient.AddToCollection(&trakt.AddToCollectionParams{Movies: movies, Shows: shows})
+			return err
+		}
+		return nil
+	}
+
+	// syncLibrary syncs the Stremio library (saved items) with the Trakt
+	// watchlist (not watched yet) or collection (all of them). Removals are only
+	// propagated from Stremio to Trakt, since Trakt does not tell when an item
+	// was removed.
+	syncLibrary := func(link *sync_stremio_trakt.SyncStremioTraktLink, target traktLibraryTarget, log *logger.Logger) error {
+		log = log.With(
+			"stremio_account_id", link.StremioAccountId,
+			"trakt_account_id", link.TraktAccountId,
+			"target", target,
+		)
+
+		ctx, err := prepareCtx(link, log)
+		if err != nil {
+			return err
+		}
+
+		var direction sync_stremio_trakt.SyncDirection
+		var lastSyncedAt *time.Time
+		switch target {
+		case traktLibraryTargetWatchlist:
+			direction = link.SyncConfig.Watchlist.Direction
+			lastSyncedAt = link.SyncState.Watchlist.LastSyncedAt
+		case traktLibraryTargetCollection:
+			direction = link.SyncConfig.Collection.Direction
+			lastSyncedAt = link.SyncState.Collection.LastSyncedAt
+		}
+
+		var startAt time.Time
+		if lastSyncedAt != nil {
+			startAt = *lastSyncedAt
+		}
+		ctx.isFullSync = startAt.IsZero()
+
+		log.Debug("starting library sync", "is_full_sync", ctx.isFullSync, "start_at", startAt)
+
+		stremioLibItemsRes, err := ctx.stremioClient.GetAllLibraryItems(&stremio_api.GetAllLibraryItemsParams{
+			Ctx: stremio_api.Ctx{APIKey: ctx.stremioToken},
+		})
+		if err != nil {
+			return err
+		}
+		stremioItemById := map[string]stremio_api.LibraryItem{}
+		for _, item := range stremioLibItemsRes.Data {
+			if !strings.HasPrefix(item.Id, "tt") || (item.Type != "movie" && item.Type != "series") {
+				continue
+			}
+			stremioItemById[item.Id] = item
+		}
+
+		traktItems, err := getTraktLibraryItems(ctx, target)
+		if err != nil {
+			return err
+		}
+		traktItemById := make(map[string]traktLibraryItem, len(traktItems))
+		for _, item := range traktItems {
+			traktItemById[item.imdbId] = item
+		}
+
+		log.Debug("fetched library items", "stremio", len(stremioItemById), "trakt", len(traktItemById))
+
+		if direction.ShouldSyncToTrakt() {
+			changes := getTraktLibraryChanges(target, stremioItemById, traktItemById, startAt)
+			moviesToAdd, showsToAdd := changes.moviesToAdd, changes.showsToAdd
+			moviesToRemove, showsToRemove := changes.moviesToRemove, changes.showsToRemove
+
+			if len(moviesToAdd) > 0 || len(showsToAdd) > 0 {
+				if err := updateTraktLibrary(ctx, target, false, moviesToAdd, showsToAdd); err != nil {
+					log.Error("failed to add items from stremio to trakt", "error", err)
+					return err
+				}
+				log.Debug("added items from stremio to trakt", "movies", len(moviesToAdd), "shows", len(showsToAdd))
+			}
+			if len(moviesToRemove) > 0 || len(showsToRemove) > 0 {
+				if err := updateTraktLibrary(ctx, target, true, moviesToRemove, showsToRemove); err != nil {
+					log.Error("failed to remove items from trakt", "error", err)
+					return err
+				}
+				for _, id := range append(moviesToRemove, showsToRemove...) {
+					delete(traktItemById, id)
+				}
+				log.Debug("removed items from trakt", "movies", len(moviesToRemove), "shows", len(showsToRemove))
+			}
+		}
+
+		if direction.ShouldSyncToStremio() {
+			var itemsToUpdate []stremio_api.LibraryItem
+			for id, traktItem := range traktItemById {
+				if !ctx.isFullSync && !traktItem.addedAt.After(startAt) {
+					continue
+				}
+				if item, exists := stremioItemById[id]; exists {
+					if !item.Removed && !item.Temp {
+						continue
+					}
+					if item.Removed && !item.Temp && item.MTime.After(traktItem.addedAt) {
+						continue
+					}
+					item.Removed = false
+					item.Temp = false
+					item.MTime = stremio_api.JSONTime{Time: ctx.now}
+					itemsToUpdate = append(itemsToUpdate, item)
+					continue
+				}
+				meta, err := cinemeta.FetchMeta(traktItem.sType, id)
+				if err != nil {
+					log.Warn("failed to fetch meta", "error", err, "id", id)
+					continue
+				}
+				itemsToUpdate = append(itemsToUpdate, createLibraryItem(ctx, meta, stremio_api.LibraryItemState{}))
+			}
+
+			if len(itemsToUpdate) > 0 {
+				_, err := ctx.stremioClient.UpdateLibraryItems(&stremio_api.UpdateLibraryItemsParams{
+					Ctx:     stremio_api.Ctx{APIKey: ctx.stremioToken},
+					Changes: itemsToUpdate,
+				})
+				if err != nil {
+					log.Error("failed to add items from trakt to stremio", "error", err)
+					return err
+				}
+				log.Debug("added items from trakt to stremio", "count", len(itemsToUpdate))
+			}
+		}
+
+		switch target {
+		case traktLibraryTargetWatchlist:
+			link.SyncState.Watchlist.LastSyncedAt = &ctx.now
+		case traktLibraryTargetCollection:
+			link.SyncState.Collection.LastSyncedAt = &ctx.now
+		}
+		sync_stremio_trakt.SetSyncState(link.StremioAccountId, link.TraktAccountId, link.SyncState)
+		return nil
+	}
+
+	// syncRatings syncs the Stremio likes with the Trakt ratings. Stremio does
+	// not tell when an item was liked, and the like status is fetched one item
+	// at a time, so the incremental sync only checks the library items changed
+	// since the last sync, and the full sync only checks the watched ones. An
+	// item already rated on the other side is left as is.
+	syncRatings := func(link *sync_stremio_trakt.SyncStremioTraktLink, log *logger.Logger) error {
+		log = log.With(
+			"stremio_account_id", link.StremioAccountId,
+			"trakt_account_id", link.TraktAccountId,
+			"target", "ratings",
+		)
+
+		ctx, err := prepareCtx(link, log)
+		if err != nil {
+			return err
+		}
+
+		direction := link.SyncConfig.Ratings.Direction
+
+		var startAt time.Time
+		if link.SyncState.Ratings.LastSyncedAt != nil {
+			startAt = *link.SyncState.Ratings.LastSyncedAt
+		}
+		ctx.isFullSync = startAt.IsZero()
+
+		log.Debug("starting ratings sync", "is_full_sync", ctx.isFullSync, "start_at", startAt)
+
+		traktRatingById := map[string]trakt.RatingItem{}
+		for _, itemType := range []trakt.HistoryItemType{trakt.HistoryItemTypeMovies, trakt.HistoryItemTypeShows} {
+			res, err := ctx.traktClient.GetRatings(&trakt.GetRatingsParams{Type: itemType})
+			if err != nil {
+				return err
+			}
+			for _, item := range res.Data {
+				if item.Movie != nil && item.Movie.Ids.IMDB != "" {
+					traktRatingById[item.Movie.Ids.IMDB] = item
+				} else if item.Show != nil && item.Show.Ids.IMDB != "" {
+					traktRatingById[item.Show.Ids.IMDB] = item
+				}
+			}
+		}
+
+		getLikeStatus := func(id, sType string) (stremio_api.LikeStatus, error) {
+			return ctx.stremioClient.GetLikeStatus(&stremio_api.GetLikeStatusParams{
+				Ctx:       stremio_api.Ctx{APIKey: ctx.stremioToken},
+				MediaId:   id,
+				MediaType: sType,
+			})
+		}
+
+		if direction.ShouldSyncToTrakt() {
+			stremioLibItemsRes, err := ctx.stremioClient.GetAllLibraryItems(&stremio_api.GetAllLibraryItemsParams{
+				Ctx: stremio_api.Ctx{APIKey: ctx.stremioToken},
+			})
+			if err != nil {
+				return err
+			}
+
+			var movies, shows []trakt.SyncRatingsParamsItem
+			for _, item := range stremioLibItemsRes.Data {
+				if !strings.HasPrefix(item.Id, "tt") || (item.Type != "movie" && item.Type != "series") || item.Removed || item.Temp {
+					continue
+				}
+				if ctx.isFullSync {
+					if !isLibraryItemWatched(&item) {
+						continue
+					}
+				} else if !item.MTime.After(startAt) {
+					continue
+				}
+				if _, isRated := traktRatingById[item.Id]; isRated {
+					continue
+				}
+				status, err := getLikeStatus(item.Id, item.Type)
+				if err != nil {
+					log.Warn("failed to get like status", "error", err, "id", item.Id)
+					continue
+				}
+				rating := likeStatusToTraktRating(status)
+				if rating == 0 {
+					continue
+				}
+				ratingItem := trakt.SyncRatingsParamsItem{Rating: rating, Ids: trakt.ListItemIds{IMDB: item.Id}}
+				if item.Type == "movie" {
+					movies = append(movies, ratingItem)
+				} else {
+					shows = append(shows, ratingItem)
+				}
+			}
+
+			if len(movies) > 0 || len(shows) > 0 {
+				if _, err := ctx.traktClient.AddRatings(&trakt.AddRatingsParams{Movies: movies, Shows: shows}); err != nil {
+					log.Error("failed to add ratings from stremio to trakt", "error", err)
+					return err
+				}
+				log.Debug("synced ratings from stremio to trakt", "movies", len(movies), "shows", len(shows))
+			}
+		}
+
+		if direction.ShouldSyncToStremio() {
+			count := 0
+			for id, item := range traktRatingById {
+				if !ctx.isFullSync && !item.RatedAt.After(startAt) {
+					continue
+				}
+				status := traktRatingToLikeStatus(item.Rating)
+				if status == stremio_api.LikeStatusNone {
+					continue
+				}
+				sType := "movie"
+				if item.Show != nil {
+					sType = "series"
+				}
+				currentStatus, err := getLikeStatus(id, sType)
+				if err != nil {
+					log.Warn("failed to get like status", "error", err, "id", id)
+					continue
+				}
+				if currentStatus != stremio_api.LikeStatusNone {
+					continue
+				}
+				if err := ctx.stremioClient.SendLikeStatus(&stremio_api.SendLikeStatusParams{
+					Ctx:       stremio_api.Ctx{APIKey: ctx.stremioToken},
+					MediaId:   id,
+					MediaType: sType,
+					Status:    status,
+				}); err != nil {
+					log.Error("failed to send like status", "error", err, "id", id)
+					return err
+				}
+				count++
+			}
+			if count > 0 {
+				log.Debug("synced ratings from trakt to stremio", "count", count)
+			}
+		}
+
+		link.SyncState.Ratings.LastSyncedAt = &ctx.now
+		sync_stremio_trakt.SetSyncState(link.StremioAccountId, link.TraktAccountId, link.SyncState)
+		return nil
+	}
+
 	conf.Executor = func(w *Worker) error {
 		log := w.Log
 
@@ -658,6 +1111,24 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 					return err
 				}
 			}
+			if !link.SyncConfig.Watchlist.Direction.IsDisabled() {
+				err := syncLibrary(&link, traktLibraryTargetWatchlist, log)
+				if err != nil {
+					return err
+				}
+			}
+			if !link.SyncConfig.Collection.Direction.IsDisabled() {
+				err := syncLibrary(&link, traktLibraryTargetCollection, log)
+				if err != nil {
+					return err
+				}
+			}
+			if !link.SyncConfig.Ratings.Direction.IsDisabled() {
+				err := syncRatings(&link, log)
+				if err != nil {
+					return err
+				}
+			}
 		}
 
 		return nil
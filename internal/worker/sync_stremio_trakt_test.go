@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTraktLibraryChanges(t *testing.T) {
+	startAt := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	before, after := startAt.Add(-time.Hour), startAt.Add(time.Hour)
+
+	newItem := func(id, sType string, mtime time.Time, state stremio_api.LibraryItemState) stremio_api.LibraryItem {
+		return stremio_api.LibraryItem{Id: id, Type: sType, MTime: stremio_api.JSONTime{Time: mtime}, State: state}
+	}
+	watched := stremio_api.LibraryItemState{TimesWatched: 1}
+	started := stremio_api.LibraryItemState{Watched: "tt0000005:1:1:1:eJyLBgAAqAB3"}
+
+	stremioItemById := map[string]stremio_api.LibraryItem{
+		"tt0000001": newItem("tt0000001", "movie", after, stremio_api.LibraryItemState{}),
+		"tt0000002": newItem("tt0000002", "movie", after, watched),
+		"tt0000003": newItem("tt0000003", "series", after, stremio_api.LibraryItemState{}),
+		"tt0000004": newItem("tt0000004", "movie", before, stremio_api.LibraryItemState{}),
+		"tt0000005": newItem("tt0000005", "series", after, started),
+		"tt0000006": newItem("tt0000006", "movie", after, stremio_api.LibraryItemState{}),
+		"tt0000007": newItem("tt0000007", "movie", after, stremio_api.LibraryItemState{}),
+		"tt0000008": newItem("tt0000008", "movie", after, watched),
+	}
+	removed := stremioItemById["tt0000006"]
+	removed.Removed = true
+	stremioItemById["tt0000006"] = removed
+	temp := stremioItemById["tt0000007"]
+	temp.Temp = true
+	stremioItemById["tt0000007"] = temp
+
+	traktItemById := map[string]traktLibraryItem{
+		"tt0000006": {imdbId: "tt0000006", sType: "movie", addedAt: before},
+		"tt0000008": {imdbId: "tt0000008", sType: "movie", addedAt: before},
+	}
+
+	t.Run("watchlist", func(t *testing.T) {
+		changes := getTraktLibraryChanges(traktLibraryTargetWatchlist, stremioItemById, traktItemById, startAt)
+		assert.Equal(t, []string{"tt0000001"}, changes.moviesToAdd)
+		assert.Equal(t, []string{"tt0000003"}, changes.showsToAdd)
+		assert.Equal(t, []string{"tt0000006"}, changes.moviesToRemove)
+		assert.Empty(t, changes.showsToRemove)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		changes := getTraktLibraryChanges(traktLibraryTargetCollection, stremioItemById, traktItemById, startAt)
+		assert.Equal(t, []string{"tt0000001", "tt0000002"}, changes.moviesToAdd)
+		assert.Equal(t, []string{"tt0000003", "tt0000005"}, changes.showsToAdd)
+		assert.Equal(t, []string{"tt0000006"}, changes.moviesToRemove)
+	})
+
+	t.Run("full sync", func(t *testing.T) {
+		changes := getTraktLibraryChanges(traktLibraryTargetWatchlist, stremioItemById, traktItemById, time.Time{})
+		assert.Equal(t, []string{"tt0000001", "tt0000004"}, changes.moviesToAdd)
+		assert.Empty(t, changes.moviesToRemove)
+	})
+}
+
+func TestTraktRatingLikeStatus(t *testing.T) {
+	for _, status := range []stremio_api.LikeStatus{stremio_api.LikeStatusLiked, stremio_api.LikeStatusLoved} {
+		assert.Equal(t, status, traktRatingToLikeStatus(likeStatusToTraktRating(status)))
+	}
+	assert.Equal(t, 0, likeStatusToTraktRating(stremio_api.LikeStatusNone))
+	assert.Equal(t, stremio_api.LikeStatusNone, traktRatingToLikeStatus(6))
+	assert.Equal(t, stremio_api.LikeStatusLiked, traktRatingToLikeStatus(7))
+	assert.Equal(t, stremio_api.LikeStatusLoved, traktRatingToLikeStatus(9))
+}
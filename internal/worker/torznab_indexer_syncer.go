@@ -63,7 +63,7 @@ func InitTorznabIndexerSyncerWorker(conf *WorkerConfig) *Worker {
 
 			var client tznc.Indexer
 			switch indexer.Type {
-			case torznab_indexer.IndexerTypeJackett:
+			case torznab_indexer.IndexerTypeJackett, torznab_indexer.IndexerTypeProwlarr, torznab_indexer.IndexerTypeTorznab:
 				c, err := indexer.GetClient()
 				if err != nil {
 					log.Error("failed to create torznab client", "error", err, "id", indexer.GetCompositeId())
@@ -197,7 +197,7 @@ func InitTorznabIndexerSyncerQueueWorker(conf *WorkerConfig) *Worker {
 			indexer := &indexers[i]
 
 			switch indexer.Type {
-			case torznab_indexer.IndexerTypeJackett:
+			case torznab_indexer.IndexerTypeJackett, torznab_indexer.IndexerTypeProwlarr, torznab_indexer.IndexerTypeTorznab:
 				client, err := indexer.GetClient()
 				if err != nil {
 					log.Error("failed to create torznab client", "error", err, "id", indexer.GetCompositeId())
@@ -8,6 +8,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/job_log"
 	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	torznab_indexer "github.com/MunifTanjim/stremthru/internal/torznab/indexer"
 	torznab_indexer_syncinfo "github.com/MunifTanjim/stremthru/internal/torznab/indexer/syncinfo"
 	"github.com/MunifTanjim/stremthru/internal/util"
@@ -27,6 +28,7 @@ var running_worker struct {
 	sync_animeapi               bool
 	sync_anidb_tvdb_episode_map bool
 	sync_manami_anime_database  bool
+	import_torrent              bool
 }
 
 type Worker struct {
@@ -115,6 +117,9 @@ var WorkerDetailsById = map[string]*WorkerDetail{
 	"sync-stremio-trakt": {
 		Title: "Sync Stremio-Trakt",
 	},
+	"sync-stremio-tracker": {
+		Title: "Sync Stremio-Tracker",
+	},
 	"sync-stremio-stremio": {
 		Title: "Sync Stremio-Stremio",
 	},
@@ -124,6 +129,15 @@ var WorkerDetailsById = map[string]*WorkerDetail{
 	"sync-torznab-indexer": {
 		Title: "Sync Torznab Indexer",
 	},
+	"import-torrent": {
+		Title: "Import Torrent",
+	},
+	"check-federated-addon": {
+		Title: "Check Federated Addon",
+	},
+	"backup-stremio-account": {
+		Title: "Backup Stremio Account",
+	},
 }
 
 func NewWorker(conf *WorkerConfig) *Worker {
@@ -184,6 +198,7 @@ func NewWorker(conf *WorkerConfig) *Worker {
 	worker.jobTracker = jobTracker
 
 	jobId := ""
+	var jobStartedAt time.Time
 	id, err := worker.scheduler.Add(&tasks.Task{
 		Interval:          conf.Interval,
 		RunSingleInstance: true,
@@ -264,7 +279,8 @@ func NewWorker(conf *WorkerConfig) *Worker {
 				}
 			}
 
-			jobId = time.Now().Format(time.DateTime)
+			jobStartedAt = time.Now()
+			jobId = jobStartedAt.Format(time.DateTime)
 
 			err = jobTracker.Set(jobId, "started", "", nil)
 			if err != nil {
@@ -306,6 +322,7 @@ func NewWorker(conf *WorkerConfig) *Worker {
 				log.Error("failed to set job status", "error", err, "jobId", jobId, "status", "done")
 				return err
 			}
+			metrics.ObserveWorkerRun(conf.Name, "done", jobStartedAt)
 
 			log.Info("done", "jobId", jobId)
 
@@ -324,6 +341,9 @@ func NewWorker(conf *WorkerConfig) *Worker {
 			if terr := jobTracker.Set(jobId, "failed", err.Error(), nil); terr != nil {
 				log.Error("failed to set job status", "error", terr, "jobId", jobId, "status", "failed")
 			}
+			if jobId != "" {
+				metrics.ObserveWorkerRun(conf.Name, "failed", jobStartedAt)
+			}
 		},
 	})
 
@@ -345,6 +365,16 @@ func NewWorker(conf *WorkerConfig) *Worker {
 	return worker
 }
 
+// lockSyncLink keeps a link from being synced by the worker and by an
+// immediate sync at the same time. Returns nil if it is already locked.
+func lockSyncLink(name string, ids ...string) db.AdvisoryLock {
+	lock := db.NewAdvisoryLock(append([]string{name, "link"}, ids...)...)
+	if lock == nil || !lock.TryAcquire() {
+		return nil
+	}
+	return lock
+}
+
 func InitWorkers() func() {
 	workers := []*Worker{}
 
@@ -357,6 +387,9 @@ func InitWorkers() func() {
 			mutex.Lock()
 			defer mutex.Unlock()
 
+			if running_worker.import_torrent {
+				return true, "import_torrent is running"
+			}
 			if running_worker.sync_animetosho {
 				return true, "sync_animetosho is running"
 			}
@@ -501,6 +534,9 @@ func InitWorkers() func() {
 			if running_worker.sync_dmm_hashlist {
 				return true, "sync_dmm_hashlist is running"
 			}
+			if running_worker.import_torrent {
+				return true, "import_torrent is running"
+			}
 			return false, ""
 		},
 		OnStart: func() {
@@ -781,6 +817,40 @@ func InitWorkers() func() {
 		workers = append(workers, worker)
 	}
 
+	if worker := InitImportTorrentWorker(&WorkerConfig{
+		Disabled:          !config.Feature.HasTorrentInfo(),
+		Name:              "import-torrent",
+		Interval:          15 * time.Minute,
+		RunAtStartupAfter: 60 * time.Second,
+		ShouldSkip: func() bool {
+			return len(getPendingTorrentImports()) == 0
+		},
+		ShouldWait: func() (bool, string) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if running_worker.sync_dmm_hashlist {
+				return true, "sync_dmm_hashlist is running"
+			}
+
+			return false, ""
+		},
+		OnStart: func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			running_worker.import_torrent = true
+		},
+		OnEnd: func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			running_worker.import_torrent = false
+		},
+	}); worker != nil {
+		workers = append(workers, worker)
+	}
+
 	if worker := InitSyncAnimeToshoWorker(&WorkerConfig{
 		Disabled:          !config.Feature.IsEnabled("anime"),
 		Name:              "sync-animetosho",
@@ -849,6 +919,32 @@ func InitWorkers() func() {
 		workers = append(workers, worker)
 	}
 
+	if worker := InitSyncStremioTrackerWorker(&WorkerConfig{
+		Disabled:          !config.Feature.HasVault() || !config.Integration.HasTracker(),
+		Name:              "sync-stremio-tracker",
+		Interval:          30 * time.Minute,
+		RunAtStartupAfter: 5 * time.Minute,
+		RunExclusive:      true,
+		ShouldWait: func() (bool, string) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if running_worker.sync_animeapi {
+				return true, "sync_animeapi is running"
+			}
+
+			if running_worker.sync_anidb_tvdb_episode_map {
+				return true, "sync_anidb_tvdb_episode_map is running"
+			}
+
+			return false, ""
+		},
+		OnStart: func() {},
+		OnEnd:   func() {},
+	}); worker != nil {
+		workers = append(workers, worker)
+	}
+
 	if worker := InitSyncStremioStremioWorker(&WorkerConfig{
 		Disabled:          !config.Feature.HasVault(),
 		Name:              "sync-stremio-stremio",
@@ -888,6 +984,25 @@ func InitWorkers() func() {
 		workers = append(workers, worker)
 	}
 
+	if worker := InitCheckFederatedAddonWorker(&WorkerConfig{
+		Name:              "check-federated-addon",
+		Interval:          30 * time.Minute,
+		RunAtStartupAfter: 1 * time.Minute,
+		RunExclusive:      true,
+	}); worker != nil {
+		workers = append(workers, worker)
+	}
+
+	if worker := InitBackupStremioAccountWorker(&WorkerConfig{
+		Disabled:          !config.Feature.HasVault() || !config.Feature.IsEnabled(config.FeatureStremioSidekick) || config.Stremio.Sidekick.BackupKeepCount <= 0,
+		Name:              "backup-stremio-account",
+		Interval:          config.Stremio.Sidekick.BackupInterval,
+		RunAtStartupAfter: 5 * time.Minute,
+		RunExclusive:      true,
+	}); worker != nil {
+		workers = append(workers, worker)
+	}
+
 	return func() {
 		for _, worker := range workers {
 			worker.scheduler.Stop()
@@ -19,6 +19,8 @@ func main() {
 			string(store.StoreNameAlldebrid),
 			string(store.StoreNameDebridLink),
 			string(store.StoreNameEasyDebrid),
+			string(store.StoreNameLocal),
+			string(store.StoreNameMulti),
 			string(store.StoreNameOffcloud),
 			string(store.StoreNamePikPak),
 			string(store.StoreNamePremiumize),
@@ -50,7 +52,9 @@ func main() {
 	endpoint.AddStremioEndpoints(mux)
 	endpoint.AddTorrentEndpoints(mux)
 	endpoint.AddTorznabEndpoints(mux)
+	endpoint.AddNewznabEndpoints(mux)
 	endpoint.AddExperimentEndpoints(mux)
+	endpoint.AddMetricsEndpoints(mux)
 
 	handler := shared.RootServerContext(mux)
 
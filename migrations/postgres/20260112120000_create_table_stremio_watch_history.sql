@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."stremio_watch_history" (
+  "addon" text NOT NULL,
+  "key" text NOT NULL,
+  "hash" text NOT NULL,
+  "file" text NOT NULL,
+  "sid" text NOT NULL DEFAULT '',
+  "mid" text NOT NULL DEFAULT '',
+  "cat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "uat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY ("addon", "key", "hash", "file")
+);
+
+CREATE INDEX IF NOT EXISTS "stremio_watch_history_idx_addon_key_uat" ON "public"."stremio_watch_history" ("addon", "key", "uat");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "stremio_watch_history_idx_addon_key_uat";
+DROP TABLE IF EXISTS "public"."stremio_watch_history";
+-- +goose StatementEnd
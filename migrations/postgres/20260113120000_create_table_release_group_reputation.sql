@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."release_group_reputation" (
+  "name" text NOT NULL,
+  "success" int NOT NULL DEFAULT 0,
+  "failed" int NOT NULL DEFAULT 0,
+  "invalid" int NOT NULL DEFAULT 0,
+  "override" int,
+  "cat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "uat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY ("name")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "public"."release_group_reputation";
+-- +goose StatementEnd
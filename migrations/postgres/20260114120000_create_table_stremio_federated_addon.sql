@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."stremio_federated_addon" (
+  "url" text NOT NULL,
+  "is_peer" boolean NOT NULL DEFAULT false,
+  "manifest" jsonb NOT NULL DEFAULT '{}',
+  "status" text NOT NULL DEFAULT 'unknown',
+  "error" text NOT NULL DEFAULT '',
+  "checked_at" timestamptz,
+  "seen_at" timestamptz,
+  "cat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "uat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY ("url")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "public"."stremio_federated_addon";
+-- +goose StatementEnd
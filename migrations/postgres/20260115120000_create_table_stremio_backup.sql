@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."stremio_backup" (
+  "id" text NOT NULL,
+  "account_id" text NOT NULL,
+  "kind" text NOT NULL,
+  "hash" text NOT NULL,
+  "count" int NOT NULL DEFAULT 0,
+  "data" jsonb NOT NULL,
+  "cat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "stremio_backup_idx_account_id_kind" ON "public"."stremio_backup" ("account_id", "kind");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "stremio_backup_idx_account_id_kind";
+DROP TABLE IF EXISTS "public"."stremio_backup";
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "public"."release_group_reputation"
+  ADD COLUMN "kind" text NOT NULL DEFAULT 'group',
+  DROP CONSTRAINT "release_group_reputation_pkey",
+  ADD PRIMARY KEY ("kind", "name");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM "public"."release_group_reputation" WHERE "kind" != 'group';
+ALTER TABLE "public"."release_group_reputation"
+  DROP CONSTRAINT "release_group_reputation_pkey",
+  ADD PRIMARY KEY ("name"),
+  DROP COLUMN "kind";
+-- +goose StatementEnd
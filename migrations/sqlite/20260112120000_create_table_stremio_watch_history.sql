@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `stremio_watch_history` (
+  `addon` varchar NOT NULL,
+  `key` varchar NOT NULL,
+  `hash` varchar NOT NULL,
+  `file` varchar NOT NULL,
+  `sid` varchar NOT NULL DEFAULT '',
+  `mid` varchar NOT NULL DEFAULT '',
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`addon`, `key`, `hash`, `file`)
+);
+
+CREATE INDEX IF NOT EXISTS `stremio_watch_history_idx_addon_key_uat` ON `stremio_watch_history` (`addon`, `key`, `uat`);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS `stremio_watch_history_idx_addon_key_uat`;
+DROP TABLE IF EXISTS `stremio_watch_history`;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `release_group_reputation` (
+  `name` varchar NOT NULL,
+  `success` int NOT NULL DEFAULT 0,
+  `failed` int NOT NULL DEFAULT 0,
+  `invalid` int NOT NULL DEFAULT 0,
+  `override` int,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`name`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `release_group_reputation`;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `stremio_federated_addon` (
+  `url` varchar NOT NULL,
+  `is_peer` bool NOT NULL DEFAULT false,
+  `manifest` json NOT NULL DEFAULT '{}',
+  `status` varchar NOT NULL DEFAULT 'unknown',
+  `error` varchar NOT NULL DEFAULT '',
+  `checked_at` datetime,
+  `seen_at` datetime,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`url`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `stremio_federated_addon`;
+-- +goose StatementEnd
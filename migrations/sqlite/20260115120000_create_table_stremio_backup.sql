@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `stremio_backup` (
+  `id` varchar NOT NULL,
+  `account_id` varchar NOT NULL,
+  `kind` varchar NOT NULL,
+  `hash` varchar NOT NULL,
+  `count` int NOT NULL DEFAULT 0,
+  `data` json NOT NULL,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`id`)
+);
+
+CREATE INDEX IF NOT EXISTS `stremio_backup_idx_account_id_kind` ON `stremio_backup` (`account_id`, `kind`);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS `stremio_backup_idx_account_id_kind`;
+DROP TABLE IF EXISTS `stremio_backup`;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `release_group_reputation` RENAME TO `release_group_reputation_old`;
+
+CREATE TABLE IF NOT EXISTS `release_group_reputation` (
+  `kind` varchar NOT NULL DEFAULT 'group',
+  `name` varchar NOT NULL,
+  `success` int NOT NULL DEFAULT 0,
+  `failed` int NOT NULL DEFAULT 0,
+  `invalid` int NOT NULL DEFAULT 0,
+  `override` int,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`kind`, `name`)
+);
+
+INSERT INTO `release_group_reputation` (`kind`, `name`, `success`, `failed`, `invalid`, `override`, `cat`, `uat`)
+SELECT 'group', `name`, `success`, `failed`, `invalid`, `override`, `cat`, `uat` FROM `release_group_reputation_old`;
+
+DROP TABLE `release_group_reputation_old`;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `release_group_reputation` RENAME TO `release_group_reputation_old`;
+
+CREATE TABLE IF NOT EXISTS `release_group_reputation` (
+  `name` varchar NOT NULL,
+  `success` int NOT NULL DEFAULT 0,
+  `failed` int NOT NULL DEFAULT 0,
+  `invalid` int NOT NULL DEFAULT 0,
+  `override` int,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`name`)
+);
+
+INSERT INTO `release_group_reputation` (`name`, `success`, `failed`, `invalid`, `override`, `cat`, `uat`)
+SELECT `name`, `success`, `failed`, `invalid`, `override`, `cat`, `uat` FROM `release_group_reputation_old` WHERE `kind` = 'group';
+
+DROP TABLE `release_group_reputation_old`;
+-- +goose StatementEnd
@@ -75,6 +75,54 @@ class StremThruStore {
     });
   }
 
+  async addNewz({
+    clientIp = this.#clientIp,
+    file,
+    link,
+    name,
+    password,
+  }: {
+    clientIp?: string;
+    name?: string;
+    password?: string;
+  } & (
+    | { file: File; link?: never }
+    | { file?: never; link: string }
+  )) {
+    let body: FormData | Record<string, unknown>;
+    if (link) {
+      body = { link, name, password };
+    } else {
+      body = new FormData();
+      body.set("file", file!);
+      if (name) {
+        body.set("name", name);
+      }
+      if (password) {
+        body.set("password", password);
+      }
+    }
+    return await this.#client.request<{
+      added_at: string;
+      files: Array<{
+        index: number;
+        link: string;
+        name: string;
+        path: string;
+        size: number;
+      }>;
+      hash: string;
+      id: string;
+      name: string;
+      size: number;
+      status: StoreMagnetStatus;
+    }>("/v0/store/newz", {
+      body,
+      method: "POST",
+      params: clientIp ? { client_ip: clientIp } : {},
+    });
+  }
+
   async checkMagnet(params: { magnet: string[]; sid?: string }) {
     return await this.#client.request<{
       items: Array<{
@@ -95,6 +143,20 @@ class StremThruStore {
     });
   }
 
+  async checkNewz(params: { hash: string[] }) {
+    return await this.#client.request<{
+      items: Array<{
+        hash: string;
+        name: string;
+        size: number;
+        status: StoreMagnetStatus;
+      }>;
+    }>("/v0/store/newz/check", {
+      method: "GET",
+      params,
+    });
+  }
+
   async generateLink({
     clientIp = this.#clientIp,
     link,
@@ -111,6 +173,22 @@ class StremThruStore {
     });
   }
 
+  async generateNewzLink({
+    clientIp = this.#clientIp,
+    link,
+  }: {
+    clientIp?: string;
+    link: string;
+  }) {
+    return await this.#client.request<{
+      link: string;
+    }>(`/v0/store/newz/link/generate`, {
+      body: { link },
+      method: "POST",
+      params: clientIp ? { client_ip: clientIp } : {},
+    });
+  }
+
   async getMagnet(magnetId: string) {
     return await this.#client.request<{
       added_at: string;
@@ -130,6 +208,24 @@ class StremThruStore {
     }>(`/v0/store/magnets/${magnetId}`, { method: "GET" });
   }
 
+  async getNewz(newzId: string) {
+    return await this.#client.request<{
+      added_at: string;
+      files: Array<{
+        index: number;
+        link: string;
+        name: string;
+        path: string;
+        size: number;
+      }>;
+      hash: string;
+      id: string;
+      name: string;
+      size: number;
+      status: StoreMagnetStatus;
+    }>(`/v0/store/newz/${newzId}`, { method: "GET" });
+  }
+
   async getUser() {
     return await this.#client.request<{
       email: string;
@@ -167,11 +263,46 @@ class StremThruStore {
     }>("/v0/store/magnets", { method: "GET", params });
   }
 
+  async listNewz({
+    limit,
+    offset,
+  }: {
+    // min `1`, max `500`, default `100`
+    limit?: number;
+    // min `0`, default `0`
+    offset?: number;
+  }) {
+    const params: Record<string, string> = {};
+    if (limit) {
+      params["limit"] = String(limit);
+    }
+    if (offset) {
+      params["offset"] = String(offset);
+    }
+    return await this.#client.request<{
+      items: Array<{
+        added_at: string;
+        hash: string;
+        id: string;
+        name: string;
+        size: number;
+        status: StoreMagnetStatus;
+      }>;
+      total_items: number;
+    }>("/v0/store/newz", { method: "GET", params });
+  }
+
   async removeMagnet(magnetId: string) {
     return await this.#client.request<null>(`/v0/store/magnets/${magnetId}`, {
       method: "DELETE",
     });
   }
+
+  async removeNewz(newzId: string) {
+    return await this.#client.request<null>(`/v0/store/newz/${newzId}`, {
+      method: "DELETE",
+    });
+  }
 }
 
 export class StremThru {
@@ -238,6 +238,52 @@ class ListMagnetsData(TypedDict):
     total_items: int
 
 
+class NewzFile(TypedDict):
+    index: int
+    link: str
+    name: str
+    path: str
+    size: int
+
+
+class AddNewzData(TypedDict):
+    added_at: str
+    files: list[NewzFile]
+    hash: str
+    id: str
+    name: str
+    size: int
+    status: StoreMagnetStatus
+
+
+class CheckNewzDataItem(TypedDict):
+    hash: str
+    name: str
+    size: int
+    status: StoreMagnetStatus
+
+
+class CheckNewzData(TypedDict):
+    items: list[CheckNewzDataItem]
+
+
+GetNewzData = AddNewzData
+
+
+class ListNewzDataItem(TypedDict):
+    added_at: str
+    hash: str
+    id: str
+    name: str
+    size: int
+    status: StoreMagnetStatus
+
+
+class ListNewzData(TypedDict):
+    items: list[ListNewzDataItem]
+    total_items: int
+
+
 class StremThruStore:
     _client_ip: str | None = None
 
@@ -319,3 +365,74 @@ class StremThruStore:
 
     async def remove_magnet(self, magnet_id: str) -> Response[None]:
         return await self.client.request(f"/v0/store/magnets/{magnet_id}", "DELETE")
+
+    async def add_newz(
+        self,
+        link: Optional[str] = None,
+        file: Optional[io.BufferedReader] = None,
+        name: Optional[str] = None,
+        password: Optional[str] = None,
+        client_ip: str | None = None,
+    ) -> Response[AddNewzData]:
+        if not client_ip:
+            client_ip = self._client_ip
+
+        if type(link) is str:
+            payload: dict[str, Any] = {"link": link}
+            if name:
+                payload["name"] = name
+            if password:
+                payload["password"] = password
+            return await self.client.request(
+                "/v0/store/newz",
+                "POST",
+                json=payload,
+                params={"client_ip": client_ip} if client_ip else None,
+            )
+
+        data = aiohttp.FormData()
+        data.add_field("file", file)
+        if name:
+            data.add_field("name", name)
+        if password:
+            data.add_field("password", password)
+        return await self.client.request(
+            "/v0/store/newz",
+            "POST",
+            data=data,
+            params={"client_ip": client_ip} if client_ip else None,
+        )
+
+    async def check_newz(self, hash: list[str]) -> Response[CheckNewzData]:
+        return await self.client.request(
+            "/v0/store/newz/check", params={"hash": hash}
+        )
+
+    async def generate_newz_link(
+        self, link: str, client_ip: str | None = None
+    ) -> Response[GenerateLinkData]:
+        if not client_ip:
+            client_ip = self._client_ip
+
+        return await self.client.request(
+            "/v0/store/newz/link/generate",
+            "POST",
+            json={"link": link},
+            params={"client_ip": client_ip} if client_ip else None,
+        )
+
+    async def get_newz(self, newz_id: str) -> Response[GetNewzData]:
+        return await self.client.request(f"/v0/store/newz/{newz_id}")
+
+    async def list_newz(
+        self, limit: int | None = None, offset: int | None = None
+    ) -> Response[ListNewzData]:
+        params = {}
+        if limit:
+            params["limit"] = limit
+        if offset:
+            params["offset"] = offset
+        return await self.client.request("/v0/store/newz", params=params)
+
+    async def remove_newz(self, newz_id: str) -> Response[None]:
+        return await self.client.request(f"/v0/store/newz/{newz_id}", "DELETE")
@@ -354,6 +354,9 @@ func (s *StoreClient) GetUser(params *store.GetUserParams) (*store.User, error)
 		Email:              res.Data.Email,
 		SubscriptionStatus: store.UserSubscriptionStatusExpired,
 	}
+	if res.Data.Subscription.Plan.Metadata.DailyNzbDownloads != 0 {
+		data.HasUsenet = true
+	}
 	switch res.Data.Subscription.Status {
 	case "active":
 		data.SubscriptionStatus = store.UserSubscriptionStatusPremium
@@ -429,3 +432,150 @@ func NewStoreClient(config *StoreClientConfig) *StoreClient {
 
 	return c
 }
+
+func (s *StoreClient) toNZBFiles(task *Task) []store.NZBFile {
+	files := []store.NZBFile{}
+	source := string(s.GetName().Code())
+	for i := range task.Files {
+		f := &task.Files[i]
+		files = append(files, store.NZBFile{
+			Idx:    i,
+			Link:   LockedFileLink("").Create(task.Id, f.Name),
+			Name:   f.GetName(),
+			Path:   f.GetPath(),
+			Size:   f.Size,
+			Source: source,
+		})
+	}
+	return files
+}
+
+// CheckNZB always returns `unknown`, debrider has no availability check for nzb.
+func (s *StoreClient) CheckNZB(params *store.CheckNZBParams) (*store.CheckNZBData, error) {
+	data := &store.CheckNZBData{
+		Items: []store.CheckNZBDataItem{},
+	}
+	for _, hash := range params.Hashes {
+		data.Items = append(data.Items, store.CheckNZBDataItem{
+			Hash:   strings.ToLower(hash),
+			Status: store.MagnetStatusUnknown,
+		})
+	}
+	return data, nil
+}
+
+func (s *StoreClient) AddNZB(params *store.AddNZBParams) (*store.AddNZBData, error) {
+	if params.File == nil {
+		err := core.NewAPIError("nzb file is required")
+		err.StatusCode = http.StatusBadRequest
+		err.StoreName = string(store.StoreNameDebrider)
+		return nil, err
+	}
+	res, err := s.client.CreateDownloadTask(&CreateDownloadTaskParams{
+		Ctx:  params.Ctx,
+		Type: DownloadTaskTypeNzb,
+		Data: CreateDownloadTaskParamsData{
+			FileContent: params.File,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.AddNZBData{
+		Id:      res.Data.Id,
+		Hash:    res.Data.Hash,
+		Name:    res.Data.Name,
+		Size:    res.Data.Size,
+		Status:  getMagnetStatusFromTaskStatus(res.Data.Status),
+		Files:   s.toNZBFiles(&res.Data),
+		AddedAt: res.Data.GetAddedAt(),
+	}
+	return data, nil
+}
+
+func (s *StoreClient) GetNZB(params *store.GetNZBParams) (*store.GetNZBData, error) {
+	res, err := s.client.GetTask(&GetTaskParams{
+		Ctx: params.Ctx,
+		Id:  params.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Data.Type != string(DownloadTaskTypeNzb) {
+		err := core.NewAPIError("not found")
+		err.StatusCode = http.StatusNotFound
+		err.StoreName = string(store.StoreNameDebrider)
+		return nil, err
+	}
+	data := &store.GetNZBData{
+		Id:      res.Data.Id,
+		Hash:    res.Data.Hash,
+		Name:    res.Data.Name,
+		Size:    res.Data.Size,
+		Status:  getMagnetStatusFromTaskStatus(res.Data.Status),
+		Files:   s.toNZBFiles(&res.Data),
+		AddedAt: res.Data.GetAddedAt(),
+	}
+	return data, nil
+}
+
+func (s *StoreClient) ListNZBs(params *store.ListNZBsParams) (*store.ListNZBsData, error) {
+	res, err := s.client.ListTask(&ListTaskParams{
+		Ctx: params.Ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := []store.ListNZBsDataItem{}
+	for i := range res.Data {
+		task := &res.Data[i]
+		if task.Type != string(DownloadTaskTypeNzb) {
+			continue
+		}
+		items = append(items, store.ListNZBsDataItem{
+			Id:      task.Id,
+			Hash:    task.Hash,
+			Name:    task.Name,
+			Size:    task.Size,
+			Status:  getMagnetStatusFromTaskStatus(task.Status),
+			Files:   s.toNZBFiles(task),
+			AddedAt: task.GetAddedAt(),
+		})
+	}
+
+	data := &store.ListNZBsData{
+		Items:      []store.ListNZBsDataItem{},
+		TotalItems: len(items),
+	}
+	if params.Offset < len(items) {
+		end := len(items)
+		if params.Limit > 0 {
+			end = min(end, params.Offset+params.Limit)
+		}
+		data.Items = items[params.Offset:end]
+	}
+	return data, nil
+}
+
+func (s *StoreClient) RemoveNZB(params *store.RemoveNZBParams) (*store.RemoveNZBData, error) {
+	_, err := s.client.DeleteTask(&DeleteTaskParams{
+		Ctx: params.Ctx,
+		Id:  params.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.RemoveNZBData{
+		Id: params.Id,
+	}
+	return data, nil
+}
+
+func (s *StoreClient) GenerateNZBLink(params *store.GenerateNZBLinkParams) (*store.GenerateLinkData, error) {
+	return s.GenerateLink(&store.GenerateLinkParams{
+		Ctx:      params.Ctx,
+		Link:     params.Link,
+		ClientIP: params.ClientIP,
+	})
+}
@@ -0,0 +1,130 @@
+package debrider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStoreClient(t *testing.T) *StoreClient {
+	t.Helper()
+
+	tasks := ListTaskData{
+		{
+			Id:        "nzb-1",
+			Hash:      "abc",
+			Name:      "Movie",
+			Size:      200,
+			Status:    TaskStatusCompleted,
+			Type:      string(DownloadTaskTypeNzb),
+			AddedDate: "2024-01-02T03:04:05Z",
+			Files:     []TaskFile{{Name: "Movie/Movie.2024.mkv", Size: 200}},
+		},
+		{
+			Id:     "magnet-1",
+			Hash:   "def",
+			Status: TaskStatusDownloading,
+			Type:   string(DownloadTaskTypeMagnet),
+		},
+		{
+			Id:     "nzb-2",
+			Hash:   "ghi",
+			Status: TaskStatusParsing,
+			Type:   string(DownloadTaskTypeNzb),
+		},
+	}
+
+	send := func(w http.ResponseWriter, status int, data any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(data)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/tasks", func(w http.ResponseWriter, r *http.Request) {
+		send(w, 200, tasks)
+	})
+	mux.HandleFunc("GET /v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		for _, task := range tasks {
+			if task.Id == r.PathValue("id") {
+				send(w, 200, task)
+				return
+			}
+		}
+		send(w, 404, ResponseContainer{Message: "not found"})
+	})
+	mux.HandleFunc("DELETE /v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		send(w, 200, ResponseContainer{})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c := NewStoreClient(&StoreClientConfig{})
+	c.client = NewAPIClient(&APIClientConfig{BaseURL: server.URL})
+	return c
+}
+
+func TestStoreClientUsenet(t *testing.T) {
+	c := newTestStoreClient(t)
+	ctx := store.Ctx{APIKey: "token"}
+
+	t.Run("CheckNZB", func(t *testing.T) {
+		data, err := c.CheckNZB(&store.CheckNZBParams{Ctx: ctx, Hashes: []string{"ABC"}})
+		require.NoError(t, err)
+		require.Len(t, data.Items, 1)
+		assert.Equal(t, "abc", data.Items[0].Hash)
+		assert.Equal(t, store.MagnetStatusUnknown, data.Items[0].Status)
+	})
+
+	t.Run("AddNZB", func(t *testing.T) {
+		_, err := c.AddNZB(&store.AddNZBParams{Ctx: ctx, Link: "https://indexer/nzb/1"})
+		var apiErr *core.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+
+	t.Run("GetNZB", func(t *testing.T) {
+		data, err := c.GetNZB(&store.GetNZBParams{Ctx: ctx, Id: "nzb-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "nzb-1", data.Id)
+		assert.Equal(t, store.MagnetStatusDownloaded, data.Status)
+		require.Len(t, data.Files, 1)
+		assert.Equal(t, "Movie.2024.mkv", data.Files[0].Name)
+		assert.Equal(t, "/Movie.2024.mkv", data.Files[0].Path)
+		taskId, fileName, err := LockedFileLink(data.Files[0].Link).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, "nzb-1", taskId)
+		assert.Equal(t, "Movie/Movie.2024.mkv", fileName)
+
+		_, err = c.GetNZB(&store.GetNZBParams{Ctx: ctx, Id: "magnet-1"})
+		var apiErr *core.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+
+	t.Run("ListNZBs", func(t *testing.T) {
+		data, err := c.ListNZBs(&store.ListNZBsParams{Ctx: ctx, Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 2, data.TotalItems)
+		require.Len(t, data.Items, 1)
+		assert.Equal(t, "nzb-2", data.Items[0].Id)
+		assert.Equal(t, store.MagnetStatusQueued, data.Items[0].Status)
+
+		data, err = c.ListNZBs(&store.ListNZBsParams{Ctx: ctx, Limit: 10, Offset: 5})
+		require.NoError(t, err)
+		assert.Empty(t, data.Items)
+	})
+
+	t.Run("RemoveNZB", func(t *testing.T) {
+		data, err := c.RemoveNZB(&store.RemoveNZBParams{Ctx: ctx, Id: "nzb-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "nzb-1", data.Id)
+	})
+}
@@ -74,25 +74,13 @@ func (l LockedFileLink) parse() (magnetHash string, fileIdx int, err error) {
 const magnetIdPrefix = "st:ed:"
 
 func (s *StoreClient) AddMagnet(params *store.AddMagnetParams) (*store.AddMagnetData, error) {
-	var magnet *core.MagnetLink
-	var isPrivate bool
-	if params.Magnet != "" {
-		m, err := core.ParseMagnetLink(params.Magnet)
-		if err != nil {
-			return nil, err
-		}
-		magnet = &m
-	} else {
-		mi, mii, err := params.GetTorrentMeta()
-		if err != nil {
-			return nil, err
-		}
+	magnet, err := params.GetMagnet()
+	if err != nil {
+		return nil, err
+	}
+	isPrivate := false
+	if _, mii, _ := params.GetTorrentMeta(); mii != nil {
 		isPrivate = util.PtrToBool(mii.Private, false)
-		m, err := core.ParseMagnetLink(mi.HashInfoBytes().HexString())
-		if err != nil {
-			return nil, err
-		}
-		magnet = &m
 	}
 	res, err := s.client.LookupLinkDetails(&LookupLinkDetailsParams{
 		Ctx:  params.Ctx,
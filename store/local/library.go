@@ -0,0 +1,163 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+type libraryFile struct {
+	Idx  int
+	Path string // relative to torrent root, with leading `/`
+	Name string
+	Size int64
+}
+
+type libraryTorrent struct {
+	Hash    string
+	Name    string
+	Size    int64
+	Private bool
+	Files   []libraryFile
+
+	isSingleFile bool
+	modTime      time.Time
+}
+
+// library indexes the `.torrent` files in `<dir>/torrents`, and resolves
+// their content from `<dir>/content/<torrent-name>`.
+type library struct {
+	dir          string
+	scanInterval time.Duration
+
+	m            sync.Mutex
+	lastScanAt   time.Time
+	byHash       map[string]*libraryTorrent
+	byTorrentKey map[string]*libraryTorrent
+}
+
+func newLibrary(dir string) *library {
+	return &library{
+		dir:          dir,
+		scanInterval: 10 * time.Second,
+		byHash:       map[string]*libraryTorrent{},
+		byTorrentKey: map[string]*libraryTorrent{},
+	}
+}
+
+func (l *library) torrentsDir() string {
+	return filepath.Join(l.dir, "torrents")
+}
+
+func (l *library) contentDir() string {
+	return filepath.Join(l.dir, "content")
+}
+
+func loadLibraryTorrent(path string) (*libraryTorrent, error) {
+	mi, err := metainfo.LoadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := mi.UnmarshalInfo()
+	if err != nil {
+		return nil, err
+	}
+	t := &libraryTorrent{
+		Hash:         mi.HashInfoBytes().HexString(),
+		Name:         info.BestName(),
+		Size:         info.TotalLength(),
+		isSingleFile: !info.IsDir(),
+	}
+	if info.Private != nil {
+		t.Private = *info.Private
+	}
+	for idx, f := range info.UpvertedFiles() {
+		if strings.Contains(f.Attr, "p") {
+			continue
+		}
+		path := "/" + strings.Join(f.BestPath(), "/")
+		if t.isSingleFile {
+			path = "/" + t.Name
+		}
+		t.Files = append(t.Files, libraryFile{
+			Idx:  idx,
+			Path: path,
+			Name: filepath.Base(path),
+			Size: f.Length,
+		})
+	}
+	return t, nil
+}
+
+func (l *library) scan() {
+	entries, err := os.ReadDir(l.torrentsDir())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Error("failed to read torrents dir", "error", err)
+		}
+		return
+	}
+
+	byHash := make(map[string]*libraryTorrent, len(entries))
+	byTorrentKey := make(map[string]*libraryTorrent, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".torrent") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		key := entry.Name()
+		t, ok := l.byTorrentKey[key]
+		if !ok || !t.modTime.Equal(info.ModTime()) {
+			t, err = loadLibraryTorrent(filepath.Join(l.torrentsDir(), key))
+			if err != nil {
+				log.Warn("failed to load torrent", "error", err, "file", key)
+				continue
+			}
+			t.modTime = info.ModTime()
+		}
+		byHash[t.Hash] = t
+		byTorrentKey[key] = t
+	}
+	l.byHash = byHash
+	l.byTorrentKey = byTorrentKey
+}
+
+func (l *library) Get(hash string) *libraryTorrent {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if time.Since(l.lastScanAt) > l.scanInterval {
+		l.scan()
+		l.lastScanAt = time.Now()
+	}
+	return l.byHash[strings.ToLower(hash)]
+}
+
+func (l *library) GetFilePath(t *libraryTorrent, f *libraryFile) string {
+	if t.isSingleFile {
+		return filepath.Join(l.contentDir(), filepath.FromSlash(t.Name))
+	}
+	return filepath.Join(l.contentDir(), filepath.FromSlash(t.Name), filepath.FromSlash(f.Path))
+}
+
+// HasContent checks if all the files for the torrent are present.
+func (l *library) HasContent(t *libraryTorrent) bool {
+	if len(t.Files) == 0 {
+		return false
+	}
+	for i := range t.Files {
+		f := &t.Files[i]
+		info, err := os.Stat(l.GetFilePath(t, f))
+		if err != nil || !info.Mode().IsRegular() || info.Size() != f.Size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package local
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+type magnetEntry struct {
+	Id      string    `json:"id"`
+	Hash    string    `json:"hash"`
+	Name    string    `json:"name,omitempty"`
+	Cached  bool      `json:"cached"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// magnetRegistry keeps track of the magnets added by each user, persisted
+// as json in `<dir>/magnets.json`.
+type magnetRegistry struct {
+	path   string
+	m      sync.Mutex
+	loaded bool
+	byUser map[string][]magnetEntry
+}
+
+func newMagnetRegistry(dir string) *magnetRegistry {
+	return &magnetRegistry{
+		path:   filepath.Join(dir, "magnets.json"),
+		byUser: map[string][]magnetEntry{},
+	}
+}
+
+func (mr *magnetRegistry) load() {
+	if mr.loaded {
+		return
+	}
+	mr.loaded = true
+	blob, err := os.ReadFile(mr.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Error("failed to read magnets", "error", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(blob, &mr.byUser); err != nil {
+		log.Error("failed to parse magnets", "error", err)
+	}
+}
+
+func (mr *magnetRegistry) save() {
+	blob, err := json.Marshal(mr.byUser)
+	if err != nil {
+		log.Error("failed to encode magnets", "error", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(mr.path), 0755); err != nil {
+		log.Error("failed to create dir", "error", err)
+		return
+	}
+	if err := os.WriteFile(mr.path, blob, 0644); err != nil {
+		log.Error("failed to write magnets", "error", err)
+	}
+}
+
+func (mr *magnetRegistry) List(userId string) []magnetEntry {
+	mr.m.Lock()
+	defer mr.m.Unlock()
+
+	mr.load()
+	items := slices.Clone(mr.byUser[userId])
+	slices.SortStableFunc(items, func(a, b magnetEntry) int {
+		return b.AddedAt.Compare(a.AddedAt)
+	})
+	return items
+}
+
+func (mr *magnetRegistry) Get(userId, id string) *magnetEntry {
+	mr.m.Lock()
+	defer mr.m.Unlock()
+
+	mr.load()
+	for _, entry := range mr.byUser[userId] {
+		if entry.Id == id {
+			return &entry
+		}
+	}
+	return nil
+}
+
+// Add returns the existing entry if the magnet is already added.
+func (mr *magnetRegistry) Add(userId string, entry magnetEntry) magnetEntry {
+	mr.m.Lock()
+	defer mr.m.Unlock()
+
+	mr.load()
+	for _, e := range mr.byUser[userId] {
+		if e.Hash == entry.Hash {
+			return e
+		}
+	}
+	mr.byUser[userId] = append(mr.byUser[userId], entry)
+	mr.save()
+	return entry
+}
+
+func (mr *magnetRegistry) Remove(userId, id string) bool {
+	mr.m.Lock()
+	defer mr.m.Unlock()
+
+	mr.load()
+	entries := mr.byUser[userId]
+	idx := slices.IndexFunc(entries, func(e magnetEntry) bool {
+		return e.Id == id
+	})
+	if idx == -1 {
+		return false
+	}
+	mr.byUser[userId] = slices.Delete(entries, idx, idx+1)
+	mr.save()
+	return true
+}
@@ -0,0 +1,458 @@
+package local
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type StoreClientConfig struct {
+	// directory containing `torrents/*.torrent` and `content/<torrent-name>`
+	Dir     string
+	BaseURL *url.URL
+	// time spent in `queued` status for uncached magnets
+	QueuedDuration time.Duration
+	// time spent in `downloading` status for uncached magnets
+	DownloadingDuration time.Duration
+}
+
+// StoreClient is a stand-in store backed by local files, for offline
+// development and integration tests.
+type StoreClient struct {
+	Name                store.StoreName
+	dir                 string
+	baseURL             *url.URL
+	queuedDuration      time.Duration
+	downloadingDuration time.Duration
+	library             *library
+	magnets             *magnetRegistry
+	secret              string
+	secretOnce          sync.Once
+}
+
+func NewStoreClient(config *StoreClientConfig) *StoreClient {
+	c := &StoreClient{}
+	c.Name = store.StoreNameLocal
+	c.dir = config.Dir
+	c.baseURL = config.BaseURL
+	c.queuedDuration = config.QueuedDuration
+	if c.queuedDuration == 0 {
+		c.queuedDuration = 5 * time.Second
+	}
+	c.downloadingDuration = config.DownloadingDuration
+	if c.downloadingDuration == 0 {
+		c.downloadingDuration = 15 * time.Second
+	}
+	c.library = newLibrary(c.dir)
+	c.magnets = newMagnetRegistry(c.dir)
+	return c
+}
+
+func (c *StoreClient) GetName() store.StoreName {
+	return c.Name
+}
+
+func (c *StoreClient) getSecret() string {
+	c.secretOnce.Do(func() {
+		path := filepath.Join(c.dir, "secret")
+		if blob, err := os.ReadFile(path); err == nil && len(blob) > 0 {
+			c.secret = strings.TrimSpace(string(blob))
+			return
+		}
+		c.secret = util.GenerateRandomString(32, util.CharSet.AlphaNumericMixedCase)
+		if err := os.MkdirAll(c.dir, 0755); err != nil {
+			log.Error("failed to create dir", "error", err)
+			return
+		}
+		if err := os.WriteFile(path, []byte(c.secret), 0600); err != nil {
+			log.Error("failed to write secret", "error", err)
+		}
+	})
+	return c.secret
+}
+
+func (c *StoreClient) getUserId(ctx store.Ctx) (string, error) {
+	apiKey := ctx.GetAPIKey("")
+	if apiKey == "" {
+		err := core.NewAPIError("missing token")
+		err.StatusCode = http.StatusUnauthorized
+		err.StoreName = string(store.StoreNameLocal)
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(hash[:8]), nil
+}
+
+func (c *StoreClient) GetUser(params *store.GetUserParams) (*store.User, error) {
+	userId, err := c.getUserId(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	data := &store.User{
+		Id:                 userId,
+		Email:              userId + "@local",
+		SubscriptionStatus: store.UserSubscriptionStatusPremium,
+	}
+	return data, nil
+}
+
+func (c *StoreClient) toMagnetFiles(t *libraryTorrent, withLink bool) []store.MagnetFile {
+	files := make([]store.MagnetFile, len(t.Files))
+	source := string(c.GetName().Code())
+	for i, f := range t.Files {
+		file := store.MagnetFile{
+			Idx:    f.Idx,
+			Path:   f.Path,
+			Name:   f.Name,
+			Size:   f.Size,
+			Source: source,
+		}
+		if withLink {
+			file.Link = LockedFileLink("").Create(t.Hash, f.Idx)
+		}
+		files[i] = file
+	}
+	return files
+}
+
+func (c *StoreClient) CheckMagnet(params *store.CheckMagnetParams) (*store.CheckMagnetData, error) {
+	if _, err := c.getUserId(params.Ctx); err != nil {
+		return nil, err
+	}
+	data := &store.CheckMagnetData{
+		Items: []store.CheckMagnetDataItem{},
+	}
+	for _, m := range params.Magnets {
+		magnet, err := core.ParseMagnetLink(m)
+		if err != nil {
+			return nil, err
+		}
+		item := store.CheckMagnetDataItem{
+			Hash:   magnet.Hash,
+			Magnet: magnet.Link,
+			Status: store.MagnetStatusUnknown,
+			Files:  []store.MagnetFile{},
+		}
+		if t := c.library.Get(magnet.Hash); t != nil && c.library.HasContent(t) {
+			item.Name = t.Name
+			item.Size = t.Size
+			item.Status = store.MagnetStatusCached
+			item.Files = c.toMagnetFiles(t, false)
+		}
+		data.Items = append(data.Items, item)
+	}
+	return data, nil
+}
+
+// getStatus simulates the lifecycle of a magnet:
+// queued -> downloading -> downloaded (failed, without the content files)
+func (c *StoreClient) getStatus(entry *magnetEntry, t *libraryTorrent) store.MagnetStatus {
+	elapsed := time.Since(entry.AddedAt)
+	if t == nil {
+		if elapsed < c.queuedDuration {
+			return store.MagnetStatusQueued
+		}
+		return store.MagnetStatusFailed
+	}
+	if entry.Cached {
+		return store.MagnetStatusDownloaded
+	}
+	if elapsed < c.queuedDuration {
+		return store.MagnetStatusQueued
+	}
+	if elapsed < c.queuedDuration+c.downloadingDuration {
+		return store.MagnetStatusDownloading
+	}
+	if !c.library.HasContent(t) {
+		return store.MagnetStatusFailed
+	}
+	return store.MagnetStatusDownloaded
+}
+
+func (c *StoreClient) AddMagnet(params *store.AddMagnetParams) (*store.AddMagnetData, error) {
+	userId, err := c.getUserId(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var magnet core.MagnetLink
+	if params.Magnet != "" {
+		magnet, err = core.ParseMagnetLink(params.Magnet)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		mi, info, err := params.GetTorrentMeta()
+		if err != nil {
+			return nil, err
+		}
+		if mi == nil {
+			error := core.NewAPIError("missing magnet")
+			error.StatusCode = http.StatusBadRequest
+			return nil, error
+		}
+		magnet, err = core.ParseMagnetLink(mi.HashInfoBytes().HexString())
+		if err != nil {
+			return nil, err
+		}
+		magnet.Name = info.BestName()
+	}
+	if magnet.Hash == "" {
+		error := core.NewStoreError("invalid magnet")
+		error.Code = core.ErrorCodeStoreMagnetInvalid
+		error.StoreName = string(store.StoreNameLocal)
+		return nil, error
+	}
+
+	t := c.library.Get(magnet.Hash)
+	entry := c.magnets.Add(userId, magnetEntry{
+		Id:      magnet.Hash,
+		Hash:    magnet.Hash,
+		Name:    magnet.Name,
+		Cached:  t != nil && c.library.HasContent(t),
+		AddedAt: time.Now().UTC(),
+	})
+
+	data := &store.AddMagnetData{
+		Id:      entry.Id,
+		Hash:    entry.Hash,
+		Magnet:  magnet.Link,
+		Name:    entry.Name,
+		Status:  c.getStatus(&entry, t),
+		Files:   []store.MagnetFile{},
+		AddedAt: entry.AddedAt,
+	}
+	if t != nil {
+		data.Name = t.Name
+		data.Size = t.Size
+		data.Private = t.Private
+		data.Files = c.toMagnetFiles(t, data.Status == store.MagnetStatusDownloaded)
+	}
+	return data, nil
+}
+
+func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnetData, error) {
+	userId, err := c.getUserId(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry := c.magnets.Get(userId, params.Id)
+	if entry == nil {
+		error := core.NewAPIError("not found")
+		error.StatusCode = http.StatusNotFound
+		error.StoreName = string(store.StoreNameLocal)
+		return nil, error
+	}
+	t := c.library.Get(entry.Hash)
+	data := &store.GetMagnetData{
+		Id:      entry.Id,
+		Name:    entry.Name,
+		Hash:    entry.Hash,
+		Status:  c.getStatus(entry, t),
+		Files:   []store.MagnetFile{},
+		AddedAt: entry.AddedAt,
+	}
+	if t != nil {
+		data.Name = t.Name
+		data.Size = t.Size
+		data.Private = t.Private
+		data.Files = c.toMagnetFiles(t, data.Status == store.MagnetStatusDownloaded)
+	}
+	return data, nil
+}
+
+func (c *StoreClient) ListMagnets(params *store.ListMagnetsParams) (*store.ListMagnetsData, error) {
+	userId, err := c.getUserId(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := c.magnets.List(userId)
+	data := &store.ListMagnetsData{
+		Items:      []store.ListMagnetsDataItem{},
+		TotalItems: len(entries),
+	}
+	start := min(params.Offset, len(entries))
+	end := min(params.Offset+params.Limit, len(entries))
+	for i := range entries[start:end] {
+		entry := &entries[start+i]
+		t := c.library.Get(entry.Hash)
+		item := store.ListMagnetsDataItem{
+			Id:      entry.Id,
+			Hash:    entry.Hash,
+			Name:    entry.Name,
+			Status:  c.getStatus(entry, t),
+			AddedAt: entry.AddedAt,
+		}
+		if t != nil {
+			item.Name = t.Name
+			item.Size = t.Size
+			item.Private = t.Private
+		}
+		data.Items = append(data.Items, item)
+	}
+	return data, nil
+}
+
+func (c *StoreClient) RemoveMagnet(params *store.RemoveMagnetParams) (*store.RemoveMagnetData, error) {
+	userId, err := c.getUserId(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !c.magnets.Remove(userId, params.Id) {
+		error := core.NewAPIError("not found")
+		error.StatusCode = http.StatusNotFound
+		error.StoreName = string(store.StoreNameLocal)
+		return nil, error
+	}
+	return &store.RemoveMagnetData{Id: params.Id}, nil
+}
+
+type LockedFileLink string
+
+const lockedFileLinkPrefix = "stremthru://store/local/"
+
+func (l LockedFileLink) Create(hash string, fileIdx int) string {
+	return lockedFileLinkPrefix + core.Base64Encode(hash+":"+strconv.Itoa(fileIdx))
+}
+
+func (l LockedFileLink) Parse() (hash string, fileIdx int, err error) {
+	encoded, ok := strings.CutPrefix(string(l), lockedFileLinkPrefix)
+	if !ok {
+		return "", 0, errors.New("invalid link")
+	}
+	decoded, err := core.Base64Decode(encoded)
+	if err != nil {
+		return "", 0, err
+	}
+	hash, idx, ok := strings.Cut(decoded, ":")
+	if !ok {
+		return "", 0, errors.New("invalid link")
+	}
+	fileIdx, err = strconv.Atoi(idx)
+	if err != nil {
+		return "", 0, err
+	}
+	return hash, fileIdx, nil
+}
+
+type contentTokenData struct {
+	Hash    string `json:"hash"`
+	FileIdx int    `json:"fidx"`
+}
+
+func (c *StoreClient) GenerateLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	userId, err := c.getUserId(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	hash, fileIdx, err := LockedFileLink(params.Link).Parse()
+	if err != nil {
+		error := core.NewAPIError("invalid link")
+		error.StatusCode = http.StatusBadRequest
+		error.Cause = err
+		return nil, error
+	}
+	entry := c.magnets.Get(userId, hash)
+	t := c.library.Get(hash)
+	if entry == nil || t == nil || c.getStatus(entry, t) != store.MagnetStatusDownloaded {
+		error := core.NewAPIError("not found")
+		error.StatusCode = http.StatusNotFound
+		error.StoreName = string(store.StoreNameLocal)
+		return nil, error
+	}
+	var file *libraryFile
+	for i := range t.Files {
+		if t.Files[i].Idx == fileIdx {
+			file = &t.Files[i]
+			break
+		}
+	}
+	if file == nil {
+		error := core.NewAPIError("file not found")
+		error.StatusCode = http.StatusNotFound
+		error.StoreName = string(store.StoreNameLocal)
+		return nil, error
+	}
+
+	token, err := core.CreateJWT(c.getSecret(), core.JWTClaims[contentTokenData]{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "stremthru",
+			Subject:   userId,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(12 * time.Hour)),
+		},
+		Data: &contentTokenData{
+			Hash:    hash,
+			FileIdx: fileIdx,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	link := c.baseURL.JoinPath("/v0/store/_/local", token, file.Name)
+	data := &store.GenerateLinkData{Link: link.String()}
+	return data, nil
+}
+
+// ServeContent serves the file for the token from link generated by GenerateLink.
+func (c *StoreClient) ServeContent(w http.ResponseWriter, r *http.Request, token string) error {
+	claims := &core.JWTClaims[contentTokenData]{}
+	if _, err := core.ParseJWT(func(t *jwt.Token) (any, error) {
+		return []byte(c.getSecret()), nil
+	}, token, claims); err != nil {
+		error := core.NewAPIError("unauthorized")
+		error.StatusCode = http.StatusUnauthorized
+		error.Cause = err
+		return error
+	}
+
+	notFoundError := core.NewAPIError("not found")
+	notFoundError.StatusCode = http.StatusNotFound
+	notFoundError.StoreName = string(store.StoreNameLocal)
+
+	t := c.library.Get(claims.Data.Hash)
+	if t == nil {
+		return notFoundError
+	}
+	var file *libraryFile
+	for i := range t.Files {
+		if t.Files[i].Idx == claims.Data.FileIdx {
+			file = &t.Files[i]
+			break
+		}
+	}
+	if file == nil {
+		return notFoundError
+	}
+	path := c.library.GetFilePath(t, file)
+	if !strings.HasPrefix(path, c.library.contentDir()+string(filepath.Separator)) {
+		return notFoundError
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notFoundError
+		}
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	http.ServeContent(w, r, file.Name, info.ModTime(), f)
+	return nil
+}
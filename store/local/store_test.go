@@ -0,0 +1,130 @@
+package local
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTorrent(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	contentPath := filepath.Join(dir, "content", name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(contentPath), 0755))
+	require.NoError(t, os.WriteFile(contentPath, []byte(content), 0644))
+
+	info := metainfo.Info{PieceLength: 16 * 1024}
+	require.NoError(t, info.BuildFromFilePath(contentPath))
+	infoBytes, err := bencode.Marshal(info)
+	require.NoError(t, err)
+	mi := metainfo.MetaInfo{InfoBytes: infoBytes}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "torrents"), 0755))
+	f, err := os.Create(filepath.Join(dir, "torrents", name+".torrent"))
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, mi.Write(f))
+
+	return mi.HashInfoBytes().HexString()
+}
+
+func TestStoreClient(t *testing.T) {
+	dir := t.TempDir()
+	hash := createTorrent(t, dir, "video.mkv", "hello world")
+	unknownHash := strings.Repeat("a", 40)
+
+	c := NewStoreClient(&StoreClientConfig{
+		Dir:                 dir,
+		BaseURL:             &url.URL{Scheme: "http", Host: "localhost"},
+		QueuedDuration:      time.Hour,
+		DownloadingDuration: time.Hour,
+	})
+
+	ctx := store.Ctx{APIKey: "token"}
+
+	cmParams := &store.CheckMagnetParams{Ctx: ctx, Magnets: []string{hash, unknownHash}}
+	cmData, err := c.CheckMagnet(cmParams)
+	require.NoError(t, err)
+	require.Len(t, cmData.Items, 2)
+	assert.Equal(t, store.MagnetStatusCached, cmData.Items[0].Status)
+	assert.Len(t, cmData.Items[0].Files, 1)
+	assert.Equal(t, store.MagnetStatusUnknown, cmData.Items[1].Status)
+
+	amData, err := c.AddMagnet(&store.AddMagnetParams{Ctx: ctx, Magnet: hash})
+	require.NoError(t, err)
+	assert.Equal(t, store.MagnetStatusDownloaded, amData.Status)
+	require.Len(t, amData.Files, 1)
+
+	amData2, err := c.AddMagnet(&store.AddMagnetParams{Ctx: ctx, Magnet: unknownHash})
+	require.NoError(t, err)
+	assert.Equal(t, store.MagnetStatusQueued, amData2.Status)
+
+	lmData, err := c.ListMagnets(&store.ListMagnetsParams{Ctx: ctx, Limit: 100})
+	require.NoError(t, err)
+	assert.Equal(t, 2, lmData.TotalItems)
+
+	otherLmData, err := c.ListMagnets(&store.ListMagnetsParams{Ctx: store.Ctx{APIKey: "other"}, Limit: 100})
+	require.NoError(t, err)
+	assert.Equal(t, 0, otherLmData.TotalItems)
+
+	glData, err := c.GenerateLink(&store.GenerateLinkParams{Ctx: ctx, Link: amData.Files[0].Link})
+	require.NoError(t, err)
+
+	link, err := url.Parse(glData.Link)
+	require.NoError(t, err)
+	token := strings.Split(strings.TrimPrefix(link.Path, "/v0/store/_/local/"), "/")[0]
+
+	req := httptest.NewRequest(http.MethodGet, link.Path, nil)
+	req.Header.Set("Range", "bytes=6-")
+	w := httptest.NewRecorder()
+	require.NoError(t, c.ServeContent(w, req, token))
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "world", w.Body.String())
+
+	_, err = c.RemoveMagnet(&store.RemoveMagnetParams{Ctx: ctx, Id: amData2.Id})
+	require.NoError(t, err)
+	lmData, err = c.ListMagnets(&store.ListMagnetsParams{Ctx: ctx, Limit: 100})
+	require.NoError(t, err)
+	assert.Equal(t, 1, lmData.TotalItems)
+}
+
+func TestStoreClientMissingContent(t *testing.T) {
+	dir := t.TempDir()
+	hash := createTorrent(t, dir, "video.mkv", "hello world")
+	require.NoError(t, os.Remove(filepath.Join(dir, "content", "video.mkv")))
+
+	c := NewStoreClient(&StoreClientConfig{
+		Dir:                 dir,
+		BaseURL:             &url.URL{Scheme: "http", Host: "localhost"},
+		QueuedDuration:      time.Nanosecond,
+		DownloadingDuration: time.Nanosecond,
+	})
+
+	ctx := store.Ctx{APIKey: "token"}
+
+	cmData, err := c.CheckMagnet(&store.CheckMagnetParams{Ctx: ctx, Magnets: []string{hash}})
+	require.NoError(t, err)
+	assert.Equal(t, store.MagnetStatusUnknown, cmData.Items[0].Status)
+
+	amData, err := c.AddMagnet(&store.AddMagnetParams{Ctx: ctx, Magnet: hash})
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+
+	gmData, err := c.GetMagnet(&store.GetMagnetParams{Ctx: ctx, Id: amData.Id})
+	require.NoError(t, err)
+	assert.Equal(t, store.MagnetStatusFailed, gmData.Status)
+	for _, f := range gmData.Files {
+		assert.Empty(t, f.Link)
+	}
+}
@@ -0,0 +1,67 @@
+package multi
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+// MagnetId is `<store_code>.<base64url(id)>`, the encoding keeps it free of
+// `:` which is used as separator in stremio ids.
+type MagnetId string
+
+func (id MagnetId) Create(storeCode store.StoreCode, memberId string) string {
+	return string(storeCode) + "." + base64.RawURLEncoding.EncodeToString([]byte(memberId))
+}
+
+func (id MagnetId) Parse() (storeCode store.StoreCode, memberId string, err error) {
+	code, encoded, ok := strings.Cut(string(id), ".")
+	if !ok {
+		return "", "", errors.New("invalid id")
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", err
+	}
+	return store.StoreCode(code), string(decoded), nil
+}
+
+type LockedFileLink string
+
+const lockedFileLinkPrefix = "stremthru://store/multi/"
+
+type lockedFileLinkData struct {
+	StoreCode store.StoreCode
+	Hash      string
+	Path      string
+	Link      string
+}
+
+func (l LockedFileLink) Create(storeCode store.StoreCode, hash, path, link string) string {
+	blob := string(storeCode) + "\n" + hash + "\n" + path + "\n" + link
+	return lockedFileLinkPrefix + core.Base64Encode(blob)
+}
+
+func (l LockedFileLink) Parse() (*lockedFileLinkData, error) {
+	encoded, ok := strings.CutPrefix(string(l), lockedFileLinkPrefix)
+	if !ok {
+		return nil, errors.New("invalid link")
+	}
+	blob, err := core.Base64Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(blob, "\n", 4)
+	if len(parts) != 4 {
+		return nil, errors.New("invalid link")
+	}
+	return &lockedFileLinkData{
+		StoreCode: store.StoreCode(parts[0]),
+		Hash:      parts[1],
+		Path:      parts[2],
+		Link:      parts[3],
+	}, nil
+}
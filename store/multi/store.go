@@ -0,0 +1,528 @@
+package multi
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+type StoreClientConfig struct {
+	Stores []store.Store
+}
+
+// StoreClient is a virtual store backed by an ordered list of member stores.
+//
+// - CheckMagnet merges the cache status across all members.
+// - AddMagnet goes to the first member that has the magnet cached.
+// - GenerateLink fails over to the next member on store errors.
+type StoreClient struct {
+	Name        store.StoreName
+	storeByName map[store.StoreName]store.Store
+}
+
+func NewStoreClient(config *StoreClientConfig) *StoreClient {
+	c := &StoreClient{}
+	c.Name = store.StoreNameMulti
+
+	c.storeByName = make(map[store.StoreName]store.Store, len(config.Stores))
+	for _, s := range config.Stores {
+		c.storeByName[s.GetName()] = s
+	}
+
+	return c
+}
+
+func (c *StoreClient) GetName() store.StoreName {
+	return c.Name
+}
+
+type member struct {
+	store store.Store
+	token string
+}
+
+func (m member) code() store.StoreCode {
+	return m.store.GetName().Code()
+}
+
+func (c *StoreClient) getMembers(ctx store.Ctx) ([]member, error) {
+	items, err := ParseToken(ctx.GetAPIKey(""))
+	if err != nil {
+		return nil, err
+	}
+	members := make([]member, len(items))
+	for i, item := range items {
+		s, ok := c.storeByName[item.StoreName]
+		if !ok {
+			return nil, ErrorInvalidToken(store.ErrorInvalidStoreName(string(item.StoreName)))
+		}
+		members[i] = member{store: s, token: item.Token}
+	}
+	return members, nil
+}
+
+func (c *StoreClient) getMemberByCode(ctx store.Ctx, storeCode store.StoreCode) (*member, error) {
+	members, err := c.getMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range members {
+		if members[i].code() == storeCode {
+			return &members[i], nil
+		}
+	}
+	error := core.NewAPIError("store not configured: " + string(storeCode))
+	error.StatusCode = http.StatusBadRequest
+	error.StoreName = string(store.StoreNameMulti)
+	return nil, error
+}
+
+// shouldFailover reports whether the error originated from the store itself,
+// i.e. trying the same operation on another member may succeed.
+func shouldFailover(err error) bool {
+	var storeErr *core.StoreError
+	var upstreamErr *core.UpstreamError
+	return errors.As(err, &storeErr) || errors.As(err, &upstreamErr)
+}
+
+func invalidIdError(cause error) error {
+	error := core.NewAPIError("invalid id")
+	error.StatusCode = http.StatusBadRequest
+	error.StoreName = string(store.StoreNameMulti)
+	error.Cause = cause
+	return error
+}
+
+func (c *StoreClient) GetUser(params *store.GetUserParams) (*store.User, error) {
+	members, err := c.getMembers(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*store.User, len(members))
+	errs := make([]error, len(members))
+
+	var wg sync.WaitGroup
+	for i, m := range members {
+		wg.Go(func() {
+			guParams := &store.GetUserParams{Ctx: params.Ctx}
+			guParams.APIKey = m.token
+			users[i], errs[i] = m.store.GetUser(guParams)
+		})
+	}
+	wg.Wait()
+
+	var data *store.User
+	for i, user := range users {
+		if errs[i] != nil {
+			continue
+		}
+		if data == nil {
+			data = &store.User{
+				Id:                 user.Id,
+				Email:              user.Email,
+				SubscriptionStatus: user.SubscriptionStatus,
+			}
+		}
+		if user.SubscriptionStatus == store.UserSubscriptionStatusPremium {
+			data.SubscriptionStatus = store.UserSubscriptionStatusPremium
+		}
+		if user.HasUsenet {
+			data.HasUsenet = true
+		}
+	}
+	if data == nil {
+		return nil, errors.Join(errs...)
+	}
+	return data, nil
+}
+
+type memberCheckMagnetResult struct {
+	member member
+	byHash map[string]store.CheckMagnetDataItem
+	err    error
+}
+
+func (c *StoreClient) checkMagnet(params *store.CheckMagnetParams, members []member) []memberCheckMagnetResult {
+	results := make([]memberCheckMagnetResult, len(members))
+
+	var wg sync.WaitGroup
+	for i, m := range members {
+		results[i].member = m
+		wg.Go(func() {
+			cmParams := &store.CheckMagnetParams{
+				Ctx:              params.Ctx,
+				Magnets:          params.Magnets,
+				ClientIP:         params.ClientIP,
+				SId:              params.SId,
+				LocalOnly:        params.LocalOnly,
+				IsTrustedRequest: params.IsTrustedRequest,
+			}
+			cmParams.APIKey = m.token
+			res, err := m.store.CheckMagnet(cmParams)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			results[i].byHash = make(map[string]store.CheckMagnetDataItem, len(res.Items))
+			for _, item := range res.Items {
+				results[i].byHash[strings.ToLower(item.Hash)] = item
+			}
+		})
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (c *StoreClient) CheckMagnet(params *store.CheckMagnetParams) (*store.CheckMagnetData, error) {
+	members, err := c.getMembers(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	magnets := make([]core.MagnetLink, len(params.Magnets))
+	for i, m := range params.Magnets {
+		magnet, err := core.ParseMagnetLink(m)
+		if err != nil {
+			return nil, err
+		}
+		magnets[i] = magnet
+	}
+
+	results := c.checkMagnet(params, members)
+
+	errs := []error{}
+	for i := range results {
+		if results[i].err != nil {
+			errs = append(errs, results[i].err)
+		}
+	}
+	if len(errs) == len(results) {
+		return nil, errors.Join(errs...)
+	}
+
+	data := &store.CheckMagnetData{
+		Items: []store.CheckMagnetDataItem{},
+	}
+	for _, magnet := range magnets {
+		var found *store.CheckMagnetDataItem
+		for i := range results {
+			item, ok := results[i].byHash[magnet.Hash]
+			if !ok {
+				continue
+			}
+			if item.Status == store.MagnetStatusCached {
+				found = &item
+				break
+			}
+			if found == nil {
+				found = &item
+			}
+		}
+		if found == nil {
+			if params.LocalOnly {
+				continue
+			}
+			found = &store.CheckMagnetDataItem{
+				Hash:   magnet.Hash,
+				Magnet: magnet.Link,
+				Status: store.MagnetStatusUnknown,
+				Files:  []store.MagnetFile{},
+			}
+		}
+		data.Items = append(data.Items, *found)
+	}
+	return data, nil
+}
+
+func (c *StoreClient) wrapFiles(storeCode store.StoreCode, hash string, files []store.MagnetFile) []store.MagnetFile {
+	wrapped := make([]store.MagnetFile, len(files))
+	for i, f := range files {
+		if f.Link != "" {
+			f.Link = LockedFileLink("").Create(storeCode, hash, f.Path, f.Link)
+		}
+		wrapped[i] = f
+	}
+	return wrapped
+}
+
+func (c *StoreClient) addMagnet(params *store.AddMagnetParams, m member) (*store.AddMagnetData, error) {
+	amParams := &store.AddMagnetParams{
+		Ctx:      params.Ctx,
+		Magnet:   params.Magnet,
+		Torrent:  params.Torrent,
+		ClientIP: params.ClientIP,
+	}
+	amParams.APIKey = m.token
+	data, err := m.store.AddMagnet(amParams)
+	if err != nil {
+		return nil, err
+	}
+	storeCode := m.code()
+	data.Id = MagnetId("").Create(storeCode, data.Id)
+	data.Files = c.wrapFiles(storeCode, data.Hash, data.Files)
+	return data, nil
+}
+
+func (c *StoreClient) AddMagnet(params *store.AddMagnetParams) (*store.AddMagnetData, error) {
+	members, err := c.getMembers(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := ""
+	if params.Magnet != "" {
+		magnet, err := core.ParseMagnetLink(params.Magnet)
+		if err != nil {
+			return nil, err
+		}
+		hash = magnet.Hash
+	} else {
+		mi, _, err := params.GetTorrentMeta()
+		if err != nil {
+			return nil, err
+		}
+		if mi != nil {
+			hash = mi.HashInfoBytes().HexString()
+		}
+	}
+
+	candidates := members
+	if hash != "" && len(members) > 1 {
+		results := c.checkMagnet(&store.CheckMagnetParams{
+			Ctx:      params.Ctx,
+			Magnets:  []string{hash},
+			ClientIP: params.ClientIP,
+		}, members)
+		cached, uncached := []member{}, []member{}
+		for i := range results {
+			if item, ok := results[i].byHash[hash]; ok && item.Status == store.MagnetStatusCached {
+				cached = append(cached, results[i].member)
+			} else {
+				uncached = append(uncached, results[i].member)
+			}
+		}
+		candidates = append(cached, uncached...)
+	}
+
+	var firstErr error
+	for _, m := range candidates {
+		data, err := c.addMagnet(params, m)
+		if err == nil {
+			return data, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		if !shouldFailover(err) {
+			break
+		}
+	}
+	return nil, firstErr
+}
+
+func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnetData, error) {
+	storeCode, id, err := MagnetId(params.Id).Parse()
+	if err != nil {
+		return nil, invalidIdError(err)
+	}
+	m, err := c.getMemberByCode(params.Ctx, storeCode)
+	if err != nil {
+		return nil, err
+	}
+	gmParams := &store.GetMagnetParams{
+		Ctx:      params.Ctx,
+		Id:       id,
+		ClientIP: params.ClientIP,
+	}
+	gmParams.APIKey = m.token
+	data, err := m.store.GetMagnet(gmParams)
+	if err != nil {
+		return nil, err
+	}
+	data.Id = params.Id
+	data.Files = c.wrapFiles(storeCode, data.Hash, data.Files)
+	return data, nil
+}
+
+const listMagnetsPageSize = 500
+
+// listMagnets fetches the first `count` magnets of the member, page by page.
+func (c *StoreClient) listMagnets(params *store.ListMagnetsParams, m member, count int) (*store.ListMagnetsData, error) {
+	data := &store.ListMagnetsData{
+		Items: []store.ListMagnetsDataItem{},
+	}
+	for offset := 0; offset < count; {
+		lmParams := &store.ListMagnetsParams{
+			Ctx:      params.Ctx,
+			Limit:    min(count-offset, listMagnetsPageSize),
+			Offset:   offset,
+			ClientIP: params.ClientIP,
+		}
+		lmParams.APIKey = m.token
+		res, err := m.store.ListMagnets(lmParams)
+		if err != nil {
+			return nil, err
+		}
+		data.Items = append(data.Items, res.Items...)
+		data.TotalItems = res.TotalItems
+		offset += len(res.Items)
+		if len(res.Items) == 0 || offset >= res.TotalItems {
+			break
+		}
+	}
+	return data, nil
+}
+
+func (c *StoreClient) ListMagnets(params *store.ListMagnetsParams) (*store.ListMagnetsData, error) {
+	members, err := c.getMembers(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	count := params.Offset + params.Limit
+
+	results := make([]*store.ListMagnetsData, len(members))
+	errs := make([]error, len(members))
+
+	var wg sync.WaitGroup
+	for i, m := range members {
+		wg.Go(func() {
+			results[i], errs[i] = c.listMagnets(params, m, count)
+		})
+	}
+	wg.Wait()
+
+	data := &store.ListMagnetsData{
+		Items:      []store.ListMagnetsDataItem{},
+		TotalItems: 0,
+	}
+	hasData := false
+	for i, res := range results {
+		if errs[i] != nil {
+			continue
+		}
+		hasData = true
+		storeCode := members[i].code()
+		for _, item := range res.Items {
+			item.Id = MagnetId("").Create(storeCode, item.Id)
+			data.Items = append(data.Items, item)
+		}
+		data.TotalItems += res.TotalItems
+	}
+	if !hasData {
+		return nil, errors.Join(errs...)
+	}
+
+	slices.SortStableFunc(data.Items, func(a, b store.ListMagnetsDataItem) int {
+		return b.AddedAt.Compare(a.AddedAt)
+	})
+
+	start := min(params.Offset, len(data.Items))
+	end := min(params.Offset+params.Limit, len(data.Items))
+	data.Items = data.Items[start:end]
+
+	return data, nil
+}
+
+func (c *StoreClient) RemoveMagnet(params *store.RemoveMagnetParams) (*store.RemoveMagnetData, error) {
+	storeCode, id, err := MagnetId(params.Id).Parse()
+	if err != nil {
+		return nil, invalidIdError(err)
+	}
+	m, err := c.getMemberByCode(params.Ctx, storeCode)
+	if err != nil {
+		return nil, err
+	}
+	rmParams := &store.RemoveMagnetParams{
+		Ctx: params.Ctx,
+		Id:  id,
+	}
+	rmParams.APIKey = m.token
+	if _, err := m.store.RemoveMagnet(rmParams); err != nil {
+		return nil, err
+	}
+	return &store.RemoveMagnetData{Id: params.Id}, nil
+}
+
+func (c *StoreClient) generateLink(params *store.GenerateLinkParams, m member, link string) (*store.GenerateLinkData, error) {
+	glParams := &store.GenerateLinkParams{
+		Ctx:      params.Ctx,
+		Link:     link,
+		ClientIP: params.ClientIP,
+	}
+	glParams.APIKey = m.token
+	return m.store.GenerateLink(glParams)
+}
+
+// generateLinkFromMember adds the magnet to the member store, and generates
+// link for the matching file, if the magnet is ready for download.
+func (c *StoreClient) generateLinkFromMember(params *store.GenerateLinkParams, m member, hash, path string) (*store.GenerateLinkData, error) {
+	amParams := &store.AddMagnetParams{
+		Ctx:      params.Ctx,
+		Magnet:   hash,
+		ClientIP: params.ClientIP,
+	}
+	amParams.APIKey = m.token
+	magnet, err := m.store.AddMagnet(amParams)
+	if err != nil {
+		return nil, err
+	}
+	if magnet.Status != store.MagnetStatusDownloaded {
+		error := core.NewStoreError("magnet not downloaded, status: " + string(magnet.Status))
+		error.StoreName = string(m.store.GetName())
+		return nil, error
+	}
+	for _, f := range magnet.Files {
+		if f.Path == path && f.Link != "" {
+			return c.generateLink(params, m, f.Link)
+		}
+	}
+	error := core.NewStoreError("file not found")
+	error.StoreName = string(m.store.GetName())
+	return nil, error
+}
+
+func (c *StoreClient) GenerateLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	ld, err := LockedFileLink(params.Link).Parse()
+	if err != nil {
+		error := core.NewAPIError("invalid link")
+		error.StatusCode = http.StatusBadRequest
+		error.Cause = err
+		return nil, error
+	}
+
+	members, err := c.getMembers(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	originIdx := slices.IndexFunc(members, func(m member) bool {
+		return m.code() == ld.StoreCode
+	})
+	if originIdx == -1 {
+		return nil, invalidIdError(errors.New("store not configured: " + string(ld.StoreCode)))
+	}
+
+	data, err := c.generateLink(params, members[originIdx], ld.Link)
+	if err == nil || !shouldFailover(err) || ld.Hash == "" {
+		return data, err
+	}
+
+	for i, m := range members {
+		if i == originIdx {
+			continue
+		}
+		if data, ferr := c.generateLinkFromMember(params, m, ld.Hash, ld.Path); ferr == nil {
+			return data, nil
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,264 @@
+package multi
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	hashA = strings.Repeat("a", 40)
+	hashB = strings.Repeat("b", 40)
+	hashC = strings.Repeat("c", 40)
+)
+
+type fakeStore struct {
+	name store.StoreName
+
+	statusByHash map[string]store.MagnetStatus
+	magnets      []store.ListMagnetsDataItem
+	files        []store.MagnetFile
+
+	addMagnetErr    error
+	generateLinkErr error
+
+	mu          sync.Mutex
+	addedHashes []string
+}
+
+func (s *fakeStore) GetName() store.StoreName {
+	return s.name
+}
+
+func (s *fakeStore) GetUser(params *store.GetUserParams) (*store.User, error) {
+	return &store.User{Id: string(s.name)}, nil
+}
+
+func (s *fakeStore) CheckMagnet(params *store.CheckMagnetParams) (*store.CheckMagnetData, error) {
+	data := &store.CheckMagnetData{}
+	for _, m := range params.Magnets {
+		magnet, err := core.ParseMagnetLink(m)
+		if err != nil {
+			return nil, err
+		}
+		status, ok := s.statusByHash[magnet.Hash]
+		if !ok {
+			status = store.MagnetStatusUnknown
+		}
+		data.Items = append(data.Items, store.CheckMagnetDataItem{
+			Hash:   magnet.Hash,
+			Magnet: magnet.Link,
+			Status: status,
+			Files:  []store.MagnetFile{{Path: "/" + string(s.name)}},
+		})
+	}
+	return data, nil
+}
+
+func (s *fakeStore) AddMagnet(params *store.AddMagnetParams) (*store.AddMagnetData, error) {
+	magnet, err := core.ParseMagnetLink(params.Magnet)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.addedHashes = append(s.addedHashes, magnet.Hash)
+	s.mu.Unlock()
+	if s.addMagnetErr != nil {
+		return nil, s.addMagnetErr
+	}
+	return &store.AddMagnetData{
+		Id:     string(s.name) + ":" + magnet.Hash,
+		Hash:   magnet.Hash,
+		Status: store.MagnetStatusDownloaded,
+		Files:  s.files,
+	}, nil
+}
+
+func (s *fakeStore) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnetData, error) {
+	return &store.GetMagnetData{Id: params.Id}, nil
+}
+
+func (s *fakeStore) ListMagnets(params *store.ListMagnetsParams) (*store.ListMagnetsData, error) {
+	start := min(params.Offset, len(s.magnets))
+	end := min(params.Offset+min(params.Limit, 500), len(s.magnets))
+	return &store.ListMagnetsData{
+		Items:      s.magnets[start:end],
+		TotalItems: len(s.magnets),
+	}, nil
+}
+
+func (s *fakeStore) RemoveMagnet(params *store.RemoveMagnetParams) (*store.RemoveMagnetData, error) {
+	return &store.RemoveMagnetData{Id: params.Id}, nil
+}
+
+func (s *fakeStore) GenerateLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	if s.generateLinkErr != nil {
+		return nil, s.generateLinkErr
+	}
+	return &store.GenerateLinkData{Link: "https://" + string(s.name) + "/" + params.Link}, nil
+}
+
+func newTestClient(stores ...*fakeStore) (*StoreClient, store.Ctx) {
+	members := make([]Member, len(stores))
+	ss := make([]store.Store, len(stores))
+	for i, s := range stores {
+		members[i] = Member{StoreName: s.name, Token: "token"}
+		ss[i] = s
+	}
+	client := NewStoreClient(&StoreClientConfig{Stores: ss})
+	ctx := store.Ctx{APIKey: FormatToken(members)}
+	return client, ctx
+}
+
+func TestStoreClient_CheckMagnet(t *testing.T) {
+	rd := &fakeStore{name: store.StoreNameRealDebrid, statusByHash: map[string]store.MagnetStatus{
+		hashA: store.MagnetStatusDownloading,
+	}}
+	tb := &fakeStore{name: store.StoreNameTorBox, statusByHash: map[string]store.MagnetStatus{
+		hashA: store.MagnetStatusCached,
+		hashB: store.MagnetStatusCached,
+	}}
+	client, ctx := newTestClient(rd, tb)
+
+	params := &store.CheckMagnetParams{Ctx: ctx, Magnets: []string{hashA, hashB, hashC}}
+	data, err := client.CheckMagnet(params)
+	assert.NoError(t, err)
+	assert.Len(t, data.Items, 3)
+
+	assert.Equal(t, hashA, data.Items[0].Hash)
+	assert.Equal(t, store.MagnetStatusCached, data.Items[0].Status)
+	assert.Equal(t, "/torbox", data.Items[0].Files[0].Path)
+
+	assert.Equal(t, hashB, data.Items[1].Hash)
+	assert.Equal(t, store.MagnetStatusCached, data.Items[1].Status)
+
+	assert.Equal(t, hashC, data.Items[2].Hash)
+	assert.Equal(t, store.MagnetStatusUnknown, data.Items[2].Status)
+	assert.Equal(t, "/realdebrid", data.Items[2].Files[0].Path)
+}
+
+func TestStoreClient_AddMagnet(t *testing.T) {
+	t.Run("prefers cached member", func(t *testing.T) {
+		rd := &fakeStore{name: store.StoreNameRealDebrid}
+		tb := &fakeStore{name: store.StoreNameTorBox, statusByHash: map[string]store.MagnetStatus{
+			hashA: store.MagnetStatusCached,
+		}}
+		client, ctx := newTestClient(rd, tb)
+
+		data, err := client.AddMagnet(&store.AddMagnetParams{Ctx: ctx, Magnet: hashA})
+		assert.NoError(t, err)
+		assert.Empty(t, rd.addedHashes)
+		assert.Equal(t, []string{hashA}, tb.addedHashes)
+
+		storeCode, id, err := MagnetId(data.Id).Parse()
+		assert.NoError(t, err)
+		assert.Equal(t, store.StoreCodeTorBox, storeCode)
+		assert.Equal(t, "torbox:"+hashA, id)
+	})
+
+	t.Run("keeps order when none cached", func(t *testing.T) {
+		rd := &fakeStore{name: store.StoreNameRealDebrid}
+		tb := &fakeStore{name: store.StoreNameTorBox}
+		client, ctx := newTestClient(rd, tb)
+
+		_, err := client.AddMagnet(&store.AddMagnetParams{Ctx: ctx, Magnet: hashA})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{hashA}, rd.addedHashes)
+		assert.Empty(t, tb.addedHashes)
+	})
+
+	t.Run("fails over on store error", func(t *testing.T) {
+		rd := &fakeStore{name: store.StoreNameRealDebrid, addMagnetErr: core.NewStoreError("boom")}
+		tb := &fakeStore{name: store.StoreNameTorBox}
+		client, ctx := newTestClient(rd, tb)
+
+		data, err := client.AddMagnet(&store.AddMagnetParams{Ctx: ctx, Magnet: hashA})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{hashA}, rd.addedHashes)
+		assert.Equal(t, []string{hashA}, tb.addedHashes)
+		storeCode, _, _ := MagnetId(data.Id).Parse()
+		assert.Equal(t, store.StoreCodeTorBox, storeCode)
+	})
+
+	t.Run("does not fail over on other error", func(t *testing.T) {
+		rd := &fakeStore{name: store.StoreNameRealDebrid, addMagnetErr: core.NewAPIError("bad request")}
+		tb := &fakeStore{name: store.StoreNameTorBox}
+		client, ctx := newTestClient(rd, tb)
+
+		_, err := client.AddMagnet(&store.AddMagnetParams{Ctx: ctx, Magnet: hashA})
+		assert.Error(t, err)
+		assert.Empty(t, tb.addedHashes)
+	})
+}
+
+func TestStoreClient_GenerateLink(t *testing.T) {
+	t.Run("origin member", func(t *testing.T) {
+		rd := &fakeStore{name: store.StoreNameRealDebrid}
+		tb := &fakeStore{name: store.StoreNameTorBox}
+		client, ctx := newTestClient(rd, tb)
+
+		link := LockedFileLink("").Create(store.StoreCodeRealDebrid, hashA, "/movie.mkv", "rd-link")
+		data, err := client.GenerateLink(&store.GenerateLinkParams{Ctx: ctx, Link: link})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://realdebrid/rd-link", data.Link)
+		assert.Empty(t, tb.addedHashes)
+	})
+
+	t.Run("fails over to next member", func(t *testing.T) {
+		rd := &fakeStore{name: store.StoreNameRealDebrid, generateLinkErr: core.NewStoreError("boom")}
+		tb := &fakeStore{name: store.StoreNameTorBox, files: []store.MagnetFile{
+			{Path: "/sample.mkv", Link: "tb-sample-link"},
+			{Path: "/movie.mkv", Link: "tb-link"},
+		}}
+		client, ctx := newTestClient(rd, tb)
+
+		link := LockedFileLink("").Create(store.StoreCodeRealDebrid, hashA, "/movie.mkv", "rd-link")
+		data, err := client.GenerateLink(&store.GenerateLinkParams{Ctx: ctx, Link: link})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://torbox/tb-link", data.Link)
+		assert.Equal(t, []string{hashA}, tb.addedHashes)
+	})
+
+	t.Run("returns origin error when failover fails", func(t *testing.T) {
+		originErr := core.NewStoreError("boom")
+		rd := &fakeStore{name: store.StoreNameRealDebrid, generateLinkErr: originErr}
+		tb := &fakeStore{name: store.StoreNameTorBox}
+		client, ctx := newTestClient(rd, tb)
+
+		link := LockedFileLink("").Create(store.StoreCodeRealDebrid, hashA, "/movie.mkv", "rd-link")
+		_, err := client.GenerateLink(&store.GenerateLinkParams{Ctx: ctx, Link: link})
+		assert.ErrorIs(t, err, originErr)
+	})
+}
+
+func TestStoreClient_ListMagnets(t *testing.T) {
+	now := time.Now()
+	newMagnets := func(prefix string, count int, offset time.Duration) []store.ListMagnetsDataItem {
+		items := make([]store.ListMagnetsDataItem, count)
+		for i := range items {
+			items[i] = store.ListMagnetsDataItem{
+				Id:      prefix + strings.Repeat("x", i%3),
+				AddedAt: now.Add(-time.Duration(2*i)*time.Minute - offset),
+			}
+		}
+		return items
+	}
+
+	rd := &fakeStore{name: store.StoreNameRealDebrid, magnets: newMagnets("rd", 700, 0)}
+	tb := &fakeStore{name: store.StoreNameTorBox, magnets: newMagnets("tb", 700, time.Minute)}
+	client, ctx := newTestClient(rd, tb)
+
+	data, err := client.ListMagnets(&store.ListMagnetsParams{Ctx: ctx, Limit: 100, Offset: 1200})
+	assert.NoError(t, err)
+	assert.Equal(t, 1400, data.TotalItems)
+	assert.Len(t, data.Items, 100)
+	assert.True(t, data.Items[0].AddedAt.Equal(rd.magnets[600].AddedAt))
+	for i := 1; i < len(data.Items); i++ {
+		assert.False(t, data.Items[i].AddedAt.After(data.Items[i-1].AddedAt))
+	}
+}
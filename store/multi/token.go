@@ -0,0 +1,72 @@
+package multi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+// Member is a single store, with its own token, backing the multi store.
+//
+// The multi store token is a whitespace separated, ordered list of
+// `<store_name>:<store_token>` items, e.g. `realdebrid:XXX torbox:YYY`.
+// Store codes are accepted in place of store names.
+type Member struct {
+	StoreName store.StoreName
+	Token     string
+}
+
+func ErrorInvalidToken(cause error) *core.APIError {
+	err := core.NewAPIError("invalid token")
+	err.StatusCode = http.StatusUnauthorized
+	err.StoreName = string(store.StoreNameMulti)
+	err.Cause = cause
+	return err
+}
+
+func ParseToken(token string) ([]Member, error) {
+	items := strings.Fields(token)
+	if len(items) == 0 {
+		return nil, ErrorInvalidToken(core.NewError("missing store"))
+	}
+
+	members := make([]Member, 0, len(items))
+	seen := map[store.StoreName]struct{}{}
+	for _, item := range items {
+		name, memberToken, ok := strings.Cut(item, ":")
+		if !ok || memberToken == "" {
+			return nil, ErrorInvalidToken(core.NewError("missing token for store: " + name))
+		}
+		storeName := store.StoreName(name)
+		if !storeName.IsValid() {
+			storeName = store.StoreCode(name).Name()
+		}
+		if !storeName.IsValid() || storeName == store.StoreNameMulti {
+			return nil, ErrorInvalidToken(store.ErrorInvalidStoreName(name))
+		}
+		if _, ok := seen[storeName]; ok {
+			return nil, ErrorInvalidToken(core.NewError("duplicate store: " + name))
+		}
+		seen[storeName] = struct{}{}
+		members = append(members, Member{
+			StoreName: storeName,
+			Token:     memberToken,
+		})
+	}
+	return members, nil
+}
+
+func FormatToken(members []Member) string {
+	var token strings.Builder
+	for i, m := range members {
+		if i > 0 {
+			token.WriteString(" ")
+		}
+		token.WriteString(string(m.StoreName))
+		token.WriteString(":")
+		token.WriteString(m.Token)
+	}
+	return token.String()
+}
@@ -0,0 +1,65 @@
+package multi
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseToken(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		token   string
+		members []Member
+		isErr   bool
+	}{
+		{"single", "realdebrid:xxx", []Member{
+			{StoreName: store.StoreNameRealDebrid, Token: "xxx"},
+		}, false},
+		{"multiple", "realdebrid:xxx  torbox:yyy", []Member{
+			{StoreName: store.StoreNameRealDebrid, Token: "xxx"},
+			{StoreName: store.StoreNameTorBox, Token: "yyy"},
+		}, false},
+		{"store code", "tb:yyy pp:email:password", []Member{
+			{StoreName: store.StoreNameTorBox, Token: "yyy"},
+			{StoreName: store.StoreNamePikPak, Token: "email:password"},
+		}, false},
+		{"empty", "", nil, true},
+		{"missing token", "realdebrid", nil, true},
+		{"invalid store", "unknown:xxx", nil, true},
+		{"nested", "multi:xxx", nil, true},
+		{"duplicate", "rd:xxx realdebrid:yyy", nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			members, err := ParseToken(tc.token)
+			if tc.isErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.members, members)
+		})
+	}
+}
+
+func TestFormatToken(t *testing.T) {
+	members := []Member{
+		{StoreName: store.StoreNameRealDebrid, Token: "xxx"},
+		{StoreName: store.StoreNamePikPak, Token: "email:password"},
+	}
+	token := FormatToken(members)
+	assert.Equal(t, "realdebrid:xxx pikpak:email:password", token)
+	parsed, err := ParseToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, members, parsed)
+}
+
+func TestMagnetId(t *testing.T) {
+	id := MagnetId("").Create(store.StoreCodePremiumize, "premiumize:cached:magnet:xxx")
+	assert.NotContains(t, id, ":")
+	storeCode, memberId, err := MagnetId(id).Parse()
+	assert.NoError(t, err)
+	assert.Equal(t, store.StoreCodePremiumize, storeCode)
+	assert.Equal(t, "premiumize:cached:magnet:xxx", memberId)
+}
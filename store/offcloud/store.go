@@ -1,7 +1,6 @@
 package offcloud
 
 import (
-	"errors"
 	"net/http"
 	"path/filepath"
 	"strings"
@@ -110,11 +109,7 @@ func (s *StoreClient) getMagnetFiles(ctx Ctx, requestId string, server string) (
 }
 
 func (s *StoreClient) AddMagnet(params *store.AddMagnetParams) (*store.AddMagnetData, error) {
-	if params.Magnet == "" {
-		return nil, errors.New("torrent file not supported")
-	}
-
-	magnet, err := core.ParseMagnetLink(params.Magnet)
+	magnet, err := params.GetMagnet()
 	if err != nil {
 		return nil, err
 	}
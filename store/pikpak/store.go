@@ -1,7 +1,6 @@
 package pikpak
 
 import (
-	"errors"
 	"net/http"
 	"path"
 	"slices"
@@ -135,11 +134,7 @@ func (s *StoreClient) getFileByMagnetHash(ctx Ctx, hash string) (*File, error) {
 }
 
 func (s *StoreClient) AddMagnet(params *store.AddMagnetParams) (*store.AddMagnetData, error) {
-	if params.Magnet == "" {
-		return nil, errors.New("torrent file not supported")
-	}
-
-	magnet, err := core.ParseMagnetLink(params.Magnet)
+	magnet, err := params.GetMagnet()
 	if err != nil {
 		return nil, err
 	}
@@ -19,6 +19,8 @@ const (
 	StoreNameDebrider   StoreName = "debrider"
 	StoreNameDebridLink StoreName = "debridlink"
 	StoreNameEasyDebrid StoreName = "easydebrid"
+	StoreNameLocal      StoreName = "local"
+	StoreNameMulti      StoreName = "multi"
 	StoreNameOffcloud   StoreName = "offcloud"
 	StoreNamePikPak     StoreName = "pikpak"
 	StoreNamePremiumize StoreName = "premiumize"
@@ -33,6 +35,8 @@ const (
 	StoreCodeDebrider   StoreCode = "dr"
 	StoreCodeDebridLink StoreCode = "dl"
 	StoreCodeEasyDebrid StoreCode = "ed"
+	StoreCodeLocal      StoreCode = "lo"
+	StoreCodeMulti      StoreCode = "mu"
 	StoreCodeOffcloud   StoreCode = "oc"
 	StoreCodePikPak     StoreCode = "pp"
 	StoreCodePremiumize StoreCode = "pm"
@@ -45,6 +49,8 @@ var storeCodeByName = map[StoreName]StoreCode{
 	StoreNameDebrider:   StoreCodeDebrider,
 	StoreNameDebridLink: StoreCodeDebridLink,
 	StoreNameEasyDebrid: StoreCodeEasyDebrid,
+	StoreNameLocal:      StoreCodeLocal,
+	StoreNameMulti:      StoreCodeMulti,
 	StoreNameOffcloud:   StoreCodeOffcloud,
 	StoreNamePikPak:     StoreCodePikPak,
 	StoreNamePremiumize: StoreCodePremiumize,
@@ -57,6 +63,8 @@ var storeNameByCode = map[StoreCode]StoreName{
 	StoreCodeDebrider:   StoreNameDebrider,
 	StoreCodeDebridLink: StoreNameDebridLink,
 	StoreCodeEasyDebrid: StoreNameEasyDebrid,
+	StoreCodeLocal:      StoreNameLocal,
+	StoreCodeMulti:      StoreNameMulti,
 	StoreCodeOffcloud:   StoreNameOffcloud,
 	StoreCodePikPak:     StoreNamePikPak,
 	StoreCodePremiumize: StoreNamePremiumize,
@@ -210,6 +218,23 @@ func (p *AddMagnetParams) GetTorrentMeta() (*metainfo.MetaInfo, *metainfo.Info,
 	return p.torrentMetaInfo, p.torrentInfo, nil
 }
 
+// GetMagnet returns the magnet, building it from the torrent file's info
+// dict (with name and trackers) if magnet is missing. For stores without
+// an upload endpoint.
+func (p *AddMagnetParams) GetMagnet() (core.MagnetLink, error) {
+	if p.Magnet != "" {
+		return core.ParseMagnetLink(p.Magnet)
+	}
+	mi, info, err := p.GetTorrentMeta()
+	if err != nil {
+		return core.MagnetLink{}, err
+	}
+	if mi == nil {
+		return core.MagnetLink{}, errors.New("missing magnet")
+	}
+	return core.ParseMagnetLink(mi.Magnet(nil, info).String())
+}
+
 type GetMagnetData struct {
 	Id      string       `json:"id"`
 	Name    string       `json:"name"`
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"bytes"
+	"mime/multipart"
+	"testing"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTorrentFileHeader(t *testing.T, mi *metainfo.MetaInfo) *multipart.FileHeader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("torrent", "test.torrent")
+	require.NoError(t, err)
+	require.NoError(t, mi.Write(part))
+	require.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(&buf, w.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	return form.File["torrent"][0]
+}
+
+func TestAddMagnetParamsGetMagnet(t *testing.T) {
+	infoBytes, err := bencode.Marshal(metainfo.Info{
+		Name:        "video.mkv",
+		PieceLength: 16 * 1024,
+		Length:      11,
+		Pieces:      make([]byte, 20),
+	})
+	require.NoError(t, err)
+	mi := &metainfo.MetaInfo{
+		InfoBytes: infoBytes,
+		Announce:  "https://tracker.example/announce?passkey=xxx",
+	}
+	hash := mi.HashInfoBytes().HexString()
+
+	t.Run("magnet", func(t *testing.T) {
+		p := &AddMagnetParams{Magnet: hash}
+		magnet, err := p.GetMagnet()
+		require.NoError(t, err)
+		assert.Equal(t, hash, magnet.Hash)
+	})
+
+	t.Run("torrent", func(t *testing.T) {
+		p := &AddMagnetParams{Torrent: createTorrentFileHeader(t, mi)}
+		magnet, err := p.GetMagnet()
+		require.NoError(t, err)
+		assert.Equal(t, hash, magnet.Hash)
+		assert.Equal(t, "video.mkv", magnet.Name)
+		assert.Equal(t, []string{mi.Announce}, magnet.Trackers)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		p := &AddMagnetParams{}
+		_, err := p.GetMagnet()
+		assert.Error(t, err)
+	})
+}
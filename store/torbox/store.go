@@ -3,6 +3,7 @@ package torbox
 import (
 	"errors"
 	"net/http"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -520,3 +521,226 @@ func (c *StoreClient) RemoveMagnet(params *store.RemoveMagnetParams) (*store.Rem
 	data := &store.RemoveMagnetData{Id: params.Id}
 	return data, nil
 }
+
+var garbageNZBNameRegex = regexp.MustCompile(`(?i)^\[[a-z0-9]+\]\s*-\s*[a-z0-9]+$`)
+
+func (c *StoreClient) toNZBFiles(und *UsenetDownload) (name string, files []store.NZBFile) {
+	name = und.Name
+	hasGarbageName := garbageNZBNameRegex.MatchString(name)
+	maxFileSize := int64(0)
+	source := string(c.GetName().Code())
+	files = []store.NZBFile{}
+	for i := range und.Files {
+		f := &und.Files[i]
+		file := store.NZBFile{
+			Idx:    f.Id,
+			Link:   LockedFileLink("").Create(und.Id, f.Id),
+			Name:   f.ShortName,
+			Path:   "/" + f.Name,
+			Size:   f.Size,
+			Source: source,
+		}
+		if hasGarbageName && file.Size > maxFileSize {
+			name = file.Name
+			maxFileSize = file.Size
+		}
+		files = append(files, file)
+	}
+	return name, files
+}
+
+func getNZBStatus(und *UsenetDownload) store.NZBStatus {
+	if und.DownloadFinished && und.DownloadPresent {
+		return store.MagnetStatusDownloaded
+	}
+	if und.DownloadState == TorrentDownloadStateDownloading {
+		return store.MagnetStatusDownloading
+	}
+	return store.MagnetStatusUnknown
+}
+
+func (c *StoreClient) CheckNZB(params *store.CheckNZBParams) (*store.CheckNZBData, error) {
+	res, err := c.client.CheckUsenetCached(&CheckUsenetCachedParams{
+		Ctx:    params.Ctx,
+		Hashes: params.Hashes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cachedByHash := make(map[string]CheckUsenetCachedDataItem, len(res.Data))
+	for _, item := range res.Data {
+		cachedByHash[strings.ToLower(item.Hash)] = item
+	}
+	data := &store.CheckNZBData{
+		Items: []store.CheckNZBDataItem{},
+	}
+	for _, hash := range params.Hashes {
+		item := store.CheckNZBDataItem{
+			Hash:   strings.ToLower(hash),
+			Status: store.MagnetStatusUnknown,
+		}
+		if cItem, ok := cachedByHash[item.Hash]; ok {
+			item.Name = cItem.Name
+			item.Size = cItem.Size
+			item.Status = store.MagnetStatusCached
+		}
+		data.Items = append(data.Items, item)
+	}
+	return data, nil
+}
+
+func (c *StoreClient) AddNZB(params *store.AddNZBParams) (*store.AddNZBData, error) {
+	if params.Link == "" && params.File == nil {
+		error := core.NewAPIError("missing nzb")
+		error.StatusCode = http.StatusBadRequest
+		error.StoreName = string(store.StoreNameTorBox)
+		return nil, error
+	}
+	res, err := c.client.CreateUsenetDownload(&CreateUsenetDownloadParams{
+		Ctx:      params.Ctx,
+		File:     params.File,
+		Link:     params.Link,
+		Name:     params.Name,
+		Password: params.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	id := strconv.Itoa(res.Data.UsenetDownloadId)
+	nzb, err := c.GetNZB(&store.GetNZBParams{
+		Ctx:         params.Ctx,
+		Id:          id,
+		ClientIP:    params.ClientIP,
+		BypassCache: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.AddNZBData{
+		Id:      nzb.Id,
+		Hash:    nzb.Hash,
+		Name:    nzb.Name,
+		Size:    nzb.Size,
+		Status:  nzb.Status,
+		Files:   nzb.Files,
+		AddedAt: nzb.AddedAt,
+	}
+	if data.Status == store.MagnetStatusUnknown {
+		data.Status = store.MagnetStatusQueued
+	}
+	return data, nil
+}
+
+func (c *StoreClient) GetNZB(params *store.GetNZBParams) (*store.GetNZBData, error) {
+	id, err := strconv.Atoi(params.Id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.client.GetUsenetDownload(&GetUsenetDownloadParams{
+		Ctx:         params.Ctx,
+		Id:          id,
+		BypassCache: params.BypassCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Data.Id == 0 {
+		error := core.NewAPIError("not found")
+		error.StatusCode = http.StatusNotFound
+		error.StoreName = string(store.StoreNameTorBox)
+		return nil, error
+	}
+	und := &res.Data
+	data := &store.GetNZBData{
+		Id:      strconv.Itoa(und.Id),
+		Hash:    und.Hash,
+		Size:    und.Size,
+		Status:  getNZBStatus(und),
+		AddedAt: und.GetAddedAt(),
+	}
+	data.Name, data.Files = c.toNZBFiles(und)
+	return data, nil
+}
+
+func (c *StoreClient) ListNZBs(params *store.ListNZBsParams) (*store.ListNZBsData, error) {
+	res, err := c.client.ListUsenetDownload(&ListUsenetDownloadParams{
+		Ctx:         params.Ctx,
+		BypassCache: true,
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.ListNZBsData{
+		Items:      []store.ListNZBsDataItem{},
+		TotalItems: 0,
+	}
+	for i := range res.Data {
+		und := &res.Data[i]
+		item := store.ListNZBsDataItem{
+			Id:      strconv.Itoa(und.Id),
+			Hash:    und.Hash,
+			Size:    und.Size,
+			Status:  getNZBStatus(und),
+			AddedAt: und.GetAddedAt(),
+		}
+		item.Name, item.Files = c.toNZBFiles(und)
+		data.Items = append(data.Items, item)
+	}
+	count := len(data.Items)
+	// torbox returns 1 extra item
+	if count > params.Limit {
+		data.Items = data.Items[0:params.Limit]
+		count = params.Limit
+	}
+	data.TotalItems = params.Offset + count
+	if count == params.Limit {
+		data.TotalItems += 1
+	}
+	return data, nil
+}
+
+func (c *StoreClient) RemoveNZB(params *store.RemoveNZBParams) (*store.RemoveNZBData, error) {
+	id, err := strconv.Atoi(params.Id)
+	if err != nil {
+		return nil, err
+	}
+	_, err = c.client.ControlUsenetDownload(&ControlUsenetDownloadParams{
+		Ctx:       params.Ctx,
+		UsenetId:  id,
+		Operation: ControlUsenetDownloadOperationDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.RemoveNZBData{Id: params.Id}
+	return data, nil
+}
+
+func (c *StoreClient) GenerateNZBLink(params *store.GenerateNZBLinkParams) (*store.GenerateLinkData, error) {
+	usenetId, fileId, err := LockedFileLink(params.Link).Parse()
+	if err != nil {
+		error := core.NewAPIError("invalid link")
+		error.StatusCode = http.StatusBadRequest
+		error.Cause = err
+		return nil, error
+	}
+	cacheKey := params.GetAPIKey(c.client.apiKey) + ":usenet" + intToStr(usenetId, fileId)
+	data := &store.GenerateLinkData{}
+	if c.generateLinkCache.Get(cacheKey, data) {
+		return data, nil
+	}
+	res, err := c.client.RequestUsenetDownloadLink(&RequestUsenetDownloadLinkParams{
+		Ctx:      params.Ctx,
+		UsenetId: usenetId,
+		FileId:   fileId,
+		UserIP:   params.ClientIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data.Link = res.Data.Link
+	c.generateLinkCache.Add(cacheKey, *data)
+	return data, nil
+}
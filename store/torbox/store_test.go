@@ -0,0 +1,149 @@
+package torbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	mu      sync.Mutex
+	queries map[string][]url.Values
+}
+
+func (ts *testServer) record(path string, query url.Values) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.queries[path] = append(ts.queries[path], query)
+}
+
+func (ts *testServer) lastQuery(path string) url.Values {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	queries := ts.queries[path]
+	if len(queries) == 0 {
+		return nil
+	}
+	return queries[len(queries)-1]
+}
+
+func newTestStoreClient(t *testing.T) (*StoreClient, *testServer) {
+	t.Helper()
+
+	ts := &testServer{queries: map[string][]url.Values{}}
+
+	download := UsenetDownload{
+		Id:               42,
+		Hash:             "abc",
+		Name:             "[abc123] - xyz789",
+		Size:             300,
+		CreatedAt:        "2024-01-02T03:04:05Z",
+		DownloadPresent:  true,
+		DownloadFinished: true,
+		Files: []UsenetDownloadFile{
+			{Id: 0, Name: "Movie/sample.mkv", ShortName: "sample.mkv", Size: 100},
+			{Id: 1, Name: "Movie/Movie.2024.mkv", ShortName: "Movie.2024.mkv", Size: 200},
+		},
+	}
+
+	mux := http.NewServeMux()
+	send := func(w http.ResponseWriter, data any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data})
+	}
+	mux.HandleFunc("GET /v1/api/usenet/checkcached", func(w http.ResponseWriter, r *http.Request) {
+		ts.record(r.URL.Path, r.URL.Query())
+		send(w, CheckUsenetCachedData{{Name: "Cached", Size: 10, Hash: "ABC"}})
+	})
+	mux.HandleFunc("POST /v1/api/usenet/createusenetdownload", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		ts.record(r.URL.Path, r.PostForm)
+		send(w, CreateUsenetDownloadData{UsenetDownloadId: download.Id, Hash: download.Hash})
+	})
+	mux.HandleFunc("GET /v1/api/usenet/mylist", func(w http.ResponseWriter, r *http.Request) {
+		ts.record(r.URL.Path, r.URL.Query())
+		if r.URL.Query().Has("id") {
+			send(w, download)
+			return
+		}
+		send(w, ListUsenetDownloadData{download, download})
+	})
+	mux.HandleFunc("POST /v1/api/usenet/controlusenetdownload", func(w http.ResponseWriter, r *http.Request) {
+		ts.record(r.URL.Path, nil)
+		send(w, ControlUsenetDownloadData{})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c := NewStoreClient(&StoreClientConfig{})
+	c.client = NewAPIClient(&APIClientConfig{BaseURL: server.URL})
+	return c, ts
+}
+
+func TestStoreClientUsenet(t *testing.T) {
+	c, ts := newTestStoreClient(t)
+	ctx := store.Ctx{APIKey: "token"}
+
+	t.Run("CheckNZB", func(t *testing.T) {
+		data, err := c.CheckNZB(&store.CheckNZBParams{Ctx: ctx, Hashes: []string{"abc", "def"}})
+		require.NoError(t, err)
+		require.Len(t, data.Items, 2)
+		assert.Equal(t, store.MagnetStatusCached, data.Items[0].Status)
+		assert.Equal(t, "Cached", data.Items[0].Name)
+		assert.Equal(t, store.MagnetStatusUnknown, data.Items[1].Status)
+		assert.Equal(t, []string{"abc", "def"}, ts.lastQuery("/v1/api/usenet/checkcached")["hash"])
+	})
+
+	t.Run("GetNZB", func(t *testing.T) {
+		data, err := c.GetNZB(&store.GetNZBParams{Ctx: ctx, Id: "42"})
+		require.NoError(t, err)
+		assert.Equal(t, "false", ts.lastQuery("/v1/api/usenet/mylist").Get("bypass_cache"))
+		assert.Equal(t, "42", data.Id)
+		assert.Equal(t, store.MagnetStatusDownloaded, data.Status)
+		assert.Equal(t, "Movie.2024.mkv", data.Name)
+		require.Len(t, data.Files, 2)
+		assert.Equal(t, "/Movie/Movie.2024.mkv", data.Files[1].Path)
+		assert.Equal(t, "tb", data.Files[1].Source)
+
+		_, err = c.GetNZB(&store.GetNZBParams{Ctx: ctx, Id: "42", BypassCache: true})
+		require.NoError(t, err)
+		assert.Equal(t, "true", ts.lastQuery("/v1/api/usenet/mylist").Get("bypass_cache"))
+	})
+
+	t.Run("AddNZB", func(t *testing.T) {
+		data, err := c.AddNZB(&store.AddNZBParams{Ctx: ctx, Link: "https://indexer/nzb/1"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://indexer/nzb/1", ts.lastQuery("/v1/api/usenet/createusenetdownload").Get("link"))
+		assert.Equal(t, "true", ts.lastQuery("/v1/api/usenet/mylist").Get("bypass_cache"))
+		assert.Equal(t, "42", data.Id)
+		assert.Len(t, data.Files, 2)
+
+		_, err = c.AddNZB(&store.AddNZBParams{Ctx: ctx})
+		assert.Error(t, err)
+	})
+
+	t.Run("ListNZBs", func(t *testing.T) {
+		data, err := c.ListNZBs(&store.ListNZBsParams{Ctx: ctx, Limit: 1})
+		require.NoError(t, err)
+		assert.Len(t, data.Items, 1)
+		assert.Equal(t, 2, data.TotalItems)
+	})
+
+	t.Run("RemoveNZB", func(t *testing.T) {
+		data, err := c.RemoveNZB(&store.RemoveNZBParams{Ctx: ctx, Id: "42"})
+		require.NoError(t, err)
+		assert.Equal(t, "42", data.Id)
+		assert.Len(t, ts.queries["/v1/api/usenet/controlusenetdownload"], 1)
+
+		_, err = c.RemoveNZB(&store.RemoveNZBParams{Ctx: ctx, Id: "x"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+type NZBStatus = MagnetStatus
+
+type NZBFile = MagnetFile
+
+type CheckNZBParams struct {
+	Ctx
+	Hashes   []string
+	ClientIP string
+}
+
+type CheckNZBDataItem struct {
+	Hash   string    `json:"hash"`
+	Name   string    `json:"name"`
+	Size   int64     `json:"size"`
+	Status NZBStatus `json:"status"`
+}
+
+type CheckNZBData struct {
+	Items []CheckNZBDataItem `json:"items"`
+}
+
+type AddNZBParams struct {
+	Ctx
+	Link     string
+	File     *multipart.FileHeader
+	Name     string
+	Password string
+	ClientIP string
+}
+
+type AddNZBData struct {
+	Id      string    `json:"id"`
+	Hash    string    `json:"hash"`
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	Status  NZBStatus `json:"status"`
+	Files   []NZBFile `json:"files"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+type GetNZBParams struct {
+	Ctx
+	Id          string
+	ClientIP    string
+	BypassCache bool
+}
+
+type GetNZBData struct {
+	Id      string    `json:"id"`
+	Hash    string    `json:"hash"`
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	Status  NZBStatus `json:"status"`
+	Files   []NZBFile `json:"files"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+func (d GetNZBData) GetLargestFileName() string {
+	return getLargestNZBFileName(d.Files)
+}
+
+type ListNZBsParams struct {
+	Ctx
+	Limit    int // min 1, max 500, default 100
+	Offset   int // default 0
+	ClientIP string
+}
+
+type ListNZBsDataItem struct {
+	Id      string    `json:"id"`
+	Hash    string    `json:"hash"`
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	Status  NZBStatus `json:"status"`
+	Files   []NZBFile `json:"files,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+func (d ListNZBsDataItem) GetLargestFileName() string {
+	return getLargestNZBFileName(d.Files)
+}
+
+type ListNZBsData struct {
+	Items      []ListNZBsDataItem `json:"items"`
+	TotalItems int                `json:"total_items"`
+}
+
+type RemoveNZBParams struct {
+	Ctx
+	Id string
+}
+
+type RemoveNZBData struct {
+	Id string `json:"id"`
+}
+
+type GenerateNZBLinkParams struct {
+	Ctx
+	Link     string
+	ClientIP string
+}
+
+func getLargestNZBFileName(files []NZBFile) string {
+	name, size := "", int64(0)
+	for i, file := range files {
+		if file.Size > size {
+			name = file.Name
+			size = file.Size
+		}
+		if i > 99 {
+			break
+		}
+	}
+	return name
+}
+
+// UsenetStore is implemented by the stores that support NZB downloads.
+type UsenetStore interface {
+	Store
+	CheckNZB(params *CheckNZBParams) (*CheckNZBData, error)
+	AddNZB(params *AddNZBParams) (*AddNZBData, error)
+	GetNZB(params *GetNZBParams) (*GetNZBData, error)
+	ListNZBs(params *ListNZBsParams) (*ListNZBsData, error)
+	RemoveNZB(params *RemoveNZBParams) (*RemoveNZBData, error)
+	GenerateNZBLink(params *GenerateNZBLinkParams) (*GenerateLinkData, error)
+}